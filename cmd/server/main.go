@@ -1,15 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"io/fs"
 	"log"
 	"net/http"
+	"os"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/joho/godotenv"
 	"github.com/marcoglnd/mercado-fresco-packmain/cmd/server/routes"
 	"github.com/marcoglnd/mercado-fresco-packmain/db"
+	"github.com/marcoglnd/mercado-fresco-packmain/db/migrations"
 	"github.com/marcoglnd/mercado-fresco-packmain/docs"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/config"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/migrate"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/swaggo/gin-swagger/swaggerFiles"
 )
@@ -29,27 +37,96 @@ import (
 // BasePath /api/v1
 // @query.collection.format multi
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by the token returned by /auth/login.
+
 func main() {
-	err := godotenv.Load()
+	// A .env file is optional, and its variables never override the
+	// environment.
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Fatal(err)
+	}
+
+	cfg, args, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+
+	dbConnection := db.GetDBConnection(cfg.DB)
+
+	migrator, err := migrate.New(dbConnection, migrations.Files)
 	if err != nil {
 		log.Fatal(err)
 	}
-	dbConnection := db.GetDBConnection()
-	PATH := "/api/v1"
-	router := gin.Default()
-	routerGroup := router.Group(PATH)
-	routes.AddRoutes(routerGroup, dbConnection)
-	docs.SwaggerInfo.BasePath = PATH
+
+	if len(args) > 0 {
+		if args[0] != "migrate" {
+			log.Fatalf("unknown command %q", args[0])
+		}
+		if err := migrate.Run(context.Background(), migrator, args[1:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if cfg.DB.MigrateOnStart {
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("applied %d migrations", len(applied))
+	}
+
+	routes.BootstrapAdmin(context.Background(), dbConnection, cfg.Auth)
+	if cfg.Features.Jobs {
+		routes.StartJobs(context.Background(), dbConnection, cfg)
+	}
+
+	router := newRouter(cfg.Log)
+	routerGroup := router.Group(cfg.HTTP.BasePath)
+	routes.AddRoutes(routerGroup, dbConnection, cfg)
+	docs.SwaggerInfo.BasePath = cfg.HTTP.BasePath
 
 	router.GET("/ping", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, "pong")
 	})
 
-	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	if cfg.Features.Swagger {
+		router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
+
+	server := &http.Server{
+		Addr:         cfg.HTTP.Addr(),
+		Handler:      router,
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
+	}
 
-	err = router.Run()
+	log.Printf("listening on %s", server.Addr)
+	err = server.ListenAndServe()
 
 	if err != nil {
 		log.Fatal(err)
 	}
 }
+
+// newRouter logs every request at the debug and info levels, and runs gin
+// in debug mode only at debug.
+func newRouter(cfg config.Log) *gin.Engine {
+	if cfg.Level != config.LogDebug {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	router := gin.New()
+	if cfg.Level == config.LogDebug || cfg.Level == config.LogInfo {
+		router.Use(gin.Logger())
+	}
+	router.Use(gin.Recovery())
+
+	return router
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/controller"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/service"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+)
+
+func auditRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
+	repository := mariadb.NewMariaDBRepository(DBConnection)
+
+	auditService := service.NewAuditService(repository)
+
+	auditController, _ := controller.NewAuditController(auditService)
+
+	superRouter.GET("/audit", policy.Require(policy.Admin), auditController.Search())
+}
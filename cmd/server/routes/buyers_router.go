@@ -8,6 +8,7 @@ import (
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/buyers/service"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 )
 
 func buyersRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
@@ -20,9 +21,9 @@ func buyersRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
 	{
 		pr.GET("/", buyerController.GetAll())
 		pr.GET("/:id", buyerController.GetById())
-		pr.POST("/", buyerController.Create())
-		pr.PATCH("/:id", buyerController.Update())
-		pr.DELETE("/:id", buyerController.Delete())
+		pr.POST("/", policy.Require(policy.Admin), buyerController.Create())
+		pr.PATCH("/:id", policy.Require(policy.Admin), buyerController.Update())
+		pr.DELETE("/:id", policy.Require(policy.Admin), buyerController.Delete())
 		pr.GET("/reportPurchaseOrders", buyerController.ReportPurchaseOrders())
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/carriers/controller"
 	repository "github.com/marcoglnd/mercado-fresco-packmain/internal/carriers/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/carriers/service"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 )
 
 func carriersRouter(superRouter *gin.RouterGroup, dbConnection *sql.DB) {
@@ -16,7 +17,7 @@ func carriersRouter(superRouter *gin.RouterGroup, dbConnection *sql.DB) {
 
 	pr := superRouter.Group("/carriers")
 	{
-		pr.POST("/", carrierController.Create())
+		pr.POST("/", policy.Require(policy.Admin), carrierController.Create())
 	}
 	superRouter.GET("/localities/reportCarriers", carrierController.ReportCarriers())
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/employees/controller"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/employees/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/employees/service"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 )
 
 func employeesRouter(superRouter *gin.RouterGroup, conn *sql.DB) {
@@ -18,9 +19,9 @@ func employeesRouter(superRouter *gin.RouterGroup, conn *sql.DB) {
 	{
 		pr.GET("/", controller.GetAll())
 		pr.GET("/:id", controller.GetById())
-		pr.POST("/", controller.Create())
-		pr.PATCH("/:id", controller.Update())
-		pr.DELETE("/:id", controller.Delete())
+		pr.POST("/", policy.Require(policy.Admin), controller.Create())
+		pr.PATCH("/:id", policy.Require(policy.Admin), controller.Update())
+		pr.DELETE("/:id", policy.Require(policy.Admin), controller.Delete())
 		pr.GET("/reportInboundOrders", controller.ReportInboundOrders())
 	}
 }
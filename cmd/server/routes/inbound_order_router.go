@@ -8,6 +8,7 @@ import (
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/inbound_orders/controller"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/inbound_orders/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/inbound_orders/service"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 )
 
 func inboundOrderRouter(superRouter *gin.RouterGroup, conn *sql.DB) {
@@ -17,7 +18,7 @@ func inboundOrderRouter(superRouter *gin.RouterGroup, conn *sql.DB) {
 
 	pr := superRouter.Group("/inboundOrders")
 	{
-		pr.POST("/", controller.Create())
+		pr.POST("/", policy.Require(policy.Employee), controller.Create())
 		pr.GET("/", controller.GetAll())
 	}
 }
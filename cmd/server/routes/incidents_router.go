@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/config"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/controller"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/notifier"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/service"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+)
+
+func incidentsRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB, cfg config.Incidents) {
+	incidentController, _ := controller.NewIncidentController(newIncidentService(DBConnection, cfg))
+	pr := superRouter.Group("/incidents")
+	{
+		pr.GET("/", incidentController.GetAll())
+		pr.GET("/:id", incidentController.GetById())
+		pr.POST("/:id/acknowledge", policy.Require(policy.Employee), incidentController.Acknowledge())
+	}
+}
+
+// newIncidentService always logs incidents, also posting them to the
+// webhook URL of cfg when it is set.
+func newIncidentService(DBConnection *sql.DB, cfg config.Incidents) domain.IncidentService {
+	notifiers := []domain.Notifier{notifier.NewLogNotifier(log.Default())}
+	if cfg.WebhookURL != "" {
+		notifiers = append(notifiers, notifier.NewWebhookNotifier(cfg.WebhookURL, nil))
+	}
+
+	return service.NewIncidentService(mariadb.NewMariaDBRepository(DBConnection), cfg.Rules(), notifiers...)
+}
@@ -4,17 +4,36 @@ import (
 	"database/sql"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/config"
 )
 
-func AddRoutes(superRouter *gin.RouterGroup, dbConnection *sql.DB) {
-	buyersRouter(superRouter, dbConnection)
-	purchaseOrdersRouter(superRouter, dbConnection)
-	productsRouter(superRouter, dbConnection)
-	employeesRouter(superRouter, dbConnection)
-	inboundOrderRouter(superRouter, dbConnection)
-	sectionsRouter(superRouter, dbConnection)
-	warehousesRouter(superRouter, dbConnection)
-	sellersRouter(superRouter, dbConnection)
-	localitiesRouter(superRouter, dbConnection)
-	carriersRouter(superRouter, dbConnection)
+func AddRoutes(superRouter *gin.RouterGroup, dbConnection *sql.DB, cfg *config.Config) {
+	signer := newSigner(cfg.Auth)
+	loginRouter(superRouter, dbConnection, signer)
+
+	// Every other endpoint needs a token, whose user is the actor of the
+	// changes made by the request.
+	router := superRouter.Group("", auth.Middleware(signer))
+
+	buyersRouter(router, dbConnection)
+	purchaseOrdersRouter(router, dbConnection)
+	productsRouter(router, dbConnection)
+	productTypesRouter(router, dbConnection)
+	employeesRouter(router, dbConnection)
+	inboundOrderRouter(router, dbConnection)
+	sectionsRouter(router, dbConnection)
+	warehousesRouter(router, dbConnection)
+	sellersRouter(router, dbConnection)
+	localitiesRouter(router, dbConnection)
+	carriersRouter(router, dbConnection)
+	pickingRouter(router, dbConnection)
+	telemetryRouter(router, dbConnection, cfg.Incidents)
+	incidentsRouter(router, dbConnection, cfg.Incidents)
+	movementsRouter(router, dbConnection)
+	transfersRouter(router, dbConnection)
+	reportsRouter(router, dbConnection)
+	auditRouter(router, dbConnection)
+	webhooksRouter(router, dbConnection)
+	usersRouter(router, dbConnection, signer)
 }
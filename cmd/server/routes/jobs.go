@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/config"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/dispatcher"
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	outbox "github.com/marcoglnd/mercado-fresco-packmain/internal/events/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/sink"
+	incidentsService "github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/service"
+	productsRepository "github.com/marcoglnd/mercado-fresco-packmain/internal/products/repository/mariadb"
+	productsService "github.com/marcoglnd/mercado-fresco-packmain/internal/products/service"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/delivery"
+	webhooks "github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain"
+	webhooksRepository "github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/repository/mariadb"
+)
+
+// StartJobs starts the background jobs of the API, which stop when ctx is
+// cancelled.
+func StartJobs(ctx context.Context, dbConnection *sql.DB, cfg *config.Config) {
+	go productsService.RunQuarantineSweep(
+		ctx,
+		productsService.NewService(productsRepository.NewMariaDBRepository(dbConnection)),
+		time.Hour,
+	)
+
+	go incidentsService.RunMonitor(ctx, newIncidentService(dbConnection, cfg.Incidents), time.Minute)
+
+	webhookRepository := webhooksRepository.NewMariaDBRepository(dbConnection)
+	sinks := newEventSinks(webhookRepository, cfg.Events)
+	go dispatcher.NewDispatcher(outbox.NewMariaDBRepository(dbConnection), sinks...).Run(ctx, 5*time.Second)
+	go delivery.NewDeliverer(webhookRepository, nil, cfg.Webhooks.MaxAttempts).Run(ctx, 5*time.Second)
+}
+
+// newEventSinks always queues the events for the webhook subscriptions,
+// and also writes them to stdout, a URL and a file as cfg sets.
+func newEventSinks(webhookRepository webhooks.WebhookRepository, cfg config.Events) []events.Sink {
+	sinks := []events.Sink{delivery.NewSink(webhookRepository)}
+
+	if cfg.Stdout {
+		sinks = append(sinks, sink.NewStdoutSink(os.Stdout))
+	}
+
+	if cfg.WebhookURL != "" {
+		sinks = append(sinks, sink.NewWebhookSink(cfg.WebhookURL, nil))
+	}
+
+	if cfg.File != "" {
+		sinks = append(sinks, sink.NewFileSink(cfg.File))
+	}
+
+	return sinks
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/localities/controller"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/localities/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/localities/service"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 )
 
 func localitiesRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
@@ -21,7 +22,7 @@ func localitiesRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
 
 	ll := superRouter.Group("/localities")
 	{
-		ll.POST("/", localityController.CreateLocality())
+		ll.POST("/", policy.Require(policy.Admin), localityController.CreateLocality())
 		ll.GET("/reportSellers", localityController.GetAllQtyOfSellers())
 	}
 }
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/movements/controller"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/movements/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/movements/service"
+)
+
+func movementsRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
+	repository := mariadb.NewMariaDBRepository(DBConnection)
+
+	movementService := service.NewMovementService(repository)
+
+	movementController, _ := controller.NewMovementController(movementService)
+
+	superRouter.GET("/productBatches/:id/movements", movementController.GetBatchHistory())
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/picking/controller"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/picking/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/picking/service"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+)
+
+func pickingRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
+	repository := mariadb.NewMariaDBRepository(DBConnection)
+
+	pickingService := service.NewPickingService(repository)
+
+	pickingController, _ := controller.NewPickingController(pickingService)
+	pr := superRouter.Group("/picking")
+	{
+		pr.POST("/", policy.Require(policy.Employee), pickingController.PickList())
+		pr.GET("/shelfLife/:buyerId", pickingController.GetShelfLifePolicy())
+		pr.PUT("/shelfLife/:buyerId", policy.Require(policy.Admin), pickingController.SaveShelfLifePolicy())
+	}
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/product-types/controller"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/product-types/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/product-types/service"
+)
+
+func productTypesRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
+	repository := mariadb.NewMariaDBRepository(DBConnection)
+
+	productTypeService := service.NewProductTypeService(repository)
+
+	productTypeController, _ := controller.NewProductTypeController(productTypeService)
+
+	pr := superRouter.Group("/productTypes")
+	{
+		pr.GET("/", productTypeController.GetAll())
+		pr.GET("/:id", productTypeController.GetById())
+		pr.POST("/", policy.Require(policy.Admin), productTypeController.Create())
+		pr.PATCH("/:id", policy.Require(policy.Admin), productTypeController.Update())
+		pr.DELETE("/:id", policy.Require(policy.Admin), productTypeController.Delete())
+		pr.GET("/reportUsage", productTypeController.GetUsage())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/controller"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/service"
@@ -14,16 +15,23 @@ func productsRouter(superRouter *gin.RouterGroup, conn *sql.DB) {
 	service := service.NewService(repo)
 	controller := controller.NewProduct(service)
 
-	superRouter.POST("/productRecords", controller.CreateProductRecords())
-	superRouter.POST("/productBatches", controller.CreateProductBatches())
+	superRouter.POST("/productRecords", policy.Require(policy.Seller), controller.CreateProductRecords())
+	superRouter.POST("/productBatches", policy.Require(policy.Employee), controller.CreateProductBatches())
+	superRouter.GET("/productBatches", controller.GetAllProductBatches())
+	superRouter.GET("/productBatches/expiring", controller.GetExpiringBatches())
+	superRouter.GET("/productBatches/:id", controller.GetProductBatchById())
+	superRouter.PATCH("/productBatches/:id", policy.Require(policy.Employee), controller.AdjustProductBatch())
+	superRouter.DELETE("/productBatches/:id", policy.Require(policy.Employee), controller.DeleteProductBatch())
 
 	pr := superRouter.Group("/products")
 	{
 		pr.GET("/", controller.GetAll())
 		pr.GET("/:id", controller.GetById())
-		pr.POST("/", controller.CreateNewProduct())
-		pr.PATCH("/:id", controller.Update())
-		pr.DELETE("/:id", controller.Delete())
+		pr.POST("/", policy.Require(policy.Seller), controller.CreateNewProduct())
+		pr.PATCH("/:id", policy.Require(policy.Seller), controller.Update())
+		pr.DELETE("/:id", policy.Require(policy.Seller), controller.Delete())
+		pr.GET("/:id/prices", controller.GetPriceHistory())
+		pr.GET("/:id/price", controller.GetPriceAt())
 		pr.GET("/reportRecords", controller.GetQtyOfRecords())
 		pr.GET("/reportProducts", controller.GetQtdProductsBySectionId())
 	}
@@ -3,6 +3,7 @@ package routes
 import (
 	"database/sql"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/controller"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/service"
@@ -18,6 +19,19 @@ func purchaseOrdersRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
 	purchaseOrderController, _ := controller.NewPurchaseOrderController(purchaseOrderService)
 	pr := superRouter.Group("/purchaseOrders")
 	{
-		pr.POST("/", purchaseOrderController.Create())
+		pr.GET("/", purchaseOrderController.GetAll())
+		pr.POST("/", policy.Require(policy.Employee), purchaseOrderController.Create())
+		pr.GET("/tracking/:code", purchaseOrderController.GetByTrackingCode())
+		pr.GET("/:id", purchaseOrderController.GetById())
+		pr.PATCH("/:id", policy.Require(policy.Employee), purchaseOrderController.Update())
+		pr.DELETE("/:id", policy.Require(policy.Employee), purchaseOrderController.Delete())
+		pr.GET("/:id/history", purchaseOrderController.GetStatusHistory())
+		pr.POST("/:id/pick", policy.Require(policy.Employee), purchaseOrderController.Pick())
+		pr.POST("/:id/ship", policy.Require(policy.Employee), purchaseOrderController.Ship())
+		pr.POST("/:id/deliver", policy.Require(policy.Employee), purchaseOrderController.Deliver())
+		pr.POST("/:id/cancel", policy.Require(policy.Employee), purchaseOrderController.Cancel())
+		pr.POST("/:id/orderDetails", policy.Require(policy.Employee), purchaseOrderController.CreateOrderDetail())
+		pr.PATCH("/:id/orderDetails/:detailId", policy.Require(policy.Employee), purchaseOrderController.UpdateOrderDetail())
+		pr.DELETE("/:id/orderDetails/:detailId", policy.Require(policy.Employee), purchaseOrderController.DeleteOrderDetail())
 	}
 }
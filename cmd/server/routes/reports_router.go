@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/reports/controller"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/reports/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/reports/service"
+)
+
+func reportsRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
+	repository := mariadb.NewMariaDBRepository(DBConnection)
+
+	reportService := service.NewReportService(repository)
+
+	reportController, _ := controller.NewReportController(reportService)
+
+	pr := superRouter.Group("/reports")
+	{
+		pr.GET("/margins", reportController.GetMargins())
+	}
+}
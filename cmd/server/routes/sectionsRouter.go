@@ -4,6 +4,7 @@ import (
 	"database/sql"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sections/controller"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sections/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sections/service"
@@ -18,8 +19,8 @@ func sectionsRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
 	{
 		pr.GET("/", sectionController.GetAll())
 		pr.GET("/:id", sectionController.GetById())
-		pr.POST("/", sectionController.Create())
-		pr.PATCH("/:id", sectionController.Update())
-		pr.DELETE("/:id", sectionController.Delete())
+		pr.POST("/", policy.Require(policy.Admin), sectionController.Create())
+		pr.PATCH("/:id", policy.Require(policy.Admin), sectionController.Update())
+		pr.DELETE("/:id", policy.Require(policy.Admin), sectionController.Delete())
 	}
 }
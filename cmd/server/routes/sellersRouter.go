@@ -4,6 +4,7 @@ import (
 	"database/sql"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sellers/controller"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sellers/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sellers/service"
@@ -23,8 +24,8 @@ func sellersRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
 	{
 		sl.GET("/", sellerController.GetAll())
 		sl.GET("/:id", sellerController.GetByID())
-		sl.POST("/", sellerController.Create())
-		sl.PATCH("/:id", sellerController.Update())
-		sl.DELETE("/:id", sellerController.Delete())
+		sl.POST("/", policy.Require(policy.Admin), sellerController.Create())
+		sl.PATCH("/:id", policy.Require(policy.Admin), sellerController.Update())
+		sl.DELETE("/:id", policy.Require(policy.Admin), sellerController.Delete())
 	}
 }
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/config"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/controller"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/service"
+)
+
+func telemetryRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB, cfg config.Incidents) {
+	repository := mariadb.NewMariaDBRepository(DBConnection)
+
+	telemetryService := service.NewTelemetryService(repository, newIncidentService(DBConnection, cfg))
+
+	telemetryController, _ := controller.NewTelemetryController(telemetryService)
+	pr := superRouter.Group("/sections")
+	{
+		pr.POST("/temperatures", policy.Require(policy.Employee), telemetryController.Ingest())
+		pr.GET("/:id/temperatures", telemetryController.GetTemperatureReport())
+	}
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/controller"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/service"
+)
+
+func transfersRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
+	repository := mariadb.NewMariaDBRepository(DBConnection)
+
+	transferService := service.NewTransferService(repository)
+
+	transferController, _ := controller.NewTransferController(transferService)
+
+	pr := superRouter.Group("/transfers")
+	{
+		pr.GET("/", transferController.GetAll())
+		pr.GET("/:id", transferController.GetById())
+		pr.POST("/", policy.Require(policy.Employee), transferController.Create())
+		pr.POST("/:id/receive", policy.Require(policy.Employee), transferController.Receive())
+	}
+}
@@ -0,0 +1,85 @@
+package routes
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/config"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/controller"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/service"
+)
+
+// loginRouter registers the only endpoint open to anonymous callers.
+func loginRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB, signer *auth.Signer) {
+	userController, _ := controller.NewUserController(newUserService(DBConnection, signer))
+
+	superRouter.POST("/auth/login", userController.Login())
+}
+
+// usersRouter registers the user and role management, which only admins
+// may use.
+func usersRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB, signer *auth.Signer) {
+	userController, _ := controller.NewUserController(newUserService(DBConnection, signer))
+	roleController, _ := controller.NewRoleController(service.NewRoleService(mariadb.NewRolesRepository(DBConnection)))
+
+	pr := superRouter.Group("/users", policy.Require(policy.Admin))
+	{
+		pr.GET("/", userController.GetAll())
+		pr.GET("/:id", userController.GetById())
+		pr.POST("/", userController.Create())
+		pr.PATCH("/:id", userController.Update())
+		pr.PUT("/:id/roles", userController.SetRoles())
+		pr.DELETE("/:id", userController.Delete())
+	}
+
+	rr := superRouter.Group("/roles", policy.Require(policy.Admin))
+	{
+		rr.GET("/", roleController.GetAll())
+		rr.GET("/:id", roleController.GetById())
+		rr.POST("/", roleController.Create())
+		rr.PATCH("/:id", roleController.Update())
+		rr.DELETE("/:id", roleController.Delete())
+	}
+}
+
+func newUserService(DBConnection *sql.DB, signer *auth.Signer) domain.UserService {
+	return service.NewUserService(
+		mariadb.NewMariaDBRepository(DBConnection),
+		mariadb.NewRolesRepository(DBConnection),
+		signer,
+	)
+}
+
+// newSigner signs tokens with the secret of cfg. Without one a random
+// secret is used, and tokens stop working when the server restarts.
+func newSigner(cfg config.Auth) *auth.Signer {
+	secret := []byte(cfg.TokenSecret)
+	if len(secret) == 0 {
+		log.Print("no token secret is set, using a random secret")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return auth.NewSigner(secret, cfg.TokenTTL)
+}
+
+// BootstrapAdmin creates the admin user of cfg when the database has no
+// users.
+func BootstrapAdmin(ctx context.Context, DBConnection *sql.DB, cfg config.Auth) {
+	if cfg.AdminUsername == "" || cfg.AdminPassword == "" {
+		return
+	}
+
+	if err := newUserService(DBConnection, nil).Bootstrap(ctx, cfg.AdminUsername, cfg.AdminPassword); err != nil {
+		log.Printf("could not create the admin user: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/controller"
 	repository "github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/service"
@@ -16,10 +17,10 @@ func warehousesRouter(superRouter *gin.RouterGroup, dbConnection *sql.DB) {
 
 	pr := superRouter.Group("/warehouses")
 	{
-		pr.POST("/", warehouseController.Create())
+		pr.POST("/", policy.Require(policy.Admin), warehouseController.Create())
 		pr.GET("/", warehouseController.GetAll())
 		pr.GET("/:id", warehouseController.GetById())
-		pr.PATCH("/:id", warehouseController.Update())
-		pr.DELETE("/:id", warehouseController.Delete())
+		pr.PATCH("/:id", policy.Require(policy.Admin), warehouseController.Update())
+		pr.DELETE("/:id", policy.Require(policy.Admin), warehouseController.Delete())
 	}
 }
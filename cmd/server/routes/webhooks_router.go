@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/controller"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/service"
+)
+
+func webhooksRouter(superRouter *gin.RouterGroup, DBConnection *sql.DB) {
+	repository := mariadb.NewMariaDBRepository(DBConnection)
+
+	webhookService := service.NewWebhookService(repository)
+
+	webhookController, _ := controller.NewWebhookController(webhookService)
+
+	pr := superRouter.Group("/webhooks", policy.Require(policy.Admin))
+	{
+		pr.GET("/", webhookController.GetAll())
+		pr.GET("/:id", webhookController.GetById())
+		pr.POST("/", webhookController.Create())
+		pr.PATCH("/:id", webhookController.Update())
+		pr.DELETE("/:id", webhookController.Delete())
+		pr.GET("/:id/deliveries", webhookController.GetDeliveries())
+		pr.POST("/:id/deliveries/:deliveryId/replay", webhookController.Replay())
+	}
+}
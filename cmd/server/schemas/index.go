@@ -1,9 +1,12 @@
 package schemas
 
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+
 type JSONSuccessResult struct {
 	Data interface{} `json:"data"`
 }
 
-type JSONBadReqResult struct {
-	Error interface{} `json:"error"`
+type JSONPaginatedResult struct {
+	Data interface{}  `json:"data"`
+	Meta listing.Meta `json:"meta"`
 }
@@ -2,25 +2,20 @@ package db
 
 import (
 	"database/sql"
-	"fmt"
 	"log"
-	"os"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/config"
 )
 
 var dbConnection *sql.DB
 
-func GetDBConnection() *sql.DB {
+func GetDBConnection(cfg config.DB) *sql.DB {
 	if dbConnection != nil {
 		return dbConnection
 	}
-	dataSource := fmt.Sprintf(
-		"%s:%s@tcp(localhost:%s)/%s?parseTime=true",
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASS"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_NAME"),
-	)
-	dbConnection, err := sql.Open("mysql", dataSource)
+
+	var err error
+	dbConnection, err = sql.Open("mysql", cfg.DSN())
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,37 @@
+SET FOREIGN_KEY_CHECKS = 0;
+
+DROP TABLE IF EXISTS `webhook_deliveries`;
+DROP TABLE IF EXISTS `webhook_subscriptions`;
+DROP TABLE IF EXISTS `outbox_events`;
+DROP TABLE IF EXISTS `audit_log`;
+DROP TABLE IF EXISTS `stock_transfers`;
+DROP TABLE IF EXISTS `stock_movements`;
+DROP TABLE IF EXISTS `product_batch_adjustments`;
+DROP TABLE IF EXISTS `temperature_incidents`;
+DROP TABLE IF EXISTS `section_temperature_rollups`;
+DROP TABLE IF EXISTS `section_temperature_readings`;
+DROP TABLE IF EXISTS `buyer_shelf_life_policies`;
+DROP TABLE IF EXISTS `stock_reservations`;
+DROP TABLE IF EXISTS `order_details`;
+DROP TABLE IF EXISTS `product_records`;
+DROP TABLE IF EXISTS `products_types`;
+DROP TABLE IF EXISTS `product_batches`;
+DROP TABLE IF EXISTS `inbound_orders`;
+DROP TABLE IF EXISTS `carriers`;
+DROP TABLE IF EXISTS `order_status_history`;
+DROP TABLE IF EXISTS `order_status`;
+DROP TABLE IF EXISTS `purchase_orders`;
+DROP TABLE IF EXISTS `rol`;
+DROP TABLE IF EXISTS `users_rol`;
+DROP TABLE IF EXISTS `users`;
+DROP TABLE IF EXISTS `countries`;
+DROP TABLE IF EXISTS `provinces`;
+DROP TABLE IF EXISTS `localities`;
+DROP TABLE IF EXISTS `buyers`;
+DROP TABLE IF EXISTS `employees`;
+DROP TABLE IF EXISTS `sections`;
+DROP TABLE IF EXISTS `warehouses`;
+DROP TABLE IF EXISTS `products`;
+DROP TABLE IF EXISTS `sellers`;
+
+SET FOREIGN_KEY_CHECKS = 1;
@@ -0,0 +1,430 @@
+CREATE TABLE `sellers` (
+  `id` INT AUTO_INCREMENT PRIMARY KEY,
+  `cid` VARCHAR(255) NOT NULL UNIQUE,
+  `company_name` VARCHAR(255) NOT NULL,
+  `address` VARCHAR(255) NOT NULL,
+  `telephone` VARCHAR(255) NOT NULL,
+  `locality_id` INT NOT NULL
+)ROW_FORMAT=DYNAMIC;
+
+CREATE TABLE `products` (
+  `id` INT AUTO_INCREMENT PRIMARY KEY,
+  `description` varchar(255) NOT NULL,
+  `expiration_rate` int NOT NULL,
+  `freezing_rate` int NOT NULL,
+  `height` decimal(19, 2) NOT NULL,
+  `length` decimal(19, 2) NOT NULL,
+  `net_weight` decimal(19, 2) NOT NULL,
+  `product_code` varchar(255) NOT NULL UNIQUE,
+  `recommended_freezing_temperature` decimal(19, 2) NOT NULL,
+  `width` decimal(19, 2) NOT NULL,
+  `product_type_id` int NOT NULL,
+  `seller_id` int NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `warehouses` (
+  `id` INT AUTO_INCREMENT PRIMARY KEY,
+  `address` varchar(255) NOT NULL,
+  `telephone` varchar(255) NOT NULL,
+  `warehouse_code` varchar(255) NOT NULL UNIQUE,
+  `minimum_capacity` int NOT NULL,
+  `minimum_temperature` DECIMAL(19,2) NOT NULL,
+  `locality_id` INT NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `sections` (
+  `id` INT AUTO_INCREMENT PRIMARY KEY,
+  `section_number` INT NOT NULL UNIQUE,
+  `current_temperature` DECIMAL(19,2) NOT NULL,
+  `minimum_temperature` DECIMAL(19,2) NOT NULL,
+  `current_capacity` INT NOT NULL,
+  `minimum_capacity` INT NOT NULL,
+  `maximum_capacity` int NOT NULL,
+  `warehouse_id` int NOT NULL,
+  `product_type_id` int NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `employees` (
+  `id` INT AUTO_INCREMENT PRIMARY KEY,
+  `card_number_id` VARCHAR(255) NOT NULL UNIQUE,
+  `first_name` VARCHAR(255) NOT NULL,
+  `last_name` VARCHAR(255) NOT NULL,
+  `warehouse_id` int NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `buyers` (
+  `id` INT AUTO_INCREMENT PRIMARY KEY,
+  `card_number_id` VARCHAR(255) NOT NULL UNIQUE,
+  `first_name` VARCHAR(255) NOT NULL,
+  `last_name` VARCHAR(255) NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `localities` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `locality_name` VARCHAR(255) NOT NULL UNIQUE,
+    `province_id` INT NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `provinces` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `province_name` VARCHAR(255) NOT NULL,
+    `id_country_fk` INT NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `countries` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `country_name` VARCHAR(255) NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `users` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `password` VARCHAR(255) NOT NULL,
+    `username` VARCHAR(255) NOT NULL UNIQUE,
+    `warehouse_id` INT,
+    `seller_id` INT
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `users_rol` (
+	`usuario_id` INT NOT NULL,
+    `rol_id` INT NOT NULL,
+    PRIMARY KEY (`usuario_id`, `rol_id`)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `rol` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `description` VARCHAR(255) NOT NULL,
+    `rol_name` VARCHAR(255) NOT NULL UNIQUE
+)ROW_FORMAT=DYNAMIC ;
+
+INSERT INTO `rol` (`id`, `rol_name`, `description`) VALUES
+    (1, 'admin', 'Manages users, roles and every resource'),
+    (2, 'employee', 'Works in the warehouse of the user'),
+    (3, 'seller', 'Manages the products of the seller of the user');
+
+CREATE TABLE `purchase_orders` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `order_number` VARCHAR(255) NOT NULL,
+    `order_date` DATETIME(6) NOT NULL,
+    `tracking_code` VARCHAR(255) NOT NULL,
+    `buyer_id` INT NOT NULL,
+    `carrier_id` INT NOT NULL,
+    `order_status_id` INT NOT NULL,
+    `warehouse_id` INT NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `order_status` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `description` VARCHAR(255) NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+INSERT INTO `order_status` (`id`, `description`) VALUES
+    (1, 'created'),
+    (2, 'picking'),
+    (3, 'shipped'),
+    (4, 'delivered'),
+    (5, 'cancelled');
+
+CREATE TABLE `order_status_history` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `purchase_order_id` INT NOT NULL,
+    `from_status_id` INT,
+    `to_status_id` INT NOT NULL,
+    `changed_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `carriers` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `cid` VARCHAR(255) NOT NULL UNIQUE,
+    `company_name` VARCHAR(255) NOT NULL,
+    `address` VARCHAR(255) NOT NULL,
+    `telephone` VARCHAR(255) NOT NULL,
+    `locality_id` INT NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `inbound_orders` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `order_date` DATETIME(6) NOT NULL,
+    `order_number` VARCHAR(255) NOT NULL UNIQUE,
+    `employee_id` INT NOT NULL,
+    `product_batch_id` INT NOT NULL,
+    `warehouse_id` INT NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `product_batches` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `batch_number` INT NOT NULL UNIQUE,
+    `current_quantity` INT,
+    `reserved_quantity` INT NOT NULL DEFAULT 0,
+    `current_temperature` DECIMAL(19,2),
+    `due_date` DATETIME(6),
+    `quarantined_at` DATETIME(6),
+    `initial_quantity` INT NOT NULL,
+    `manufacturing_date` DATETIME(6),
+    `manufacturing_hour` INT,
+    `minimum_temperature` DECIMAL(19,2),
+    `product_id` INT NOT NULL,
+    `section_id` INT NOT NULL,
+    FOREIGN KEY (`product_id`) REFERENCES `products`(`id`),
+    FOREIGN KEY (`section_id`) REFERENCES `sections`(`id`)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `products_types` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `description` VARCHAR(255) NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `product_records` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `last_update_date` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `purchase_price` DECIMAL(19,2) NOT NULL,
+    `sale_price` DECIMAL(19,2) NOT NULL,
+    `product_id` INT NOT NULL,
+    FOREIGN KEY (`product_id`) REFERENCES `products`(`id`),
+    INDEX (`product_id`, `last_update_date`)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `order_details` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `clean_liness_status` VARCHAR(255) NOT NULL,
+    `quantity` INT NOT NULL,
+    `temperature` DECIMAL(19,2),
+    `product_record_id` INT NOT NULL,
+    `purchase_order_id` INT NOT NULL,
+    FOREIGN KEY (`product_record_id`) REFERENCES `product_records`(`id`),
+    FOREIGN KEY (`purchase_order_id`) REFERENCES `purchase_orders`(`id`)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `stock_reservations` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `purchase_order_id` INT NOT NULL,
+    `order_detail_id` INT NOT NULL,
+    `product_batch_id` INT NOT NULL,
+    `quantity` INT NOT NULL
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `buyer_shelf_life_policies` (
+	`buyer_id` INT PRIMARY KEY,
+    `minimum_shelf_life_days` INT NOT NULL DEFAULT 0
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `section_temperature_readings` (
+	`id` BIGINT AUTO_INCREMENT PRIMARY KEY,
+    `section_id` INT NOT NULL,
+    `recorded_at` DATETIME(6) NOT NULL,
+    `temperature` DECIMAL(19,2) NOT NULL,
+    UNIQUE KEY `section_recorded_at` (`section_id`, `recorded_at`)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `section_temperature_rollups` (
+	`section_id` INT NOT NULL,
+    `hour` DATETIME NOT NULL,
+    `min_temperature` DECIMAL(19,2) NOT NULL,
+    `max_temperature` DECIMAL(19,2) NOT NULL,
+    `sum_temperature` DECIMAL(19,2) NOT NULL,
+    `readings_count` INT NOT NULL,
+    PRIMARY KEY (`section_id`, `hour`)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `temperature_incidents` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `kind` VARCHAR(32) NOT NULL,
+    `status` VARCHAR(16) NOT NULL DEFAULT 'open',
+    `section_id` INT NOT NULL,
+    `product_batch_id` INT,
+    `parent_id` INT,
+    `temperature` DECIMAL(19,2) NOT NULL,
+    `limit_temperature` DECIMAL(19,2) NOT NULL,
+    `started_at` DATETIME(6) NOT NULL,
+    `resolved_at` DATETIME(6),
+    `acknowledged_at` DATETIME(6),
+    `acknowledged_by` VARCHAR(255),
+    `open_key` VARCHAR(64) UNIQUE
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `product_batch_adjustments` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `product_batch_id` INT NOT NULL,
+    `reason` VARCHAR(32) NOT NULL,
+    `previous_quantity` INT NOT NULL,
+    `current_quantity` INT NOT NULL,
+    `previous_temperature` DECIMAL(19,2) NOT NULL,
+    `current_temperature` DECIMAL(19,2) NOT NULL,
+    `adjusted_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `stock_movements` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `product_batch_id` INT NOT NULL,
+    `movement_type` VARCHAR(32) NOT NULL,
+    `delta` INT NOT NULL,
+    `balance` INT NOT NULL,
+    `actor` VARCHAR(255) NOT NULL,
+    `inbound_order_id` INT,
+    `purchase_order_id` INT,
+    `transfer_id` INT,
+    `created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX (`product_batch_id`, `id`)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `stock_transfers` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `product_batch_id` INT NOT NULL,
+    `target_batch_id` INT,
+    `batch_number` INT,
+    `from_section_id` INT NOT NULL,
+    `to_section_id` INT NOT NULL,
+    `from_warehouse_id` INT NOT NULL,
+    `to_warehouse_id` INT NOT NULL,
+    `quantity` INT NOT NULL,
+    `status` VARCHAR(32) NOT NULL,
+    `actor` VARCHAR(255) NOT NULL,
+    `created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    `received_at` DATETIME(6),
+    INDEX (`status`)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `audit_log` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `actor` VARCHAR(255) NOT NULL,
+    `entity` VARCHAR(64) NOT NULL,
+    `entity_id` INT NOT NULL,
+    `action` VARCHAR(16) NOT NULL,
+    `before_data` JSON,
+    `after_data` JSON,
+    `created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX (`entity`, `entity_id`, `id`),
+    INDEX (`created_at`)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `outbox_events` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `event_type` VARCHAR(64) NOT NULL,
+    `entity` VARCHAR(64) NOT NULL,
+    `entity_id` INT NOT NULL,
+    `payload` JSON NOT NULL,
+    `occurred_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    `attempts` INT NOT NULL DEFAULT 0,
+    `next_attempt_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    `last_error` VARCHAR(1024),
+    `delivered_at` DATETIME(6),
+    INDEX (`delivered_at`, `next_attempt_at`)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `webhook_subscriptions` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `url` VARCHAR(2048) NOT NULL,
+    `secret` VARCHAR(255) NOT NULL,
+    `event_types` JSON NOT NULL,
+    `warehouse_id` INT,
+    `active` BOOLEAN NOT NULL DEFAULT TRUE,
+    `created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+)ROW_FORMAT=DYNAMIC ;
+
+CREATE TABLE `webhook_deliveries` (
+	`id` INT AUTO_INCREMENT PRIMARY KEY,
+    `subscription_id` INT NOT NULL,
+    `event_id` INT NOT NULL,
+    `status` VARCHAR(16) NOT NULL DEFAULT 'pending',
+    `attempts` INT NOT NULL DEFAULT 0,
+    `next_attempt_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    `last_error` VARCHAR(1024) NOT NULL DEFAULT '',
+    `last_status_code` INT NOT NULL DEFAULT 0,
+    `delivered_at` DATETIME(6),
+    `created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    UNIQUE (`subscription_id`, `event_id`),
+    INDEX (`status`, `next_attempt_at`)
+)ROW_FORMAT=DYNAMIC ;
+
+ALTER TABLE `products` ADD FOREIGN KEY (`seller_id`) REFERENCES `sellers` (`id`);
+
+ALTER TABLE `products` ADD FOREIGN KEY (`product_type_id`) REFERENCES `products_types` (`id`);
+
+ALTER TABLE `sections` ADD FOREIGN KEY (`warehouse_id`) REFERENCES `warehouses` (`id`);
+
+ALTER TABLE `sections` ADD FOREIGN KEY (`product_type_id`) REFERENCES `products_types` (`id`);
+
+ALTER TABLE `employees` ADD FOREIGN KEY (`warehouse_id`) REFERENCES `warehouses` (`id`);
+
+ALTER TABLE `warehouses` ADD FOREIGN KEY (`locality_id`) REFERENCES `localities` (`id`);
+
+ALTER TABLE `sellers` ADD FOREIGN KEY (`locality_id`) REFERENCES `localities` (`id`);
+
+ALTER TABLE `localities` ADD FOREIGN KEY (`province_id`) REFERENCES `provinces` (`id`);
+
+ALTER TABLE `provinces` ADD FOREIGN KEY (`id_country_fk`) REFERENCES `countries` (`id`);
+
+ALTER TABLE `users` ADD FOREIGN KEY (`warehouse_id`) REFERENCES `warehouses` (`id`);
+
+ALTER TABLE `users` ADD FOREIGN KEY (`seller_id`) REFERENCES `sellers` (`id`);
+
+ALTER TABLE `users_rol` ADD FOREIGN KEY (`usuario_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `users_rol` ADD FOREIGN KEY (`rol_id`) REFERENCES `rol` (`id`);
+
+ALTER TABLE `purchase_orders` ADD FOREIGN KEY (`buyer_id`) REFERENCES `buyers` (`id`);
+
+ALTER TABLE `purchase_orders` ADD FOREIGN KEY (`carrier_id`) REFERENCES `carriers` (`id`);
+
+ALTER TABLE `purchase_orders` ADD FOREIGN KEY (`order_status_id`) REFERENCES `order_status` (`id`);
+
+ALTER TABLE `purchase_orders` ADD FOREIGN KEY (`wareHouse_id`) REFERENCES `warehouses` (`id`);
+
+ALTER TABLE `order_status_history` ADD FOREIGN KEY (`purchase_order_id`) REFERENCES `purchase_orders` (`id`);
+
+ALTER TABLE `order_status_history` ADD FOREIGN KEY (`from_status_id`) REFERENCES `order_status` (`id`);
+
+ALTER TABLE `order_status_history` ADD FOREIGN KEY (`to_status_id`) REFERENCES `order_status` (`id`);
+
+ALTER TABLE `carriers` ADD FOREIGN KEY (`locality_id`) REFERENCES `localities` (`id`);
+
+ALTER TABLE `inbound_orders` ADD FOREIGN KEY (`employee_id`) REFERENCES `employees` (`id`);
+
+ALTER TABLE `inbound_orders` ADD FOREIGN KEY (`product_batch_id`) REFERENCES `product_batches` (`id`);
+
+ALTER TABLE `inbound_orders` ADD FOREIGN KEY (`warehouse_id`) REFERENCES `warehouses` (`id`);
+
+ALTER TABLE `stock_reservations` ADD FOREIGN KEY (`purchase_order_id`) REFERENCES `purchase_orders` (`id`);
+
+ALTER TABLE `stock_reservations` ADD FOREIGN KEY (`order_detail_id`) REFERENCES `order_details` (`id`);
+
+ALTER TABLE `stock_reservations` ADD FOREIGN KEY (`product_batch_id`) REFERENCES `product_batches` (`id`);
+
+ALTER TABLE `buyer_shelf_life_policies` ADD FOREIGN KEY (`buyer_id`) REFERENCES `buyers` (`id`);
+
+ALTER TABLE `section_temperature_readings` ADD FOREIGN KEY (`section_id`) REFERENCES `sections` (`id`);
+
+ALTER TABLE `section_temperature_rollups` ADD FOREIGN KEY (`section_id`) REFERENCES `sections` (`id`);
+
+ALTER TABLE `temperature_incidents` ADD FOREIGN KEY (`section_id`) REFERENCES `sections` (`id`);
+
+ALTER TABLE `temperature_incidents` ADD FOREIGN KEY (`product_batch_id`) REFERENCES `product_batches` (`id`) ON DELETE SET NULL;
+
+ALTER TABLE `temperature_incidents` ADD FOREIGN KEY (`parent_id`) REFERENCES `temperature_incidents` (`id`);
+
+ALTER TABLE `product_batch_adjustments` ADD FOREIGN KEY (`product_batch_id`) REFERENCES `product_batches` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `stock_movements` ADD FOREIGN KEY (`product_batch_id`) REFERENCES `product_batches` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `stock_movements` ADD FOREIGN KEY (`inbound_order_id`) REFERENCES `inbound_orders` (`id`) ON DELETE SET NULL;
+
+ALTER TABLE `stock_movements` ADD FOREIGN KEY (`purchase_order_id`) REFERENCES `purchase_orders` (`id`) ON DELETE SET NULL;
+
+ALTER TABLE `stock_movements` ADD FOREIGN KEY (`transfer_id`) REFERENCES `stock_transfers` (`id`) ON DELETE SET NULL;
+
+ALTER TABLE `stock_transfers` ADD FOREIGN KEY (`product_batch_id`) REFERENCES `product_batches` (`id`);
+
+ALTER TABLE `stock_transfers` ADD FOREIGN KEY (`target_batch_id`) REFERENCES `product_batches` (`id`);
+
+ALTER TABLE `stock_transfers` ADD FOREIGN KEY (`from_section_id`) REFERENCES `sections` (`id`);
+
+ALTER TABLE `stock_transfers` ADD FOREIGN KEY (`to_section_id`) REFERENCES `sections` (`id`);
+
+ALTER TABLE `stock_transfers` ADD FOREIGN KEY (`from_warehouse_id`) REFERENCES `warehouses` (`id`);
+
+ALTER TABLE `stock_transfers` ADD FOREIGN KEY (`to_warehouse_id`) REFERENCES `warehouses` (`id`);
+
+ALTER TABLE `webhook_subscriptions` ADD FOREIGN KEY (`warehouse_id`) REFERENCES `warehouses` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `webhook_deliveries` ADD FOREIGN KEY (`subscription_id`) REFERENCES `webhook_subscriptions` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `webhook_deliveries` ADD FOREIGN KEY (`event_id`) REFERENCES `outbox_events` (`id`) ON DELETE CASCADE;
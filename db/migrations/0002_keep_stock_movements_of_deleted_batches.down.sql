@@ -0,0 +1,5 @@
+-- The movements of deleted batches would break the constraint, and with it
+-- back they would have been deleted along with their batch.
+DELETE FROM `stock_movements` WHERE `product_batch_id` NOT IN (SELECT `id` FROM `product_batches`);
+
+ALTER TABLE `stock_movements` ADD CONSTRAINT `stock_movements_ibfk_1` FOREIGN KEY (`product_batch_id`) REFERENCES `product_batches` (`id`) ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+-- The ledger of a batch outlives it, closed by a write-off movement, so
+-- deleting the batch must no longer cascade to its movements.
+ALTER TABLE `stock_movements` DROP FOREIGN KEY `stock_movements_ibfk_1`;
@@ -0,0 +1,8 @@
+// Package migrations embeds the numbered migrations of the database, as
+// <version>_<name>.up.sql and <version>_<name>.down.sql pairs.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var Files embed.FS
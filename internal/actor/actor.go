@@ -0,0 +1,24 @@
+// Package actor carries the name of who is making a change through the
+// request context, so the records written deep in the repositories can say
+// who did it.
+package actor
+
+import "context"
+
+// System is the actor of the changes made outside a request, like the ones
+// done by the background jobs.
+const System = "system"
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, contextKey{}, name)
+}
+
+// FromContext returns the actor stored in ctx, or System when there is none.
+func FromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(contextKey{}).(string); ok && name != "" {
+		return name
+	}
+	return System
+}
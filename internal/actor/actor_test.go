@@ -0,0 +1,14 @@
+package actor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, System, FromContext(context.Background()))
+	assert.Equal(t, System, FromContext(NewContext(context.Background(), "")))
+	assert.Equal(t, "ana", FromContext(NewContext(context.Background(), "ana")))
+}
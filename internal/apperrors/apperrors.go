@@ -0,0 +1,84 @@
+// Package apperrors classifies the errors the modules return so controllers
+// can answer them with the same status codes everywhere.
+package apperrors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Kinds of error. Every *Error matches exactly one of them with errors.Is.
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrValidation = errors.New("validation failed")
+	// ErrForeignKey means a field refers to an entity that does not exist.
+	ErrForeignKey = errors.New("referenced entity not found")
+	// ErrUnauthorized means the caller could not be authenticated.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden means the caller may not do what it asked for.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Error is an error of a given kind. Entity and Field name the entity and
+// the field involved when they are known, as for foreign key and duplicate
+// key violations.
+type Error struct {
+	Kind    error
+	Entity  string
+	Field   string
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NotFound(message string) *Error {
+	return &Error{Kind: ErrNotFound, Message: message}
+}
+
+func Conflict(message string) *Error {
+	return &Error{Kind: ErrConflict, Message: message}
+}
+
+func Validation(message string) *Error {
+	return &Error{Kind: ErrValidation, Message: message}
+}
+
+func Unauthorized(message string) *Error {
+	return &Error{Kind: ErrUnauthorized, Message: message}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{Kind: ErrForbidden, Message: message}
+}
+
+// Status returns the HTTP status answering err: 401 for unauthorized, 403
+// for forbidden, 404 for not found, 409 for conflicts, 422 for validation
+// and foreign key errors and 500 otherwise.
+func Status(err error) int {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrValidation), errors.Is(err, ErrForeignKey):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,94 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatus(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"not found", NotFound("seller id not found"), http.StatusNotFound},
+		{"wrapped not found", fmt.Errorf("loading: %w", NotFound("seller id not found")), http.StatusNotFound},
+		{"conflict", Conflict("duplicated cid"), http.StatusConflict},
+		{"validation", Validation("invalid dates"), http.StatusUnprocessableEntity},
+		{"foreign key", &Error{Kind: ErrForeignKey}, http.StatusUnprocessableEntity},
+		{"unauthorized", Unauthorized("invalid token"), http.StatusUnauthorized},
+		{"forbidden", Forbidden("admins only"), http.StatusForbidden},
+		{"other", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.status, Status(c.err))
+		})
+	}
+}
+
+func TestSentinel(t *testing.T) {
+	errSellerNotFound := NotFound("seller id not found")
+
+	assert.ErrorIs(t, errSellerNotFound, errSellerNotFound)
+	assert.ErrorIs(t, errSellerNotFound, ErrNotFound)
+	assert.NotErrorIs(t, errSellerNotFound, NotFound("seller id not found"))
+	assert.NotErrorIs(t, errSellerNotFound, ErrConflict)
+}
+
+func TestFromMySQL(t *testing.T) {
+	t.Run("no referenced row", func(t *testing.T) {
+		err := FromMySQL(&mysql.MySQLError{
+			Number:  1452,
+			Message: "Cannot add or update a child row: a foreign key constraint fails (`mercado_fresco`.`products`, CONSTRAINT `products_ibfk_1` FOREIGN KEY (`seller_id`) REFERENCES `sellers` (`id`))",
+		})
+
+		var appErr *Error
+		assert.ErrorAs(t, err, &appErr)
+		assert.ErrorIs(t, err, ErrForeignKey)
+		assert.Equal(t, "sellers", appErr.Entity)
+		assert.Equal(t, "seller_id", appErr.Field)
+		assert.EqualError(t, err, "seller_id: no sellers with that id")
+	})
+
+	t.Run("row is referenced", func(t *testing.T) {
+		err := FromMySQL(&mysql.MySQLError{
+			Number:  1451,
+			Message: "Cannot delete or update a parent row: a foreign key constraint fails (`mercado_fresco`.`sections`, CONSTRAINT `sections_ibfk_1` FOREIGN KEY (`warehouse_id`) REFERENCES `warehouses` (`id`))",
+		})
+
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.EqualError(t, err, "still referenced by sections through warehouse_id")
+	})
+
+	t.Run("duplicate entry", func(t *testing.T) {
+		err := FromMySQL(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '42' for key 'sellers.cid'"})
+
+		var appErr *Error
+		assert.ErrorAs(t, err, &appErr)
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Equal(t, "sellers", appErr.Entity)
+		assert.Equal(t, "cid", appErr.Field)
+		assert.EqualError(t, err, `cid "42" is already in use`)
+	})
+
+	t.Run("unrecognized message", func(t *testing.T) {
+		err := FromMySQL(&mysql.MySQLError{Number: 1452, Message: "foreign key constraint fails"})
+
+		assert.ErrorIs(t, err, ErrForeignKey)
+		assert.EqualError(t, err, "referenced entity not found")
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		original := &mysql.MySQLError{Number: 1045, Message: "Access denied"}
+
+		assert.Equal(t, original, FromMySQL(original))
+		assert.Nil(t, FromMySQL(nil))
+	})
+}
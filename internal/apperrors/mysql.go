@@ -0,0 +1,65 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL error numbers translated by FromMySQL.
+const (
+	mysqlDuplicateEntry  = 1062
+	mysqlRowIsReferenced = 1451
+	mysqlNoReferencedRow = 1452
+)
+
+var (
+	foreignKeyPattern = regexp.MustCompile("`([^`]+)`, CONSTRAINT `[^`]+` FOREIGN KEY \\(`([^`]+)`\\) REFERENCES `([^`]+)`")
+	duplicatePattern  = regexp.MustCompile(`Duplicate entry '(.*)' for key '([^']+)'`)
+)
+
+// FromMySQL translates duplicate keys (1062), rows still referenced by
+// others (1451) and references to missing rows (1452) into conflict and
+// foreign key errors naming the table and column involved. Other errors are
+// returned unchanged.
+func FromMySQL(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return err
+	}
+
+	switch mysqlErr.Number {
+	case mysqlDuplicateEntry:
+		appErr := &Error{Kind: ErrConflict, Message: "duplicate entry", Err: err}
+		if match := duplicatePattern.FindStringSubmatch(mysqlErr.Message); match != nil {
+			key := match[2]
+			if i := strings.LastIndex(key, "."); i >= 0 {
+				appErr.Entity, key = key[:i], key[i+1:]
+			}
+			appErr.Field = key
+			appErr.Message = fmt.Sprintf("%s %q is already in use", key, match[1])
+		}
+		return appErr
+
+	case mysqlRowIsReferenced:
+		appErr := &Error{Kind: ErrConflict, Message: "still referenced by other records", Err: err}
+		if match := foreignKeyPattern.FindStringSubmatch(mysqlErr.Message); match != nil {
+			appErr.Entity, appErr.Field = match[1], match[2]
+			appErr.Message = fmt.Sprintf("still referenced by %s through %s", match[1], match[2])
+		}
+		return appErr
+
+	case mysqlNoReferencedRow:
+		appErr := &Error{Kind: ErrForeignKey, Message: ErrForeignKey.Error(), Err: err}
+		if match := foreignKeyPattern.FindStringSubmatch(mysqlErr.Message); match != nil {
+			appErr.Entity, appErr.Field = match[3], match[2]
+			appErr.Message = fmt.Sprintf("%s: no %s with that id", match[2], match[3])
+		}
+		return appErr
+	}
+
+	return err
+}
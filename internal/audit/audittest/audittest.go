@@ -0,0 +1,47 @@
+// Package audittest sets the sqlmock expectations of the audit records the
+// repositories write next to their changes.
+package audittest
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var queryInsert = regexp.QuoteMeta("INSERT INTO audit_log")
+
+func querySnapshot(table string) string {
+	return regexp.QuoteMeta(fmt.Sprintf("SELECT * FROM %s WHERE id = ? FOR UPDATE;", table))
+}
+
+// ExpectSnapshot expects the row id of table to be read before a change,
+// answering that it exists.
+func ExpectSnapshot(mock sqlmock.Sqlmock, table string, id int64) {
+	mock.ExpectQuery(querySnapshot(table)).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+}
+
+// ExpectMissingSnapshot expects the row id of table to be read before a
+// change, answering that it does not exist.
+func ExpectMissingSnapshot(mock sqlmock.Sqlmock, table string, id int64) {
+	mock.ExpectQuery(querySnapshot(table)).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+}
+
+// ExpectRecord expects the creation or update of the row id of table to be
+// recorded, reading the row again first.
+func ExpectRecord(mock sqlmock.Sqlmock, action, table string, id int64) {
+	ExpectSnapshot(mock, table, id)
+	ExpectEntry(mock, action, table, id)
+}
+
+// ExpectEntry expects the entry of action on the row id of table to be
+// written, alone for deletions, which do not read the row again.
+func ExpectEntry(mock sqlmock.Sqlmock, action, table string, id int64) {
+	mock.ExpectExec(queryInsert).
+		WithArgs(sqlmock.AnyArg(), table, id, action, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+)
+
+type AuditController struct {
+	service domain.AuditService
+}
+
+func NewAuditController(service domain.AuditService) (*AuditController, error) {
+	if service == nil {
+		return nil, errors.New("invalid service")
+	}
+
+	return &AuditController{
+		service: service,
+	}, nil
+}
+
+// @Summary Audit log
+// @Tags Audit
+// @Description get who created, updated or deleted what and when, with the columns each change touched
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entity query string false "Table of the changed rows, like warehouses or product_batches"
+// @Param id query int false "ID of the changed row"
+// @Param from query string false "Changes made from this time on (RFC 3339)"
+// @Param to query string false "Changes made up to this time (RFC 3339)"
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.Entry}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /audit [get]
+func (c AuditController) Search() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestSearch
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.EntryListFields)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		entries, total, err := c.service.Search(ctx.Request.Context(), req, params)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": entries,
+			"meta": listing.NewMeta(params, total),
+		})
+	}
+}
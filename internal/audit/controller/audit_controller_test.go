@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain/mocks"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearch(t *testing.T) {
+	serve := func(service domain.AuditService, url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		_, engine := gin.CreateTestContext(rec)
+
+		controller, err := NewAuditController(service)
+		assert.NoError(t, err)
+
+		engine.GET("/api/v1/audit", controller.Search())
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		from := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+		serviceMock := mocks.NewAuditService(t)
+		serviceMock.On("Search", mock.Anything,
+			domain.RequestSearch{Entity: "warehouses", Id: 3, From: &from},
+			listing.Params{Limit: listing.DefaultLimit},
+		).Return(&[]domain.Entry{{Id: 1, Entity: "warehouses", EntityId: 3, Action: domain.ActionDelete}}, int64(1), nil).Once()
+
+		rec := serve(serviceMock, "/api/v1/audit?entity=warehouses&id=3&from=2022-08-01T00:00:00Z")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"action":"delete"`)
+		assert.Contains(t, rec.Body.String(), `"total":1`)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		rec := serve(mocks.NewAuditService(t), "/api/v1/audit?id=abc")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		rec := serve(mocks.NewAuditService(t), "/api/v1/audit?from=yesterday")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
+
+// Actions recorded in the audit log.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Row is a snapshot of a table row, keyed by column name.
+type Row map[string]interface{}
+
+// Change is the value of a column before and after a mutation.
+type Change struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Entry records that Actor made Action on the row EntityId of the table
+// Entity. Before is nil for creations and After is nil for deletions.
+// Changes holds only the columns whose value differs between both.
+type Entry struct {
+	Id        int64             `json:"id"`
+	Actor     string            `json:"actor"`
+	Entity    string            `json:"entity"`
+	EntityId  int64             `json:"entity_id"`
+	Action    string            `json:"action"`
+	Before    Row               `json:"before"`
+	After     Row               `json:"after"`
+	Changes   map[string]Change `json:"changes"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// RequestSearch narrows the audit log to an entity, a row of it and a
+// window of time. Every field is optional.
+type RequestSearch struct {
+	Entity string     `form:"entity"`
+	Id     int64      `form:"id" binding:"omitempty,min=1"`
+	From   *time.Time `form:"from"`
+	To     *time.Time `form:"to"`
+}
+
+// EntryListFields lists the sort fields of the audit log. The filters come
+// from RequestSearch instead.
+var EntryListFields = listing.Fields{
+	Sort: []string{"id", "created_at"},
+}
+
+type AuditRepository interface {
+	Search(ctx context.Context, params listing.Params) (*[]Entry, int64, error)
+}
+
+type AuditService interface {
+	Search(ctx context.Context, request RequestSearch, params listing.Params) (*[]Entry, int64, error)
+}
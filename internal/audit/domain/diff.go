@@ -0,0 +1,24 @@
+package domain
+
+import "reflect"
+
+// Diff returns the columns whose value differs between before and after. A
+// column missing on one side, as every column of a creation or deletion is,
+// changes from or to nil.
+func Diff(before, after Row) map[string]Change {
+	changes := map[string]Change{}
+
+	for column, from := range before {
+		if to := after[column]; !reflect.DeepEqual(from, to) {
+			changes[column] = Change{From: from, To: to}
+		}
+	}
+
+	for column, to := range after {
+		if _, ok := before[column]; !ok && to != nil {
+			changes[column] = Change{To: to}
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("update", func(t *testing.T) {
+		changes := Diff(
+			Row{"id": 1.0, "address": "Rua A", "telephone": "555"},
+			Row{"id": 1.0, "address": "Rua B", "telephone": "555"},
+		)
+
+		assert.Equal(t, map[string]Change{"address": {From: "Rua A", To: "Rua B"}}, changes)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		changes := Diff(nil, Row{"id": 1.0, "locality_id": nil})
+
+		assert.Equal(t, map[string]Change{"id": {To: 1.0}}, changes)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		changes := Diff(Row{"id": 1.0, "address": "Rua A"}, nil)
+
+		assert.Equal(t, map[string]Change{"id": {From: 1.0}, "address": {From: "Rua A"}}, changes)
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		assert.Empty(t, Diff(Row{"id": 1.0}, Row{"id": 1.0}))
+	})
+}
@@ -0,0 +1,62 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditService is an autogenerated mock type for the AuditService type
+type AuditService struct {
+	mock.Mock
+}
+
+// Search provides a mock function with given fields: ctx, request, params
+func (_m *AuditService) Search(ctx context.Context, request domain.RequestSearch, params listing.Params) (*[]domain.Entry, int64, error) {
+	ret := _m.Called(ctx, request, params)
+
+	var r0 *[]domain.Entry
+	if rf, ok := ret.Get(0).(func(context.Context, domain.RequestSearch, listing.Params) *[]domain.Entry); ok {
+		r0 = rf(ctx, request, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Entry)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, domain.RequestSearch, listing.Params) int64); ok {
+		r1 = rf(ctx, request, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, domain.RequestSearch, listing.Params) error); ok {
+		r2 = rf(ctx, request, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewAuditService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAuditService creates a new instance of AuditService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAuditService(t mockConstructorTestingTNewAuditService) *AuditService {
+	mock := &AuditService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,160 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/actor"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
+
+type mariadbRepository struct {
+	db *sql.DB
+}
+
+func NewMariaDBRepository(db *sql.DB) domain.AuditRepository {
+	return mariadbRepository{db: db}
+}
+
+func (m mariadbRepository) Search(ctx context.Context, params listing.Params) (*[]domain.Entry, int64, error) {
+	entries := []domain.Entry{}
+
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlSearch, params, nil)
+	if err := m.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &entries, 0, err
+	}
+
+	query, args := listing.Build(sqlSearch, params, nil)
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return &entries, 0, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry domain.Entry
+		var before, after []byte
+
+		if err := rows.Scan(
+			&entry.Id,
+			&entry.Actor,
+			&entry.Entity,
+			&entry.EntityId,
+			&entry.Action,
+			&before,
+			&after,
+			&entry.CreatedAt,
+		); err != nil {
+			return &entries, 0, err
+		}
+
+		if entry.Before, err = unmarshalRow(before); err != nil {
+			return &entries, 0, err
+		}
+
+		if entry.After, err = unmarshalRow(after); err != nil {
+			return &entries, 0, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return &entries, total, rows.Err()
+}
+
+// Snapshot reads the row id of table inside tx, locking it until tx ends,
+// so that it can be handed to Record as the row before a change. It returns
+// nil when there is no such row.
+func Snapshot(ctx context.Context, tx *sql.Tx, table string, id int64) (domain.Row, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(sqlSnapshot, table), id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	if err := rows.Scan(pointers...); err != nil {
+		return nil, err
+	}
+
+	row := domain.Row{}
+	for i, column := range columns {
+		if raw, ok := values[i].([]byte); ok {
+			values[i] = string(raw)
+		}
+		row[column] = values[i]
+	}
+
+	return row, rows.Err()
+}
+
+// Record writes to the audit log, inside tx, that the actor of ctx made
+// action on the row id of table. before is the Snapshot taken ahead of the
+// change, nil for creations. The row as the change left it is read again,
+// unless it was deleted.
+func Record(ctx context.Context, tx *sql.Tx, action, table string, id int64, before domain.Row) error {
+	var after domain.Row
+	if action != domain.ActionDelete {
+		var err error
+		if after, err = Snapshot(ctx, tx, table, id); err != nil {
+			return err
+		}
+	}
+
+	beforeData, err := marshalRow(before)
+	if err != nil {
+		return err
+	}
+
+	afterData, err := marshalRow(after)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlInsert, actor.FromContext(ctx), table, id, action, beforeData, afterData)
+	return err
+}
+
+// marshalRow returns nil for a missing row, which is stored as NULL.
+func marshalRow(row domain.Row) (interface{}, error) {
+	if row == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func unmarshalRow(data []byte) (domain.Row, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var row domain.Row
+	err := json.Unmarshal(data, &row)
+	return row, err
+}
@@ -0,0 +1,135 @@
+package mariadb
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/actor"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	querySnapshot = regexp.QuoteMeta(fmt.Sprintf(sqlSnapshot, "warehouses"))
+	queryInsert   = regexp.QuoteMeta(sqlInsert)
+	querySearch   = regexp.QuoteMeta(sqlSearch)
+	queryCount    = regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlSearch)
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(querySnapshot).WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "address", "locality_id"}).AddRow(int64(1), []byte("Rua A"), nil))
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		row, err := Snapshot(context.Background(), tx, "warehouses", 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Row{"id": int64(1), "address": "Rua A", "locality_id": nil}, row)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(querySnapshot).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		row, err := Snapshot(context.Background(), tx, "warehouses", 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, row)
+	})
+}
+
+func TestRecord(t *testing.T) {
+	ctx := actor.NewContext(context.Background(), "ana")
+
+	t.Run("update", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(querySnapshot).WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "address"}).AddRow(int64(1), "Rua B"))
+		mock.ExpectExec(queryInsert).
+			WithArgs("ana", "warehouses", int64(1), domain.ActionUpdate, `{"address":"Rua A","id":1}`, `{"address":"Rua B","id":1}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		err = Record(ctx, tx, domain.ActionUpdate, "warehouses", 1, domain.Row{"id": int64(1), "address": "Rua A"})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsert).
+			WithArgs("ana", "warehouses", int64(1), domain.ActionDelete, `{"id":1}`, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		err = Record(ctx, tx, domain.ActionDelete, "warehouses", 1, domain.Row{"id": int64(1)})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSearch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+	params := listing.Params{
+		Limit:   10,
+		Filters: []listing.Filter{{Field: "entity", Value: "warehouses"}},
+	}
+
+	mock.ExpectQuery(queryCount).WithArgs("warehouses").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(querySearch).WithArgs("warehouses", int64(10), int64(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor", "entity", "entity_id", "action", "before_data", "after_data", "created_at"}).
+			AddRow(7, "ana", "warehouses", 1, domain.ActionCreate, nil, []byte(`{"id":1}`), createdAt))
+
+	entries, total, err := NewMariaDBRepository(db).Search(context.Background(), params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, []domain.Entry{{
+		Id:        7,
+		Actor:     "ana",
+		Entity:    "warehouses",
+		EntityId:  1,
+		Action:    domain.ActionCreate,
+		After:     domain.Row{"id": 1.0},
+		CreatedAt: createdAt,
+	}}, *entries)
+}
@@ -0,0 +1,7 @@
+package mariadb
+
+const (
+	sqlSnapshot = "SELECT * FROM %s WHERE id = ? FOR UPDATE;"
+	sqlInsert   = "INSERT INTO audit_log (actor, entity, entity_id, action, before_data, after_data) VALUES (?, ?, ?, ?, ?, ?);"
+	sqlSearch   = "SELECT id, actor, entity, entity_id, action, before_data, after_data, created_at FROM audit_log"
+)
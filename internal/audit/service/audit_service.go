@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
+
+// timeFormat is how the bounds of the search are compared with created_at.
+const timeFormat = "2006-01-02 15:04:05.999999"
+
+type auditService struct {
+	repository domain.AuditRepository
+}
+
+func NewAuditService(r domain.AuditRepository) domain.AuditService {
+	return &auditService{repository: r}
+}
+
+// Search returns the entries matching request, each with the columns its
+// change touched.
+func (s auditService) Search(
+	ctx context.Context,
+	request domain.RequestSearch,
+	params listing.Params,
+) (*[]domain.Entry, int64, error) {
+	if request.Entity != "" {
+		params.Filters = append(params.Filters, listing.Filter{Field: "entity", Value: request.Entity})
+	}
+
+	if request.Id != 0 {
+		params.Filters = append(params.Filters, listing.Filter{Field: "entity_id", Value: strconv.FormatInt(request.Id, 10)})
+	}
+
+	if request.From != nil {
+		params.Filters = append(params.Filters, listing.Filter{Field: "created_at", Op: ">=", Value: request.From.UTC().Format(timeFormat)})
+	}
+
+	if request.To != nil {
+		params.Filters = append(params.Filters, listing.Filter{Field: "created_at", Op: "<=", Value: request.To.UTC().Format(timeFormat)})
+	}
+
+	entries, total, err := s.repository.Search(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range *entries {
+		entry := &(*entries)[i]
+		entry.Changes = domain.Diff(entry.Before, entry.After)
+	}
+
+	return entries, total, nil
+}
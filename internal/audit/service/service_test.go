@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain/mocks"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearch(t *testing.T) {
+	t.Run("filters and changes", func(t *testing.T) {
+		from := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2022, 8, 2, 0, 0, 0, 0, time.UTC)
+		entries := []domain.Entry{{
+			Id:       1,
+			Entity:   "warehouses",
+			EntityId: 3,
+			Action:   domain.ActionUpdate,
+			Before:   domain.Row{"id": 3.0, "address": "Rua A"},
+			After:    domain.Row{"id": 3.0, "address": "Rua B"},
+		}}
+
+		repositoryMock := mocks.NewAuditRepository(t)
+		repositoryMock.On("Search", mock.Anything, listing.Params{
+			Limit: 10,
+			Filters: []listing.Filter{
+				{Field: "entity", Value: "warehouses"},
+				{Field: "entity_id", Value: "3"},
+				{Field: "created_at", Op: ">=", Value: "2022-08-01 00:00:00"},
+				{Field: "created_at", Op: "<=", Value: "2022-08-02 00:00:00"},
+			},
+		}).Return(&entries, int64(1), nil).Once()
+
+		result, total, err := NewAuditService(repositoryMock).Search(
+			context.Background(),
+			domain.RequestSearch{Entity: "warehouses", Id: 3, From: &from, To: &to},
+			listing.Params{Limit: 10},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, map[string]domain.Change{"address": {From: "Rua A", To: "Rua B"}}, (*result)[0].Changes)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		repositoryMock := mocks.NewAuditRepository(t)
+		repositoryMock.On("Search", mock.Anything, listing.Params{}).Return(nil, int64(0), errors.New("connection refused")).Once()
+
+		_, _, err := NewAuditService(repositoryMock).Search(context.Background(), domain.RequestSearch{}, listing.Params{})
+
+		assert.EqualError(t, err, "connection refused")
+	})
+}
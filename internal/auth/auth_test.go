@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/actor"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+	"github.com/stretchr/testify/assert"
+)
+
+var issuedAt = time.Date(2022, 7, 1, 10, 0, 0, 0, time.UTC)
+
+func newTestSigner(secret string) *Signer {
+	signer := NewSigner([]byte(secret), time.Hour)
+	signer.now = func() time.Time { return issuedAt }
+	return signer
+}
+
+func TestSigner(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		signer := newTestSigner("secret")
+
+		warehouseId := int64(3)
+		token, err := signer.Sign(Claims{Subject: "ana", UserId: 7, Roles: []string{"admin"}, WarehouseId: &warehouseId})
+		assert.NoError(t, err)
+
+		claims, err := signer.Parse(token)
+		assert.NoError(t, err)
+		assert.Equal(t, Claims{
+			Subject:     "ana",
+			UserId:      7,
+			Roles:       []string{"admin"},
+			WarehouseId: &warehouseId,
+			IssuedAt:    issuedAt.Unix(),
+			ExpiresAt:   issuedAt.Add(time.Hour).Unix(),
+		}, claims)
+		assert.True(t, claims.HasRole("admin"))
+		assert.False(t, claims.HasRole("seller"))
+	})
+
+	t.Run("default ttl", func(t *testing.T) {
+		assert.Equal(t, DefaultTTL, NewSigner([]byte("secret"), 0).TTL())
+	})
+
+	t.Run("other secret", func(t *testing.T) {
+		token, err := newTestSigner("secret").Sign(Claims{Subject: "ana", UserId: 7})
+		assert.NoError(t, err)
+
+		_, err = newTestSigner("other").Parse(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		signer := newTestSigner("secret")
+		token, err := signer.Sign(Claims{Subject: "ana", UserId: 7})
+		assert.NoError(t, err)
+
+		forged, err := signer.Sign(Claims{Subject: "admin", UserId: 1, Roles: []string{"admin"}})
+		assert.NoError(t, err)
+
+		parts, forgedParts := strings.Split(token, "."), strings.Split(forged, ".")
+		_, err = signer.Parse(parts[0] + "." + forgedParts[1] + "." + parts[2])
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := newTestSigner("secret").Parse("not-a-token")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		signer := newTestSigner("secret")
+		token, err := signer.Sign(Claims{Subject: "ana", UserId: 7})
+		assert.NoError(t, err)
+
+		signer.now = func() time.Time { return issuedAt.Add(time.Hour) }
+		_, err = signer.Parse(token)
+		assert.ErrorIs(t, err, ErrExpiredToken)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	signer := newTestSigner("secret")
+	token, err := signer.Sign(Claims{Subject: "ana", UserId: 7, Roles: []string{"admin"}})
+	assert.NoError(t, err)
+
+	serve := func(authorization string) (*httptest.ResponseRecorder, *Claims, string) {
+		rec := httptest.NewRecorder()
+		_, engine := gin.CreateTestContext(rec)
+
+		var (
+			claims *Claims
+			name   string
+		)
+		engine.Use(Middleware(signer))
+		engine.GET("/", func(ctx *gin.Context) {
+			if c, ok := FromContext(ctx.Request.Context()); ok {
+				claims = &c
+			}
+			name = actor.FromContext(ctx.Request.Context())
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		engine.ServeHTTP(rec, req)
+
+		return rec, claims, name
+	}
+
+	t.Run("authenticated", func(t *testing.T) {
+		rec, claims, name := serve("Bearer " + token)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, int64(7), claims.UserId)
+		assert.Equal(t, "ana", name)
+	})
+
+	for _, c := range []struct {
+		name, authorization, detail string
+	}{
+		{"no header", "", "missing bearer token"},
+		{"other scheme", "Basic YW5hOnNlY3JldA==", "missing bearer token"},
+		{"invalid token", "Bearer " + token + "x", "invalid token"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			rec, claims, _ := serve(c.authorization)
+
+			var p problem.Problem
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+			assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+			assert.Equal(t, problem.TypeUnauthorized, p.Type)
+			assert.Equal(t, c.detail, p.Detail)
+			assert.Nil(t, claims)
+		})
+	}
+}
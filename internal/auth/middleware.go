@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/actor"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+)
+
+var ErrMissingToken = apperrors.Unauthorized("missing bearer token")
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, contextKey{}, claims)
+}
+
+// FromContext returns the claims of the authenticated user of ctx.
+func FromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(contextKey{}).(Claims)
+	return claims, ok
+}
+
+// Middleware rejects the requests without a valid bearer token. The claims
+// of the token are stored in the request context, and its user becomes the
+// actor of the request.
+func Middleware(signer *Signer) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token, ok := bearer(ctx.GetHeader("Authorization"))
+		if !ok {
+			unauthorized(ctx, ErrMissingToken)
+			return
+		}
+
+		claims, err := signer.Parse(token)
+		if err != nil {
+			unauthorized(ctx, err)
+			return
+		}
+
+		c := NewContext(ctx.Request.Context(), claims)
+		ctx.Request = ctx.Request.WithContext(actor.NewContext(c, claims.Subject))
+		ctx.Next()
+	}
+}
+
+func bearer(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func unauthorized(ctx *gin.Context, err error) {
+	ctx.Header("WWW-Authenticate", `Bearer realm="mercado-fresco"`)
+	problem.AbortError(ctx, err)
+}
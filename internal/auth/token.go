@@ -0,0 +1,119 @@
+// Package auth issues and checks the signed tokens of the API users. Tokens
+// are JWTs signed with HMAC-SHA256 (HS256).
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+)
+
+// DefaultTTL is how long tokens last when no other duration is configured.
+const DefaultTTL = time.Hour
+
+var (
+	ErrInvalidToken = apperrors.Unauthorized("invalid token")
+	ErrExpiredToken = apperrors.Unauthorized("token has expired")
+)
+
+// header is the same for every token, so it is encoded once.
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims identify the user a token was issued to. WarehouseId and SellerId
+// are set for the users that work for a warehouse or a seller.
+type Claims struct {
+	Subject     string   `json:"sub"`
+	UserId      int64    `json:"uid"`
+	Roles       []string `json:"roles"`
+	WarehouseId *int64   `json:"wid,omitempty"`
+	SellerId    *int64   `json:"sid,omitempty"`
+	IssuedAt    int64    `json:"iat"`
+	ExpiresAt   int64    `json:"exp"`
+}
+
+// HasRole tells whether role is among the roles of the claims.
+func (c Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewSigner signs tokens with secret that expire after ttl, or after
+// DefaultTTL when ttl is not positive.
+func NewSigner(secret []byte, ttl time.Duration) *Signer {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Signer{secret: secret, ttl: ttl, now: time.Now}
+}
+
+// TTL is how long the tokens of s last.
+func (s *Signer) TTL() time.Duration {
+	return s.ttl
+}
+
+// Sign issues a token with claims, filling in when it was issued and when
+// it expires.
+func (s *Signer) Sign(claims Claims) (string, error) {
+	now := s.now()
+	claims.IssuedAt = now.Unix()
+	claims.ExpiresAt = now.Add(s.ttl).Unix()
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + s.signature(unsigned), nil
+}
+
+// Parse checks the signature and expiry of token and returns its claims.
+func (s *Signer) Parse(token string) (Claims, error) {
+	var claims Claims
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return claims, ErrInvalidToken
+	}
+
+	expected := s.signature(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(parts[2]), []byte(expected)) {
+		return claims, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, ErrInvalidToken
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, ErrInvalidToken
+	}
+
+	if s.now().Unix() >= claims.ExpiresAt {
+		return claims, ErrExpiredToken
+	}
+
+	return claims, nil
+}
+
+func (s *Signer) signature(unsigned string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/buyers/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
 )
 
 type BuyerController struct {
@@ -40,21 +42,37 @@ func NewBuyerController(buyer domain.BuyerService) (*BuyerController, error) {
 // @Description get all buyers
 // @Accept json
 // @Produce json
-// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Buyer}
-// @Failure 500 {object} schemas.JSONBadReqResult{error=string}
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Param card_number_id query string false "Card number ID"
+// @Param first_name query string false "First name"
+// @Param last_name query string false "Last name"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.Buyer}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /buyers [get]
 func (c BuyerController) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		buyers, err := c.buyer.GetAll(ctx.Request.Context())
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.BuyerListFields)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		buyers, total, err := c.buyer.GetAll(ctx.Request.Context(), params)
 
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"message": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusInternalServerError, err)
 			return
 		}
 
-		ctx.JSON(http.StatusOK, buyers)
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": buyers,
+			"meta": listing.NewMeta(params, total),
+		})
 	}
 }
 
@@ -63,27 +81,25 @@ func (c BuyerController) GetAll() gin.HandlerFunc {
 // @Description get buyer by it's id
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Buyer ID"
 // @Success 200 {object} domain.Buyer
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /buyers/{id} [get]
 func (c BuyerController) GetById() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
 		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"message": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		buyer, err := c.buyer.GetById(ctx, id)
+		buyer, err := c.buyer.GetById(ctx.Request.Context(), id)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{
-				"message": err.Error(),
-			})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -96,33 +112,24 @@ func (c BuyerController) GetById() gin.HandlerFunc {
 // @Description Add a new buyer to the list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param buyer body domain.RequestBuyer true "Buyer to create"
 // @Success 201 {object} domain.Buyer
-// @Failure 409 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /buyers [post]
 func (c BuyerController) Create() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req request
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"message": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		buyer, err := c.buyer.Create(ctx, req.CardNumberID, req.FirstName, req.LastName)
+		buyer, err := c.buyer.Create(ctx.Request.Context(), req.CardNumberID, req.FirstName, req.LastName)
 
 		if err != nil {
-			if errors.Is(err, domain.ErrDuplicatedID) {
-				ctx.JSON(http.StatusConflict, gin.H{
-					"message": err.Error(),
-				})
-				return
-			}
-			ctx.JSON(http.StatusUnprocessableEntity, gin.H{
-				"message": err.Error(),
-			})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -135,36 +142,32 @@ func (c BuyerController) Create() gin.HandlerFunc {
 // @Description Update existing buyer in list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Buyer ID"
 // @Param buyer body domain.RequestBuyer true "Buyer to update"
 // @Success 200 {object} domain.Buyer
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /buyers/{id} [patch]
 func (c BuyerController) Update() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"message": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
 		var req request
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		buyer, err := c.buyer.Update(ctx, id, req.CardNumberID, req.FirstName, req.LastName)
+		buyer, err := c.buyer.Update(ctx.Request.Context(), id, req.CardNumberID, req.FirstName, req.LastName)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{
-				"message": err.Error(),
-			})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -177,26 +180,24 @@ func (c BuyerController) Update() gin.HandlerFunc {
 // @Description Delete existing buyer in list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "buyer ID"
 // @Success 204 {object} schemas.JSONSuccessResult{data=string}
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
 // @Router /buyers/{id} [delete]
 func (c BuyerController) Delete() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"message": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		err = c.buyer.Delete(ctx, id)
+		err = c.buyer.Delete(ctx.Request.Context(), id)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{
-				"message": err.Error(),
-			})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -209,10 +210,12 @@ func (c BuyerController) Delete() gin.HandlerFunc {
 // @Description Get quantity of purchase orders for buyer
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id query int false "buyer ID"
 // @Success 201 {object} domain.PurchaseOrdersResponse
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /buyers/reportPurchaseOrders [get]
 func (c BuyerController) ReportPurchaseOrders() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -220,12 +223,10 @@ func (c BuyerController) ReportPurchaseOrders() gin.HandlerFunc {
 		id, _ := strconv.ParseInt(ctx.Query("id"), 10, 64)
 
 		if id == 0 {
-			report, err := c.buyer.ReportAllPurchaseOrders(ctx)
+			report, err := c.buyer.ReportAllPurchaseOrders(ctx.Request.Context())
 
 			if err != nil {
-				ctx.JSON(http.StatusInternalServerError, gin.H{
-					"message": err.Error(),
-				})
+				problem.Abort(ctx, http.StatusInternalServerError, err)
 				return
 			}
 
@@ -235,11 +236,9 @@ func (c BuyerController) ReportPurchaseOrders() gin.HandlerFunc {
 			return
 		}
 
-		report, err := c.buyer.ReportPurchaseOrders(ctx, id)
+		report, err := c.buyer.ReportPurchaseOrders(ctx.Request.Context(), id)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{
-				"message": err.Error(),
-			})
+			problem.AbortError(ctx, err)
 			return
 		}
 
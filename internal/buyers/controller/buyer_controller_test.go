@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/buyers/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/buyers/mocks"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
@@ -58,7 +59,7 @@ func TestCreateNewBuyer(t *testing.T) {
 			mock.Anything,
 			mock.Anything,
 			mock.Anything,
-		).Return(mockBuyerBad, errors.New("error: unprocessable entity")).Maybe()
+		).Return(mockBuyerBad, apperrors.Validation("first_name is required")).Maybe()
 
 		payload, err := json.Marshal(mockBuyer)
 		assert.NoError(t, err)
@@ -149,7 +150,8 @@ func TestGetAll(t *testing.T) {
 
 		buyerServiceMock.On("GetAll",
 			mock.Anything,
-		).Return(&mockBuyer, nil).Once()
+			mock.Anything,
+		).Return(&mockBuyer, int64(len(mockBuyer)), nil).Once()
 
 		payload, err := json.Marshal(mockBuyer)
 		assert.NoError(t, err)
@@ -175,7 +177,8 @@ func TestGetAll(t *testing.T) {
 
 		buyerServiceMock.On("GetAll",
 			mock.Anything,
-		).Return(mockBuyerBad, errors.New("Internal server error")).Maybe()
+			mock.Anything,
+		).Return(mockBuyerBad, int64(0), errors.New("Internal server error")).Maybe()
 
 		payload, err := json.Marshal(mockBuyerBad)
 		assert.NoError(t, err)
@@ -261,7 +264,7 @@ func TestGetById(t *testing.T) {
 		buyerServiceMock.On("GetById",
 			mock.Anything,
 			mock.AnythingOfType("int64"),
-		).Return(nil, errors.New("expected conflict error")).Maybe()
+		).Return(nil, domain.ErrIDNotFound).Maybe()
 
 		payload, err := json.Marshal(mockBuyer)
 		assert.NoError(t, err)
@@ -282,6 +285,31 @@ func TestGetById(t *testing.T) {
 
 		buyerServiceMock.AssertExpectations(t)
 	})
+
+	t.Run("In case of database failure", func(t *testing.T) {
+		buyerServiceMock := mocks.NewBuyerService(t)
+
+		buyerServiceMock.On("GetById",
+			mock.Anything,
+			mock.AnythingOfType("int64"),
+		).Return(nil, errors.New("connection refused")).Once()
+
+		PATH := fmt.Sprintf("/api/v1/buyers/%v", utils.RandomInt(0, 999))
+		req := httptest.NewRequest(http.MethodGet, PATH, nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		buyerController := BuyerController{buyer: buyerServiceMock}
+
+		engine.GET("/api/v1/buyers/:id", buyerController.GetById())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		buyerServiceMock.AssertExpectations(t)
+	})
 }
 
 func TestUpdate(t *testing.T) {
@@ -372,13 +400,15 @@ func TestUpdate(t *testing.T) {
 	})
 
 	t.Run("In case of nonexisting buyer", func(t *testing.T) {
+		buyerServiceMock := mocks.NewBuyerService(t)
+
 		buyerServiceMock.On("Update",
 			mock.Anything,
 			mock.Anything,
 			mock.Anything,
 			mock.Anything,
 			mock.Anything,
-		).Return(nil, errors.New("id not found error")).Maybe()
+		).Return(nil, domain.ErrIDNotFound).Maybe()
 
 		payload, err := json.Marshal(mockBuyer)
 		assert.NoError(t, err)
@@ -456,7 +486,7 @@ func TestDelete(t *testing.T) {
 		buyerServiceMock.On("Delete",
 			mock.Anything,
 			mock.AnythingOfType("int64"),
-		).Return(errors.New("expected conflict error")).Maybe()
+		).Return(domain.ErrIDNotFound).Maybe()
 
 		PATH := fmt.Sprintf("/api/v1/buyers/%v", utils.RandomInt(0, 999))
 		req := httptest.NewRequest(http.MethodDelete, PATH, nil)
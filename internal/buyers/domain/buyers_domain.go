@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 )
 
 type Buyer struct {
@@ -25,8 +27,13 @@ type PurchaseOrdersResponse struct {
 	PurchaseOrdersCount int64  `json:"purchase_orders_count"`
 }
 
+var BuyerListFields = listing.Fields{
+	Sort:   []string{"id", "card_number_id", "first_name", "last_name"},
+	Filter: []string{"card_number_id", "first_name", "last_name"},
+}
+
 type BuyerRepository interface {
-	GetAll(ctx context.Context) (*[]Buyer, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]Buyer, int64, error)
 	GetById(ctx context.Context, id int64) (*Buyer, error)
 	GetByCardNumberId(ctx context.Context, cardNumberId string) (*Buyer, error)
 	Create(ctx context.Context, cardNumberId, firstName, lastName string) (*Buyer, error)
@@ -37,7 +44,7 @@ type BuyerRepository interface {
 }
 
 type BuyerService interface {
-	GetAll(ctx context.Context) (*[]Buyer, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]Buyer, int64, error)
 	GetById(ctx context.Context, id int64) (*Buyer, error)
 	Create(ctx context.Context, cardNumberId, firstName, lastName string) (*Buyer, error)
 	Update(ctx context.Context, id int64, cardNumberId, firstName, lastName string) (*Buyer, error)
@@ -1,8 +1,8 @@
 package domain
 
-import "errors"
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
 
 var (
-	ErrIDNotFound   = errors.New("buyer id not found")
-	ErrDuplicatedID = errors.New("duplicated card_number_id")
+	ErrIDNotFound   = apperrors.NotFound("buyer id not found")
+	ErrDuplicatedID = apperrors.Conflict("duplicated card_number_id")
 )
@@ -5,7 +5,11 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	auditlog "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/buyers/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 )
 
 type mariadbRepository struct {
@@ -16,12 +20,19 @@ func NewMariaDBRepository(db *sql.DB) domain.BuyerRepository {
 	return mariadbRepository{db: db}
 }
 
-func (m mariadbRepository) GetAll(ctx context.Context) (*[]domain.Buyer, error) {
+func (m mariadbRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Buyer, int64, error) {
 	var buyers []domain.Buyer = []domain.Buyer{}
 
-	rows, err := m.db.QueryContext(ctx, sqlGetAll)
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAll, params, nil)
+	if err := m.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &buyers, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAll, params, nil)
+	rows, err := m.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return &buyers, err
+		return &buyers, 0, err
 	}
 
 	defer rows.Close()
@@ -35,13 +46,13 @@ func (m mariadbRepository) GetAll(ctx context.Context) (*[]domain.Buyer, error)
 			&buyer.FirstName,
 			&buyer.LastName,
 		); err != nil {
-			return &buyers, err
+			return &buyers, 0, err
 		}
 
 		buyers = append(buyers, buyer)
 	}
 
-	return &buyers, nil
+	return &buyers, total, nil
 }
 
 func (m mariadbRepository) GetById(ctx context.Context, id int64) (*domain.Buyer, error) {
@@ -101,9 +112,16 @@ func (m mariadbRepository) Create(ctx context.Context, cardNumberId, firstName,
 		LastName:     lastName,
 	}
 
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &newBuyer, err
+	}
+
+	defer tx.Rollback()
+
 	query := sqlInsert
 
-	result, err := m.db.ExecContext(
+	result, err := tx.ExecContext(
 		ctx,
 		query,
 		&newBuyer.CardNumberID,
@@ -111,7 +129,7 @@ func (m mariadbRepository) Create(ctx context.Context, cardNumberId, firstName,
 		&newBuyer.LastName,
 	)
 	if err != nil {
-		return &newBuyer, err
+		return &newBuyer, apperrors.FromMySQL(err)
 	}
 
 	lastID, err := result.LastInsertId()
@@ -121,7 +139,11 @@ func (m mariadbRepository) Create(ctx context.Context, cardNumberId, firstName,
 
 	newBuyer.ID = lastID
 
-	return &newBuyer, nil
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "buyers", lastID, nil); err != nil {
+		return &newBuyer, err
+	}
+
+	return &newBuyer, tx.Commit()
 }
 
 func (m mariadbRepository) Update(ctx context.Context, id int64, cardNumberId, firstName, lastName string) (*domain.Buyer, error) {
@@ -132,9 +154,21 @@ func (m mariadbRepository) Update(ctx context.Context, id int64, cardNumberId, f
 		LastName:     lastName,
 	}
 
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &newBuyer, err
+	}
+
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "buyers", id)
+	if err != nil {
+		return &newBuyer, err
+	}
+
 	query := sqlUpdate
 
-	result, err := m.db.ExecContext(
+	result, err := tx.ExecContext(
 		ctx,
 		query,
 		&newBuyer.CardNumberID,
@@ -143,7 +177,7 @@ func (m mariadbRepository) Update(ctx context.Context, id int64, cardNumberId, f
 		&newBuyer.ID,
 	)
 	if err != nil {
-		return &newBuyer, err
+		return &newBuyer, apperrors.FromMySQL(err)
 	}
 
 	affectedRows, err := result.RowsAffected()
@@ -155,15 +189,31 @@ func (m mariadbRepository) Update(ctx context.Context, id int64, cardNumberId, f
 		return &newBuyer, err
 	}
 
-	return &newBuyer, nil
+	if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "buyers", id, before); err != nil {
+		return &newBuyer, err
+	}
+
+	return &newBuyer, tx.Commit()
 }
 
 func (m mariadbRepository) Delete(ctx context.Context, id int64) error {
-	result, err := m.db.ExecContext(ctx, sqlDelete, id)
+	tx, err := m.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "buyers", id)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, sqlDelete, id)
+	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
 	affectedRows, err := result.RowsAffected()
 
 	if affectedRows == 0 {
@@ -174,7 +224,11 @@ func (m mariadbRepository) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
-	return nil
+	if err := auditlog.Record(ctx, tx, audit.ActionDelete, "buyers", id, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (m mariadbRepository) ReportAllPurchaseOrders(ctx context.Context) (*[]domain.PurchaseOrdersResponse, error) {
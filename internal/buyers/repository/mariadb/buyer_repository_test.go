@@ -7,7 +7,10 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/buyers/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,6 +18,7 @@ import (
 var (
 	queryInsert                = regexp.QuoteMeta(sqlInsert)
 	queryGetAll                = regexp.QuoteMeta(sqlGetAll)
+	queryCountAll              = regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)
 	queryGetById               = regexp.QuoteMeta(sqlGetById)
 	queryUpdate                = regexp.QuoteMeta(sqlUpdate)
 	queryDelete                = regexp.QuoteMeta(sqlDelete)
@@ -44,12 +48,15 @@ func TestCreateNewBuyer(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsert).
 			WithArgs(
 				mockBuyer.CardNumberID,
 				mockBuyer.FirstName,
 				mockBuyer.LastName,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "buyers", 1)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 
@@ -64,6 +71,7 @@ func TestCreateNewBuyer(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsert).
 			WithArgs(0, 0, 0).
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -93,12 +101,14 @@ func TestGetAll(t *testing.T) {
 			)
 		}
 
+		mock.ExpectQuery(queryCountAll).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(mockBuyers)))
 		mock.ExpectQuery(queryGetAll).WillReturnRows(rows)
 
 		buyersRepo := NewMariaDBRepository(db)
 
-		result, err := buyersRepo.GetAll(context.Background())
+		result, total, err := buyersRepo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 		assert.NoError(t, err)
+		assert.Equal(t, int64(len(mockBuyers)), total)
 
 		assert.Equal(t, result, &mockBuyers)
 	})
@@ -110,11 +120,12 @@ func TestGetAll(t *testing.T) {
 
 		rows := sqlmock.NewRows(rowsStruct).AddRow("", "", "", "")
 
+		mock.ExpectQuery(queryCountAll).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(queryGetAll).WillReturnRows(rows)
 
 		buyersRepo := NewMariaDBRepository(db)
 
-		_, err = buyersRepo.GetAll(context.Background())
+		_, _, err = buyersRepo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 		assert.Error(t, err)
 	})
 
@@ -123,11 +134,12 @@ func TestGetAll(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectQuery(queryCountAll).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(queryGetAll).WillReturnError(sql.ErrNoRows)
 
 		buyersRepo := NewMariaDBRepository(db)
 
-		_, err = buyersRepo.GetAll(context.Background())
+		_, _, err = buyersRepo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 		assert.Error(t, err)
 	})
 }
@@ -194,6 +206,8 @@ func TestUpdateBuyer(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "buyers", mockBuyer.ID)
 		mock.ExpectExec(queryUpdate).
 			WithArgs(
 				mockBuyer.CardNumberID,
@@ -201,6 +215,8 @@ func TestUpdateBuyer(t *testing.T) {
 				mockBuyer.LastName,
 				mockBuyer.ID,
 			).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "buyers", mockBuyer.ID)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 
@@ -215,6 +231,8 @@ func TestUpdateBuyer(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "buyers", mockBuyer.ID)
 		mock.ExpectExec(queryUpdate).
 			WithArgs(0, 0, 0, 0).
 			WillReturnResult(sqlmock.NewResult(0, 1))
@@ -229,6 +247,8 @@ func TestUpdateBuyer(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "buyers", mockBuyer.ID)
 		mock.ExpectExec(queryUpdate).
 			WithArgs(
 				mockBuyer.CardNumberID,
@@ -253,10 +273,14 @@ func TestDeleteBuyer(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "buyers", mockBuyer.ID)
 		mock.ExpectExec(queryDelete).
 			WithArgs(
 				mockBuyer.ID,
 			).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectEntry(mock, audit.ActionDelete, "buyers", mockBuyer.ID)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 
@@ -269,6 +293,8 @@ func TestDeleteBuyer(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "buyers", mockBuyer.ID)
 		mock.ExpectExec(queryDelete).
 			WithArgs(0).
 			WillReturnResult(sqlmock.NewResult(0, 1))
@@ -283,6 +309,8 @@ func TestDeleteBuyer(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "buyers", mockBuyer.ID)
 		mock.ExpectExec(queryDelete).
 			WithArgs(mockBuyer.ID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
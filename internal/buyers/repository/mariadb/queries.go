@@ -2,7 +2,7 @@ package mariadb
 
 const (
 	sqlInsert                     = "INSERT INTO buyers (card_number_id, first_name, last_name) VALUES (?, ?, ?);"
-	sqlGetAll                     = "SELECT * FROM buyers;"
+	sqlGetAll                     = "SELECT * FROM buyers"
 	sqlGetById                    = "SELECT * FROM buyers WHERE ID = ?;"
 	sqlGetByCardNumberId          = "SELECT * FROM buyers WHERE card_number_id = ?;"
 	sqlUpdate                     = "UPDATE buyers SET card_number_id=?, first_name=?, last_name=? WHERE id=?;"
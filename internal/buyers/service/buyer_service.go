@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/buyers/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 )
 
 type buyerService struct {
@@ -14,13 +15,13 @@ func NewBuyerService(sr domain.BuyerRepository) domain.BuyerService {
 	return &buyerService{repository: sr}
 }
 
-func (s buyerService) GetAll(ctx context.Context) (*[]domain.Buyer, error) {
-	buyers, err := s.repository.GetAll(ctx)
+func (s buyerService) GetAll(ctx context.Context, params listing.Params) (*[]domain.Buyer, int64, error) {
+	buyers, total, err := s.repository.GetAll(ctx, params)
 	if err != nil {
-		return buyers, err
+		return buyers, 0, err
 	}
 
-	return buyers, nil
+	return buyers, total, nil
 }
 
 func (s buyerService) GetById(ctx context.Context, id int64) (*domain.Buyer, error) {
@@ -7,6 +7,7 @@ import (
 
 	. "github.com/marcoglnd/mercado-fresco-packmain/internal/buyers/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/buyers/mocks"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -59,11 +60,11 @@ func TestGetAll(t *testing.T) {
 	mockBuyers := utils.CreateRandomListBuyers()
 
 	t.Run("In case of success", func(t *testing.T) {
-		mockBuyerRepo.On("GetAll", mock.Anything).
-			Return(&mockBuyers, nil).Once()
+		mockBuyerRepo.On("GetAll", mock.Anything, mock.Anything).
+			Return(&mockBuyers, int64(len(mockBuyers)), nil).Once()
 
 		s := NewBuyerService(mockBuyerRepo)
-		list, err := s.GetAll(context.Background())
+		list, _, err := s.GetAll(context.Background(), listing.Params{})
 
 		assert.NoError(t, err)
 
@@ -73,12 +74,12 @@ func TestGetAll(t *testing.T) {
 	})
 
 	t.Run("In case of error", func(t *testing.T) {
-		mockBuyerRepo.On("GetAll", mock.Anything).
-			Return(nil, errors.New("failed to retrieve buyers")).
+		mockBuyerRepo.On("GetAll", mock.Anything, mock.Anything).
+			Return(nil, int64(0), errors.New("failed to retrieve buyers")).
 			Once()
 
 		s := NewBuyerService(mockBuyerRepo)
-		_, err := s.GetAll(context.Background())
+		_, _, err := s.GetAll(context.Background(), listing.Params{})
 
 		assert.NotNil(t, err)
 
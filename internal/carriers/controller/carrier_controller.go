@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/carriers/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
 )
 
 type CarrierController struct {
@@ -20,27 +21,22 @@ func NewCarrierController(cs domain.CarrierService) *CarrierController {
 // @Description Add a new carrier checking for duplicate carriers cid before
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param carrier body domain.CreateCarrierInput true "Carrier to create"
 // @Success 201 {object} domain.Carrier
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 409 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 422 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
 // @Router /carriers [post]
 func (cc *CarrierController) Create() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var carrierInput domain.CreateCarrierInput
 		if err := ctx.ShouldBindJSON(&carrierInput); err != nil {
-			ctx.AbortWithStatusJSON(
-				http.StatusUnprocessableEntity,
-				gin.H{"error": err.Error()},
-			)
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 
-		if err := cc.service.IsCidAvailable(ctx, carrierInput.Cid); err != nil {
-			ctx.AbortWithStatusJSON(
-				http.StatusConflict,
-				gin.H{"error": err.Error()},
-			)
+		if err := cc.service.IsCidAvailable(ctx.Request.Context(), carrierInput.Cid); err != nil {
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -52,13 +48,10 @@ func (cc *CarrierController) Create() gin.HandlerFunc {
 			LocalityId:  carrierInput.LocalityId,
 		}
 
-		createdCarrier, err := cc.service.Create(ctx, &carrier)
+		createdCarrier, err := cc.service.Create(ctx.Request.Context(), &carrier)
 
 		if err != nil {
-			ctx.AbortWithStatusJSON(
-				http.StatusUnprocessableEntity,
-				gin.H{"error": err.Error()},
-			)
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -73,11 +66,12 @@ func (cc *CarrierController) Create() gin.HandlerFunc {
 // @Description Get quantity of carriers by locality id
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id query int false "locality ID"
 // @Success 200 {object} schemas.JSONSuccessResult{data=[]domain.CarrierReport}
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
 // @Router /localities/reportCarriers [get]
 func (cc *CarrierController) ReportCarriers() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -85,24 +79,24 @@ func (cc *CarrierController) ReportCarriers() gin.HandlerFunc {
 			Id int64 `form:"id"`
 		}
 		if err := ctx.ShouldBindQuery(&reportCarriersInput); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 		var reports *[]domain.CarrierReport
 		if reportCarriersInput.Id == 0 {
-			allReports, err := cc.service.GetAllCarriersReport(ctx)
+			allReports, err := cc.service.GetAllCarriersReport(ctx.Request.Context())
 			if err != nil {
-				ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+				problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 				return
 			}
 			reports = allReports
 		} else {
 			customReport, err := cc.service.GetCarriersReportById(
-				ctx,
+				ctx.Request.Context(),
 				reportCarriersInput.Id,
 			)
 			if err != nil {
-				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				problem.Abort(ctx, http.StatusNotFound, err)
 				return
 			}
 			reports = &[]domain.CarrierReport{
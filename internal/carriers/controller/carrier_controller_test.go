@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/carriers/controller"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/carriers/domain"
 	mock "github.com/marcoglnd/mercado-fresco-packmain/internal/carriers/mocks"
@@ -57,7 +58,7 @@ func TestCreateConflict(t *testing.T) {
 	err := json.NewEncoder(&buf).Encode(carrierInput)
 	assert.Nil(t, err)
 
-	serviceMock.EXPECT().IsCidAvailable(gomock.Any(), carrierInput.Cid).Return(errors.New("duplicate"))
+	serviceMock.EXPECT().IsCidAvailable(gomock.Any(), carrierInput.Cid).Return(domain.ErrCidDuplicate)
 
 	rr := httptest.NewRecorder()
 	_, engine := gin.CreateTestContext(rr)
@@ -99,7 +100,12 @@ func TestCreateFail(t *testing.T) {
 	assert.Nil(t, err)
 
 	serviceMock.EXPECT().IsCidAvailable(gomock.Any(), carrierInput.Cid).Return(nil)
-	serviceMock.EXPECT().Create(gomock.Any(), &carrierInput).Return(nil, errors.New("error saving"))
+	serviceMock.EXPECT().Create(gomock.Any(), &carrierInput).Return(nil, &apperrors.Error{
+		Kind:    apperrors.ErrForeignKey,
+		Entity:  "localities",
+		Field:   "locality_id",
+		Message: "locality_id: no localities with that id",
+	})
 
 	rr := httptest.NewRecorder()
 	_, engine := gin.CreateTestContext(rr)
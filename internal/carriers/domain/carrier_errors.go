@@ -0,0 +1,8 @@
+package domain
+
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+
+var (
+	ErrCarrierNotFound = apperrors.NotFound("could not find carrier by id")
+	ErrCidDuplicate    = apperrors.Conflict("cid already exists")
+)
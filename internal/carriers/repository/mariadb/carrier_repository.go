@@ -5,6 +5,9 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	auditlog "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/carriers/domain"
 )
 
@@ -20,7 +23,14 @@ func (r *carrierRepository) Create(
 	ctx context.Context,
 	carrier *domain.Carrier,
 ) (*domain.Carrier, error) {
-	result, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
 		ctx,
 		sqlStore,
 		&carrier.Cid,
@@ -30,7 +40,7 @@ func (r *carrierRepository) Create(
 		&carrier.LocalityId,
 	)
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromMySQL(err)
 	}
 
 	lastID, err := result.LastInsertId()
@@ -40,6 +50,14 @@ func (r *carrierRepository) Create(
 
 	carrier.ID = lastID
 
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "carriers", lastID, nil); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return carrier, nil
 }
 
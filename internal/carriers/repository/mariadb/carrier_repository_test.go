@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,6 +22,7 @@ func TestCreate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(sqlStore)).
 			WithArgs(
 				&carrierFake.Cid,
@@ -28,6 +31,8 @@ func TestCreate(t *testing.T) {
 				&carrierFake.Telephone,
 				&carrierFake.LocalityId,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "carriers", 1)
+		mock.ExpectCommit()
 
 		carriersRepo := NewCarrierRepository(db)
 
@@ -41,6 +46,7 @@ func TestCreate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(sqlStore)).
 			WithArgs(
 				&carrierFake.Cid,
@@ -61,6 +67,7 @@ func TestCreate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(sqlStore)).
 			WithArgs(
 				&carrierFake.Cid,
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/carriers/domain"
 )
@@ -36,7 +35,7 @@ func (s *carrierService) IsCidAvailable(ctx context.Context, cid string) error {
 		return err
 	}
 	if carrierDuplicated != nil {
-		return fmt.Errorf("cid already exists")
+		return domain.ErrCidDuplicate
 	}
 	return nil
 }
@@ -49,7 +48,7 @@ func (s *carrierService) FindById(ctx context.Context, id int64) (*domain.Carrie
 	}
 
 	if foundCarrier == nil {
-		return nil, fmt.Errorf("could not find carrier by id")
+		return nil, domain.ErrCarrierNotFound
 	}
 
 	return foundCarrier, nil
@@ -0,0 +1,240 @@
+// Package config loads the settings of the server from, in increasing
+// precedence, their defaults, an optional JSON file, the environment and
+// the command line flags.
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	incidents "github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+)
+
+const (
+	LogDebug = "debug"
+	LogInfo  = "info"
+	LogWarn  = "warn"
+	LogError = "error"
+)
+
+// tlsModes are the tls values understood by the MySQL driver.
+var tlsModes = []string{"false", "true", "skip-verify", "preferred"}
+
+var logLevels = []string{LogDebug, LogInfo, LogWarn, LogError}
+
+type Config struct {
+	HTTP      HTTP
+	DB        DB
+	Log       Log
+	Auth      Auth
+	Incidents Incidents
+	Events    Events
+	Webhooks  Webhooks
+	Features  Features
+}
+
+type HTTP struct {
+	Port     int
+	BasePath string
+	// ReadTimeout and WriteTimeout bound each request, 0 meaning no limit.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+type DB struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Name     string
+	TLS      string
+	// ConnectTimeout, ReadTimeout and WriteTimeout bound the I/O with the
+	// database, 0 meaning no limit.
+	ConnectTimeout time.Duration
+	ReadTimeout    time.Duration
+	WriteTimeout   time.Duration
+	MigrateOnStart bool
+}
+
+type Log struct {
+	// Level logs every request at debug and info, and only the errors of
+	// the server at warn and error. Debug also runs gin in debug mode.
+	Level string
+}
+
+type Auth struct {
+	// TokenSecret signs the tokens. Without it a random one is used, and
+	// tokens stop working when the server restarts.
+	TokenSecret string
+	TokenTTL    time.Duration
+	// AdminUsername and AdminPassword create the first admin user when the
+	// database has no users.
+	AdminUsername string
+	AdminPassword string
+}
+
+type Incidents struct {
+	SectionBand float64
+	GracePeriod time.Duration
+	// WebhookURL is posted every incident, besides logging it.
+	WebhookURL string
+}
+
+type Events struct {
+	Stdout     bool
+	WebhookURL string
+	File       string
+}
+
+type Webhooks struct {
+	// MaxAttempts is the failures after which a delivery is dead.
+	MaxAttempts int
+}
+
+type Features struct {
+	Swagger bool
+	Jobs    bool
+}
+
+// Default returns the configuration used when nothing else is set.
+func Default() *Config {
+	return &Config{
+		HTTP: HTTP{
+			Port:         8080,
+			BasePath:     "/api/v1",
+			ReadTimeout:  30 * time.Second,
+			WriteTimeout: 30 * time.Second,
+		},
+		DB: DB{
+			Host:           "localhost",
+			Port:           3306,
+			TLS:            "false",
+			ConnectTimeout: 10 * time.Second,
+			ReadTimeout:    30 * time.Second,
+			WriteTimeout:   30 * time.Second,
+		},
+		Log: Log{Level: LogInfo},
+		Auth: Auth{
+			TokenTTL: auth.DefaultTTL,
+		},
+		Incidents: Incidents{
+			SectionBand: incidents.DefaultRules.SectionBand,
+			GracePeriod: incidents.DefaultRules.GracePeriod,
+		},
+		Webhooks: Webhooks{MaxAttempts: 8},
+		Features: Features{Swagger: true, Jobs: true},
+	}
+}
+
+// Addr is the address the server listens on.
+func (h HTTP) Addr() string {
+	return ":" + strconv.Itoa(h.Port)
+}
+
+// DSN is the data source name of d for the MySQL driver.
+func (d DB) DSN() string {
+	dsn := mysql.NewConfig()
+	dsn.User = d.User
+	dsn.Passwd = d.Password
+	dsn.Net = "tcp"
+	dsn.Addr = net.JoinHostPort(d.Host, strconv.Itoa(d.Port))
+	dsn.DBName = d.Name
+	dsn.ParseTime = true
+	dsn.Timeout = d.ConnectTimeout
+	dsn.ReadTimeout = d.ReadTimeout
+	dsn.WriteTimeout = d.WriteTimeout
+
+	if d.TLS != "false" {
+		dsn.TLSConfig = d.TLS
+	}
+
+	return dsn.FormatDSN()
+}
+
+// Rules are the breach rules of the incidents.
+func (i Incidents) Rules() incidents.Rules {
+	return incidents.Rules{SectionBand: i.SectionBand, GracePeriod: i.GracePeriod}
+}
+
+// Errors lists every invalid setting, one per line.
+type Errors []error
+
+func (e Errors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate returns every setting of c that is out of range.
+func (c *Config) Validate() Errors {
+	var errs Errors
+
+	check := func(ok bool, key string, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(validPort(c.HTTP.Port), "http.port", "%d is not a port", c.HTTP.Port)
+	check(strings.HasPrefix(c.HTTP.BasePath, "/"), "http.base_path", "%q must start with /", c.HTTP.BasePath)
+	check(c.HTTP.ReadTimeout >= 0, "http.read_timeout", "must not be negative")
+	check(c.HTTP.WriteTimeout >= 0, "http.write_timeout", "must not be negative")
+
+	check(c.DB.Host != "", "db.host", "is required")
+	check(validPort(c.DB.Port), "db.port", "%d is not a port", c.DB.Port)
+	check(c.DB.User != "", "db.user", "is required")
+	check(c.DB.Name != "", "db.name", "is required")
+	check(oneOf(c.DB.TLS, tlsModes), "db.tls", "%q is not one of %s", c.DB.TLS, strings.Join(tlsModes, ", "))
+	check(c.DB.ConnectTimeout >= 0, "db.connect_timeout", "must not be negative")
+	check(c.DB.ReadTimeout >= 0, "db.read_timeout", "must not be negative")
+	check(c.DB.WriteTimeout >= 0, "db.write_timeout", "must not be negative")
+
+	check(oneOf(c.Log.Level, logLevels), "log.level", "%q is not one of %s", c.Log.Level, strings.Join(logLevels, ", "))
+
+	check(c.Auth.TokenTTL > 0, "auth.token_ttl", "must be positive")
+	check(
+		(c.Auth.AdminUsername == "") == (c.Auth.AdminPassword == ""),
+		"auth.admin_password", "must be set together with auth.admin_username",
+	)
+
+	check(c.Incidents.SectionBand > 0, "incidents.section_band", "must be positive")
+	check(c.Incidents.GracePeriod >= 0, "incidents.grace_period", "must not be negative")
+	check(validURL(c.Incidents.WebhookURL), "incidents.webhook_url", "%q is not an http URL", c.Incidents.WebhookURL)
+
+	check(validURL(c.Events.WebhookURL), "events.webhook_url", "%q is not an http URL", c.Events.WebhookURL)
+
+	check(c.Webhooks.MaxAttempts >= 1, "webhooks.max_attempts", "must be at least 1")
+
+	return errs
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+// validURL accepts an empty URL, which leaves its feature off.
+func validURL(raw string) bool {
+	if raw == "" {
+		return true
+	}
+
+	parsed, err := url.ParseRequestURI(raw)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func oneOf(value string, values []string) bool {
+	for _, v := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func TestLoad(t *testing.T) {
+	required := map[string]string{"DB_USER": "root", "DB_NAME": "mercado_fresco"}
+
+	t.Run("defaults", func(t *testing.T) {
+		c, args, err := load(nil, env(required))
+
+		assert.NoError(t, err)
+		assert.Empty(t, args)
+		assert.Equal(t, 8080, c.HTTP.Port)
+		assert.Equal(t, "/api/v1", c.HTTP.BasePath)
+		assert.Equal(t, "localhost", c.DB.Host)
+		assert.True(t, c.Features.Jobs)
+	})
+
+	t.Run("flags over env over file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		assert.NoError(t, os.WriteFile(path, []byte(`{
+			"http": {"port": 9000, "base_path": "/v2"},
+			"db": {"host": "db.internal", "tls": "true", "migrate_on_start": true},
+			"incidents": {"section_band": 2.5}
+		}`), 0o600))
+
+		c, args, err := load(
+			[]string{"-config", path, "-http.port", "9100", "migrate", "up"},
+			env(map[string]string{"DB_USER": "root", "DB_NAME": "mercado_fresco", "DB_HOST": "db.example.com", "HTTP_PORT": "9050"}),
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"migrate", "up"}, args)
+		assert.Equal(t, 9100, c.HTTP.Port)
+		assert.Equal(t, "/v2", c.HTTP.BasePath)
+		assert.Equal(t, "db.example.com", c.DB.Host)
+		assert.Equal(t, "true", c.DB.TLS)
+		assert.True(t, c.DB.MigrateOnStart)
+		assert.Equal(t, 2.5, c.Incidents.SectionBand)
+	})
+
+	t.Run("bare bool flag", func(t *testing.T) {
+		c, args, err := load([]string{"-db.migrate_on_start", "-features.jobs=false", "migrate", "up"}, env(required))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"migrate", "up"}, args)
+		assert.True(t, c.DB.MigrateOnStart)
+		assert.False(t, c.Features.Jobs)
+	})
+
+	t.Run("file from the environment", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		assert.NoError(t, os.WriteFile(path, []byte(`{"db": {"user": "app", "name": "fresco"}}`), 0o600))
+
+		c, _, err := load(nil, env(map[string]string{fileEnv: path}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "app", c.DB.User)
+	})
+
+	t.Run("reports every invalid setting", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		assert.NoError(t, os.WriteFile(path, []byte(`{"db": {"hots": "x"}}`), 0o600))
+
+		_, _, err := load(
+			[]string{"-config", path, "-log.level", "loud"},
+			env(map[string]string{"DB_PORT": "abc", "INCIDENT_GRACE_PERIOD": "soon", "AUTH_ADMIN_USERNAME": "admin"}),
+		)
+
+		var errs Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, `db.hots: unknown setting (from `+path+`)
+db.port: "abc" is not a whole number (from DB_PORT)
+incidents.grace_period: "soon" is not a duration such as 30s (from INCIDENT_GRACE_PERIOD)
+db.user: is required
+db.name: is required
+log.level: "loud" is not one of debug, info, warn, error
+auth.admin_password: must be set together with auth.admin_username`, err.Error())
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, _, err := load([]string{"-config", "missing.json"}, env(required))
+
+		assert.ErrorContains(t, err, "missing.json")
+	})
+
+	t.Run("help", func(t *testing.T) {
+		_, _, err := load([]string{"-h"}, env(required))
+
+		assert.ErrorIs(t, err, flag.ErrHelp)
+	})
+}
+
+func TestDSN(t *testing.T) {
+	db := Default().DB
+	db.User, db.Password, db.Name = "root", "p@ss", "mercado_fresco"
+	db.ConnectTimeout, db.ReadTimeout, db.WriteTimeout = 5*time.Second, 0, 0
+
+	assert.Equal(t, "root:p@ss@tcp(localhost:3306)/mercado_fresco?parseTime=true&timeout=5s", db.DSN())
+
+	db.TLS = "skip-verify"
+	assert.Equal(t, "root:p@ss@tcp(localhost:3306)/mercado_fresco?parseTime=true&timeout=5s&tls=skip-verify", db.DSN())
+}
@@ -0,0 +1,254 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// fileEnv names the JSON file to read when the -config flag is not given.
+const fileEnv = "CONFIG_FILE"
+
+// setting is read from the key of the file, which is also its flag, and
+// from env. value points at its field in a Config.
+type setting struct {
+	key   string
+	env   string
+	usage string
+	value func(c *Config) interface{}
+}
+
+var settings = []setting{
+	{"http.port", "HTTP_PORT", "port the API listens on", func(c *Config) interface{} { return &c.HTTP.Port }},
+	{"http.base_path", "HTTP_BASE_PATH", "path prefix of the API", func(c *Config) interface{} { return &c.HTTP.BasePath }},
+	{"http.read_timeout", "HTTP_READ_TIMEOUT", "limit to read a request", func(c *Config) interface{} { return &c.HTTP.ReadTimeout }},
+	{"http.write_timeout", "HTTP_WRITE_TIMEOUT", "limit to write a response", func(c *Config) interface{} { return &c.HTTP.WriteTimeout }},
+
+	{"db.host", "DB_HOST", "database host", func(c *Config) interface{} { return &c.DB.Host }},
+	{"db.port", "DB_PORT", "database port", func(c *Config) interface{} { return &c.DB.Port }},
+	{"db.user", "DB_USER", "database user", func(c *Config) interface{} { return &c.DB.User }},
+	{"db.password", "DB_PASS", "database password", func(c *Config) interface{} { return &c.DB.Password }},
+	{"db.name", "DB_NAME", "database name", func(c *Config) interface{} { return &c.DB.Name }},
+	{"db.tls", "DB_TLS", "TLS to the database: false, true, skip-verify or preferred", func(c *Config) interface{} { return &c.DB.TLS }},
+	{"db.connect_timeout", "DB_CONNECT_TIMEOUT", "limit to connect to the database", func(c *Config) interface{} { return &c.DB.ConnectTimeout }},
+	{"db.read_timeout", "DB_READ_TIMEOUT", "limit to read from the database", func(c *Config) interface{} { return &c.DB.ReadTimeout }},
+	{"db.write_timeout", "DB_WRITE_TIMEOUT", "limit to write to the database", func(c *Config) interface{} { return &c.DB.WriteTimeout }},
+	{"db.migrate_on_start", "DB_MIGRATE_ON_START", "apply the pending migrations on start", func(c *Config) interface{} { return &c.DB.MigrateOnStart }},
+
+	{"log.level", "LOG_LEVEL", "debug, info, warn or error", func(c *Config) interface{} { return &c.Log.Level }},
+
+	{"auth.token_secret", "AUTH_TOKEN_SECRET", "secret signing the tokens", func(c *Config) interface{} { return &c.Auth.TokenSecret }},
+	{"auth.token_ttl", "AUTH_TOKEN_TTL", "how long tokens last", func(c *Config) interface{} { return &c.Auth.TokenTTL }},
+	{"auth.admin_username", "AUTH_ADMIN_USERNAME", "first admin user", func(c *Config) interface{} { return &c.Auth.AdminUsername }},
+	{"auth.admin_password", "AUTH_ADMIN_PASSWORD", "password of the first admin user", func(c *Config) interface{} { return &c.Auth.AdminPassword }},
+
+	{"incidents.section_band", "SECTION_TEMPERATURE_BAND", "degrees a section may drift from its range", func(c *Config) interface{} { return &c.Incidents.SectionBand }},
+	{"incidents.grace_period", "INCIDENT_GRACE_PERIOD", "how long a breach lasts before an incident", func(c *Config) interface{} { return &c.Incidents.GracePeriod }},
+	{"incidents.webhook_url", "INCIDENT_WEBHOOK_URL", "URL posted every incident", func(c *Config) interface{} { return &c.Incidents.WebhookURL }},
+
+	{"events.stdout", "EVENT_STDOUT", "write the events to stdout", func(c *Config) interface{} { return &c.Events.Stdout }},
+	{"events.webhook_url", "EVENT_WEBHOOK_URL", "URL posted every event", func(c *Config) interface{} { return &c.Events.WebhookURL }},
+	{"events.file", "EVENT_FILE", "file the events are appended to", func(c *Config) interface{} { return &c.Events.File }},
+
+	{"webhooks.max_attempts", "WEBHOOK_MAX_ATTEMPTS", "failures after which a delivery is dead", func(c *Config) interface{} { return &c.Webhooks.MaxAttempts }},
+
+	{"features.swagger", "FEATURE_SWAGGER", "serve the docs at /docs", func(c *Config) interface{} { return &c.Features.Swagger }},
+	{"features.jobs", "FEATURE_JOBS", "run the background jobs", func(c *Config) interface{} { return &c.Features.Jobs }},
+}
+
+// Load reads the configuration with the flags of args and returns the
+// arguments left after them. Its Errors list every invalid setting, and
+// it returns flag.ErrHelp when -help is given.
+func Load(args []string) (*Config, []string, error) {
+	return load(args, os.LookupEnv)
+}
+
+func load(args []string, lookupEnv func(string) (string, bool)) (*Config, []string, error) {
+	c := Default()
+
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	path := flags.String("config", "", "JSON file with the settings, also read from "+fileEnv)
+	for _, s := range settings {
+		_, isBool := s.value(c).(*bool)
+		flags.Var(&flagValue{isBool: isBool}, s.key, fmt.Sprintf("%s (%s, default %v)", s.usage, s.env, show(s.value(c))))
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	var errs Errors
+	apply := func(s setting, raw, source string) {
+		if err := parse(s.value(c), raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v (from %s)", s.key, err, source))
+		}
+	}
+
+	if *path == "" {
+		*path, _ = lookupEnv(fileEnv)
+	}
+
+	if *path != "" {
+		values, err := readFile(*path)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			s, ok := find(key)
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: unknown setting (from %s)", key, *path))
+				continue
+			}
+			apply(s, values[key], *path)
+		}
+	}
+
+	for _, s := range settings {
+		if raw, ok := lookupEnv(s.env); ok && raw != "" {
+			apply(s, raw, s.env)
+		}
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		if s, ok := find(f.Name); ok {
+			apply(s, f.Value.String(), "-"+f.Name)
+		}
+	})
+
+	errs = append(errs, c.Validate()...)
+	if len(errs) > 0 {
+		return nil, nil, errs
+	}
+
+	return c, flags.Args(), nil
+}
+
+// flagValue keeps the text of a flag until the other sources are read.
+// Bool settings are bool flags, so a bare -db.migrate_on_start means true.
+type flagValue struct {
+	raw    string
+	isBool bool
+}
+
+func (v *flagValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.raw
+}
+
+func (v *flagValue) Set(raw string) error {
+	v.raw = raw
+	return nil
+}
+
+func (v *flagValue) IsBoolFlag() bool {
+	return v.isBool
+}
+
+func find(key string) (setting, bool) {
+	for _, s := range settings {
+		if s.key == key {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+func parse(value interface{}, raw string) error {
+	switch value := value.(type) {
+	case *string:
+		*value = raw
+	case *int:
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", raw)
+		}
+		*value = parsed
+	case *float64:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", raw)
+		}
+		*value = parsed
+	case *bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", raw)
+		}
+		*value = parsed
+	case *time.Duration:
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration such as 30s", raw)
+		}
+		*value = parsed
+	}
+
+	return nil
+}
+
+func show(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *string:
+		return strconv.Quote(*value)
+	case *int:
+		return *value
+	case *float64:
+		return *value
+	case *bool:
+		return *value
+	case *time.Duration:
+		return *value
+	}
+	return value
+}
+
+// readFile reads the settings of a JSON file, whose nested objects make
+// the dotted keys, as in {"db": {"host": "localhost"}}.
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var tree map[string]interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	flatten("", tree, values)
+
+	return values, nil
+}
+
+func flatten(prefix string, tree map[string]interface{}, values map[string]string) {
+	for key, value := range tree {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok {
+			flatten(key, nested, values)
+			continue
+		}
+
+		values[key] = fmt.Sprint(value)
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/employees/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
 )
 
 type requestEmployeeCreate struct {
@@ -41,19 +43,33 @@ func NewEmployeeController(service domain.EmployeeService) (*EmployeeController,
 // @Description get all employees
 // @Accept json
 // @Produce json
-// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Employee}
-// @Failure 500 {object} schemas.JSONBadReqResult{error=string}
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param card_number_id query string false "Card number ID"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.Employee}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /employees [get]
 func (c EmployeeController) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
-		employees, err := c.service.GetAll(ctx.Request.Context())
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.EmployeeListFields)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		ctx.JSON(http.StatusOK, gin.H{"data": employees})
+		employees, total, err := c.service.GetAll(ctx.Request.Context(), params)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": employees, "meta": listing.NewMeta(params, total)})
 	}
 }
 
@@ -62,23 +78,25 @@ func (c EmployeeController) GetAll() gin.HandlerFunc {
 // @Description get employee by id
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Employee ID"
 // @Success 200 {object} domain.Employee
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /employees/{id} [get]
 func (c EmployeeController) GetById() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
 		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		employee, err := c.service.GetById(ctx, id)
+		employee, err := c.service.GetById(ctx.Request.Context(), id)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, employee)
@@ -90,28 +108,29 @@ func (c EmployeeController) GetById() gin.HandlerFunc {
 // @Description Add a new employee to the list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param employee body requestEmployeeCreate true "Employee to create"
 // @Success 201 {object} domain.Employee
-// @Failure 409 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /employees [post]
 func (c EmployeeController) Create() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
 		var req requestEmployeeCreate
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 
-		employee, err := c.service.Create(ctx, &domain.Employee{
+		employee, err := c.service.Create(ctx.Request.Context(), &domain.Employee{
 			CardNumberId: req.CardNumberId,
 			FirstName:    req.FirstName,
 			LastName:     req.LastName,
 			WarehouseId:  req.WarehouseId,
 		})
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -124,28 +143,31 @@ func (c EmployeeController) Create() gin.HandlerFunc {
 // @Description Update existing employee in list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Employee ID"
 // @Param employee body requestEmployeeUpdate true "Employee to update"
 // @Success 200 {object} domain.Employee
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /employees/{id} [patch]
 func (c EmployeeController) Update() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
 		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
 		var req requestEmployeeUpdate
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		employee, err := c.service.Update(ctx, &domain.Employee{
+		employee, err := c.service.Update(ctx.Request.Context(), &domain.Employee{
 			ID:           id,
 			CardNumberId: req.CardNumberId,
 			FirstName:    req.FirstName,
@@ -153,7 +175,7 @@ func (c EmployeeController) Update() gin.HandlerFunc {
 			WarehouseId:  req.WarehouseId,
 		})
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -166,23 +188,25 @@ func (c EmployeeController) Update() gin.HandlerFunc {
 // @Description Delete existing employee in list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Employee ID"
 // @Success 204 {object} schemas.JSONSuccessResult{data=string}
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
 // @Router /employees/{id} [delete]
 func (c EmployeeController) Delete() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
 		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		err = c.service.Delete(ctx, id)
+		err = c.service.Delete(ctx.Request.Context(), id)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -195,10 +219,11 @@ func (c EmployeeController) Delete() gin.HandlerFunc {
 // @Description Get quantity of inbound orders
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id query int false "employee ID"
 // @Success 200 {object} domain.InboundOrderResponse
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 500 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /employees/reportInboundOrders [get]
 func (c EmployeeController) ReportInboundOrders() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -206,20 +231,20 @@ func (c EmployeeController) ReportInboundOrders() gin.HandlerFunc {
 		id, _ := strconv.ParseInt(ctx.Query("id"), 10, 64)
 
 		if id == 0 {
-			inboundOrders, err := c.service.ReportAllInboundOrders(ctx)
+			inboundOrders, err := c.service.ReportAllInboundOrders(ctx.Request.Context())
 
 			if err != nil {
-				ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				problem.Abort(ctx, http.StatusInternalServerError, err)
 				return
 			}
 			ctx.JSON(http.StatusOK, gin.H{"data": inboundOrders})
 			return
 		}
 
-		inboundOrder, err := c.service.ReportInboundOrders(ctx, id)
+		inboundOrder, err := c.service.ReportInboundOrders(ctx.Request.Context(), id)
 
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, gin.H{"data": inboundOrder})
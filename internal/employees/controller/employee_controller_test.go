@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/employees/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/employees/domain/mocks"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
@@ -82,7 +83,7 @@ func TestCreateNewEmployee(t *testing.T) {
 		mockEmployeeService.On("Create",
 			mock.Anything,
 			mock.Anything,
-		).Return(nil, errors.New("expected conflict error")).Maybe()
+		).Return(nil, domain.ErrDuplicatedID).Maybe()
 
 		payload, err := json.Marshal(mockEmployee)
 		assert.NoError(t, err)
@@ -102,6 +103,46 @@ func TestCreateNewEmployee(t *testing.T) {
 
 		mockEmployeeService.AssertExpectations(t)
 	})
+
+	t.Run("fail with unknown warehouse", func(t *testing.T) {
+		mockEmployee := utils.CreateRandomEmployee()
+		mockEmployeeService := mocks.NewEmployeeService(t)
+
+		mockEmployeeService.On("Create",
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, &apperrors.Error{
+			Kind:    apperrors.ErrForeignKey,
+			Entity:  "warehouses",
+			Field:   "warehouse_id",
+			Message: "warehouse_id: no warehouses with that id",
+		}).Once()
+
+		payload, err := json.Marshal(mockEmployee)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/employees", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		employeeController := EmployeeController{service: mockEmployeeService}
+
+		engine.POST("/api/v1/employees", employeeController.Create())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.JSONEq(t, `{
+			"type": "/problems/foreign-key",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "warehouse_id: no warehouses with that id",
+			"errors": [{"field": "warehouse_id", "rule": "exists", "param": "warehouses", "message": "warehouse_id: no warehouses with that id"}]
+		}`, rec.Body.String())
+
+		mockEmployeeService.AssertExpectations(t)
+	})
 }
 
 func TestGetAll(t *testing.T) {
@@ -111,7 +152,8 @@ func TestGetAll(t *testing.T) {
 
 		mockEmployeeService.On("GetAll",
 			mock.Anything,
-		).Return(&mockEmployee, nil).Once()
+			mock.Anything,
+		).Return(&mockEmployee, int64(len(mockEmployee)), nil).Once()
 
 		payload, err := json.Marshal(mockEmployee)
 		assert.NoError(t, err)
@@ -138,7 +180,8 @@ func TestGetAll(t *testing.T) {
 
 		mockEmployeeService.On("GetAll",
 			mock.Anything,
-		).Return(mockEmployee, errors.New("Internal server error")).Maybe()
+			mock.Anything,
+		).Return(mockEmployee, int64(0), errors.New("Internal server error")).Maybe()
 
 		payload, err := json.Marshal(mockEmployee)
 		assert.NoError(t, err)
@@ -226,7 +269,7 @@ func TestGetById(t *testing.T) {
 		mockEmployeeService.On("GetById",
 			mock.Anything,
 			mock.AnythingOfType("int64"),
-		).Return(nil, errors.New("expected conflict error")).Maybe()
+		).Return(nil, domain.ErrIdNotFound).Maybe()
 
 		payload, err := json.Marshal(mockEmployee)
 		assert.NoError(t, err)
@@ -247,6 +290,31 @@ func TestGetById(t *testing.T) {
 
 		mockEmployeeService.AssertExpectations(t)
 	})
+
+	t.Run("In case of database failure", func(t *testing.T) {
+		mockEmployeeService := mocks.NewEmployeeService(t)
+
+		mockEmployeeService.On("GetById",
+			mock.Anything,
+			mock.AnythingOfType("int64"),
+		).Return(nil, errors.New("connection refused")).Once()
+
+		PATH := fmt.Sprintf("/api/v1/employees/%v", utils.RandomInt64())
+		req := httptest.NewRequest(http.MethodGet, PATH, nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		employeeController := EmployeeController{service: mockEmployeeService}
+
+		engine.GET("/api/v1/employees/:id", employeeController.GetById())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		mockEmployeeService.AssertExpectations(t)
+	})
 }
 
 func TestUpdate(t *testing.T) {
@@ -342,7 +410,7 @@ func TestUpdate(t *testing.T) {
 		mockEmployeeService.On("Update",
 			mock.Anything,
 			mock.Anything,
-		).Return(nil, errors.New("expected not found error")).Maybe()
+		).Return(nil, domain.ErrIdNotFound).Maybe()
 
 		payload, err := json.Marshal(mockEmployee)
 		assert.NoError(t, err)
@@ -423,7 +491,7 @@ func TestDelete(t *testing.T) {
 		mockEmployeeService.On("Delete",
 			mock.Anything,
 			mock.AnythingOfType("int64"),
-		).Return(errors.New("expected conflict error")).Maybe()
+		).Return(domain.ErrIdNotFound).Maybe()
 
 		PATH := fmt.Sprintf("/api/v1/employees/%v", utils.RandomInt64())
 		req := httptest.NewRequest(http.MethodDelete, PATH, nil)
@@ -1,9 +1,18 @@
 package domain
 
-import "context"
+import (
+	"context"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
+
+var EmployeeListFields = listing.Fields{
+	Sort:   []string{"id", "card_number_id", "first_name", "last_name", "warehouse_id"},
+	Filter: []string{"warehouse_id", "card_number_id"},
+}
 
 type EmployeeRepository interface {
-	GetAll(ctx context.Context) (*[]Employee, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]Employee, int64, error)
 	GetById(ctx context.Context, id int64) (*Employee, error)
 	Create(ctx context.Context, employee *Employee) (*Employee, error)
 	Update(ctx context.Context, employee *Employee) (*Employee, error)
@@ -13,7 +22,7 @@ type EmployeeRepository interface {
 }
 
 type EmployeeService interface {
-	GetAll(ctx context.Context) (*[]Employee, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]Employee, int64, error)
 	GetById(ctx context.Context, id int64) (*Employee, error)
 	Create(ctx context.Context, employee *Employee) (*Employee, error)
 	Update(ctx context.Context, employee *Employee) (*Employee, error)
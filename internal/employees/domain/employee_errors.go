@@ -1,13 +1,17 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+)
 
 var (
-	ErrIdNotFound               = errors.New("employee id not found")
-	ErrDuplicatedID             = errors.New("duplicated card_number_id")
+	ErrIdNotFound               = apperrors.NotFound("employee id not found")
+	ErrDuplicatedID             = apperrors.Conflict("duplicated card_number_id")
 	ErrInvalidService           = errors.New("invalid service")
-	CardNumberIdIsRequired      = errors.New("card_number_id is required")
-	FirstNameIsRequired         = errors.New("first_name is required")
-	LastNameIsRequired          = errors.New("last_name is required")
-	WarehouseIdCannotBeNegative = errors.New("warehouse_id cannot be negative")
+	CardNumberIdIsRequired      = apperrors.Validation("card_number_id is required")
+	FirstNameIsRequired         = apperrors.Validation("first_name is required")
+	LastNameIsRequired          = apperrors.Validation("last_name is required")
+	WarehouseIdCannotBeNegative = apperrors.Validation("warehouse_id cannot be negative")
 )
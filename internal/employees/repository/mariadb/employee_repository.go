@@ -5,7 +5,11 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	auditlog "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/employees/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 )
 
 type mariadbRepository struct {
@@ -16,12 +20,19 @@ func NewMariaDBRepository(db *sql.DB) domain.EmployeeRepository {
 	return mariadbRepository{db: db}
 }
 
-func (m mariadbRepository) GetAll(ctx context.Context) (*[]domain.Employee, error) {
+func (m mariadbRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Employee, int64, error) {
 	var employees []domain.Employee
 
-	rows, err := m.db.QueryContext(ctx, sqlGetAll)
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAll, params, nil)
+	if err := m.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &employees, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAll, params, nil)
+	rows, err := m.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return &employees, err
+		return &employees, 0, err
 	}
 
 	defer rows.Close()
@@ -36,13 +47,13 @@ func (m mariadbRepository) GetAll(ctx context.Context) (*[]domain.Employee, erro
 			&employee.LastName,
 			&employee.WarehouseId,
 		); err != nil {
-			return &employees, err
+			return &employees, 0, err
 		}
 
 		employees = append(employees, employee)
 	}
 
-	return &employees, nil
+	return &employees, total, nil
 }
 
 func (m mariadbRepository) GetById(ctx context.Context, id int64) (*domain.Employee, error) {
@@ -75,7 +86,14 @@ func (m mariadbRepository) GetById(ctx context.Context, id int64) (*domain.Emplo
 func (m mariadbRepository) Create(ctx context.Context, employee *domain.Employee) (*domain.Employee, error) {
 	newEmployee := domain.Employee{}
 
-	result, err := m.db.ExecContext(
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &newEmployee, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
 		ctx,
 		sqlInsert,
 		&employee.CardNumberId,
@@ -84,7 +102,7 @@ func (m mariadbRepository) Create(ctx context.Context, employee *domain.Employee
 		&employee.WarehouseId,
 	)
 	if err != nil {
-		return &newEmployee, err
+		return &newEmployee, apperrors.FromMySQL(err)
 	}
 
 	lastId, err := result.LastInsertId()
@@ -95,13 +113,33 @@ func (m mariadbRepository) Create(ctx context.Context, employee *domain.Employee
 
 	employee.ID = lastId
 
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "employees", lastId, nil); err != nil {
+		return &newEmployee, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &newEmployee, err
+	}
+
 	return employee, nil
 }
 
 func (m mariadbRepository) Update(ctx context.Context, employee *domain.Employee) (*domain.Employee, error) {
 	newEmployee := domain.Employee{}
 
-	result, err := m.db.ExecContext(
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &newEmployee, err
+	}
+
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "employees", employee.ID)
+	if err != nil {
+		return &newEmployee, err
+	}
+
+	result, err := tx.ExecContext(
 		ctx,
 		sqlUpdate,
 		&employee.CardNumberId,
@@ -112,7 +150,7 @@ func (m mariadbRepository) Update(ctx context.Context, employee *domain.Employee
 	)
 
 	if err != nil {
-		return &newEmployee, err
+		return &newEmployee, apperrors.FromMySQL(err)
 	}
 
 	affectedRows, err := result.RowsAffected()
@@ -127,15 +165,35 @@ func (m mariadbRepository) Update(ctx context.Context, employee *domain.Employee
 		return &newEmployee, err
 	}
 
+	if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "employees", employee.ID, before); err != nil {
+		return &newEmployee, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &newEmployee, err
+	}
+
 	return employee, nil
 }
 
 func (m mariadbRepository) Delete(ctx context.Context, id int64) error {
-	result, err := m.db.ExecContext(ctx, sqlDelete, id)
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "employees", id)
 	if err != nil {
 		return err
 	}
 
+	result, err := tx.ExecContext(ctx, sqlDelete, id)
+	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
 	affectedRows, err := result.RowsAffected()
 
 	// ID not found
@@ -148,7 +206,11 @@ func (m mariadbRepository) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
-	return nil
+	if err := auditlog.Record(ctx, tx, audit.ActionDelete, "employees", id, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (m mariadbRepository) ReportAllInboundOrders(ctx context.Context) (*[]domain.InboundOrderResponse, error) {
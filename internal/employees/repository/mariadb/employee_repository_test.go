@@ -7,7 +7,10 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/employees/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -39,12 +42,15 @@ func TestCreateNewEmployee(t *testing.T) {
 
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(sqlInsert)).WithArgs(
 			mockEmployee.CardNumberId,
 			mockEmployee.FirstName,
 			mockEmployee.LastName,
 			mockEmployee.WarehouseId,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "employees", 1)
+		mock.ExpectCommit()
 
 		repository := NewMariaDBRepository(db)
 		newEmployee, err := repository.Create(context.Background(), &mockEmployee)
@@ -60,6 +66,7 @@ func TestCreateNewEmployee(t *testing.T) {
 
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(sqlInsert)).WithArgs(
 			"123",
 			"Liz",
@@ -95,12 +102,14 @@ func TestGetAll(t *testing.T) {
 			)
 		}
 
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(mockEmployees)))
 		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAll)).WillReturnRows(rows)
 
 		repository := NewMariaDBRepository(db)
-		result, err := repository.GetAll(context.Background())
+		result, total, err := repository.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 
 		assert.NoError(t, err)
+		assert.Equal(t, int64(len(mockEmployees)), total)
 		assert.Equal(t, result, &mockEmployees)
 
 	})
@@ -114,10 +123,11 @@ func TestGetAll(t *testing.T) {
 
 		rows := sqlmock.NewRows(rowsEmployeeStruct).AddRow("", "", "", "", "")
 
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAll)).WillReturnRows(rows)
 
 		repository := NewMariaDBRepository(db)
-		_, err = repository.GetAll(context.Background())
+		_, _, err = repository.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 
 		assert.Error(t, err)
 	})
@@ -129,10 +139,11 @@ func TestGetAll(t *testing.T) {
 
 		defer db.Close()
 
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAll)).WillReturnError(sql.ErrNoRows)
 
 		repository := NewMariaDBRepository(db)
-		_, err = repository.GetAll(context.Background())
+		_, _, err = repository.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 
 		assert.Error(t, err)
 	})
@@ -208,6 +219,8 @@ func TestUpdateEmployee(t *testing.T) {
 
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "employees", mockEmployee.ID)
 		mock.ExpectExec(regexp.QuoteMeta(sqlUpdate)).WithArgs(
 			mockEmployee.CardNumberId,
 			mockEmployee.FirstName,
@@ -215,6 +228,8 @@ func TestUpdateEmployee(t *testing.T) {
 			mockEmployee.WarehouseId,
 			mockEmployee.ID,
 		).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "employees", mockEmployee.ID)
+		mock.ExpectCommit()
 
 		repository := NewMariaDBRepository(db)
 		result, err := repository.Update(context.Background(), &mockEmployee)
@@ -230,6 +245,8 @@ func TestUpdateEmployee(t *testing.T) {
 
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "employees", mockEmployee.ID)
 		mock.ExpectExec(regexp.QuoteMeta(sqlUpdate)).WithArgs("", "", "", "").
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -246,6 +263,8 @@ func TestUpdateEmployee(t *testing.T) {
 
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "employees", mockEmployee.ID)
 		mock.ExpectExec(regexp.QuoteMeta(sqlUpdate)).WithArgs(
 			mockEmployee.CardNumberId,
 			mockEmployee.FirstName,
@@ -272,10 +291,14 @@ func TestDeleteEmployee(t *testing.T) {
 
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "employees", mockEmployee.ID)
 		mock.ExpectExec(regexp.QuoteMeta(sqlDelete)).
 			WithArgs(
 				mockEmployee.ID,
 			).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectEntry(mock, audit.ActionDelete, "employees", mockEmployee.ID)
+		mock.ExpectCommit()
 
 		repository := NewMariaDBRepository(db)
 		err = repository.Delete(context.Background(), mockEmployee.ID)
@@ -290,6 +313,8 @@ func TestDeleteEmployee(t *testing.T) {
 
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "employees", mockEmployee.ID)
 		mock.ExpectExec(regexp.QuoteMeta(sqlDelete)).
 			WithArgs(0).
 			WillReturnResult(sqlmock.NewResult(0, 1))
@@ -307,6 +332,8 @@ func TestDeleteEmployee(t *testing.T) {
 
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "employees", mockEmployee.ID)
 		mock.ExpectExec(regexp.QuoteMeta(sqlDelete)).
 			WithArgs(mockEmployee.ID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
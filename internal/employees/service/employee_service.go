@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/employees/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 )
 
 type employeeService struct {
@@ -14,14 +15,14 @@ func NewEmployeeService(er domain.EmployeeRepository) domain.EmployeeService {
 	return &employeeService{repository: er}
 }
 
-func (e employeeService) GetAll(ctx context.Context) (*[]domain.Employee, error) {
-	employees, err := e.repository.GetAll(ctx)
+func (e employeeService) GetAll(ctx context.Context, params listing.Params) (*[]domain.Employee, int64, error) {
+	employees, total, err := e.repository.GetAll(ctx, params)
 
 	if err != nil {
-		return employees, err
+		return employees, 0, err
 	}
 
-	return employees, nil
+	return employees, total, nil
 }
 
 func (e employeeService) GetById(ctx context.Context, id int64) (*domain.Employee, error) {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/employees/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/employees/domain/mocks"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -55,10 +56,10 @@ func TestGetAll(t *testing.T) {
 		mockEmployeeRepository := mocks.NewEmployeeRepository(t)
 		mockEmployees := utils.CreateRandomListEmployees()
 
-		mockEmployeeRepository.On("GetAll", mock.Anything).Return(&mockEmployees, nil).Once()
+		mockEmployeeRepository.On("GetAll", mock.Anything, mock.Anything).Return(&mockEmployees, int64(len(mockEmployees)), nil).Once()
 
 		service := NewEmployeeService(mockEmployeeRepository)
-		newEmployees, err := service.GetAll(context.Background())
+		newEmployees, _, err := service.GetAll(context.Background(), listing.Params{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, &mockEmployees, newEmployees)
@@ -70,10 +71,10 @@ func TestGetAll(t *testing.T) {
 	t.Run("In case of error", func(t *testing.T) {
 		mockEmployeeRepository := mocks.NewEmployeeRepository(t)
 
-		mockEmployeeRepository.On("GetAll", mock.Anything).Return(nil, errors.New("failed to retrieve employees")).Once()
+		mockEmployeeRepository.On("GetAll", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("failed to retrieve employees")).Once()
 
 		service := NewEmployeeService(mockEmployeeRepository)
-		_, err := service.GetAll(context.Background())
+		_, _, err := service.GetAll(context.Background(), listing.Params{})
 
 		assert.NotNil(t, err)
 
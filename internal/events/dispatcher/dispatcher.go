@@ -0,0 +1,106 @@
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+)
+
+const (
+	batchSize  = 100
+	minBackoff = 5 * time.Second
+	maxBackoff = time.Hour
+)
+
+// Dispatcher delivers the events of the outbox to its sinks. An event is
+// only marked delivered once every sink took it, otherwise all of them get
+// it again after a backoff, so each sink gets each event at least once.
+type Dispatcher struct {
+	repository domain.OutboxRepository
+	sinks      []domain.Sink
+	now        func() time.Time
+}
+
+func NewDispatcher(repository domain.OutboxRepository, sinks ...domain.Sink) *Dispatcher {
+	return &Dispatcher{repository: repository, sinks: sinks, now: time.Now}
+}
+
+// Backoff is how long an event waits before its next attempt, doubling
+// from minBackoff after each failed one up to maxBackoff.
+func Backoff(attempts int) time.Duration {
+	backoff := minBackoff
+	for i := 0; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+
+	return backoff
+}
+
+// Dispatch sends the events that are due to the sinks and returns how many
+// of them were delivered.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	events, err := d.repository.Pending(ctx, d.now(), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, event := range events {
+		if err := d.send(ctx, event); err != nil {
+			next := d.now().Add(Backoff(event.Attempts))
+			if err := d.repository.MarkFailed(ctx, event.Id, err.Error(), next); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+
+		if err := d.repository.MarkDelivered(ctx, event.Id, d.now()); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+
+	return delivered, nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, event domain.Event) error {
+	failures := []string{}
+	for _, sink := range d.sinks {
+		if err := sink.Send(ctx, event); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", sink.Name(), err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("event %d: %s", event.Id, strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// Run dispatches the due events right away and then once per interval,
+// until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.Dispatch(ctx); err != nil {
+			log.Printf("event dispatcher: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 5*time.Second, Backoff(0))
+	assert.Equal(t, 20*time.Second, Backoff(2))
+	assert.Equal(t, time.Hour, Backoff(30))
+}
+
+func TestDispatch(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	event := domain.Event{Id: 1, Type: domain.TypeBatchReceived, Attempts: 1}
+
+	newDispatcher := func(repository domain.OutboxRepository, sinks ...domain.Sink) *Dispatcher {
+		d := NewDispatcher(repository, sinks...)
+		d.now = func() time.Time { return now }
+		return d
+	}
+
+	t.Run("delivered to every sink", func(t *testing.T) {
+		repositoryMock := mocks.NewOutboxRepository(t)
+		repositoryMock.On("Pending", mock.Anything, now, batchSize).Return([]domain.Event{event}, nil).Once()
+		repositoryMock.On("MarkDelivered", mock.Anything, int64(1), now).Return(nil).Once()
+
+		first, second := mocks.NewSink(t), mocks.NewSink(t)
+		first.On("Send", mock.Anything, event).Return(nil).Once()
+		second.On("Send", mock.Anything, event).Return(nil).Once()
+
+		delivered, err := newDispatcher(repositoryMock, first, second).Dispatch(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+	})
+
+	t.Run("retried when a sink fails", func(t *testing.T) {
+		repositoryMock := mocks.NewOutboxRepository(t)
+		repositoryMock.On("Pending", mock.Anything, now, batchSize).Return([]domain.Event{event}, nil).Once()
+		repositoryMock.On("MarkFailed", mock.Anything, int64(1), "event 1: webhook: connection refused", now.Add(10*time.Second)).
+			Return(nil).Once()
+
+		ok, failing := mocks.NewSink(t), mocks.NewSink(t)
+		ok.On("Send", mock.Anything, event).Return(nil).Once()
+		failing.On("Send", mock.Anything, event).Return(errors.New("connection refused")).Once()
+		failing.On("Name").Return("webhook").Once()
+
+		delivered, err := newDispatcher(repositoryMock, ok, failing).Dispatch(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+	})
+
+	t.Run("fail to read the outbox", func(t *testing.T) {
+		repositoryMock := mocks.NewOutboxRepository(t)
+		repositoryMock.On("Pending", mock.Anything, now, batchSize).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := newDispatcher(repositoryMock).Dispatch(context.Background())
+
+		assert.EqualError(t, err, "connection refused")
+	})
+}
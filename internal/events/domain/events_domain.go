@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	TypePurchaseOrderCreated       = "purchase_order.created"
+	TypePurchaseOrderStatusChanged = "purchase_order.status_changed"
+	TypeInboundOrderCreated        = "inbound_order.created"
+	TypeBatchReceived              = "batch.received"
+	TypeSectionTemperatureBreached = "section.temperature_breached"
+)
+
+// Event is a change other systems may react to. It is written to the
+// outbox in the same transaction as the change, and Payload holds the
+// changed entity as JSON.
+type Event struct {
+	Id         int64           `json:"id"`
+	Type       string          `json:"type"`
+	Entity     string          `json:"entity"`
+	EntityId   int64           `json:"entity_id"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Attempts   int             `json:"-"`
+}
+
+// Sink delivers events to a consumer. A sink may receive the same event
+// more than once, so consumers should skip the ids they have seen.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, event Event) error
+}
+
+type OutboxRepository interface {
+	// Pending returns up to limit undelivered events whose next attempt is
+	// due at now, oldest first.
+	Pending(ctx context.Context, now time.Time, limit int) ([]Event, error)
+	MarkDelivered(ctx context.Context, id int64, at time.Time) error
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+}
@@ -0,0 +1,58 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Sink is an autogenerated mock type for the Sink type
+type Sink struct {
+	mock.Mock
+}
+
+// Name provides a mock function with given fields:
+func (_m *Sink) Name() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Send provides a mock function with given fields: ctx, event
+func (_m *Sink) Send(ctx context.Context, event domain.Event) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Event) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewSink interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSink creates a new instance of Sink. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSink(t mockConstructorTestingTNewSink) *Sink {
+	mock := &Sink{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,33 @@
+// Package eventstest sets the sqlmock expectations of the events the
+// repositories write to the outbox next to their changes.
+package eventstest
+
+import (
+	"encoding/json"
+	"regexp"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var queryPublish = regexp.QuoteMeta("INSERT INTO outbox_events")
+
+// ExpectPublish expects an event of eventType about the row id of entity to
+// be written to the outbox.
+func ExpectPublish(mock sqlmock.Sqlmock, eventType, entity string, id int64) {
+	mock.ExpectExec(queryPublish).
+		WithArgs(eventType, entity, id, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+// ExpectPublishPayload is ExpectPublish also checking that the payload is
+// the JSON of payload.
+func ExpectPublishPayload(mock sqlmock.Sqlmock, eventType, entity string, id int64, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		panic(err)
+	}
+
+	mock.ExpectExec(queryPublish).
+		WithArgs(eventType, entity, id, string(data)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
@@ -0,0 +1,80 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+)
+
+// maxErrorLength is the size of the last_error column.
+const maxErrorLength = 1024
+
+type mariadbRepository struct {
+	db *sql.DB
+}
+
+func NewMariaDBRepository(db *sql.DB) domain.OutboxRepository {
+	return mariadbRepository{db: db}
+}
+
+// Publish writes an event of eventType about the row id of entity to the
+// outbox inside tx, so that it is only delivered if tx commits.
+func Publish(ctx context.Context, tx *sql.Tx, eventType, entity string, id int64, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlPublish, eventType, entity, id, string(data))
+	return err
+}
+
+func (m mariadbRepository) Pending(ctx context.Context, now time.Time, limit int) ([]domain.Event, error) {
+	events := []domain.Event{}
+
+	rows, err := m.db.QueryContext(ctx, sqlPending, now, limit)
+	if err != nil {
+		return events, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var event domain.Event
+		var payload []byte
+
+		if err := rows.Scan(
+			&event.Id,
+			&event.Type,
+			&event.Entity,
+			&event.EntityId,
+			&payload,
+			&event.OccurredAt,
+			&event.Attempts,
+		); err != nil {
+			return events, err
+		}
+
+		event.Payload = json.RawMessage(payload)
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func (m mariadbRepository) MarkDelivered(ctx context.Context, id int64, at time.Time) error {
+	_, err := m.db.ExecContext(ctx, sqlMarkDelivered, at, id)
+	return err
+}
+
+func (m mariadbRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	if len(lastError) > maxErrorLength {
+		lastError = lastError[:maxErrorLength]
+	}
+
+	_, err := m.db.ExecContext(ctx, sqlMarkFailed, lastError, nextAttemptAt, id)
+	return err
+}
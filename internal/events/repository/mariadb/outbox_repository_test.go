@@ -0,0 +1,128 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	queryPublish       = regexp.QuoteMeta(sqlPublish)
+	queryPending       = regexp.QuoteMeta(sqlPending)
+	queryMarkDelivered = regexp.QuoteMeta(sqlMarkDelivered)
+	queryMarkFailed    = regexp.QuoteMeta(sqlMarkFailed)
+)
+
+func TestPublish(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryPublish).
+			WithArgs(domain.TypeBatchReceived, "product_batches", int64(4), `{"id":4}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		err = Publish(context.Background(), tx, domain.TypeBatchReceived, "product_batches", 4, map[string]int64{"id": 4})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryPublish).WillReturnError(sql.ErrConnDone)
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		err = Publish(context.Background(), tx, domain.TypeBatchReceived, "product_batches", 4, nil)
+
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestPending(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "event_type", "entity", "entity_id", "payload", "occurred_at", "attempts"}).
+			AddRow(1, domain.TypePurchaseOrderCreated, "purchase_orders", 3, []byte(`{"id":3}`), now, 2)
+		mock.ExpectQuery(queryPending).WithArgs(now, 10).WillReturnRows(rows)
+
+		events, err := NewMariaDBRepository(db).Pending(context.Background(), now, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Event{{
+			Id:         1,
+			Type:       domain.TypePurchaseOrderCreated,
+			Entity:     "purchase_orders",
+			EntityId:   3,
+			Payload:    json.RawMessage(`{"id":3}`),
+			OccurredAt: now,
+			Attempts:   2,
+		}}, events)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryPending).WillReturnError(sql.ErrConnDone)
+
+		_, err = NewMariaDBRepository(db).Pending(context.Background(), now, 10)
+
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestMarkDelivered(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	at := time.Now()
+	mock.ExpectExec(queryMarkDelivered).WithArgs(at, int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewMariaDBRepository(db).MarkDelivered(context.Background(), 1, at)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	next := time.Now()
+	mock.ExpectExec(queryMarkFailed).
+		WithArgs(strings.Repeat("x", maxErrorLength), next, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewMariaDBRepository(db).MarkFailed(context.Background(), 1, strings.Repeat("x", 2*maxErrorLength), next)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
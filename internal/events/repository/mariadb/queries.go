@@ -0,0 +1,8 @@
+package mariadb
+
+const (
+	sqlPublish       = "INSERT INTO outbox_events (event_type, entity, entity_id, payload) VALUES (?, ?, ?, ?);"
+	sqlPending       = "SELECT id, event_type, entity, entity_id, payload, occurred_at, attempts FROM outbox_events WHERE delivered_at IS NULL AND next_attempt_at <= ? ORDER BY id LIMIT ?;"
+	sqlMarkDelivered = "UPDATE outbox_events SET delivered_at = ?, last_error = NULL WHERE id = ?;"
+	sqlMarkFailed    = "UPDATE outbox_events SET attempts = attempts + 1, last_error = ?, next_attempt_at = ? WHERE id = ?;"
+)
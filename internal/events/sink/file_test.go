@@ -0,0 +1,38 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	s := NewFileSink(path)
+
+	assert.NoError(t, s.Send(context.Background(), domain.Event{Id: 1, Payload: json.RawMessage(`{}`)}))
+	assert.NoError(t, s.Send(context.Background(), domain.Event{Id: 2, Payload: json.RawMessage(`{}`)}))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	assert.Len(t, lines, 2)
+	assert.Contains(t, string(lines[1]), `"id":2`)
+}
+
+func TestStdoutSink(t *testing.T) {
+	var out bytes.Buffer
+
+	err := NewStdoutSink(&out).Send(context.Background(), domain.Event{Id: 1, Type: domain.TypePurchaseOrderCreated, Payload: json.RawMessage(`{"id":3}`)})
+
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), `"type":"purchase_order.created"`)
+	assert.Contains(t, out.String(), `"payload":{"id":3}`)
+}
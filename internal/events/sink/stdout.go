@@ -0,0 +1,37 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+)
+
+// StdoutSink writes each event as a line of JSON, usually to os.Stdout.
+type StdoutSink struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func NewStdoutSink(out io.Writer) *StdoutSink {
+	return &StdoutSink{out: out}
+}
+
+func (s *StdoutSink) Name() string {
+	return "stdout"
+}
+
+func (s *StdoutSink) Send(_ context.Context, event domain.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.out.Write(append(line, '\n'))
+	return err
+}
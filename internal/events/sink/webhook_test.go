@@ -0,0 +1,47 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSink(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var event domain.Event
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "7", r.Header.Get("X-Event-Id"))
+			assert.Equal(t, domain.TypeBatchReceived, r.Header.Get("X-Event-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		err := NewWebhookSink(server.URL, nil).Send(context.Background(), domain.Event{
+			Id:      7,
+			Type:    domain.TypeBatchReceived,
+			Payload: json.RawMessage(`{"id":4}`),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), event.Id)
+		assert.JSONEq(t, `{"id":4}`, string(event.Payload))
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := NewWebhookSink(server.URL, nil).Send(context.Background(), domain.Event{Id: 7, Payload: json.RawMessage(`{}`)})
+
+		assert.Error(t, err)
+	})
+}
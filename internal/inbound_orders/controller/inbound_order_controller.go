@@ -5,6 +5,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/inbound_orders/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
 )
 
 type requestInboundOrderCreate struct {
@@ -34,19 +36,34 @@ func NewInboundOrderController(service domain.InboundOrderService) (*InboundOrde
 // @Description get all inbound orders
 // @Accept json
 // @Produce json
-// @Success 200 {object} schemas.JSONSuccessResult{data=domain.InboundOrder}
-// @Failure 500 {object} schemas.JSONBadReqResult{error=string}
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Param employee_id query int false "Employee ID"
+// @Param product_batch_id query int false "Product batch ID"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.InboundOrder}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /inboundOrders [get]
 func (c InboundOrderController) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
-		inboundOrders, err := c.service.GetAll(ctx.Request.Context())
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.InboundOrderListFields)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		ctx.JSON(http.StatusOK, gin.H{"data": inboundOrders})
+		inboundOrders, total, err := c.service.GetAll(ctx.Request.Context(), params)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": inboundOrders, "meta": listing.NewMeta(params, total)})
 	}
 }
 
@@ -55,21 +72,22 @@ func (c InboundOrderController) GetAll() gin.HandlerFunc {
 // @Description Add a new inbound order to the list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param inbound order body requestInboundOrderCreate true "Inbound Order to create"
 // @Success 201 {object} domain.InboundOrder
-// @Failure 409 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /inboundOrders [post]
 func (c InboundOrderController) Create() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
 		var req requestInboundOrderCreate
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 
-		inboundOrder, err := c.service.Create(ctx, &domain.InboundOrder{
+		inboundOrder, err := c.service.Create(ctx.Request.Context(), &domain.InboundOrder{
 			OrderDate:      req.OrderDate,
 			OrderNumber:    req.OrderNumber,
 			EmployeeId:     req.EmployeeId,
@@ -78,7 +96,7 @@ func (c InboundOrderController) Create() gin.HandlerFunc {
 		})
 
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 
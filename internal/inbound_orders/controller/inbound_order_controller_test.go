@@ -7,10 +7,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/inbound_orders/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/inbound_orders/domain/mocks"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/inbound_orders/service"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -81,7 +86,12 @@ func TestCreateNewInboundOrder(t *testing.T) {
 		mockInboundOrderService.On("Create",
 			mock.Anything,
 			mock.Anything,
-		).Return(nil, errors.New("expected conflict error")).Maybe()
+		).Return(nil, &apperrors.Error{
+			Kind:    apperrors.ErrConflict,
+			Entity:  "inbound_orders",
+			Field:   "order_number",
+			Message: `order_number "ORD-1" is already in use`,
+		}).Maybe()
 
 		payload, err := json.Marshal(mockInboundOrder)
 		assert.NoError(t, err)
@@ -101,6 +111,46 @@ func TestCreateNewInboundOrder(t *testing.T) {
 
 		mockInboundOrderService.AssertExpectations(t)
 	})
+
+	t.Run("fail with unknown employee", func(t *testing.T) {
+		mockInboundOrder := utils.CreateRandomInboundOrder()
+		mockInboundOrderService := mocks.NewInboundOrderService(t)
+
+		mockInboundOrderService.On("Create",
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, &apperrors.Error{
+			Kind:    apperrors.ErrForeignKey,
+			Entity:  "employees",
+			Field:   "employee_id",
+			Message: "employee_id: no employees with that id",
+		}).Once()
+
+		payload, err := json.Marshal(mockInboundOrder)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/inboundOrders", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		inboundOrderController := InboundOrderController{service: mockInboundOrderService}
+
+		engine.POST("/api/v1/inboundOrders", inboundOrderController.Create())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.JSONEq(t, `{
+			"type": "/problems/foreign-key",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "employee_id: no employees with that id",
+			"errors": [{"field": "employee_id", "rule": "exists", "param": "employees", "message": "employee_id: no employees with that id"}]
+		}`, rec.Body.String())
+
+		mockInboundOrderService.AssertExpectations(t)
+	})
 }
 
 func TestGetAll(t *testing.T) {
@@ -110,7 +160,8 @@ func TestGetAll(t *testing.T) {
 
 		mockInboundOrderService.On("GetAll",
 			mock.Anything,
-		).Return(&mockInboundOrders, nil).Once()
+			mock.Anything,
+		).Return(&mockInboundOrders, int64(len(mockInboundOrders)), nil).Once()
 
 		payload, err := json.Marshal(mockInboundOrders)
 		assert.NoError(t, err)
@@ -137,7 +188,8 @@ func TestGetAll(t *testing.T) {
 
 		mockInboundOrderService.On("GetAll",
 			mock.Anything,
-		).Return(mockInboundOrders, errors.New("Internal server error")).Maybe()
+			mock.Anything,
+		).Return(mockInboundOrders, int64(0), errors.New("Internal server error")).Maybe()
 
 		payload, err := json.Marshal(mockInboundOrders)
 		assert.NoError(t, err)
@@ -158,3 +210,29 @@ func TestGetAll(t *testing.T) {
 		mockInboundOrderService.AssertExpectations(t)
 	})
 }
+
+func TestWarehouseScope(t *testing.T) {
+	signer := auth.NewSigner([]byte("secret"), time.Hour)
+	warehouseId := int64(1)
+	token, err := signer.Sign(auth.Claims{Subject: "ana", Roles: []string{policy.Employee}, WarehouseId: &warehouseId})
+	assert.NoError(t, err)
+
+	mockInboundOrder := utils.CreateRandomInboundOrder()
+	mockInboundOrder.WarehouseId = 99
+	payload, err := json.Marshal(mockInboundOrder)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/inboundOrders", bytes.NewBuffer(payload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	_, engine := gin.CreateTestContext(rec)
+
+	inboundOrderController := InboundOrderController{service: service.NewInboundOrderService(mocks.NewInboundOrderRepository(t))}
+
+	engine.POST("/api/v1/inboundOrders", auth.Middleware(signer), inboundOrderController.Create())
+
+	engine.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -1,13 +1,22 @@
 package domain
 
-import "context"
+import (
+	"context"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
+
+var InboundOrderListFields = listing.Fields{
+	Sort:   []string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id"},
+	Filter: []string{"employee_id", "product_batch_id", "warehouse_id"},
+}
 
 type InboundOrderRepository interface {
-	GetAll(ctx context.Context) (*[]InboundOrder, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]InboundOrder, int64, error)
 	Create(ctx context.Context, inboundOrder *InboundOrder) (*InboundOrder, error)
 }
 
 type InboundOrderService interface {
-	GetAll(ctx context.Context) (*[]InboundOrder, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]InboundOrder, int64, error)
 	Create(ctx context.Context, inboundOrder *InboundOrder) (*InboundOrder, error)
 }
@@ -1,8 +1,12 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+)
 
 var (
-	ErrIdNotFound     = errors.New("employee id not found")
+	ErrIdNotFound     = apperrors.NotFound("employee id not found")
 	ErrInvalidService = errors.New("invalid service")
 )
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.14.0. DO NOT EDIT.
+// Code generated by mockery v2.13.1. DO NOT EDIT.
 
 package mocks
 
@@ -6,6 +6,8 @@ import (
 	context "context"
 
 	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/inbound_orders/domain"
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -37,27 +39,34 @@ func (_m *InboundOrderRepository) Create(ctx context.Context, inboundOrder *doma
 	return r0, r1
 }
 
-// GetAll provides a mock function with given fields: ctx
-func (_m *InboundOrderRepository) GetAll(ctx context.Context) (*[]domain.InboundOrder, error) {
-	ret := _m.Called(ctx)
+// GetAll provides a mock function with given fields: ctx, params
+func (_m *InboundOrderRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.InboundOrder, int64, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 *[]domain.InboundOrder
-	if rf, ok := ret.Get(0).(func(context.Context) *[]domain.InboundOrder); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.InboundOrder); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*[]domain.InboundOrder)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
 
-	return r0, r1
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 type mockConstructorTestingTNewInboundOrderRepository interface {
@@ -4,7 +4,14 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	auditlog "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	outbox "github.com/marcoglnd/mercado-fresco-packmain/internal/events/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/inbound_orders/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	ledger "github.com/marcoglnd/mercado-fresco-packmain/internal/movements/repository/mariadb"
 )
 
 type mariadbRepository struct {
@@ -15,12 +22,19 @@ func NewMariaDBRepository(db *sql.DB) domain.InboundOrderRepository {
 	return mariadbRepository{db: db}
 }
 
-func (m mariadbRepository) GetAll(ctx context.Context) (*[]domain.InboundOrder, error) {
+func (m mariadbRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.InboundOrder, int64, error) {
 	var inboundOrders []domain.InboundOrder
 
-	rows, err := m.db.QueryContext(ctx, sqlGetAll)
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAll, params, nil)
+	if err := m.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &inboundOrders, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAll, params, nil)
+	rows, err := m.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return &inboundOrders, err
+		return &inboundOrders, 0, err
 	}
 
 	defer rows.Close()
@@ -36,18 +50,27 @@ func (m mariadbRepository) GetAll(ctx context.Context) (*[]domain.InboundOrder,
 			&inboundOrder.ProductBatchId,
 			&inboundOrder.WarehouseId,
 		); err != nil {
-			return &inboundOrders, err
+			return &inboundOrders, 0, err
 		}
 
 		inboundOrders = append(inboundOrders, inboundOrder)
 	}
-	return &inboundOrders, nil
+	return &inboundOrders, total, nil
 }
 
+// Create stores the inbound order, links it to the receipt that opened the
+// ledger of its batch and publishes its creation.
 func (m mariadbRepository) Create(ctx context.Context, inbounOrder *domain.InboundOrder) (*domain.InboundOrder, error) {
 	newInboundOrder := domain.InboundOrder{}
 
-	result, err := m.db.ExecContext(
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &newInboundOrder, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
 		ctx,
 		sqlInsert,
 		&inbounOrder.OrderDate,
@@ -57,7 +80,7 @@ func (m mariadbRepository) Create(ctx context.Context, inbounOrder *domain.Inbou
 		&inbounOrder.WarehouseId,
 	)
 	if err != nil {
-		return &newInboundOrder, err
+		return &newInboundOrder, apperrors.FromMySQL(err)
 	}
 
 	lastId, err := result.LastInsertId()
@@ -66,7 +89,23 @@ func (m mariadbRepository) Create(ctx context.Context, inbounOrder *domain.Inbou
 		return &newInboundOrder, err
 	}
 
+	if err := ledger.LinkReceipt(ctx, tx, inbounOrder.ProductBatchId, lastId); err != nil {
+		return &newInboundOrder, err
+	}
+
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "inbound_orders", lastId, nil); err != nil {
+		return &newInboundOrder, err
+	}
+
 	inbounOrder.ID = lastId
 
+	if err := outbox.Publish(ctx, tx, events.TypeInboundOrderCreated, "inbound_orders", lastId, inbounOrder); err != nil {
+		return &newInboundOrder, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &newInboundOrder, err
+	}
+
 	return inbounOrder, nil
 }
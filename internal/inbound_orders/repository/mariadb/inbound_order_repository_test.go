@@ -7,6 +7,11 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/eventstest"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -30,6 +35,7 @@ func TestCreateNewInboundOrder(t *testing.T) {
 
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(sqlInsert)).WithArgs(
 			mockInboundOrder.OrderDate,
 			mockInboundOrder.OrderNumber,
@@ -37,12 +43,19 @@ func TestCreateNewInboundOrder(t *testing.T) {
 			mockInboundOrder.ProductBatchId,
 			mockInboundOrder.WarehouseId,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE stock_movements SET inbound_order_id = ?")).
+			WithArgs(1, mockInboundOrder.ProductBatchId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "inbound_orders", 1)
+		eventstest.ExpectPublish(mock, events.TypeInboundOrderCreated, "inbound_orders", 1)
+		mock.ExpectCommit()
 
 		repository := NewMariaDBRepository(db)
 		newInboundOrder, err := repository.Create(context.Background(), &mockInboundOrder)
 
 		assert.NoError(t, err)
 		assert.Equal(t, &mockInboundOrder, newInboundOrder)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("fail to create inbound order", func(t *testing.T) {
@@ -52,6 +65,7 @@ func TestCreateNewInboundOrder(t *testing.T) {
 
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(sqlInsert)).WithArgs(
 			"10-07-2022",
 			"123",
@@ -89,12 +103,14 @@ func TestGetAll(t *testing.T) {
 			)
 		}
 
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(mockInboundOrders)))
 		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAll)).WillReturnRows(rows)
 
 		repository := NewMariaDBRepository(db)
-		result, err := repository.GetAll(context.Background())
+		result, total, err := repository.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 
 		assert.NoError(t, err)
+		assert.Equal(t, int64(len(mockInboundOrders)), total)
 		assert.Equal(t, result, &mockInboundOrders)
 	})
 
@@ -107,10 +123,11 @@ func TestGetAll(t *testing.T) {
 
 		rows := sqlmock.NewRows(rowsInboundOrderStruct).AddRow("", "", "", "", "", "")
 
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAll)).WillReturnRows(rows)
 
 		repository := NewMariaDBRepository(db)
-		_, err = repository.GetAll(context.Background())
+		_, _, err = repository.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 
 		assert.Error(t, err)
 	})
@@ -122,10 +139,11 @@ func TestGetAll(t *testing.T) {
 
 		defer db.Close()
 
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAll)).WillReturnError(sql.ErrNoRows)
 
 		repository := NewMariaDBRepository(db)
-		_, err = repository.GetAll(context.Background())
+		_, _, err = repository.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 
 		assert.Error(t, err)
 	})
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/inbound_orders/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 )
 
 type inboundOrderService struct {
@@ -14,17 +16,23 @@ func NewInboundOrderService(ir domain.InboundOrderRepository) domain.InboundOrde
 	return &inboundOrderService{repository: ir}
 }
 
-func (i inboundOrderService) GetAll(ctx context.Context) (*[]domain.InboundOrder, error) {
-	inboundOrder, err := i.repository.GetAll(ctx)
+func (i inboundOrderService) GetAll(ctx context.Context, params listing.Params) (*[]domain.InboundOrder, int64, error) {
+	inboundOrder, total, err := i.repository.GetAll(ctx, params)
 
 	if err != nil {
-		return inboundOrder, err
+		return inboundOrder, 0, err
 	}
 
-	return inboundOrder, nil
+	return inboundOrder, total, nil
 }
 
+// Create registers an inbound order, which employees may only do for their
+// own warehouse.
 func (i inboundOrderService) Create(ctx context.Context, inboundOrder *domain.InboundOrder) (*domain.InboundOrder, error) {
+	if err := policy.OwnWarehouse(ctx, inboundOrder.WarehouseId); err != nil {
+		return inboundOrder, err
+	}
+
 	inboundOrder, err := i.repository.Create(ctx, inboundOrder)
 
 	if err != nil {
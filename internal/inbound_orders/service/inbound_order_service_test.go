@@ -5,8 +5,11 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/inbound_orders/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/inbound_orders/domain/mocks"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -48,6 +51,19 @@ func TestCreateNewInboundOrder(t *testing.T) {
 
 		mockInboundOrderRepository.AssertExpectations(t)
 	})
+
+	t.Run("In case of another warehouse", func(t *testing.T) {
+		mockInboundOrderRepository := mocks.NewInboundOrderRepository(t)
+		mockInboundOrder := utils.CreateRandomInboundOrder()
+
+		warehouseId := mockInboundOrder.WarehouseId + 1
+		ctx := auth.NewContext(context.Background(), auth.Claims{Roles: []string{policy.Employee}, WarehouseId: &warehouseId})
+
+		service := NewInboundOrderService(mockInboundOrderRepository)
+		_, err := service.Create(ctx, &mockInboundOrder)
+
+		assert.ErrorIs(t, err, policy.ErrOtherWarehouse)
+	})
 }
 
 func TestGetAll(t *testing.T) {
@@ -55,10 +71,10 @@ func TestGetAll(t *testing.T) {
 		mockInboundOrderRepository := mocks.NewInboundOrderRepository(t)
 		mockInboundOrder := utils.CreateRandomListInboundOrders()
 
-		mockInboundOrderRepository.On("GetAll", mock.Anything).Return(&mockInboundOrder, nil).Once()
+		mockInboundOrderRepository.On("GetAll", mock.Anything, mock.Anything).Return(&mockInboundOrder, int64(len(mockInboundOrder)), nil).Once()
 
 		service := NewInboundOrderService(mockInboundOrderRepository)
-		newInboundOrders, err := service.GetAll(context.Background())
+		newInboundOrders, _, err := service.GetAll(context.Background(), listing.Params{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, &mockInboundOrder, newInboundOrders)
@@ -70,10 +86,10 @@ func TestGetAll(t *testing.T) {
 	t.Run("In case of error", func(t *testing.T) {
 		mockInboundOrderRepository := mocks.NewInboundOrderRepository(t)
 
-		mockInboundOrderRepository.On("GetAll", mock.Anything).Return(nil, errors.New("failed to retrieve inbound orders")).Once()
+		mockInboundOrderRepository.On("GetAll", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("failed to retrieve inbound orders")).Once()
 
 		service := NewInboundOrderService(mockInboundOrderRepository)
-		_, err := service.GetAll(context.Background())
+		_, _, err := service.GetAll(context.Background(), listing.Params{})
 
 		assert.NotNil(t, err)
 
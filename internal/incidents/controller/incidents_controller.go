@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/actor"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+)
+
+type IncidentController struct {
+	service domain.IncidentService
+}
+
+func NewIncidentController(service domain.IncidentService) (*IncidentController, error) {
+	if service == nil {
+		return nil, errors.New("invalid service")
+	}
+
+	return &IncidentController{
+		service: service,
+	}, nil
+}
+
+// @Summary List temperature incidents
+// @Tags Incidents
+// @Description get all temperature breach incidents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Param kind query string false "section_out_of_band, batch_too_warm or prolonged_breach"
+// @Param status query string false "open or resolved"
+// @Param section_id query int false "Section ID"
+// @Param product_batch_id query int false "Product batch ID"
+// @Param started_at_from query string false "Started at or after"
+// @Param started_at_to query string false "Started at or before"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.Incident}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /incidents [get]
+func (c IncidentController) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.IncidentListFields)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		incidents, total, err := c.service.GetAll(ctx.Request.Context(), params)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": incidents,
+			"meta": listing.NewMeta(params, total),
+		})
+	}
+}
+
+// @Summary Temperature incident by id
+// @Tags Incidents
+// @Description get a temperature breach incident by its id
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Incident ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Incident}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /incidents/{id} [get]
+func (c IncidentController) GetById() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestIncidentId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		incident, err := c.service.GetById(ctx.Request.Context(), req.ID)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": incident})
+	}
+}
+
+// @Summary Acknowledge temperature incident
+// @Tags Incidents
+// @Description Mark an incident as seen by the caller
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Incident ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Incident}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /incidents/{id}/acknowledge [post]
+func (c IncidentController) Acknowledge() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var reqId domain.RequestIncidentId
+		if err := ctx.ShouldBindUri(&reqId); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		incident, err := c.service.Acknowledge(ctx.Request.Context(), reqId.ID, actor.FromContext(ctx.Request.Context()))
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": incident})
+	}
+}
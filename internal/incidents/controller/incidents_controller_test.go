@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/actor"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain/mocks"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func serve(serviceMock *mocks.IncidentService, method, path string, body *bytes.Buffer) *httptest.ResponseRecorder {
+	if body == nil {
+		body = &bytes.Buffer{}
+	}
+
+	req := httptest.NewRequest(method, path, body)
+	rec := httptest.NewRecorder()
+
+	_, engine := gin.CreateTestContext(rec)
+
+	incidentController := IncidentController{service: serviceMock}
+
+	engine.GET("/api/v1/incidents", incidentController.GetAll())
+	engine.GET("/api/v1/incidents/:id", incidentController.GetById())
+	engine.POST("/api/v1/incidents/:id/acknowledge", incidentController.Acknowledge())
+
+	engine.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		params := listing.Params{
+			Limit:   listing.DefaultLimit,
+			Filters: []listing.Filter{{Field: "status", Value: domain.StatusOpen}},
+		}
+
+		serviceMock := mocks.NewIncidentService(t)
+		serviceMock.On("GetAll", mock.Anything, params).Return(&[]domain.Incident{{ID: 1}}, int64(1), nil).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/incidents?status=open", nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"meta"`)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		serviceMock := mocks.NewIncidentService(t)
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/incidents?sort=temperature", nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		serviceMock := mocks.NewIncidentService(t)
+		serviceMock.On("GetAll", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("connection refused")).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/incidents", nil)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewIncidentService(t)
+		serviceMock.On("GetById", mock.Anything, int64(1)).Return(&domain.Incident{ID: 1}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/incidents/1", nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		serviceMock := mocks.NewIncidentService(t)
+		serviceMock.On("GetById", mock.Anything, int64(1)).Return(nil, domain.ErrIncidentNotFound).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/incidents/1", nil)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		serviceMock := mocks.NewIncidentService(t)
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/incidents/abc", nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestAcknowledge(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewIncidentService(t)
+		serviceMock.On("Acknowledge", mock.Anything, int64(1), actor.System).Return(&domain.Incident{ID: 1}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodPost, "/api/v1/incidents/1/acknowledge", nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("acknowledged by the caller", func(t *testing.T) {
+		serviceMock := mocks.NewIncidentService(t)
+		serviceMock.On("Acknowledge", mock.Anything, int64(1), "ana").
+			Return(&domain.Incident{ID: 1, AcknowledgedBy: "ana"}, nil).Once()
+
+		signer := auth.NewSigner([]byte("secret"), time.Hour)
+		token, err := signer.Sign(auth.Claims{Subject: "ana", Roles: []string{policy.Admin}})
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/incidents/1/acknowledge",
+			bytes.NewBufferString(`{"acknowledged_by": "bob"}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		incidentController := IncidentController{service: serviceMock}
+
+		engine.POST("/api/v1/incidents/:id/acknowledge", auth.Middleware(signer), incidentController.Acknowledge())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		serviceMock := mocks.NewIncidentService(t)
+		serviceMock.On("Acknowledge", mock.Anything, int64(1), actor.System).Return(nil, domain.ErrAlreadyAcknowledged).Once()
+
+		rec := serve(serviceMock, http.MethodPost, "/api/v1/incidents/1/acknowledge", nil)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
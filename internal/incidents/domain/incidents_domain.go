@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
+
+const (
+	KindSectionOutOfBand = "section_out_of_band"
+	KindBatchTooWarm     = "batch_too_warm"
+	KindProlongedBreach  = "prolonged_breach"
+
+	StatusOpen     = "open"
+	StatusResolved = "resolved"
+)
+
+// Incident records a temperature breach from the moment it was detected
+// until the temperature is back within its limit.
+type Incident struct {
+	ID               int64      `json:"id"`
+	Kind             string     `json:"kind"`
+	Status           string     `json:"status"`
+	SectionId        int64      `json:"section_id"`
+	ProductBatchId   int64      `json:"product_batch_id,omitempty"`
+	ParentId         int64      `json:"parent_id,omitempty"`
+	Temperature      float64    `json:"temperature"`
+	LimitTemperature float64    `json:"limit_temperature"`
+	StartedAt        time.Time  `json:"started_at"`
+	ResolvedAt       *time.Time `json:"resolved_at,omitempty"`
+	AcknowledgedAt   *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy   string     `json:"acknowledged_by,omitempty"`
+}
+
+// SectionTemperatureBreached is the payload of the event published when a
+// section goes out of its band, with the warehouse of the section.
+type SectionTemperatureBreached struct {
+	Incident
+	WarehouseId int64 `json:"warehouse_id"`
+}
+
+var IncidentListFields = listing.Fields{
+	Sort:   []string{"id", "kind", "status", "section_id", "started_at"},
+	Filter: []string{"kind", "status", "section_id", "product_batch_id"},
+	Range:  []string{"started_at"},
+}
+
+// SectionState is the temperature of a section and the lowest temperature
+// it is set up for.
+type SectionState struct {
+	SectionId          int64
+	CurrentTemperature float64
+	MinimumTemperature float64
+}
+
+// BatchState is the temperature of the section a batch is stored in and
+// the warmest temperature the batch and its product allow.
+type BatchState struct {
+	ProductBatchId     int64
+	SectionId          int64
+	SectionTemperature float64
+	MaximumTemperature float64
+}
+
+type RequestIncidentId struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// Notifier delivers raised incidents to the people on call.
+type Notifier interface {
+	Notify(ctx context.Context, incident Incident) error
+}
+
+type IncidentRepository interface {
+	GetAll(ctx context.Context, params listing.Params) (*[]Incident, int64, error)
+	GetById(ctx context.Context, id int64) (*Incident, error)
+	Acknowledge(ctx context.Context, id int64, acknowledgedBy string, at time.Time) error
+
+	// The state lookups are limited to sectionIds, or cover every section
+	// when it is empty.
+	GetSectionStates(ctx context.Context, sectionIds []int64) (*[]SectionState, error)
+	GetBatchStates(ctx context.Context, sectionIds []int64) (*[]BatchState, error)
+	GetOpenIncidents(ctx context.Context, sectionIds []int64) (*[]Incident, error)
+
+	// Apply stores the raised incidents and resolves the given ones at once,
+	// returning the raised incidents that were not already open.
+	Apply(ctx context.Context, raised []Incident, resolved []int64, at time.Time) ([]Incident, error)
+}
+
+type IncidentService interface {
+	GetAll(ctx context.Context, params listing.Params) (*[]Incident, int64, error)
+	GetById(ctx context.Context, id int64) (*Incident, error)
+	Acknowledge(ctx context.Context, id int64, acknowledgedBy string) (*Incident, error)
+
+	// Evaluate runs the breach rules on the sections, or on all of them when
+	// none is given, and notifies the incidents it raises.
+	Evaluate(ctx context.Context, sectionIds []int64) ([]Incident, error)
+	SectionsUpdated(ctx context.Context, sectionIds []int64)
+}
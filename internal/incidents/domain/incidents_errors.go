@@ -0,0 +1,8 @@
+package domain
+
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+
+var (
+	ErrIncidentNotFound    = apperrors.NotFound("incident not found")
+	ErrAlreadyAcknowledged = apperrors.Conflict("incident already acknowledged")
+)
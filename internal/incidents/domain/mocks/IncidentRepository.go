@@ -0,0 +1,193 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IncidentRepository is an autogenerated mock type for the IncidentRepository type
+type IncidentRepository struct {
+	mock.Mock
+}
+
+// Acknowledge provides a mock function with given fields: ctx, id, acknowledgedBy, at
+func (_m *IncidentRepository) Acknowledge(ctx context.Context, id int64, acknowledgedBy string, at time.Time) error {
+	ret := _m.Called(ctx, id, acknowledgedBy, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Time) error); ok {
+		r0 = rf(ctx, id, acknowledgedBy, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Apply provides a mock function with given fields: ctx, raised, resolved, at
+func (_m *IncidentRepository) Apply(ctx context.Context, raised []domain.Incident, resolved []int64, at time.Time) ([]domain.Incident, error) {
+	ret := _m.Called(ctx, raised, resolved, at)
+
+	var r0 []domain.Incident
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Incident, []int64, time.Time) []domain.Incident); ok {
+		r0 = rf(ctx, raised, resolved, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Incident)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.Incident, []int64, time.Time) error); ok {
+		r1 = rf(ctx, raised, resolved, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, params
+func (_m *IncidentRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Incident, int64, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *[]domain.Incident
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.Incident); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Incident)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetBatchStates provides a mock function with given fields: ctx, sectionIds
+func (_m *IncidentRepository) GetBatchStates(ctx context.Context, sectionIds []int64) (*[]domain.BatchState, error) {
+	ret := _m.Called(ctx, sectionIds)
+
+	var r0 *[]domain.BatchState
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) *[]domain.BatchState); ok {
+		r0 = rf(ctx, sectionIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.BatchState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, sectionIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetById provides a mock function with given fields: ctx, id
+func (_m *IncidentRepository) GetById(ctx context.Context, id int64) (*domain.Incident, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Incident
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Incident); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Incident)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenIncidents provides a mock function with given fields: ctx, sectionIds
+func (_m *IncidentRepository) GetOpenIncidents(ctx context.Context, sectionIds []int64) (*[]domain.Incident, error) {
+	ret := _m.Called(ctx, sectionIds)
+
+	var r0 *[]domain.Incident
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) *[]domain.Incident); ok {
+		r0 = rf(ctx, sectionIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Incident)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, sectionIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSectionStates provides a mock function with given fields: ctx, sectionIds
+func (_m *IncidentRepository) GetSectionStates(ctx context.Context, sectionIds []int64) (*[]domain.SectionState, error) {
+	ret := _m.Called(ctx, sectionIds)
+
+	var r0 *[]domain.SectionState
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) *[]domain.SectionState); ok {
+		r0 = rf(ctx, sectionIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.SectionState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, sectionIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIncidentRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIncidentRepository creates a new instance of IncidentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIncidentRepository(t mockConstructorTestingTNewIncidentRepository) *IncidentRepository {
+	mock := &IncidentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
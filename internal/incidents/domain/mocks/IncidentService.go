@@ -0,0 +1,136 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IncidentService is an autogenerated mock type for the IncidentService type
+type IncidentService struct {
+	mock.Mock
+}
+
+// Acknowledge provides a mock function with given fields: ctx, id, acknowledgedBy
+func (_m *IncidentService) Acknowledge(ctx context.Context, id int64, acknowledgedBy string) (*domain.Incident, error) {
+	ret := _m.Called(ctx, id, acknowledgedBy)
+
+	var r0 *domain.Incident
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *domain.Incident); ok {
+		r0 = rf(ctx, id, acknowledgedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Incident)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, id, acknowledgedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Evaluate provides a mock function with given fields: ctx, sectionIds
+func (_m *IncidentService) Evaluate(ctx context.Context, sectionIds []int64) ([]domain.Incident, error) {
+	ret := _m.Called(ctx, sectionIds)
+
+	var r0 []domain.Incident
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []domain.Incident); ok {
+		r0 = rf(ctx, sectionIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Incident)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, sectionIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, params
+func (_m *IncidentService) GetAll(ctx context.Context, params listing.Params) (*[]domain.Incident, int64, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *[]domain.Incident
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.Incident); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Incident)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetById provides a mock function with given fields: ctx, id
+func (_m *IncidentService) GetById(ctx context.Context, id int64) (*domain.Incident, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Incident
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Incident); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Incident)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SectionsUpdated provides a mock function with given fields: ctx, sectionIds
+func (_m *IncidentService) SectionsUpdated(ctx context.Context, sectionIds []int64) {
+	_m.Called(ctx, sectionIds)
+}
+
+type mockConstructorTestingTNewIncidentService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIncidentService creates a new instance of IncidentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIncidentService(t mockConstructorTestingTNewIncidentService) *IncidentService {
+	mock := &IncidentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Rules holds the settings of the breach rules. A section may run up to
+// SectionBand degrees above its minimum temperature, and a breach still
+// open after GracePeriod raises a prolonged breach.
+type Rules struct {
+	SectionBand float64
+	GracePeriod time.Duration
+}
+
+var DefaultRules = Rules{
+	SectionBand: 4,
+	GracePeriod: 30 * time.Minute,
+}
+
+// Breach is a limit being exceeded right now.
+type Breach struct {
+	Kind             string
+	SectionId        int64
+	ProductBatchId   int64
+	Temperature      float64
+	LimitTemperature float64
+}
+
+// Detect compares the sections with their band and the batches with the
+// temperature of the section they are stored in.
+func (r Rules) Detect(sections []SectionState, batches []BatchState) []Breach {
+	breaches := []Breach{}
+
+	for _, section := range sections {
+		maximum := section.MinimumTemperature + r.SectionBand
+
+		var limit float64
+		switch {
+		case section.CurrentTemperature < section.MinimumTemperature:
+			limit = section.MinimumTemperature
+		case section.CurrentTemperature > maximum:
+			limit = maximum
+		default:
+			continue
+		}
+
+		breaches = append(breaches, Breach{
+			Kind:             KindSectionOutOfBand,
+			SectionId:        section.SectionId,
+			Temperature:      section.CurrentTemperature,
+			LimitTemperature: limit,
+		})
+	}
+
+	for _, batch := range batches {
+		if batch.SectionTemperature <= batch.MaximumTemperature {
+			continue
+		}
+
+		breaches = append(breaches, Breach{
+			Kind:             KindBatchTooWarm,
+			SectionId:        batch.SectionId,
+			ProductBatchId:   batch.ProductBatchId,
+			Temperature:      batch.SectionTemperature,
+			LimitTemperature: batch.MaximumTemperature,
+		})
+	}
+
+	return breaches
+}
+
+// Reconcile compares the current breaches with the open incidents. It
+// returns the incidents to raise, including prolonged breaches for those
+// open longer than the grace period, and the ids of the incidents whose
+// breach is over.
+func (r Rules) Reconcile(breaches []Breach, open []Incident, now time.Time) ([]Incident, []int64) {
+	current := map[string]Breach{}
+	for _, breach := range breaches {
+		current[Key(breach.Kind, breach.SectionId, breach.ProductBatchId)] = breach
+	}
+
+	opened := map[string]Incident{}
+	prolonged := map[int64]Incident{}
+	for _, incident := range open {
+		if incident.Kind == KindProlongedBreach {
+			prolonged[incident.ParentId] = incident
+			continue
+		}
+		opened[Key(incident.Kind, incident.SectionId, incident.ProductBatchId)] = incident
+	}
+
+	raised := []Incident{}
+	resolved := []int64{}
+
+	for _, breach := range breaches {
+		if _, ok := opened[Key(breach.Kind, breach.SectionId, breach.ProductBatchId)]; ok {
+			continue
+		}
+
+		raised = append(raised, Incident{
+			Kind:             breach.Kind,
+			Status:           StatusOpen,
+			SectionId:        breach.SectionId,
+			ProductBatchId:   breach.ProductBatchId,
+			Temperature:      breach.Temperature,
+			LimitTemperature: breach.LimitTemperature,
+			StartedAt:        now,
+		})
+	}
+
+	for _, incident := range open {
+		if incident.Kind == KindProlongedBreach {
+			continue
+		}
+
+		breach, ok := current[Key(incident.Kind, incident.SectionId, incident.ProductBatchId)]
+		if !ok {
+			resolved = append(resolved, incident.ID)
+			if child, ok := prolonged[incident.ID]; ok {
+				resolved = append(resolved, child.ID)
+			}
+			continue
+		}
+
+		if _, ok := prolonged[incident.ID]; ok || now.Sub(incident.StartedAt) <= r.GracePeriod {
+			continue
+		}
+
+		raised = append(raised, Incident{
+			Kind:             KindProlongedBreach,
+			Status:           StatusOpen,
+			SectionId:        incident.SectionId,
+			ProductBatchId:   incident.ProductBatchId,
+			ParentId:         incident.ID,
+			Temperature:      breach.Temperature,
+			LimitTemperature: breach.LimitTemperature,
+			StartedAt:        now,
+		})
+	}
+
+	return raised, resolved
+}
+
+// Key identifies the open incident of a kind for a section or batch, so the
+// same breach is never open twice.
+func Key(kind string, sectionId, productBatchId int64) string {
+	return fmt.Sprintf("%s:%d:%d", kind, sectionId, productBatchId)
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	rules := Rules{SectionBand: 4, GracePeriod: time.Hour}
+
+	sections := []SectionState{
+		{SectionId: 1, CurrentTemperature: 2, MinimumTemperature: 0},
+		{SectionId: 2, CurrentTemperature: -3, MinimumTemperature: -2},
+		{SectionId: 3, CurrentTemperature: 5, MinimumTemperature: 0},
+	}
+	batches := []BatchState{
+		{ProductBatchId: 10, SectionId: 1, SectionTemperature: 2, MaximumTemperature: 3},
+		{ProductBatchId: 11, SectionId: 1, SectionTemperature: 2, MaximumTemperature: 1},
+	}
+
+	breaches := rules.Detect(sections, batches)
+
+	assert.Equal(t, []Breach{
+		{Kind: KindSectionOutOfBand, SectionId: 2, Temperature: -3, LimitTemperature: -2},
+		{Kind: KindSectionOutOfBand, SectionId: 3, Temperature: 5, LimitTemperature: 4},
+		{Kind: KindBatchTooWarm, SectionId: 1, ProductBatchId: 11, Temperature: 2, LimitTemperature: 1},
+	}, breaches)
+}
+
+func TestReconcile(t *testing.T) {
+	rules := Rules{SectionBand: 4, GracePeriod: time.Hour}
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("raises new breaches", func(t *testing.T) {
+		breaches := []Breach{{Kind: KindBatchTooWarm, SectionId: 1, ProductBatchId: 11, Temperature: 2, LimitTemperature: 1}}
+
+		raised, resolved := rules.Reconcile(breaches, nil, now)
+
+		assert.Equal(t, []Incident{{
+			Kind:             KindBatchTooWarm,
+			Status:           StatusOpen,
+			SectionId:        1,
+			ProductBatchId:   11,
+			Temperature:      2,
+			LimitTemperature: 1,
+			StartedAt:        now,
+		}}, raised)
+		assert.Empty(t, resolved)
+	})
+
+	t.Run("keeps breaches within the grace period", func(t *testing.T) {
+		breaches := []Breach{{Kind: KindSectionOutOfBand, SectionId: 1, Temperature: 6, LimitTemperature: 4}}
+		open := []Incident{{ID: 5, Kind: KindSectionOutOfBand, SectionId: 1, StartedAt: now.Add(-time.Hour)}}
+
+		raised, resolved := rules.Reconcile(breaches, open, now)
+
+		assert.Empty(t, raised)
+		assert.Empty(t, resolved)
+	})
+
+	t.Run("raises prolonged breaches once", func(t *testing.T) {
+		breaches := []Breach{{Kind: KindSectionOutOfBand, SectionId: 1, Temperature: 6, LimitTemperature: 4}}
+		open := []Incident{{ID: 5, Kind: KindSectionOutOfBand, SectionId: 1, StartedAt: now.Add(-2 * time.Hour)}}
+
+		raised, resolved := rules.Reconcile(breaches, open, now)
+
+		assert.Len(t, raised, 1)
+		assert.Equal(t, KindProlongedBreach, raised[0].Kind)
+		assert.Equal(t, int64(5), raised[0].ParentId)
+		assert.Empty(t, resolved)
+
+		open = append(open, Incident{ID: 6, Kind: KindProlongedBreach, SectionId: 1, ParentId: 5, StartedAt: now})
+
+		raised, resolved = rules.Reconcile(breaches, open, now.Add(time.Minute))
+
+		assert.Empty(t, raised)
+		assert.Empty(t, resolved)
+	})
+
+	t.Run("resolves breaches that are over", func(t *testing.T) {
+		open := []Incident{
+			{ID: 5, Kind: KindSectionOutOfBand, SectionId: 1, StartedAt: now.Add(-2 * time.Hour)},
+			{ID: 6, Kind: KindProlongedBreach, SectionId: 1, ParentId: 5, StartedAt: now.Add(-time.Hour)},
+			{ID: 7, Kind: KindBatchTooWarm, SectionId: 1, ProductBatchId: 11, StartedAt: now},
+		}
+
+		raised, resolved := rules.Reconcile(nil, open, now)
+
+		assert.Empty(t, raised)
+		assert.Equal(t, []int64{5, 6, 7}, resolved)
+	})
+}
@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+	"log"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+)
+
+// LogNotifier writes raised incidents to a logger.
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Notify(_ context.Context, incident domain.Incident) error {
+	n.logger.Printf(
+		"temperature incident %d: %s in section %d (batch %d) at %.2f, limit %.2f",
+		incident.ID,
+		incident.Kind,
+		incident.SectionId,
+		incident.ProductBatchId,
+		incident.Temperature,
+		incident.LimitTemperature,
+	)
+	return nil
+}
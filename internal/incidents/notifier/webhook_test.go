@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var payload webhookPayload
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		n := NewWebhookNotifier(server.URL, nil)
+
+		err := n.Notify(context.Background(), domain.Incident{ID: 3, Kind: domain.KindBatchTooWarm})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "incident.raised", payload.Event)
+		assert.Equal(t, int64(3), payload.Incident.ID)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		n := NewWebhookNotifier(server.URL, nil)
+
+		err := n.Notify(context.Background(), domain.Incident{ID: 3})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,296 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	outbox "github.com/marcoglnd/mercado-fresco-packmain/internal/events/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
+
+type mariadbRepository struct {
+	db *sql.DB
+}
+
+func NewMariaDBRepository(db *sql.DB) domain.IncidentRepository {
+	return mariadbRepository{db: db}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (m mariadbRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Incident, int64, error) {
+	incidents := []domain.Incident{}
+
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAll, params, nil)
+	if err := m.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &incidents, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAll, params, nil)
+	if err := m.query(ctx, &incidents, query, args...); err != nil {
+		return &incidents, 0, err
+	}
+
+	return &incidents, total, nil
+}
+
+func (m mariadbRepository) GetById(ctx context.Context, id int64) (*domain.Incident, error) {
+	incident, err := scanIncident(m.db.QueryRowContext(ctx, sqlGetById, id))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
+
+func (m mariadbRepository) Acknowledge(ctx context.Context, id int64, acknowledgedBy string, at time.Time) error {
+	result, err := m.db.ExecContext(ctx, sqlAcknowledge, at, acknowledgedBy, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrAlreadyAcknowledged
+	}
+
+	return nil
+}
+
+func (m mariadbRepository) GetSectionStates(ctx context.Context, sectionIds []int64) (*[]domain.SectionState, error) {
+	states := []domain.SectionState{}
+
+	query, args := inSections(sqlSectionState, " WHERE id", sectionIds)
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return &states, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var state domain.SectionState
+
+		if err := rows.Scan(
+			&state.SectionId,
+			&state.CurrentTemperature,
+			&state.MinimumTemperature,
+		); err != nil {
+			return &states, err
+		}
+
+		states = append(states, state)
+	}
+
+	if err := rows.Err(); err != nil {
+		return &states, err
+	}
+
+	return &states, nil
+}
+
+func (m mariadbRepository) GetBatchStates(ctx context.Context, sectionIds []int64) (*[]domain.BatchState, error) {
+	states := []domain.BatchState{}
+
+	query, args := inSections(sqlBatchState, " AND pb.section_id", sectionIds)
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return &states, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var state domain.BatchState
+
+		if err := rows.Scan(
+			&state.ProductBatchId,
+			&state.SectionId,
+			&state.SectionTemperature,
+			&state.MaximumTemperature,
+		); err != nil {
+			return &states, err
+		}
+
+		states = append(states, state)
+	}
+
+	if err := rows.Err(); err != nil {
+		return &states, err
+	}
+
+	return &states, nil
+}
+
+func (m mariadbRepository) GetOpenIncidents(ctx context.Context, sectionIds []int64) (*[]domain.Incident, error) {
+	incidents := []domain.Incident{}
+
+	query, args := inSections(sqlGetOpen, " AND section_id", sectionIds)
+	if err := m.query(ctx, &incidents, query, args...); err != nil {
+		return &incidents, err
+	}
+
+	return &incidents, nil
+}
+
+// Apply relies on the unique open_key of open incidents, so a breach raised
+// at the same time by another evaluation is skipped instead of duplicated.
+// Sections going out of their band are published as they are raised.
+func (m mariadbRepository) Apply(
+	ctx context.Context,
+	raised []domain.Incident,
+	resolved []int64,
+	at time.Time,
+) ([]domain.Incident, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	for _, id := range resolved {
+		if _, err := tx.ExecContext(ctx, sqlResolve, at, id); err != nil {
+			return nil, err
+		}
+	}
+
+	inserted := []domain.Incident{}
+	for _, incident := range raised {
+		result, err := tx.ExecContext(
+			ctx,
+			sqlInsert,
+			incident.Kind,
+			incident.Status,
+			incident.SectionId,
+			incident.ProductBatchId,
+			incident.ParentId,
+			incident.Temperature,
+			incident.LimitTemperature,
+			incident.StartedAt,
+			domain.Key(incident.Kind, incident.SectionId, incident.ProductBatchId),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+
+		if affected == 0 {
+			continue
+		}
+
+		incident.ID, err = result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+
+		if incident.Kind == domain.KindSectionOutOfBand {
+			breached := domain.SectionTemperatureBreached{Incident: incident}
+			if err := tx.QueryRowContext(ctx, sqlGetWarehouse, incident.SectionId).Scan(&breached.WarehouseId); err != nil {
+				return nil, err
+			}
+
+			if err := outbox.Publish(
+				ctx, tx, events.TypeSectionTemperatureBreached, "sections", incident.SectionId, breached,
+			); err != nil {
+				return nil, err
+			}
+		}
+
+		inserted = append(inserted, incident)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return inserted, nil
+}
+
+func (m mariadbRepository) query(ctx context.Context, incidents *[]domain.Incident, query string, args ...interface{}) error {
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return err
+		}
+
+		*incidents = append(*incidents, *incident)
+	}
+
+	return rows.Err()
+}
+
+func scanIncident(row scanner) (*domain.Incident, error) {
+	var incident domain.Incident
+	var resolvedAt, acknowledgedAt sql.NullTime
+
+	if err := row.Scan(
+		&incident.ID,
+		&incident.Kind,
+		&incident.Status,
+		&incident.SectionId,
+		&incident.ProductBatchId,
+		&incident.ParentId,
+		&incident.Temperature,
+		&incident.LimitTemperature,
+		&incident.StartedAt,
+		&resolvedAt,
+		&acknowledgedAt,
+		&incident.AcknowledgedBy,
+	); err != nil {
+		return nil, err
+	}
+
+	if resolvedAt.Valid {
+		incident.ResolvedAt = &resolvedAt.Time
+	}
+
+	if acknowledgedAt.Valid {
+		incident.AcknowledgedAt = &acknowledgedAt.Time
+	}
+
+	return &incident, nil
+}
+
+// inSections appends "<column> IN (...)" to query when sectionIds is not
+// empty.
+func inSections(query, column string, sectionIds []int64) (string, []interface{}) {
+	if len(sectionIds) == 0 {
+		return query + ";", nil
+	}
+
+	args := make([]interface{}, len(sectionIds))
+	for i, id := range sectionIds {
+		args[i] = id
+	}
+
+	return query + column + " IN (?" + strings.Repeat(", ?", len(sectionIds)-1) + ");", args
+}
@@ -0,0 +1,243 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/eventstest"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	queryGetAll       = regexp.QuoteMeta(sqlGetAll)
+	queryCountAll     = regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)
+	queryGetById      = regexp.QuoteMeta(sqlGetById)
+	queryGetOpen      = regexp.QuoteMeta(sqlGetOpen)
+	queryAcknowledge  = regexp.QuoteMeta(sqlAcknowledge)
+	queryInsert       = regexp.QuoteMeta(sqlInsert)
+	queryResolve      = regexp.QuoteMeta(sqlResolve)
+	querySectionState = regexp.QuoteMeta(sqlSectionState)
+	queryBatchState   = regexp.QuoteMeta(sqlBatchState)
+	queryGetWarehouse = regexp.QuoteMeta(sqlGetWarehouse)
+)
+
+var rowsIncidentsStruct = []string{
+	"id", "kind", "status", "section_id", "product_batch_id", "parent_id", "temperature", "limit_temperature",
+	"started_at", "resolved_at", "acknowledged_at", "acknowledged_by",
+}
+
+var startedAt = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func incidentRows() *sqlmock.Rows {
+	return sqlmock.NewRows(rowsIncidentsStruct).
+		AddRow(1, domain.KindSectionOutOfBand, domain.StatusOpen, 2, 0, 0, 7.0, 4.0, startedAt, nil, nil, "").
+		AddRow(2, domain.KindBatchTooWarm, domain.StatusResolved, 2, 5, 0, 3.0, 1.0, startedAt, startedAt, startedAt, "ana")
+}
+
+func TestGetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	params := listing.Params{Limit: 10, Filters: []listing.Filter{{Field: "section_id", Value: "2"}}}
+
+	mock.ExpectQuery(queryCountAll).WithArgs("2").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(queryGetAll+regexp.QuoteMeta(" WHERE section_id = ? ORDER BY id ASC LIMIT ? OFFSET ?")).
+		WithArgs("2", 10, 0).
+		WillReturnRows(incidentRows())
+
+	repository := NewMariaDBRepository(db)
+
+	incidents, total, err := repository.GetAll(context.Background(), params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, *incidents, 2)
+	assert.Nil(t, (*incidents)[0].ResolvedAt)
+	assert.Equal(t, startedAt, *(*incidents)[1].AcknowledgedAt)
+	assert.Equal(t, "ana", (*incidents)[1].AcknowledgedBy)
+}
+
+func TestGetById(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetById).WithArgs(1).WillReturnRows(incidentRows())
+
+		repository := NewMariaDBRepository(db)
+
+		incident, err := repository.GetById(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), incident.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetById).WithArgs(1).WillReturnRows(sqlmock.NewRows(rowsIncidentsStruct))
+
+		repository := NewMariaDBRepository(db)
+
+		incident, err := repository.GetById(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, incident)
+	})
+}
+
+func TestAcknowledge(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(queryAcknowledge).WithArgs(startedAt, "ana", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repository := NewMariaDBRepository(db)
+
+		assert.NoError(t, repository.Acknowledge(context.Background(), 1, "ana", startedAt))
+	})
+
+	t.Run("already acknowledged", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(queryAcknowledge).WithArgs(startedAt, "ana", 1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repository := NewMariaDBRepository(db)
+
+		assert.ErrorIs(t, repository.Acknowledge(context.Background(), 1, "ana", startedAt), domain.ErrAlreadyAcknowledged)
+	})
+}
+
+func TestGetStates(t *testing.T) {
+	t.Run("sections", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(querySectionState+regexp.QuoteMeta(" WHERE id IN (?, ?);")).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "current_temperature", "minimum_temperature"}).AddRow(1, 3.5, 0.0))
+
+		repository := NewMariaDBRepository(db)
+
+		states, err := repository.GetSectionStates(context.Background(), []int64{1, 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &[]domain.SectionState{{SectionId: 1, CurrentTemperature: 3.5}}, states)
+	})
+
+	t.Run("batches", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryBatchState + ";").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "section_id", "current_temperature", "maximum_temperature"}).AddRow(5, 1, 3.5, 2.0))
+
+		repository := NewMariaDBRepository(db)
+
+		states, err := repository.GetBatchStates(context.Background(), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &[]domain.BatchState{{ProductBatchId: 5, SectionId: 1, SectionTemperature: 3.5, MaximumTemperature: 2}}, states)
+	})
+
+	t.Run("open incidents", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetOpen + regexp.QuoteMeta(" AND section_id IN (?);")).
+			WithArgs(2).
+			WillReturnRows(incidentRows())
+
+		repository := NewMariaDBRepository(db)
+
+		incidents, err := repository.GetOpenIncidents(context.Background(), []int64{2})
+
+		assert.NoError(t, err)
+		assert.Len(t, *incidents, 2)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(querySectionState).WillReturnError(sql.ErrConnDone)
+
+		repository := NewMariaDBRepository(db)
+
+		_, err = repository.GetSectionStates(context.Background(), nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestApply(t *testing.T) {
+	raised := []domain.Incident{
+		{Kind: domain.KindSectionOutOfBand, Status: domain.StatusOpen, SectionId: 2, Temperature: 7, LimitTemperature: 4, StartedAt: startedAt},
+		{Kind: domain.KindBatchTooWarm, Status: domain.StatusOpen, SectionId: 2, ProductBatchId: 5, Temperature: 7, LimitTemperature: 1, StartedAt: startedAt},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryResolve).WithArgs(startedAt, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryInsert).
+			WithArgs(domain.KindSectionOutOfBand, domain.StatusOpen, 2, 0, 0, 7.0, 4.0, startedAt, "section_out_of_band:2:0").
+			WillReturnResult(sqlmock.NewResult(8, 1))
+		mock.ExpectQuery(queryGetWarehouse).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(6))
+		breached := domain.SectionTemperatureBreached{Incident: raised[0], WarehouseId: 6}
+		breached.ID = 8
+		eventstest.ExpectPublishPayload(mock, events.TypeSectionTemperatureBreached, "sections", 2, breached)
+		mock.ExpectExec(queryInsert).
+			WithArgs(domain.KindBatchTooWarm, domain.StatusOpen, 2, 5, 0, 7.0, 1.0, startedAt, "batch_too_warm:2:5").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		repository := NewMariaDBRepository(db)
+
+		inserted, err := repository.Apply(context.Background(), raised, []int64{3}, startedAt)
+
+		assert.NoError(t, err)
+		assert.Len(t, inserted, 1)
+		assert.Equal(t, int64(8), inserted[0].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsert).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		repository := NewMariaDBRepository(db)
+
+		_, err = repository.Apply(context.Background(), raised, nil, startedAt)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
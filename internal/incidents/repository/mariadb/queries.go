@@ -0,0 +1,20 @@
+package mariadb
+
+const (
+	sqlGetAll = `SELECT id, kind, status, section_id, COALESCE(product_batch_id, 0), COALESCE(parent_id, 0), temperature, limit_temperature,
+		started_at, resolved_at, acknowledged_at, COALESCE(acknowledged_by, '')
+		FROM temperature_incidents`
+	sqlGetById      = sqlGetAll + " WHERE id = ?;"
+	sqlGetOpen      = sqlGetAll + " WHERE status = 'open'"
+	sqlAcknowledge  = "UPDATE temperature_incidents SET acknowledged_at = ?, acknowledged_by = NULLIF(?, '') WHERE id = ? AND acknowledged_at IS NULL;"
+	sqlInsert       = "INSERT IGNORE INTO temperature_incidents (kind, status, section_id, product_batch_id, parent_id, temperature, limit_temperature, started_at, open_key) VALUES (?, ?, ?, NULLIF(?, 0), NULLIF(?, 0), ?, ?, ?, ?);"
+	sqlResolve      = "UPDATE temperature_incidents SET status = 'resolved', resolved_at = ?, open_key = NULL WHERE id = ? AND status = 'open';"
+	sqlSectionState = "SELECT id, current_temperature, minimum_temperature FROM sections"
+	sqlGetWarehouse = "SELECT warehouse_id FROM sections WHERE id = ?;"
+	sqlBatchState   = `SELECT pb.id, pb.section_id, s.current_temperature,
+		LEAST(COALESCE(pb.minimum_temperature, p.recommended_freezing_temperature), p.recommended_freezing_temperature)
+		FROM product_batches pb
+		INNER JOIN sections s ON s.id = pb.section_id
+		INNER JOIN products p ON p.id = pb.product_id
+		WHERE pb.current_quantity > 0`
+)
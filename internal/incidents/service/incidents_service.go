@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
+
+type incidentService struct {
+	repository domain.IncidentRepository
+	rules      domain.Rules
+	notifiers  []domain.Notifier
+	now        func() time.Time
+}
+
+func NewIncidentService(
+	r domain.IncidentRepository,
+	rules domain.Rules,
+	notifiers ...domain.Notifier,
+) domain.IncidentService {
+	return &incidentService{repository: r, rules: rules, notifiers: notifiers, now: time.Now}
+}
+
+func (s incidentService) GetAll(ctx context.Context, params listing.Params) (*[]domain.Incident, int64, error) {
+	return s.repository.GetAll(ctx, params)
+}
+
+func (s incidentService) GetById(ctx context.Context, id int64) (*domain.Incident, error) {
+	incident, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if incident == nil {
+		return nil, domain.ErrIncidentNotFound
+	}
+
+	return incident, nil
+}
+
+func (s incidentService) Acknowledge(ctx context.Context, id int64, acknowledgedBy string) (*domain.Incident, error) {
+	incident, err := s.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if incident.AcknowledgedAt != nil {
+		return nil, domain.ErrAlreadyAcknowledged
+	}
+
+	if err := s.repository.Acknowledge(ctx, id, acknowledgedBy, s.now()); err != nil {
+		return nil, err
+	}
+
+	return s.GetById(ctx, id)
+}
+
+func (s incidentService) Evaluate(ctx context.Context, sectionIds []int64) ([]domain.Incident, error) {
+	sections, err := s.repository.GetSectionStates(ctx, sectionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	batches, err := s.repository.GetBatchStates(ctx, sectionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := s.repository.GetOpenIncidents(ctx, sectionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	raised, resolved := s.rules.Reconcile(s.rules.Detect(*sections, *batches), *open, now)
+	if len(raised) == 0 && len(resolved) == 0 {
+		return []domain.Incident{}, nil
+	}
+
+	raised, err = s.repository.Apply(ctx, raised, resolved, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, incident := range raised {
+		for _, notifier := range s.notifiers {
+			if err := notifier.Notify(ctx, incident); err != nil {
+				log.Printf("incident %d: notify: %v", incident.ID, err)
+			}
+		}
+	}
+
+	return raised, nil
+}
+
+// SectionsUpdated evaluates the sections that just got new readings. It
+// only logs failures, so it never fails the ingestion that triggered it.
+func (s incidentService) SectionsUpdated(ctx context.Context, sectionIds []int64) {
+	if len(sectionIds) == 0 {
+		return
+	}
+
+	if _, err := s.Evaluate(ctx, sectionIds); err != nil {
+		log.Printf("incidents: evaluate sections %v: %v", sectionIds, err)
+	}
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+)
+
+// RunMonitor evaluates every section right away and then once per interval,
+// until ctx is cancelled, so breaches that outlast the grace period are
+// raised even when no new readings arrive.
+func RunMonitor(ctx context.Context, s domain.IncidentService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Evaluate(ctx, nil); err != nil {
+			log.Printf("incident monitor: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	rules := domain.Rules{SectionBand: 4, GracePeriod: time.Hour}
+
+	sections := []domain.SectionState{
+		{SectionId: 1, CurrentTemperature: 7, MinimumTemperature: 0},
+		{SectionId: 2, CurrentTemperature: 1, MinimumTemperature: 0},
+	}
+	batches := []domain.BatchState{}
+
+	t.Run("raises, resolves and notifies", func(t *testing.T) {
+		open := []domain.Incident{{ID: 9, Kind: domain.KindSectionOutOfBand, SectionId: 2, StartedAt: now}}
+		raised := []domain.Incident{{
+			Kind:             domain.KindSectionOutOfBand,
+			Status:           domain.StatusOpen,
+			SectionId:        1,
+			Temperature:      7,
+			LimitTemperature: 4,
+			StartedAt:        now,
+		}}
+		stored := raised[0]
+		stored.ID = 10
+
+		repositoryMock := mocks.NewIncidentRepository(t)
+		repositoryMock.On("GetSectionStates", mock.Anything, []int64{1, 2}).Return(&sections, nil).Once()
+		repositoryMock.On("GetBatchStates", mock.Anything, []int64{1, 2}).Return(&batches, nil).Once()
+		repositoryMock.On("GetOpenIncidents", mock.Anything, []int64{1, 2}).Return(&open, nil).Once()
+		repositoryMock.On("Apply", mock.Anything, raised, []int64{9}, now).Return([]domain.Incident{stored}, nil).Once()
+
+		failingNotifier := mocks.NewNotifier(t)
+		failingNotifier.On("Notify", mock.Anything, stored).Return(errors.New("unreachable")).Once()
+		notifierMock := mocks.NewNotifier(t)
+		notifierMock.On("Notify", mock.Anything, stored).Return(nil).Once()
+
+		s := incidentService{
+			repository: repositoryMock,
+			rules:      rules,
+			notifiers:  []domain.Notifier{failingNotifier, notifierMock},
+			now:        func() time.Time { return now },
+		}
+
+		incidents, err := s.Evaluate(context.Background(), []int64{1, 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Incident{stored}, incidents)
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		open := []domain.Incident{{ID: 10, Kind: domain.KindSectionOutOfBand, SectionId: 1, StartedAt: now}}
+
+		repositoryMock := mocks.NewIncidentRepository(t)
+		repositoryMock.On("GetSectionStates", mock.Anything, []int64(nil)).Return(&sections, nil).Once()
+		repositoryMock.On("GetBatchStates", mock.Anything, []int64(nil)).Return(&batches, nil).Once()
+		repositoryMock.On("GetOpenIncidents", mock.Anything, []int64(nil)).Return(&open, nil).Once()
+
+		s := incidentService{repository: repositoryMock, rules: rules, now: func() time.Time { return now }}
+
+		incidents, err := s.Evaluate(context.Background(), nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, incidents)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repositoryMock := mocks.NewIncidentRepository(t)
+		repositoryMock.On("GetSectionStates", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+		s := NewIncidentService(repositoryMock, rules)
+
+		_, err := s.Evaluate(context.Background(), nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestAcknowledge(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("ok", func(t *testing.T) {
+		repositoryMock := mocks.NewIncidentRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(1)).Return(&domain.Incident{ID: 1}, nil).Once()
+		repositoryMock.On("Acknowledge", mock.Anything, int64(1), "ana", now).Return(nil).Once()
+		repositoryMock.On("GetById", mock.Anything, int64(1)).
+			Return(&domain.Incident{ID: 1, AcknowledgedAt: &now, AcknowledgedBy: "ana"}, nil).Once()
+
+		s := incidentService{repository: repositoryMock, now: func() time.Time { return now }}
+
+		incident, err := s.Acknowledge(context.Background(), 1, "ana")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ana", incident.AcknowledgedBy)
+	})
+
+	t.Run("already acknowledged", func(t *testing.T) {
+		repositoryMock := mocks.NewIncidentRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(1)).
+			Return(&domain.Incident{ID: 1, AcknowledgedAt: &now}, nil).Once()
+
+		s := incidentService{repository: repositoryMock, now: func() time.Time { return now }}
+
+		_, err := s.Acknowledge(context.Background(), 1, "ana")
+
+		assert.ErrorIs(t, err, domain.ErrAlreadyAcknowledged)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repositoryMock := mocks.NewIncidentRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(1)).Return(nil, nil).Once()
+
+		s := incidentService{repository: repositoryMock, now: func() time.Time { return now }}
+
+		_, err := s.Acknowledge(context.Background(), 1, "ana")
+
+		assert.ErrorIs(t, err, domain.ErrIncidentNotFound)
+	})
+}
@@ -0,0 +1,209 @@
+package listing
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultLimit int64 = 50
+	MaxLimit     int64 = 500
+)
+
+var (
+	ErrInvalidLimit  = errors.New("limit must be a number between 1 and 500")
+	ErrInvalidOffset = errors.New("offset must be a positive number")
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidSort   = errors.New("invalid sort field")
+)
+
+// Fields lists which fields of a resource can be used in the sort
+// parameter and as filters in the query string. Equality filters use the
+// field name as parameter, range filters use <field>_from and <field>_to.
+type Fields struct {
+	Sort   []string
+	Filter []string
+	Range  []string
+}
+
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Filter compares Field with Value using Op, which is "=" when empty.
+type Filter struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// Params is the parsed form of a list request.
+type Params struct {
+	Limit   int64
+	Offset  int64
+	Sort    []Sort
+	Filters []Filter
+}
+
+// Meta is sent next to the data of every paginated response.
+type Meta struct {
+	Total      int64  `json:"total"`
+	Limit      int64  `json:"limit"`
+	Offset     int64  `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Parse reads limit, offset, cursor, sort and the filters allowed by fields
+// from the query string. A cursor, when given, takes precedence over offset.
+func Parse(values url.Values, fields Fields) (Params, error) {
+	params := Params{Limit: DefaultLimit}
+
+	if raw := values.Get("limit"); raw != "" {
+		limit, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return params, ErrInvalidLimit
+		}
+		params.Limit = limit
+	}
+
+	if raw := values.Get("offset"); raw != "" {
+		offset, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || offset < 0 {
+			return params, ErrInvalidOffset
+		}
+		params.Offset = offset
+	}
+
+	if raw := values.Get("cursor"); raw != "" {
+		offset, err := decodeCursor(raw)
+		if err != nil {
+			return params, err
+		}
+		params.Offset = offset
+	}
+
+	if raw := values.Get("sort"); raw != "" {
+		for _, field := range strings.Split(raw, ",") {
+			field = strings.TrimSpace(field)
+			sort := Sort{Field: strings.TrimPrefix(field, "-"), Desc: strings.HasPrefix(field, "-")}
+			if !contains(fields.Sort, sort.Field) {
+				return params, fmt.Errorf("%w: %s", ErrInvalidSort, sort.Field)
+			}
+			params.Sort = append(params.Sort, sort)
+		}
+	}
+
+	for _, field := range fields.Filter {
+		if value, ok := values[field]; ok && len(value) > 0 && value[0] != "" {
+			params.Filters = append(params.Filters, Filter{Field: field, Value: value[0]})
+		}
+	}
+
+	for _, field := range fields.Range {
+		if value := values.Get(field + "_from"); value != "" {
+			params.Filters = append(params.Filters, Filter{Field: field, Op: ">=", Value: value})
+		}
+		if value := values.Get(field + "_to"); value != "" {
+			params.Filters = append(params.Filters, Filter{Field: field, Op: "<=", Value: value})
+		}
+	}
+
+	return params, nil
+}
+
+// NewMeta builds the response metadata, including the cursor of the next
+// page when there are more rows after the current one.
+func NewMeta(params Params, total int64) Meta {
+	meta := Meta{Total: total, Limit: params.Limit, Offset: params.Offset}
+	if next := params.Offset + params.Limit; next < total {
+		meta.NextCursor = encodeCursor(next)
+	}
+	return meta
+}
+
+// Build appends the WHERE, ORDER BY and LIMIT clauses described by params
+// to base. columns maps field names to SQL columns when they differ.
+func Build(base string, params Params, columns map[string]string) (string, []interface{}) {
+	query, args := where(base, params, columns)
+
+	var order []string
+	for _, sort := range params.Sort {
+		direction := "ASC"
+		if sort.Desc {
+			direction = "DESC"
+		}
+		order = append(order, column(sort.Field, columns)+" "+direction)
+	}
+	if len(order) == 0 {
+		order = append(order, column("id", columns)+" ASC")
+	}
+
+	query += " ORDER BY " + strings.Join(order, ", ") + " LIMIT ? OFFSET ?"
+	args = append(args, params.Limit, params.Offset)
+
+	return query, args
+}
+
+// BuildCount returns a query counting every row matched by the filters of
+// params, ignoring pagination.
+func BuildCount(base string, params Params, columns map[string]string) (string, []interface{}) {
+	query, args := where(base, params, columns)
+	return "SELECT COUNT(*) FROM (" + query + ") AS listing", args
+}
+
+func where(base string, params Params, columns map[string]string) (string, []interface{}) {
+	query := strings.TrimSuffix(strings.TrimSpace(base), ";")
+	args := []interface{}{}
+
+	var conditions []string
+	for _, filter := range params.Filters {
+		op := filter.Op
+		if op == "" {
+			op = "="
+		}
+		conditions = append(conditions, column(filter.Field, columns)+" "+op+" ?")
+		args = append(args, filter.Value)
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return query, args
+}
+
+func column(field string, columns map[string]string) string {
+	if c, ok := columns[field]; ok {
+		return c
+	}
+	return field
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeCursor(offset int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(offset, 10)))
+}
+
+func decodeCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	offset, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return offset, nil
+}
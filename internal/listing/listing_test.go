@@ -0,0 +1,132 @@
+package listing
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFields = Fields{
+	Sort:   []string{"id", "description"},
+	Filter: []string{"seller_id", "product_type_id"},
+	Range:  []string{"due_date"},
+}
+
+func TestParse(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		params, err := Parse(url.Values{}, testFields)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Params{Limit: DefaultLimit}, params)
+	})
+
+	t.Run("limit, offset, sort and filters", func(t *testing.T) {
+		values, _ := url.ParseQuery("limit=10&offset=20&sort=description,-id&seller_id=3&unknown=1")
+
+		params, err := Parse(values, testFields)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Params{
+			Limit:   10,
+			Offset:  20,
+			Sort:    []Sort{{Field: "description"}, {Field: "id", Desc: true}},
+			Filters: []Filter{{Field: "seller_id", Value: "3"}},
+		}, params)
+	})
+
+	t.Run("range filters", func(t *testing.T) {
+		values, _ := url.ParseQuery("due_date_from=2022-01-01&due_date_to=2022-02-01")
+
+		params, err := Parse(values, testFields)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Filter{
+			{Field: "due_date", Op: ">=", Value: "2022-01-01"},
+			{Field: "due_date", Op: "<=", Value: "2022-02-01"},
+		}, params.Filters)
+	})
+
+	t.Run("cursor takes precedence over offset", func(t *testing.T) {
+		values := url.Values{"offset": {"5"}, "cursor": {encodeCursor(40)}}
+
+		params, err := Parse(values, testFields)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(40), params.Offset)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		cases := map[string]error{
+			"limit=0":        ErrInvalidLimit,
+			"limit=abc":      ErrInvalidLimit,
+			"limit=501":      ErrInvalidLimit,
+			"offset=-1":      ErrInvalidOffset,
+			"cursor=abc!":    ErrInvalidCursor,
+			"sort=height":    ErrInvalidSort,
+			"sort=id,-width": ErrInvalidSort,
+		}
+		for raw, expected := range cases {
+			values, _ := url.ParseQuery(raw)
+			_, err := Parse(values, testFields)
+			assert.ErrorIs(t, err, expected, raw)
+		}
+	})
+}
+
+func TestNewMeta(t *testing.T) {
+	t.Run("with next page", func(t *testing.T) {
+		meta := NewMeta(Params{Limit: 10, Offset: 10}, 25)
+
+		assert.Equal(t, int64(25), meta.Total)
+		offset, err := decodeCursor(meta.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20), offset)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		meta := NewMeta(Params{Limit: 10, Offset: 20}, 25)
+
+		assert.Empty(t, meta.NextCursor)
+	})
+}
+
+func TestBuild(t *testing.T) {
+	params := Params{
+		Limit:   10,
+		Offset:  30,
+		Sort:    []Sort{{Field: "description", Desc: true}},
+		Filters: []Filter{{Field: "seller_id", Value: "3"}, {Field: "product_type_id", Value: "1"}},
+	}
+
+	t.Run("select", func(t *testing.T) {
+		query, args := Build("SELECT * FROM products;", params, map[string]string{"seller_id": "p.seller_id"})
+
+		assert.Equal(t, "SELECT * FROM products WHERE p.seller_id = ? AND product_type_id = ? ORDER BY description DESC LIMIT ? OFFSET ?", query)
+		assert.Equal(t, []interface{}{"3", "1", int64(10), int64(30)}, args)
+	})
+
+	t.Run("default order", func(t *testing.T) {
+		query, args := Build("SELECT * FROM products", Params{Limit: 5}, nil)
+
+		assert.Equal(t, "SELECT * FROM products ORDER BY id ASC LIMIT ? OFFSET ?", query)
+		assert.Equal(t, []interface{}{int64(5), int64(0)}, args)
+	})
+
+	t.Run("range", func(t *testing.T) {
+		query, args := Build("SELECT * FROM product_batches", Params{
+			Limit:   5,
+			Filters: []Filter{{Field: "due_date", Op: ">=", Value: "2022-01-01"}},
+		}, nil)
+
+		assert.Equal(t, "SELECT * FROM product_batches WHERE due_date >= ? ORDER BY id ASC LIMIT ? OFFSET ?", query)
+		assert.Equal(t, []interface{}{"2022-01-01", int64(5), int64(0)}, args)
+	})
+
+	t.Run("count", func(t *testing.T) {
+		query, args := BuildCount("SELECT * FROM products", params, nil)
+
+		assert.Equal(t, "SELECT COUNT(*) FROM (SELECT * FROM products WHERE seller_id = ? AND product_type_id = ?) AS listing", query)
+		assert.Equal(t, []interface{}{"3", "1"}, args)
+	})
+}
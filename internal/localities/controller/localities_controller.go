@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/localities/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
 )
 
 // Controller receives a service
@@ -35,17 +36,18 @@ type requestCreateLocality struct {
 // @Description Add a new Locality to the list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param Locality body requestCreateLocality false "locality to create"
 // @Success 201 {object} schemas.JSONSuccessResult{data=domain.GetLocality}
-// @Failure 409 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 500 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /localities [post]
 func (c *LocalityController) CreateLocality() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req requestCreateLocality
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid inputs"})
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 		localId, err := c.service.CreateLocality(
@@ -56,15 +58,13 @@ func (c *LocalityController) CreateLocality() gin.HandlerFunc {
 			},
 		)
 		if err != nil {
-			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 
-		locality, err := c.service.GetLocalityByID(ctx, localId)
+		locality, err := c.service.GetLocalityByID(ctx.Request.Context(), localId)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"message": err.Error(),
-			})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -77,9 +77,10 @@ func (c *LocalityController) CreateLocality() gin.HandlerFunc {
 // @Description get AllQtyOfSellers
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Success 200 {object} schemas.JSONSuccessResult{data=domain.QtyOfSellers}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 500 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /localities/reportSellers [get]
 func (c LocalityController) GetAllQtyOfSellers() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -87,22 +88,18 @@ func (c LocalityController) GetAllQtyOfSellers() gin.HandlerFunc {
 		strId := ctx.Query("id")
 		intId, _ := strconv.ParseInt(strId, 10, 64)
 		if intId == 0 {
-			listsOfSellers, err := c.service.GetAllQtyOfSellers(ctx)
+			listsOfSellers, err := c.service.GetAllQtyOfSellers(ctx.Request.Context())
 			if err != nil {
-				ctx.JSON(http.StatusInternalServerError, gin.H{
-					"message": err.Error(),
-				})
+				problem.Abort(ctx, http.StatusInternalServerError, err)
 				return
 			}
 			ctx.JSON(http.StatusOK, listsOfSellers)
 			return
 		}
 
-		sellersByLocality, err := c.service.GetQtyOfSellersByLocalityId(ctx, intId)
+		sellersByLocality, err := c.service.GetQtyOfSellersByLocalityId(ctx.Request.Context(), intId)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{
-				"message": err.Error(),
-			})
+			problem.AbortError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, gin.H{"data": sellersByLocality})
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/localities/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/localities/domain/mocks"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
@@ -89,7 +90,12 @@ func TestCreateLocality(t *testing.T) {
 		localityServiceMock.On("CreateLocality",
 			mock.Anything,
 			mock.Anything,
-		).Return(int64(0), domain.ErrIDNotFound).Maybe()
+		).Return(int64(0), &apperrors.Error{
+			Kind:    apperrors.ErrConflict,
+			Entity:  "localities",
+			Field:   "locality_name",
+			Message: `locality_name "Centro" is already in use`,
+		}).Maybe()
 
 		payload, err := json.Marshal(mockLocality)
 		assert.NoError(t, err)
@@ -110,6 +116,46 @@ func TestCreateLocality(t *testing.T) {
 		localityServiceMock.AssertExpectations(t)
 	})
 
+	t.Run("unknown province", func(t *testing.T) {
+		localityServiceMock := mocks.NewLocalityService(t)
+		mockLocality := utils.CreateRandomLocality()
+
+		localityServiceMock.On("CreateLocality",
+			mock.Anything,
+			mock.Anything,
+		).Return(int64(0), &apperrors.Error{
+			Kind:    apperrors.ErrForeignKey,
+			Entity:  "provinces",
+			Field:   "province_id",
+			Message: "province_id: no provinces with that id",
+		}).Once()
+
+		payload, err := json.Marshal(mockLocality)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/localities", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		localityController := LocalityController{service: localityServiceMock}
+
+		engine.POST("/api/v1/localities", localityController.CreateLocality())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.JSONEq(t, `{
+			"type": "/problems/foreign-key",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "province_id: no provinces with that id",
+			"errors": [{"field": "province_id", "rule": "exists", "param": "provinces", "message": "province_id: no provinces with that id"}]
+		}`, rec.Body.String())
+
+		localityServiceMock.AssertExpectations(t)
+	})
+
 	t.Run("internal error", func(t *testing.T) {
 		localityServiceMock := mocks.NewLocalityService(t)
 		mockLocality := utils.CreateRandomLocality()
@@ -229,7 +275,7 @@ func TestGetAllQtyOfSellers(t *testing.T) {
 		localityServiceMock.On("GetQtyOfSellersByLocalityId",
 			mock.Anything,
 			mock.AnythingOfType("int64"),
-		).Return(nil, errors.New("id not found")).Once()
+		).Return(nil, domain.ErrIDNotFound).Once()
 
 		PATH := fmt.Sprintf("/api/v1/localities/reportSellers?id=%v", utils.RandomInt64())
 		req := httptest.NewRequest(http.MethodGet, PATH, nil)
@@ -247,4 +293,29 @@ func TestGetAllQtyOfSellers(t *testing.T) {
 
 		localityServiceMock.AssertExpectations(t)
 	})
+
+	t.Run("internal error - GetQtyOfSellersByLocalityId", func(t *testing.T) {
+		localityServiceMock := mocks.NewLocalityService(t)
+
+		localityServiceMock.On("GetQtyOfSellersByLocalityId",
+			mock.Anything,
+			mock.AnythingOfType("int64"),
+		).Return(nil, errors.New("connection refused")).Once()
+
+		PATH := fmt.Sprintf("/api/v1/localities/reportSellers?id=%v", utils.RandomInt64())
+		req := httptest.NewRequest(http.MethodGet, PATH, nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		localityController := LocalityController{service: localityServiceMock}
+
+		engine.GET("/api/v1/localities/reportSellers", localityController.GetAllQtyOfSellers())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		localityServiceMock.AssertExpectations(t)
+	})
 }
@@ -1,7 +1,7 @@
 package domain
 
-import "errors"
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
 
 var (
-	ErrIDNotFound = errors.New("locality id not found")
+	ErrIDNotFound = apperrors.NotFound("locality id not found")
 )
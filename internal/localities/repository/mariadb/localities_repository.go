@@ -5,6 +5,9 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	auditlog "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/localities/domain"
 )
 
@@ -21,19 +24,32 @@ func (r *mariadbRepository) CreateLocality(ctx context.Context, local *domain.Lo
 		LocalityName: local.LocalityName,
 		ProvinceID:   local.ProvinceID,
 	}
-	result, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
 		ctx,
 		sqlCreateLocality,
 		&newLocal.LocalityName,
 		&newLocal.ProvinceID,
 	)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromMySQL(err)
 	}
 	insertedId, err := result.LastInsertId()
 	if err != nil {
 		return 0, err
 	}
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "localities", insertedId, nil); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
 	return insertedId, nil
 }
 
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -45,11 +47,14 @@ func TestCreateLocality(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsertLocality).
 			WithArgs(
 				mockLocality.LocalityName,
 				mockLocality.ProvinceID,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "localities", 1)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 		localityId, err := repo.CreateLocality(context.Background(), &mockLocality)
@@ -63,6 +68,7 @@ func TestCreateLocality(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsertLocality).
 			WithArgs(0, 0).
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -0,0 +1,83 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const Usage = `usage: migrate <command>
+
+commands:
+  up            apply every pending migration
+  down [steps]  revert the last steps migrations, 1 by default
+  to <version>  apply or revert migrations until version, 0 reverting them all
+  status        list the migrations and whether they are applied`
+
+var ErrUsage = errors.New(Usage)
+
+// Run runs the migrate command of args on m, writing what it did to out.
+func Run(ctx context.Context, m *Migrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+
+	switch command, rest := args[0], args[1:]; {
+	case command == "up" && len(rest) == 0:
+		up, err := m.Up(ctx)
+		report(out, "applied", up)
+		return err
+
+	case command == "down" && len(rest) <= 1:
+		steps := 1
+		if len(rest) == 1 {
+			parsed, err := strconv.Atoi(rest[0])
+			if err != nil || parsed < 1 {
+				return fmt.Errorf("steps must be a positive number: %s", rest[0])
+			}
+			steps = parsed
+		}
+
+		down, err := m.Down(ctx, steps)
+		report(out, "reverted", down)
+		return err
+
+	case command == "to" && len(rest) == 1:
+		version, err := strconv.ParseInt(rest[0], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("version must be a number: %s", rest[0])
+		}
+
+		up, down, err := m.To(ctx, version)
+		report(out, "reverted", down)
+		report(out, "applied", up)
+		return err
+
+	case command == "status" && len(rest) == 0:
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			state := "pending"
+			if status.Dirty {
+				state = "dirty"
+			} else if status.Applied {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%04d_%s\t%s\n", status.Version, status.Name, state)
+		}
+		return nil
+	}
+
+	return ErrUsage
+}
+
+func report(out io.Writer, action string, migrations []Migration) {
+	for _, migration := range migrations {
+		fmt.Fprintf(out, "%s %04d_%s\n", action, migration.Version, migration.Name)
+	}
+}
@@ -0,0 +1,70 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/db/migrations"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("sorted by version", func(t *testing.T) {
+		loaded, err := Load(fstest.MapFS{
+			"0002_add_column.up.sql":     {Data: []byte("ALTER TABLE a ADD b INT;")},
+			"0002_add_column.down.sql":   {Data: []byte("ALTER TABLE a DROP b;")},
+			"0001_create_table.up.sql":   {Data: []byte("CREATE TABLE a (id INT);")},
+			"0001_create_table.down.sql": {Data: []byte("DROP TABLE a;")},
+			"migrations.go":              {Data: []byte("package migrations")},
+			"README.md":                  {Data: []byte("# migrations")},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Migration{
+			{Version: 1, Name: "create_table", Up: "CREATE TABLE a (id INT);", Down: "DROP TABLE a;"},
+			{Version: 2, Name: "add_column", Up: "ALTER TABLE a ADD b INT;", Down: "ALTER TABLE a DROP b;"},
+		}, loaded)
+	})
+
+	t.Run("missing down file", func(t *testing.T) {
+		_, err := Load(fstest.MapFS{"0001_create_table.up.sql": {Data: []byte("CREATE TABLE a (id INT);")}})
+
+		assert.EqualError(t, err, "migration 1_create_table needs both an up and a down file")
+	})
+
+	t.Run("conflicting names", func(t *testing.T) {
+		_, err := Load(fstest.MapFS{
+			"0001_create_table.up.sql": {Data: []byte("CREATE TABLE a (id INT);")},
+			"0001_create_tbl.down.sql": {Data: []byte("DROP TABLE a;")},
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("embedded migrations", func(t *testing.T) {
+		loaded, err := Load(migrations.Files)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "initial_schema", loaded[0].Name)
+		assert.NotEmpty(t, split(loaded[0].Up))
+		assert.NotEmpty(t, split(loaded[0].Down))
+		assert.Equal(t, "keep_stock_movements_of_deleted_batches", loaded[1].Name)
+		assert.Len(t, split(loaded[1].Up), 1)
+		assert.Len(t, split(loaded[1].Down), 2)
+	})
+}
+
+func TestSplit(t *testing.T) {
+	statements := split(`
+		-- the table; with a comment
+		CREATE TABLE a (name VARCHAR(10) DEFAULT 'a;b');
+		INSERT INTO a (name) VALUES ("c;d"); -- trailing
+		SELECT 1
+	`)
+
+	assert.Equal(t, []string{
+		"CREATE TABLE a (name VARCHAR(10) DEFAULT 'a;b')",
+		`INSERT INTO a (name) VALUES ("c;d")`,
+		"SELECT 1",
+	}, statements)
+}
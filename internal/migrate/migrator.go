@@ -0,0 +1,280 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+)
+
+const (
+	// lockName serializes the instances migrating the same database.
+	lockName = "schema_migrations"
+	// lockTimeout is how many seconds to wait for another instance.
+	lockTimeout = 60
+)
+
+var ErrLocked = errors.New("another instance is migrating the database")
+
+// Status tells whether a migration is applied. A dirty migration failed
+// halfway, and must be fixed by hand and removed from schema_migrations
+// before migrating again, since MariaDB commits each DDL statement.
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	Dirty     bool
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New returns a Migrator for the migrations of fsys.
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Status lists the migrations by version, with those applied to the
+// database but unknown to the Migrator.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status, ok := applied[migration.Version]
+			if !ok {
+				status = Status{Version: migration.Version, Name: migration.Name}
+			}
+			statuses = append(statuses, status)
+			delete(applied, migration.Version)
+		}
+
+		for _, status := range applied {
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, err
+}
+
+// Up applies every pending migration and returns them.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	if len(m.migrations) == 0 {
+		return nil, nil
+	}
+
+	done, _, err := m.migrate(ctx, m.migrations[len(m.migrations)-1].Version)
+	return done, err
+}
+
+// Down reverts the last steps applied migrations and returns them.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if err := checkDirty(applied); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			if _, ok := applied[m.migrations[i].Version]; !ok {
+				continue
+			}
+
+			if err := m.revert(ctx, conn, m.migrations[i]); err != nil {
+				return err
+			}
+			done = append(done, m.migrations[i])
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// To applies or reverts migrations until version is the last one applied,
+// 0 reverting them all. It returns the migrations applied and reverted.
+func (m *Migrator) To(ctx context.Context, version int64) ([]Migration, []Migration, error) {
+	if version != 0 && !m.known(version) {
+		return nil, nil, fmt.Errorf("there is no migration %d", version)
+	}
+
+	return m.migrate(ctx, version)
+}
+
+func (m *Migrator) migrate(ctx context.Context, version int64) ([]Migration, []Migration, error) {
+	var up, down []Migration
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if err := checkDirty(applied); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok || migration.Version <= version {
+				continue
+			}
+
+			if err := m.revert(ctx, conn, migration); err != nil {
+				return err
+			}
+			down = append(down, migration)
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok || migration.Version > version {
+				continue
+			}
+
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			up = append(up, migration)
+		}
+
+		return nil
+	})
+
+	return up, down, err
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	if _, err := conn.ExecContext(ctx, sqlInsert, migration.Version, migration.Name); err != nil {
+		return err
+	}
+
+	if err := run(ctx, conn, migration, migration.Up); err != nil {
+		return err
+	}
+
+	_, err := conn.ExecContext(ctx, sqlSetDirty, false, migration.Version)
+	return err
+}
+
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	if _, err := conn.ExecContext(ctx, sqlSetDirty, true, migration.Version); err != nil {
+		return err
+	}
+
+	if err := run(ctx, conn, migration, migration.Down); err != nil {
+		return err
+	}
+
+	_, err := conn.ExecContext(ctx, sqlDelete, migration.Version)
+	return err
+}
+
+func run(ctx context.Context, conn *sql.Conn, migration Migration, script string) error {
+	for _, statement := range split(script) {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// locked runs fn on a connection holding the migration lock, once
+// schema_migrations exists.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, sqlLock, lockName, lockTimeout).Scan(&locked); err != nil {
+		return err
+	}
+
+	if locked.Int64 != 1 {
+		return ErrLocked
+	}
+
+	defer conn.ExecContext(context.Background(), sqlUnlock, lockName)
+
+	if _, err := conn.ExecContext(ctx, sqlCreateTable); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]Status, error) {
+	rows, err := conn.QueryContext(ctx, sqlGetAll)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	applied := map[int64]Status{}
+	for rows.Next() {
+		status := Status{Applied: true}
+		var appliedAt time.Time
+
+		if err := rows.Scan(&status.Version, &status.Name, &status.Dirty, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		status.AppliedAt = &appliedAt
+		applied[status.Version] = status
+	}
+
+	return applied, rows.Err()
+}
+
+func (m *Migrator) known(version int64) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+func checkDirty(applied map[int64]Status) error {
+	for _, status := range applied {
+		if status.Dirty {
+			return fmt.Errorf(
+				"migration %d_%s is dirty, fix the database and delete it from schema_migrations",
+				status.Version, status.Name,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	queryCreateTable = regexp.QuoteMeta(sqlCreateTable)
+	queryLock        = regexp.QuoteMeta(sqlLock)
+	queryUnlock      = regexp.QuoteMeta(sqlUnlock)
+	queryGetAll      = regexp.QuoteMeta(sqlGetAll)
+	queryInsert      = regexp.QuoteMeta(sqlInsert)
+	querySetDirty    = regexp.QuoteMeta(sqlSetDirty)
+	queryDelete      = regexp.QuoteMeta(sqlDelete)
+)
+
+var (
+	migrationColumns = []string{"version", "name", "dirty", "applied_at"}
+	appliedAt        = time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	files            = fstest.MapFS{
+		"0001_create_a.up.sql":   {Data: []byte("CREATE TABLE a (id INT);")},
+		"0001_create_a.down.sql": {Data: []byte("DROP TABLE a;")},
+		"0002_create_b.up.sql":   {Data: []byte("CREATE TABLE b (id INT);\nCREATE INDEX b_id ON b (id);")},
+		"0002_create_b.down.sql": {Data: []byte("DROP TABLE b;")},
+	}
+)
+
+func newMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := New(db, files)
+	assert.NoError(t, err)
+
+	return m, mock
+}
+
+func expectLocked(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+	mock.ExpectQuery(queryLock).WithArgs(lockName, lockTimeout).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(1))
+	mock.ExpectExec(queryCreateTable).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(queryGetAll).WillReturnRows(rows)
+}
+
+func expectUnlocked(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(queryUnlock).WithArgs(lockName).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestUp(t *testing.T) {
+	t.Run("applies the pending migrations", func(t *testing.T) {
+		m, mock := newMigrator(t)
+
+		expectLocked(mock, sqlmock.NewRows(migrationColumns).AddRow(1, "create_a", false, appliedAt))
+		mock.ExpectExec(queryInsert).WithArgs(2, "create_b").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE b (id INT)")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("CREATE INDEX b_id ON b (id)")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(querySetDirty).WithArgs(false, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectUnlocked(mock)
+
+		applied, err := m.Up(context.Background())
+
+		assert.NoError(t, err)
+		assert.Len(t, applied, 1)
+		assert.Equal(t, int64(2), applied[0].Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("leaves a failed migration dirty", func(t *testing.T) {
+		m, mock := newMigrator(t)
+
+		expectLocked(mock, sqlmock.NewRows(migrationColumns).AddRow(1, "create_a", false, appliedAt))
+		mock.ExpectExec(queryInsert).WithArgs(2, "create_b").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE b (id INT)")).WillReturnError(errors.New("table exists"))
+		expectUnlocked(mock)
+
+		applied, err := m.Up(context.Background())
+
+		assert.EqualError(t, err, "migration 2_create_b: table exists")
+		assert.Empty(t, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("refuses a dirty database", func(t *testing.T) {
+		m, mock := newMigrator(t)
+
+		expectLocked(mock, sqlmock.NewRows(migrationColumns).AddRow(1, "create_a", true, appliedAt))
+		expectUnlocked(mock)
+
+		_, err := m.Up(context.Background())
+
+		assert.ErrorContains(t, err, "migration 1_create_a is dirty")
+	})
+
+	t.Run("locked by another instance", func(t *testing.T) {
+		m, mock := newMigrator(t)
+
+		mock.ExpectQuery(queryLock).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(0))
+
+		_, err := m.Up(context.Background())
+
+		assert.ErrorIs(t, err, ErrLocked)
+	})
+}
+
+func TestDown(t *testing.T) {
+	m, mock := newMigrator(t)
+
+	expectLocked(mock, sqlmock.NewRows(migrationColumns).
+		AddRow(1, "create_a", false, appliedAt).
+		AddRow(2, "create_b", false, appliedAt))
+	mock.ExpectExec(querySetDirty).WithArgs(true, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE b")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(queryDelete).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectUnlocked(mock)
+
+	reverted, err := m.Down(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, reverted, 1)
+	assert.Equal(t, "create_b", reverted[0].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTo(t *testing.T) {
+	t.Run("reverts the later migrations", func(t *testing.T) {
+		m, mock := newMigrator(t)
+
+		expectLocked(mock, sqlmock.NewRows(migrationColumns).
+			AddRow(1, "create_a", false, appliedAt).
+			AddRow(2, "create_b", false, appliedAt))
+		mock.ExpectExec(querySetDirty).WithArgs(true, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("DROP TABLE b")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(queryDelete).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectUnlocked(mock)
+
+		applied, reverted, err := m.To(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Empty(t, applied)
+		assert.Len(t, reverted, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		m, _ := newMigrator(t)
+
+		_, _, err := m.To(context.Background(), 7)
+
+		assert.EqualError(t, err, "there is no migration 7")
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("status", func(t *testing.T) {
+		m, mock := newMigrator(t)
+
+		expectLocked(mock, sqlmock.NewRows(migrationColumns).AddRow(1, "create_a", false, appliedAt))
+		expectUnlocked(mock)
+
+		var out bytes.Buffer
+		err := Run(context.Background(), m, []string{"status"}, &out)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0001_create_a\tapplied 2022-08-01 12:00:00\n0002_create_b\tpending\n", out.String())
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		m, _ := newMigrator(t)
+
+		assert.ErrorIs(t, Run(context.Background(), m, []string{"sideways"}, &bytes.Buffer{}), ErrUsage)
+		assert.ErrorIs(t, Run(context.Background(), m, nil, &bytes.Buffer{}), ErrUsage)
+		assert.Error(t, Run(context.Background(), m, []string{"down", "zero"}, &bytes.Buffer{}))
+	})
+}
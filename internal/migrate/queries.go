@@ -0,0 +1,16 @@
+package migrate
+
+const (
+	sqlCreateTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		dirty BOOLEAN NOT NULL DEFAULT FALSE,
+		applied_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+	);`
+	sqlLock     = "SELECT GET_LOCK(?, ?);"
+	sqlUnlock   = "SELECT RELEASE_LOCK(?);"
+	sqlGetAll   = "SELECT version, name, dirty, applied_at FROM schema_migrations ORDER BY version;"
+	sqlInsert   = "INSERT INTO schema_migrations (version, name, dirty) VALUES (?, ?, TRUE);"
+	sqlSetDirty = "UPDATE schema_migrations SET dirty = ? WHERE version = ?;"
+	sqlDelete   = "DELETE FROM schema_migrations WHERE version = ?;"
+)
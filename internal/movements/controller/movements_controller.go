@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+)
+
+type MovementController struct {
+	service domain.MovementService
+}
+
+func NewMovementController(service domain.MovementService) (*MovementController, error) {
+	if service == nil {
+		return nil, errors.New("invalid service")
+	}
+
+	return &MovementController{
+		service: service,
+	}, nil
+}
+
+// @Summary Product batch movements
+// @Tags Products
+// @Description get the stock movement ledger of a product batch and whether it matches the batch quantity
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product batch ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.BatchHistory}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /productBatches/{id}/movements [get]
+func (c MovementController) GetBatchHistory() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestProductBatchId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		history, err := c.service.GetBatchHistory(ctx.Request.Context(), req.Id)
+		if err != nil {
+			if errors.Is(err, domain.ErrProductBatchNotFound) {
+				problem.Abort(ctx, http.StatusNotFound, err)
+				return
+			}
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": history})
+	}
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetBatchHistory(t *testing.T) {
+	serve := func(service domain.MovementService, url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		_, engine := gin.CreateTestContext(rec)
+
+		controller, err := NewMovementController(service)
+		assert.NoError(t, err)
+
+		engine.GET("/api/v1/productBatches/:id/movements", controller.GetBatchHistory())
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		serviceMock := mocks.NewMovementService(t)
+		serviceMock.On("GetBatchHistory", mock.Anything, int64(1)).
+			Return(&domain.BatchHistory{ProductBatchId: 1, Consistent: true, Movements: []domain.Movement{}}, nil).Once()
+
+		rec := serve(serviceMock, "/api/v1/productBatches/1/movements")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"consistent":true`)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		rec := serve(mocks.NewMovementService(t), "/api/v1/productBatches/abc/movements")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("batch not found", func(t *testing.T) {
+		serviceMock := mocks.NewMovementService(t)
+		serviceMock.On("GetBatchHistory", mock.Anything, int64(1)).Return(nil, domain.ErrProductBatchNotFound).Once()
+
+		rec := serve(serviceMock, "/api/v1/productBatches/1/movements")
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		serviceMock := mocks.NewMovementService(t)
+		serviceMock.On("GetBatchHistory", mock.Anything, int64(1)).Return(nil, errors.New("connection lost")).Once()
+
+		rec := serve(serviceMock, "/api/v1/productBatches/1/movements")
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestNewMovementController(t *testing.T) {
+	_, err := NewMovementController(nil)
+
+	assert.Error(t, err)
+}
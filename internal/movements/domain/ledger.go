@@ -0,0 +1,20 @@
+package domain
+
+import "fmt"
+
+// Replay adds up the movements in the order they were recorded and returns
+// the balance they end in. It fails on the first entry whose balance does
+// not follow from the previous one.
+func Replay(movements []Movement) (int64, error) {
+	var balance int64
+	for _, movement := range movements {
+		if balance+movement.Delta != movement.Balance {
+			return balance, fmt.Errorf(
+				"%w: movement %d moves %d from %d to %d",
+				ErrLedgerMismatch, movement.Id, movement.Delta, balance, movement.Balance,
+			)
+		}
+		balance = movement.Balance
+	}
+	return balance, nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplay(t *testing.T) {
+	t.Run("empty ledger", func(t *testing.T) {
+		balance, err := Replay(nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), balance)
+	})
+
+	t.Run("balances follow each other", func(t *testing.T) {
+		balance, err := Replay([]Movement{
+			{Id: 1, Type: TypeInboundReceipt, Delta: 10, Balance: 10},
+			{Id: 2, Type: TypePick, Delta: -4, Balance: 6},
+			{Id: 3, Type: TypeReturn, Delta: 1, Balance: 7},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), balance)
+	})
+
+	t.Run("broken chain", func(t *testing.T) {
+		balance, err := Replay([]Movement{
+			{Id: 1, Type: TypeInboundReceipt, Delta: 10, Balance: 10},
+			{Id: 2, Type: TypePick, Delta: -4, Balance: 5},
+		})
+
+		assert.ErrorIs(t, err, ErrLedgerMismatch)
+		assert.Equal(t, int64(10), balance)
+	})
+}
@@ -0,0 +1,76 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MovementRepository is an autogenerated mock type for the MovementRepository type
+type MovementRepository struct {
+	mock.Mock
+}
+
+// GetBatchQuantity provides a mock function with given fields: ctx, productBatchId
+func (_m *MovementRepository) GetBatchQuantity(ctx context.Context, productBatchId int64) (*int64, error) {
+	ret := _m.Called(ctx, productBatchId)
+
+	var r0 *int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *int64); ok {
+		r0 = rf(ctx, productBatchId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, productBatchId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByBatch provides a mock function with given fields: ctx, productBatchId
+func (_m *MovementRepository) GetByBatch(ctx context.Context, productBatchId int64) (*[]domain.Movement, error) {
+	ret := _m.Called(ctx, productBatchId)
+
+	var r0 *[]domain.Movement
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *[]domain.Movement); ok {
+		r0 = rf(ctx, productBatchId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Movement)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, productBatchId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMovementRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMovementRepository creates a new instance of MovementRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMovementRepository(t mockConstructorTestingTNewMovementRepository) *MovementRepository {
+	mock := &MovementRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
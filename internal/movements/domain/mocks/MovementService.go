@@ -0,0 +1,53 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MovementService is an autogenerated mock type for the MovementService type
+type MovementService struct {
+	mock.Mock
+}
+
+// GetBatchHistory provides a mock function with given fields: ctx, productBatchId
+func (_m *MovementService) GetBatchHistory(ctx context.Context, productBatchId int64) (*domain.BatchHistory, error) {
+	ret := _m.Called(ctx, productBatchId)
+
+	var r0 *domain.BatchHistory
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.BatchHistory); ok {
+		r0 = rf(ctx, productBatchId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BatchHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, productBatchId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMovementService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMovementService creates a new instance of MovementService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMovementService(t mockConstructorTestingTNewMovementService) *MovementService {
+	mock := &MovementService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
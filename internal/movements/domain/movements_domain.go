@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Movement types of the stock ledger.
+const (
+	TypeInboundReceipt = "inbound_receipt"
+	TypePick           = "pick"
+	TypeAdjustment     = "adjustment"
+	TypeTransfer       = "transfer"
+	TypeSpoilage       = "spoilage"
+	TypeReturn         = "return"
+	// TypeWriteOff closes the ledger of a deleted batch, taking its balance
+	// to 0.
+	TypeWriteOff = "write_off"
+)
+
+// Movement is an entry of the append-only ledger of a product batch. Delta
+// is the change in the batch quantity and Balance the quantity left after it.
+type Movement struct {
+	Id              int64     `json:"id"`
+	ProductBatchId  int64     `json:"product_batch_id"`
+	Type            string    `json:"type"`
+	Delta           int64     `json:"delta"`
+	Balance         int64     `json:"balance"`
+	Actor           string    `json:"actor"`
+	InboundOrderId  *int64    `json:"inbound_order_id"`
+	PurchaseOrderId *int64    `json:"purchase_order_id"`
+	TransferId      *int64    `json:"transfer_id"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// BatchHistory is the ledger of a batch next to its stored quantity.
+// Consistent is false when the ledger does not add up or ends in another
+// quantity than the batch holds.
+type BatchHistory struct {
+	ProductBatchId  int64      `json:"product_batch_id"`
+	CurrentQuantity int64      `json:"current_quantity"`
+	LedgerBalance   int64      `json:"ledger_balance"`
+	Consistent      bool       `json:"consistent"`
+	Movements       []Movement `json:"movements"`
+}
+
+type RequestProductBatchId struct {
+	Id int64 `uri:"id" binding:"required,min=1"`
+}
+
+type MovementRepository interface {
+	// GetBatchQuantity returns nil when the batch does not exist.
+	GetBatchQuantity(ctx context.Context, productBatchId int64) (*int64, error)
+	GetByBatch(ctx context.Context, productBatchId int64) (*[]Movement, error)
+}
+
+type MovementService interface {
+	GetBatchHistory(ctx context.Context, productBatchId int64) (*BatchHistory, error)
+}
@@ -0,0 +1,8 @@
+package domain
+
+import "errors"
+
+var (
+	ErrProductBatchNotFound = errors.New("product batch not found")
+	ErrLedgerMismatch       = errors.New("stock movement does not match the batch ledger")
+)
@@ -0,0 +1,113 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/actor"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+)
+
+type mariadbRepository struct {
+	db *sql.DB
+}
+
+func NewMariaDBRepository(db *sql.DB) domain.MovementRepository {
+	return mariadbRepository{db: db}
+}
+
+func (m mariadbRepository) GetBatchQuantity(ctx context.Context, productBatchId int64) (*int64, error) {
+	var quantity int64
+	err := m.db.QueryRowContext(ctx, sqlGetBatchQuantity, productBatchId).Scan(&quantity)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &quantity, nil
+}
+
+func (m mariadbRepository) GetByBatch(ctx context.Context, productBatchId int64) (*[]domain.Movement, error) {
+	movements := []domain.Movement{}
+
+	rows, err := m.db.QueryContext(ctx, sqlGetByBatch, productBatchId)
+	if err != nil {
+		return &movements, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var movement domain.Movement
+
+		if err := rows.Scan(
+			&movement.Id,
+			&movement.ProductBatchId,
+			&movement.Type,
+			&movement.Delta,
+			&movement.Balance,
+			&movement.Actor,
+			&movement.InboundOrderId,
+			&movement.PurchaseOrderId,
+			&movement.TransferId,
+			&movement.CreatedAt,
+		); err != nil {
+			return &movements, err
+		}
+
+		movements = append(movements, movement)
+	}
+
+	return &movements, rows.Err()
+}
+
+// Record appends movement to the ledger of its batch inside tx, which must
+// hold the lock of the batch row. The last balance of the ledger plus the
+// delta has to give the new balance, so a quantity changed without going
+// through the ledger makes the transaction fail. The actor is taken from ctx
+// when the movement does not name one.
+func Record(ctx context.Context, tx *sql.Tx, movement domain.Movement) error {
+	var balance int64
+	err := tx.QueryRowContext(ctx, sqlGetLastBalance, movement.ProductBatchId).Scan(&balance)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if balance+movement.Delta != movement.Balance {
+		return fmt.Errorf(
+			"%w: batch %d ledger is at %d, moving %d does not give %d",
+			domain.ErrLedgerMismatch, movement.ProductBatchId, balance, movement.Delta, movement.Balance,
+		)
+	}
+
+	if movement.Actor == "" {
+		movement.Actor = actor.FromContext(ctx)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		sqlInsert,
+		movement.ProductBatchId,
+		movement.Type,
+		movement.Delta,
+		movement.Balance,
+		movement.Actor,
+		movement.InboundOrderId,
+		movement.PurchaseOrderId,
+		movement.TransferId,
+	)
+	return err
+}
+
+// LinkReceipt points the inbound receipt of a batch to the inbound order that
+// brought it, when it is not linked yet. Quantities are never changed.
+func LinkReceipt(ctx context.Context, tx *sql.Tx, productBatchId, inboundOrderId int64) error {
+	_, err := tx.ExecContext(ctx, sqlLinkReceipt, inboundOrderId, productBatchId)
+	return err
+}
@@ -0,0 +1,188 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/actor"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	queryGetBatchQuantity = regexp.QuoteMeta(sqlGetBatchQuantity)
+	queryGetByBatch       = regexp.QuoteMeta(sqlGetByBatch)
+	queryGetLastBalance   = regexp.QuoteMeta(sqlGetLastBalance)
+	queryInsert           = regexp.QuoteMeta(sqlInsert)
+	queryLinkReceipt      = regexp.QuoteMeta(sqlLinkReceipt)
+)
+
+var rowsMovementStruct = []string{
+	"id", "product_batch_id", "movement_type", "delta", "balance", "actor", "inbound_order_id", "purchase_order_id", "transfer_id", "created_at",
+}
+
+func TestGetBatchQuantity(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetBatchQuantity).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"current_quantity"}).AddRow(7))
+
+		quantity, err := NewMariaDBRepository(db).GetBatchQuantity(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), *quantity)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetBatchQuantity).WithArgs(1).WillReturnError(sql.ErrNoRows)
+
+		quantity, err := NewMariaDBRepository(db).GetBatchQuantity(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, quantity)
+	})
+}
+
+func TestGetByBatch(t *testing.T) {
+	createdAt := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetByBatch).WithArgs(1).WillReturnRows(sqlmock.NewRows(rowsMovementStruct).
+			AddRow(1, 1, domain.TypeInboundReceipt, 10, 10, "ana", 3, nil, nil, createdAt).
+			AddRow(2, 1, domain.TypePick, -4, 6, actor.System, nil, 8, nil, createdAt))
+
+		movements, err := NewMariaDBRepository(db).GetByBatch(context.Background(), 1)
+
+		inboundOrderId, purchaseOrderId := int64(3), int64(8)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Movement{
+			{Id: 1, ProductBatchId: 1, Type: domain.TypeInboundReceipt, Delta: 10, Balance: 10, Actor: "ana", InboundOrderId: &inboundOrderId, CreatedAt: createdAt},
+			{Id: 2, ProductBatchId: 1, Type: domain.TypePick, Delta: -4, Balance: 6, Actor: actor.System, PurchaseOrderId: &purchaseOrderId, CreatedAt: createdAt},
+		}, *movements)
+	})
+
+	t.Run("fail to select movements", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetByBatch).WillReturnError(sql.ErrConnDone)
+
+		_, err = NewMariaDBRepository(db).GetByBatch(context.Background(), 1)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRecord(t *testing.T) {
+	record := func(db *sql.DB, ctx context.Context, movement domain.Movement) error {
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		if err := Record(ctx, tx, movement); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	t.Run("opens the ledger", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryGetLastBalance).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"balance"}))
+		mock.ExpectExec(queryInsert).
+			WithArgs(1, domain.TypeInboundReceipt, 10, 10, "ana", nil, nil, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err = record(db, actor.NewContext(context.Background(), "ana"), domain.Movement{
+			ProductBatchId: 1,
+			Type:           domain.TypeInboundReceipt,
+			Delta:          10,
+			Balance:        10,
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("follows the last balance", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		purchaseOrderId := int64(8)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryGetLastBalance).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(10))
+		mock.ExpectExec(queryInsert).
+			WithArgs(1, domain.TypePick, -4, 6, actor.System, nil, 8, nil).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		err = record(db, context.Background(), domain.Movement{
+			ProductBatchId:  1,
+			Type:            domain.TypePick,
+			Delta:           -4,
+			Balance:         6,
+			PurchaseOrderId: &purchaseOrderId,
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects a balance the ledger does not reach", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryGetLastBalance).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(9))
+		mock.ExpectRollback()
+
+		err = record(db, context.Background(), domain.Movement{
+			ProductBatchId: 1,
+			Type:           domain.TypePick,
+			Delta:          -4,
+			Balance:        6,
+		})
+
+		assert.ErrorIs(t, err, domain.ErrLedgerMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLinkReceipt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(queryLinkReceipt).WithArgs(3, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	assert.NoError(t, LinkReceipt(context.Background(), tx, 1, 3))
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,12 @@
+package mariadb
+
+const (
+	sqlGetBatchQuantity = "SELECT COALESCE(current_quantity, 0) FROM product_batches WHERE id = ?;"
+	sqlGetByBatch       = `SELECT id, product_batch_id, movement_type, delta, balance, actor, inbound_order_id, purchase_order_id, transfer_id, created_at
+		FROM stock_movements
+		WHERE product_batch_id = ?
+		ORDER BY id;`
+	sqlGetLastBalance = "SELECT balance FROM stock_movements WHERE product_batch_id = ? ORDER BY id DESC LIMIT 1;"
+	sqlInsert         = "INSERT INTO stock_movements (product_batch_id, movement_type, delta, balance, actor, inbound_order_id, purchase_order_id, transfer_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?);"
+	sqlLinkReceipt    = "UPDATE stock_movements SET inbound_order_id = ? WHERE product_batch_id = ? AND movement_type = 'inbound_receipt' AND inbound_order_id IS NULL;"
+)
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+)
+
+type movementService struct {
+	repository domain.MovementRepository
+}
+
+func NewMovementService(r domain.MovementRepository) domain.MovementService {
+	return &movementService{repository: r}
+}
+
+// GetBatchHistory returns the ledger of a batch, checking that it adds up to
+// the quantity the batch holds. The ledger outlives a deleted batch, which
+// is taken to hold nothing.
+func (s movementService) GetBatchHistory(ctx context.Context, productBatchId int64) (*domain.BatchHistory, error) {
+	quantity, err := s.repository.GetBatchQuantity(ctx, productBatchId)
+	if err != nil {
+		return nil, err
+	}
+
+	movements, err := s.repository.GetByBatch(ctx, productBatchId)
+	if err != nil {
+		return nil, err
+	}
+
+	if quantity == nil {
+		if len(*movements) == 0 {
+			return nil, domain.ErrProductBatchNotFound
+		}
+		quantity = new(int64)
+	}
+
+	balance, err := domain.Replay(*movements)
+
+	return &domain.BatchHistory{
+		ProductBatchId:  productBatchId,
+		CurrentQuantity: *quantity,
+		LedgerBalance:   balance,
+		Consistent:      err == nil && balance == *quantity,
+		Movements:       *movements,
+	}, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetBatchHistory(t *testing.T) {
+	movements := []domain.Movement{
+		{Id: 1, ProductBatchId: 1, Type: domain.TypeInboundReceipt, Delta: 10, Balance: 10},
+		{Id: 2, ProductBatchId: 1, Type: domain.TypePick, Delta: -4, Balance: 6},
+	}
+
+	t.Run("consistent ledger", func(t *testing.T) {
+		quantity := int64(6)
+		repositoryMock := mocks.NewMovementRepository(t)
+		repositoryMock.On("GetBatchQuantity", mock.Anything, int64(1)).Return(&quantity, nil).Once()
+		repositoryMock.On("GetByBatch", mock.Anything, int64(1)).Return(&movements, nil).Once()
+
+		history, err := NewMovementService(repositoryMock).GetBatchHistory(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.BatchHistory{
+			ProductBatchId:  1,
+			CurrentQuantity: 6,
+			LedgerBalance:   6,
+			Consistent:      true,
+			Movements:       movements,
+		}, history)
+	})
+
+	t.Run("quantity written outside the ledger", func(t *testing.T) {
+		quantity := int64(8)
+		repositoryMock := mocks.NewMovementRepository(t)
+		repositoryMock.On("GetBatchQuantity", mock.Anything, int64(1)).Return(&quantity, nil).Once()
+		repositoryMock.On("GetByBatch", mock.Anything, int64(1)).Return(&movements, nil).Once()
+
+		history, err := NewMovementService(repositoryMock).GetBatchHistory(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6), history.LedgerBalance)
+		assert.False(t, history.Consistent)
+	})
+
+	t.Run("deleted batch", func(t *testing.T) {
+		closed := append(movements, domain.Movement{
+			Id: 3, ProductBatchId: 1, Type: domain.TypeAdjustment, Delta: -6, Balance: 0,
+		})
+		repositoryMock := mocks.NewMovementRepository(t)
+		repositoryMock.On("GetBatchQuantity", mock.Anything, int64(1)).Return(nil, nil).Once()
+		repositoryMock.On("GetByBatch", mock.Anything, int64(1)).Return(&closed, nil).Once()
+
+		history, err := NewMovementService(repositoryMock).GetBatchHistory(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), history.CurrentQuantity)
+		assert.True(t, history.Consistent)
+	})
+
+	t.Run("batch not found", func(t *testing.T) {
+		repositoryMock := mocks.NewMovementRepository(t)
+		repositoryMock.On("GetBatchQuantity", mock.Anything, int64(1)).Return(nil, nil).Once()
+		repositoryMock.On("GetByBatch", mock.Anything, int64(1)).Return(&[]domain.Movement{}, nil).Once()
+
+		_, err := NewMovementService(repositoryMock).GetBatchHistory(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domain.ErrProductBatchNotFound)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		quantity := int64(6)
+		repositoryMock := mocks.NewMovementRepository(t)
+		repositoryMock.On("GetBatchQuantity", mock.Anything, int64(1)).Return(&quantity, nil).Once()
+		repositoryMock.On("GetByBatch", mock.Anything, int64(1)).Return(nil, errors.New("connection lost")).Once()
+
+		_, err := NewMovementService(repositoryMock).GetBatchHistory(context.Background(), 1)
+
+		assert.Error(t, err)
+	})
+}
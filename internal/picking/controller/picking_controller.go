@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/picking/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+)
+
+type PickingController struct {
+	service domain.PickingService
+}
+
+func NewPickingController(service domain.PickingService) (*PickingController, error) {
+	if service == nil {
+		return nil, errors.New("invalid service")
+	}
+
+	return &PickingController{
+		service: service,
+	}, nil
+}
+
+// @Summary Pick list
+// @Tags Picking
+// @Description Plan which batches of a warehouse a product should be picked from, first expired first out
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param pickRequest body domain.PickRequest true "Product, warehouse and quantity to pick"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.PickList}
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /picking [post]
+func (c PickingController) PickList() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.PickRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		pickList, err := c.service.PickList(ctx.Request.Context(), req)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": pickList})
+	}
+}
+
+// @Summary Buyer shelf life policy
+// @Tags Picking
+// @Description Get the minimum shelf life a buyer needs on picked batches
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param buyerId path int true "Buyer ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.ShelfLifePolicy}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /picking/shelfLife/{buyerId} [get]
+func (c PickingController) GetShelfLifePolicy() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		buyerId, err := strconv.ParseInt(ctx.Param("buyerId"), 10, 64)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		policy, err := c.service.GetShelfLifePolicy(ctx.Request.Context(), buyerId)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": policy})
+	}
+}
+
+// @Summary Save buyer shelf life policy
+// @Tags Picking
+// @Description Set the minimum shelf life, in days, a buyer needs on picked batches
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param buyerId path int true "Buyer ID"
+// @Param policy body domain.ShelfLifePolicyRequest true "Minimum shelf life"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.ShelfLifePolicy}
+// @Failure 400 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /picking/shelfLife/{buyerId} [put]
+func (c PickingController) SaveShelfLifePolicy() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		buyerId, err := strconv.ParseInt(ctx.Param("buyerId"), 10, 64)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		var req domain.ShelfLifePolicyRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		policy, err := c.service.SaveShelfLifePolicy(ctx.Request.Context(), domain.ShelfLifePolicy{
+			BuyerId:              buyerId,
+			MinimumShelfLifeDays: req.MinimumShelfLifeDays,
+		})
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": policy})
+	}
+}
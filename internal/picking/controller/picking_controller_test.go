@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/picking/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/picking/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPickList(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewPickingService(t)
+		serviceMock.On("PickList", mock.Anything, domain.PickRequest{ProductId: 1, WarehouseId: 2, Quantity: 3}).
+			Return(&domain.PickList{ProductId: 1, WarehouseId: 2, Quantity: 3}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/picking",
+			bytes.NewBufferString(`{"product_id": 1, "warehouse_id": 2, "quantity": 3}`))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		pickingController := PickingController{service: serviceMock}
+
+		engine.POST("/api/v1/picking", pickingController.PickList())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		serviceMock := mocks.NewPickingService(t)
+		serviceMock.On("PickList", mock.Anything, mock.Anything).Return(nil, domain.ErrInsufficientStock).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/picking",
+			bytes.NewBufferString(`{"product_id": 1, "warehouse_id": 2, "quantity": 3}`))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		pickingController := PickingController{service: serviceMock}
+
+		engine.POST("/api/v1/picking", pickingController.PickList())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		serviceMock := mocks.NewPickingService(t)
+		serviceMock.On("PickList", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/picking",
+			bytes.NewBufferString(`{"product_id": 1, "warehouse_id": 2, "quantity": 3}`))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		pickingController := PickingController{service: serviceMock}
+
+		engine.POST("/api/v1/picking", pickingController.PickList())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("unprocessable entity", func(t *testing.T) {
+		serviceMock := mocks.NewPickingService(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/picking",
+			bytes.NewBufferString(`{"product_id": 1, "warehouse_id": 2, "quantity": 0}`))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		pickingController := PickingController{service: serviceMock}
+
+		engine.POST("/api/v1/picking", pickingController.PickList())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
+
+func TestShelfLifePolicy(t *testing.T) {
+	t.Run("get", func(t *testing.T) {
+		serviceMock := mocks.NewPickingService(t)
+		serviceMock.On("GetShelfLifePolicy", mock.Anything, int64(1)).
+			Return(&domain.ShelfLifePolicy{BuyerId: 1, MinimumShelfLifeDays: 7}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/picking/shelfLife/1", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		pickingController := PickingController{service: serviceMock}
+
+		engine.GET("/api/v1/picking/shelfLife/:buyerId", pickingController.GetShelfLifePolicy())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("save", func(t *testing.T) {
+		serviceMock := mocks.NewPickingService(t)
+		serviceMock.On("SaveShelfLifePolicy", mock.Anything, domain.ShelfLifePolicy{BuyerId: 1, MinimumShelfLifeDays: 7}).
+			Return(&domain.ShelfLifePolicy{BuyerId: 1, MinimumShelfLifeDays: 7}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/picking/shelfLife/1",
+			bytes.NewBufferString(`{"minimum_shelf_life_days": 7}`))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		pickingController := PickingController{service: serviceMock}
+
+		engine.PUT("/api/v1/picking/shelfLife/:buyerId", pickingController.SaveShelfLifePolicy())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("buyer not found", func(t *testing.T) {
+		serviceMock := mocks.NewPickingService(t)
+		serviceMock.On("SaveShelfLifePolicy", mock.Anything, mock.Anything).
+			Return(nil, &apperrors.Error{Kind: apperrors.ErrForeignKey, Entity: "buyers", Field: "buyer_id", Message: "buyer_id: no buyers with that id"}).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/picking/shelfLife/99",
+			bytes.NewBufferString(`{"minimum_shelf_life_days": 7}`))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		pickingController := PickingController{service: serviceMock}
+
+		engine.PUT("/api/v1/picking/shelfLife/:buyerId", pickingController.SaveShelfLifePolicy())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		serviceMock := mocks.NewPickingService(t)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/picking/shelfLife/abc",
+			bytes.NewBufferString(`{"minimum_shelf_life_days": 7}`))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		pickingController := PickingController{service: serviceMock}
+
+		engine.PUT("/api/v1/picking/shelfLife/:buyerId", pickingController.SaveShelfLifePolicy())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
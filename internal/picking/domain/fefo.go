@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const dueDateLayout = "2006-01-02"
+
+// Cutoff is the due date a batch must be after to be picked at now for a
+// buyer that needs minimumShelfLifeDays of shelf life left.
+func Cutoff(now time.Time, minimumShelfLifeDays int64) time.Time {
+	return now.AddDate(0, 0, int(minimumShelfLifeDays))
+}
+
+// Pick draws quantity from batches first expired, first out. Batches due on
+// or before cutoff are skipped and batches without a due date are used last.
+// It fails with ErrInsufficientStock when the batches left can't cover
+// quantity.
+func Pick(batches []Batch, quantity int64, cutoff time.Time) ([]PickLine, error) {
+	candidates := make([]Batch, 0, len(batches))
+	for _, batch := range batches {
+		if batch.Available <= 0 {
+			continue
+		}
+		if !batch.DueDate.IsZero() && !batch.DueDate.After(cutoff) {
+			continue
+		}
+		candidates = append(candidates, batch)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.DueDate.IsZero() != b.DueDate.IsZero() {
+			return b.DueDate.IsZero()
+		}
+		if !a.DueDate.Equal(b.DueDate) {
+			return a.DueDate.Before(b.DueDate)
+		}
+		return a.ID < b.ID
+	})
+
+	lines := []PickLine{}
+	remaining := quantity
+
+	for _, batch := range candidates {
+		if remaining == 0 {
+			break
+		}
+
+		picked := batch.Available
+		if picked > remaining {
+			picked = remaining
+		}
+
+		line := PickLine{
+			ProductBatchId: batch.ID,
+			BatchNumber:    batch.BatchNumber,
+			SectionId:      batch.SectionId,
+			Quantity:       picked,
+		}
+		if !batch.DueDate.IsZero() {
+			line.DueDate = batch.DueDate.Format(dueDateLayout)
+		}
+
+		lines = append(lines, line)
+		remaining -= picked
+	}
+
+	if remaining > 0 {
+		return nil, fmt.Errorf("%w: missing %d units", ErrInsufficientStock, remaining)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPick(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	batches := []Batch{
+		{ID: 1, BatchNumber: 101, SectionId: 1, DueDate: now.Add(10 * day), Available: 5},
+		{ID: 2, BatchNumber: 102, SectionId: 1, DueDate: now.Add(-day), Available: 50},
+		{ID: 3, BatchNumber: 103, SectionId: 2, DueDate: now.Add(2 * day), Available: 3},
+		{ID: 4, BatchNumber: 104, SectionId: 2, Available: 100},
+		{ID: 5, BatchNumber: 105, SectionId: 3, DueDate: now.Add(5 * day), Available: 0},
+	}
+
+	t.Run("earliest due date first, skipping expired batches", func(t *testing.T) {
+		lines, err := Pick(batches, 6, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []PickLine{
+			{ProductBatchId: 3, BatchNumber: 103, SectionId: 2, DueDate: "2022-06-03", Quantity: 3},
+			{ProductBatchId: 1, BatchNumber: 101, SectionId: 1, DueDate: "2022-06-11", Quantity: 3},
+		}, lines)
+	})
+
+	t.Run("batches without due date come last", func(t *testing.T) {
+		lines, err := Pick(batches, 10, now)
+
+		assert.NoError(t, err)
+		assert.Len(t, lines, 3)
+		assert.Equal(t, int64(4), lines[2].ProductBatchId)
+		assert.Equal(t, int64(2), lines[2].Quantity)
+		assert.Empty(t, lines[2].DueDate)
+	})
+
+	t.Run("minimum shelf life", func(t *testing.T) {
+		lines, err := Pick(batches, 6, Cutoff(now, 3))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), lines[0].ProductBatchId)
+		assert.Equal(t, int64(5), lines[0].Quantity)
+		assert.Equal(t, int64(4), lines[1].ProductBatchId)
+	})
+
+	t.Run("insufficient stock", func(t *testing.T) {
+		_, err := Pick(batches[:3], 20, now)
+
+		assert.ErrorIs(t, err, ErrInsufficientStock)
+	})
+}
@@ -0,0 +1,90 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/picking/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PickingRepository is an autogenerated mock type for the PickingRepository type
+type PickingRepository struct {
+	mock.Mock
+}
+
+// GetBatches provides a mock function with given fields: ctx, productId, warehouseId
+func (_m *PickingRepository) GetBatches(ctx context.Context, productId int64, warehouseId int64) (*[]domain.Batch, error) {
+	ret := _m.Called(ctx, productId, warehouseId)
+
+	var r0 *[]domain.Batch
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *[]domain.Batch); ok {
+		r0 = rf(ctx, productId, warehouseId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Batch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, productId, warehouseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShelfLifePolicy provides a mock function with given fields: ctx, buyerId
+func (_m *PickingRepository) GetShelfLifePolicy(ctx context.Context, buyerId int64) (*domain.ShelfLifePolicy, error) {
+	ret := _m.Called(ctx, buyerId)
+
+	var r0 *domain.ShelfLifePolicy
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.ShelfLifePolicy); ok {
+		r0 = rf(ctx, buyerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ShelfLifePolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, buyerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveShelfLifePolicy provides a mock function with given fields: ctx, policy
+func (_m *PickingRepository) SaveShelfLifePolicy(ctx context.Context, policy *domain.ShelfLifePolicy) error {
+	ret := _m.Called(ctx, policy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ShelfLifePolicy) error); ok {
+		r0 = rf(ctx, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPickingRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPickingRepository creates a new instance of PickingRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPickingRepository(t mockConstructorTestingTNewPickingRepository) *PickingRepository {
+	mock := &PickingRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
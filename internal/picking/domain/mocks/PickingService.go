@@ -0,0 +1,99 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/picking/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PickingService is an autogenerated mock type for the PickingService type
+type PickingService struct {
+	mock.Mock
+}
+
+// GetShelfLifePolicy provides a mock function with given fields: ctx, buyerId
+func (_m *PickingService) GetShelfLifePolicy(ctx context.Context, buyerId int64) (*domain.ShelfLifePolicy, error) {
+	ret := _m.Called(ctx, buyerId)
+
+	var r0 *domain.ShelfLifePolicy
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.ShelfLifePolicy); ok {
+		r0 = rf(ctx, buyerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ShelfLifePolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, buyerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PickList provides a mock function with given fields: ctx, request
+func (_m *PickingService) PickList(ctx context.Context, request domain.PickRequest) (*domain.PickList, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *domain.PickList
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PickRequest) *domain.PickList); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PickList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PickRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveShelfLifePolicy provides a mock function with given fields: ctx, policy
+func (_m *PickingService) SaveShelfLifePolicy(ctx context.Context, policy domain.ShelfLifePolicy) (*domain.ShelfLifePolicy, error) {
+	ret := _m.Called(ctx, policy)
+
+	var r0 *domain.ShelfLifePolicy
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ShelfLifePolicy) *domain.ShelfLifePolicy); ok {
+		r0 = rf(ctx, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ShelfLifePolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ShelfLifePolicy) error); ok {
+		r1 = rf(ctx, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPickingService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPickingService creates a new instance of PickingService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPickingService(t mockConstructorTestingTNewPickingService) *PickingService {
+	mock := &PickingService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
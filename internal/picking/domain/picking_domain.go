@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Batch is a product batch of a warehouse with stock that can be picked.
+// DueDate is zero for batches that don't expire.
+type Batch struct {
+	ID          int64
+	BatchNumber int64
+	SectionId   int64
+	DueDate     time.Time
+	Available   int64
+}
+
+type PickLine struct {
+	ProductBatchId int64  `json:"product_batch_id"`
+	BatchNumber    int64  `json:"batch_number"`
+	SectionId      int64  `json:"section_id"`
+	DueDate        string `json:"due_date,omitempty"`
+	Quantity       int64  `json:"quantity"`
+}
+
+type PickList struct {
+	ProductId   int64      `json:"product_id"`
+	WarehouseId int64      `json:"warehouse_id"`
+	Quantity    int64      `json:"quantity"`
+	Lines       []PickLine `json:"lines"`
+}
+
+type PickRequest struct {
+	ProductId   int64 `json:"product_id" binding:"required"`
+	WarehouseId int64 `json:"warehouse_id" binding:"required"`
+	Quantity    int64 `json:"quantity" binding:"required,gt=0"`
+	BuyerId     int64 `json:"buyer_id"`
+}
+
+// ShelfLifePolicy is the shelf life a buyer needs left on every batch
+// picked for them.
+type ShelfLifePolicy struct {
+	BuyerId              int64 `json:"buyer_id"`
+	MinimumShelfLifeDays int64 `json:"minimum_shelf_life_days"`
+}
+
+type ShelfLifePolicyRequest struct {
+	MinimumShelfLifeDays int64 `json:"minimum_shelf_life_days" binding:"gte=0"`
+}
+
+type PickingRepository interface {
+	GetBatches(ctx context.Context, productId, warehouseId int64) (*[]Batch, error)
+	GetShelfLifePolicy(ctx context.Context, buyerId int64) (*ShelfLifePolicy, error)
+	SaveShelfLifePolicy(ctx context.Context, policy *ShelfLifePolicy) error
+}
+
+type PickingService interface {
+	PickList(ctx context.Context, request PickRequest) (*PickList, error)
+	GetShelfLifePolicy(ctx context.Context, buyerId int64) (*ShelfLifePolicy, error)
+	SaveShelfLifePolicy(ctx context.Context, policy ShelfLifePolicy) (*ShelfLifePolicy, error)
+}
@@ -0,0 +1,7 @@
+package domain
+
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+
+var (
+	ErrInsufficientStock = apperrors.Conflict("insufficient stock")
+)
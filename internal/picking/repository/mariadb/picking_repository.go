@@ -0,0 +1,77 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/picking/domain"
+)
+
+type mariadbRepository struct {
+	db *sql.DB
+}
+
+func NewMariaDBRepository(db *sql.DB) domain.PickingRepository {
+	return mariadbRepository{db: db}
+}
+
+// GetBatches returns the batches of the product stored in the sections of
+// the warehouse that still have unreserved stock.
+func (m mariadbRepository) GetBatches(ctx context.Context, productId, warehouseId int64) (*[]domain.Batch, error) {
+	batches := []domain.Batch{}
+
+	rows, err := m.db.QueryContext(ctx, sqlGetBatches, productId, warehouseId)
+	if err != nil {
+		return &batches, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var batch domain.Batch
+		var dueDate sql.NullTime
+
+		if err := rows.Scan(
+			&batch.ID,
+			&batch.BatchNumber,
+			&batch.SectionId,
+			&dueDate,
+			&batch.Available,
+		); err != nil {
+			return &batches, err
+		}
+
+		batch.DueDate = dueDate.Time
+		batches = append(batches, batch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return &batches, err
+	}
+
+	return &batches, nil
+}
+
+func (m mariadbRepository) GetShelfLifePolicy(ctx context.Context, buyerId int64) (*domain.ShelfLifePolicy, error) {
+	row := m.db.QueryRowContext(ctx, sqlGetShelfLifePolicy, buyerId)
+
+	policy := &domain.ShelfLifePolicy{}
+	err := row.Scan(&policy.BuyerId, &policy.MinimumShelfLifeDays)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (m mariadbRepository) SaveShelfLifePolicy(ctx context.Context, policy *domain.ShelfLifePolicy) error {
+	_, err := m.db.ExecContext(ctx, sqlSaveShelfLifePolicy, policy.BuyerId, policy.MinimumShelfLifeDays)
+	return apperrors.FromMySQL(err)
+}
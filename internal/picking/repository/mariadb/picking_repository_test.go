@@ -0,0 +1,134 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/picking/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	queryGetBatches          = regexp.QuoteMeta(sqlGetBatches)
+	queryGetShelfLifePolicy  = regexp.QuoteMeta(sqlGetShelfLifePolicy)
+	querySaveShelfLifePolicy = regexp.QuoteMeta(sqlSaveShelfLifePolicy)
+)
+
+func TestGetBatches(t *testing.T) {
+	dueDate := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "batch_number", "section_id", "due_date", "available"}).
+			AddRow(1, 101, 1, dueDate, 10).
+			AddRow(2, 102, 2, nil, 5)
+
+		mock.ExpectQuery(queryGetBatches).WithArgs(3, 4).WillReturnRows(rows)
+
+		repo := NewMariaDBRepository(db)
+
+		batches, err := repo.GetBatches(context.Background(), 3, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Batch{
+			{ID: 1, BatchNumber: 101, SectionId: 1, DueDate: dueDate, Available: 10},
+			{ID: 2, BatchNumber: 102, SectionId: 2, Available: 5},
+		}, *batches)
+	})
+
+	t.Run("fail to select batches", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetBatches).WillReturnError(sql.ErrConnDone)
+
+		repo := NewMariaDBRepository(db)
+
+		_, err = repo.GetBatches(context.Background(), 3, 4)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetShelfLifePolicy(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"buyer_id", "minimum_shelf_life_days"}).AddRow(1, 7)
+		mock.ExpectQuery(queryGetShelfLifePolicy).WithArgs(1).WillReturnRows(rows)
+
+		repo := NewMariaDBRepository(db)
+
+		policy, err := repo.GetShelfLifePolicy(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.ShelfLifePolicy{BuyerId: 1, MinimumShelfLifeDays: 7}, policy)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetShelfLifePolicy).WithArgs(1).WillReturnError(sql.ErrNoRows)
+
+		repo := NewMariaDBRepository(db)
+
+		policy, err := repo.GetShelfLifePolicy(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Nil(t, policy)
+	})
+}
+
+func TestSaveShelfLifePolicy(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(querySaveShelfLifePolicy).WithArgs(1, 7).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		repo := NewMariaDBRepository(db)
+
+		err = repo.SaveShelfLifePolicy(context.Background(), &domain.ShelfLifePolicy{BuyerId: 1, MinimumShelfLifeDays: 7})
+		assert.NoError(t, err)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(querySaveShelfLifePolicy).WillReturnError(sql.ErrConnDone)
+
+		repo := NewMariaDBRepository(db)
+
+		err = repo.SaveShelfLifePolicy(context.Background(), &domain.ShelfLifePolicy{BuyerId: 1})
+		assert.Error(t, err)
+	})
+
+	t.Run("buyer not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(querySaveShelfLifePolicy).WithArgs(99, 7).WillReturnError(&mysql.MySQLError{
+			Number:  1452,
+			Message: "Cannot add or update a child row: a foreign key constraint fails (`mercado_fresco`.`buyer_shelf_life_policies`, CONSTRAINT `buyer_shelf_life_policies_ibfk_1` FOREIGN KEY (`buyer_id`) REFERENCES `buyers` (`id`))",
+		})
+
+		repo := NewMariaDBRepository(db)
+
+		err = repo.SaveShelfLifePolicy(context.Background(), &domain.ShelfLifePolicy{BuyerId: 99, MinimumShelfLifeDays: 7})
+		assert.ErrorIs(t, err, apperrors.ErrForeignKey)
+	})
+}
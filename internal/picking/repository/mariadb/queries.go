@@ -0,0 +1,11 @@
+package mariadb
+
+const (
+	sqlGetBatches = `SELECT pb.id, pb.batch_number, pb.section_id, pb.due_date, pb.current_quantity - pb.reserved_quantity
+		FROM product_batches pb
+		INNER JOIN sections s ON s.id = pb.section_id
+		WHERE pb.product_id = ? AND s.warehouse_id = ? AND pb.quarantined_at IS NULL AND pb.current_quantity > pb.reserved_quantity;`
+	sqlGetShelfLifePolicy  = "SELECT buyer_id, minimum_shelf_life_days FROM buyer_shelf_life_policies WHERE buyer_id = ?;"
+	sqlSaveShelfLifePolicy = `INSERT INTO buyer_shelf_life_policies (buyer_id, minimum_shelf_life_days) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE minimum_shelf_life_days = VALUES(minimum_shelf_life_days);`
+)
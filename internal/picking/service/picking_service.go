@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/picking/domain"
+)
+
+type pickingService struct {
+	repository domain.PickingRepository
+	now        func() time.Time
+}
+
+func NewPickingService(r domain.PickingRepository) domain.PickingService {
+	return &pickingService{repository: r, now: time.Now}
+}
+
+// PickList plans which batches of the warehouse a quantity of the product
+// should be taken from. When a buyer is given, batches that would expire
+// before their minimum shelf life are left out.
+func (s pickingService) PickList(ctx context.Context, request domain.PickRequest) (*domain.PickList, error) {
+	var minimumShelfLifeDays int64
+	if request.BuyerId > 0 {
+		policy, err := s.GetShelfLifePolicy(ctx, request.BuyerId)
+		if err != nil {
+			return nil, err
+		}
+		minimumShelfLifeDays = policy.MinimumShelfLifeDays
+	}
+
+	batches, err := s.repository.GetBatches(ctx, request.ProductId, request.WarehouseId)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := domain.Pick(*batches, request.Quantity, domain.Cutoff(s.now(), minimumShelfLifeDays))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PickList{
+		ProductId:   request.ProductId,
+		WarehouseId: request.WarehouseId,
+		Quantity:    request.Quantity,
+		Lines:       lines,
+	}, nil
+}
+
+// GetShelfLifePolicy returns the policy of the buyer, which requires no
+// shelf life when none was saved.
+func (s pickingService) GetShelfLifePolicy(ctx context.Context, buyerId int64) (*domain.ShelfLifePolicy, error) {
+	policy, err := s.repository.GetShelfLifePolicy(ctx, buyerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil {
+		return &domain.ShelfLifePolicy{BuyerId: buyerId}, nil
+	}
+
+	return policy, nil
+}
+
+func (s pickingService) SaveShelfLifePolicy(ctx context.Context, policy domain.ShelfLifePolicy) (*domain.ShelfLifePolicy, error) {
+	if err := s.repository.SaveShelfLifePolicy(ctx, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
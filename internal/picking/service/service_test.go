@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/picking/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/picking/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPickList(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	batches := []domain.Batch{
+		{ID: 1, BatchNumber: 101, SectionId: 1, DueDate: now.Add(10 * day), Available: 5},
+		{ID: 2, BatchNumber: 102, SectionId: 1, DueDate: now.Add(2 * day), Available: 5},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		repositoryMock := mocks.NewPickingRepository(t)
+		repositoryMock.On("GetBatches", mock.Anything, int64(3), int64(4)).Return(&batches, nil).Once()
+
+		s := pickingService{repository: repositoryMock, now: func() time.Time { return now }}
+
+		pickList, err := s.PickList(context.Background(), domain.PickRequest{ProductId: 3, WarehouseId: 4, Quantity: 6})
+
+		assert.NoError(t, err)
+		assert.Len(t, pickList.Lines, 2)
+		assert.Equal(t, int64(2), pickList.Lines[0].ProductBatchId)
+		assert.Equal(t, int64(5), pickList.Lines[0].Quantity)
+	})
+
+	t.Run("respects the buyer shelf life", func(t *testing.T) {
+		repositoryMock := mocks.NewPickingRepository(t)
+		repositoryMock.On("GetShelfLifePolicy", mock.Anything, int64(9)).
+			Return(&domain.ShelfLifePolicy{BuyerId: 9, MinimumShelfLifeDays: 5}, nil).Once()
+		repositoryMock.On("GetBatches", mock.Anything, int64(3), int64(4)).Return(&batches, nil).Once()
+
+		s := pickingService{repository: repositoryMock, now: func() time.Time { return now }}
+
+		pickList, err := s.PickList(context.Background(), domain.PickRequest{ProductId: 3, WarehouseId: 4, Quantity: 5, BuyerId: 9})
+
+		assert.NoError(t, err)
+		assert.Len(t, pickList.Lines, 1)
+		assert.Equal(t, int64(1), pickList.Lines[0].ProductBatchId)
+	})
+
+	t.Run("insufficient stock", func(t *testing.T) {
+		repositoryMock := mocks.NewPickingRepository(t)
+		repositoryMock.On("GetBatches", mock.Anything, int64(3), int64(4)).Return(&batches, nil).Once()
+
+		s := pickingService{repository: repositoryMock, now: func() time.Time { return now }}
+
+		_, err := s.PickList(context.Background(), domain.PickRequest{ProductId: 3, WarehouseId: 4, Quantity: 11})
+
+		assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		repositoryMock := mocks.NewPickingRepository(t)
+		repositoryMock.On("GetBatches", mock.Anything, int64(3), int64(4)).
+			Return(nil, errors.New("failed to retrieve batches")).Once()
+
+		s := NewPickingService(repositoryMock)
+
+		_, err := s.PickList(context.Background(), domain.PickRequest{ProductId: 3, WarehouseId: 4, Quantity: 1})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetShelfLifePolicy(t *testing.T) {
+	t.Run("saved policy", func(t *testing.T) {
+		repositoryMock := mocks.NewPickingRepository(t)
+		repositoryMock.On("GetShelfLifePolicy", mock.Anything, int64(1)).
+			Return(&domain.ShelfLifePolicy{BuyerId: 1, MinimumShelfLifeDays: 7}, nil).Once()
+
+		s := NewPickingService(repositoryMock)
+
+		policy, err := s.GetShelfLifePolicy(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), policy.MinimumShelfLifeDays)
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		repositoryMock := mocks.NewPickingRepository(t)
+		repositoryMock.On("GetShelfLifePolicy", mock.Anything, int64(1)).Return(nil, nil).Once()
+
+		s := NewPickingService(repositoryMock)
+
+		policy, err := s.GetShelfLifePolicy(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.ShelfLifePolicy{BuyerId: 1}, policy)
+	})
+}
+
+func TestSaveShelfLifePolicy(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		repositoryMock := mocks.NewPickingRepository(t)
+		repositoryMock.On("SaveShelfLifePolicy", mock.Anything, &domain.ShelfLifePolicy{BuyerId: 1, MinimumShelfLifeDays: 7}).
+			Return(nil).Once()
+
+		s := NewPickingService(repositoryMock)
+
+		policy, err := s.SaveShelfLifePolicy(context.Background(), domain.ShelfLifePolicy{BuyerId: 1, MinimumShelfLifeDays: 7})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), policy.MinimumShelfLifeDays)
+	})
+}
@@ -0,0 +1,108 @@
+// Package policy decides what the authenticated users may do. The route
+// files declare the roles each endpoint needs with Require, and the services
+// check that the warehouse or seller of a resource is the one of the caller
+// with OwnWarehouse and OwnSeller.
+package policy
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+)
+
+// Roles the policies refer to. Admins may do everything.
+const (
+	Admin    = "admin"
+	Employee = "employee"
+	Seller   = "seller"
+)
+
+var (
+	ErrForbidden = apperrors.Forbidden("your roles do not allow this action")
+
+	ErrOtherWarehouse = &apperrors.Error{
+		Kind:    apperrors.ErrForbidden,
+		Entity:  "warehouses",
+		Field:   "warehouse_id",
+		Message: "warehouse_id is not your warehouse",
+	}
+
+	ErrOtherSeller = &apperrors.Error{
+		Kind:    apperrors.ErrForbidden,
+		Entity:  "sellers",
+		Field:   "seller_id",
+		Message: "seller_id is not your seller",
+	}
+)
+
+// Require lets through the admins and the users with any of roles, so
+// Require(Admin) is for admins only.
+func Require(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, ok := auth.FromContext(ctx.Request.Context())
+		if !ok {
+			problem.AbortError(ctx, auth.ErrMissingToken)
+			return
+		}
+
+		if !hasAny(claims, roles) {
+			problem.AbortError(ctx, ErrForbidden)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func hasAny(claims auth.Claims, roles []string) bool {
+	if claims.HasRole(Admin) {
+		return true
+	}
+
+	for _, role := range roles {
+		if claims.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unrestricted tells whether the caller of ctx may act on any warehouse or
+// seller: admins, and the code running outside a request, like the jobs.
+func Unrestricted(ctx context.Context) bool {
+	claims, ok := auth.FromContext(ctx)
+	return !ok || claims.HasRole(Admin)
+}
+
+// OwnWarehouse returns ErrOtherWarehouse unless the caller is unrestricted
+// or works for the warehouse.
+func OwnWarehouse(ctx context.Context, warehouseId int64) error {
+	if Unrestricted(ctx) {
+		return nil
+	}
+
+	claims, _ := auth.FromContext(ctx)
+	if claims.WarehouseId == nil || *claims.WarehouseId != warehouseId {
+		return ErrOtherWarehouse
+	}
+
+	return nil
+}
+
+// OwnSeller returns ErrOtherSeller unless the caller is unrestricted or
+// works for the seller.
+func OwnSeller(ctx context.Context, sellerId int64) error {
+	if Unrestricted(ctx) {
+		return nil
+	}
+
+	claims, _ := auth.FromContext(ctx)
+	if claims.SellerId == nil || *claims.SellerId != sellerId {
+		return ErrOtherSeller
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+	"github.com/stretchr/testify/assert"
+)
+
+func withClaims(claims auth.Claims) context.Context {
+	return auth.NewContext(context.Background(), claims)
+}
+
+func TestRequire(t *testing.T) {
+	serve := func(claims *auth.Claims) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		_, engine := gin.CreateTestContext(rec)
+
+		if claims != nil {
+			engine.Use(func(ctx *gin.Context) {
+				ctx.Request = ctx.Request.WithContext(auth.NewContext(ctx.Request.Context(), *claims))
+			})
+		}
+		engine.DELETE("/", Require(Employee), func(ctx *gin.Context) {
+			ctx.Status(http.StatusNoContent)
+		})
+
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/", nil))
+		return rec
+	}
+
+	t.Run("role", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, serve(&auth.Claims{Roles: []string{Employee}}).Code)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, serve(&auth.Claims{Roles: []string{Admin}}).Code)
+	})
+
+	t.Run("other role", func(t *testing.T) {
+		rec := serve(&auth.Claims{Roles: []string{Seller}})
+
+		var p problem.Problem
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, problem.TypeForbidden, p.Type)
+	})
+
+	t.Run("not authenticated", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve(nil).Code)
+	})
+}
+
+func TestOwnWarehouse(t *testing.T) {
+	warehouseId := int64(3)
+
+	assert.NoError(t, OwnWarehouse(context.Background(), 5))
+	assert.NoError(t, OwnWarehouse(withClaims(auth.Claims{Roles: []string{Admin}}), 5))
+	assert.NoError(t, OwnWarehouse(withClaims(auth.Claims{Roles: []string{Employee}, WarehouseId: &warehouseId}), 3))
+	assert.ErrorIs(t, OwnWarehouse(withClaims(auth.Claims{Roles: []string{Employee}, WarehouseId: &warehouseId}), 5), ErrOtherWarehouse)
+	assert.ErrorIs(t, OwnWarehouse(withClaims(auth.Claims{Roles: []string{Employee}}), 3), ErrOtherWarehouse)
+}
+
+func TestOwnSeller(t *testing.T) {
+	sellerId := int64(2)
+
+	assert.NoError(t, OwnSeller(context.Background(), 5))
+	assert.NoError(t, OwnSeller(withClaims(auth.Claims{Roles: []string{Seller}, SellerId: &sellerId}), 2))
+	assert.ErrorIs(t, OwnSeller(withClaims(auth.Claims{Roles: []string{Seller}, SellerId: &sellerId}), 5), ErrOtherSeller)
+	assert.ErrorIs(t, OwnSeller(withClaims(auth.Claims{Roles: []string{Employee}}), 2), ErrOtherSeller)
+}
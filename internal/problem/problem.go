@@ -0,0 +1,203 @@
+// Package problem writes error responses as RFC 7807 problem details, with
+// the fields that failed validation listed in errors.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+)
+
+// ContentType is the media type of every error response.
+const ContentType = "application/problem+json"
+
+// Types of problem, relative to the API root. Problems without a more
+// specific type use about:blank, whose title is the HTTP status text.
+const (
+	TypeBlank        = "about:blank"
+	TypeValidation   = "/problems/validation"
+	TypeNotFound     = "/problems/not-found"
+	TypeConflict     = "/problems/conflict"
+	TypeForeignKey   = "/problems/foreign-key"
+	TypeUnauthorized = "/problems/unauthorized"
+	TypeForbidden    = "/problems/forbidden"
+)
+
+const invalidFields = "one or more fields are invalid"
+
+type Problem struct {
+	Type   string       `json:"type" example:"/problems/validation"`
+	Title  string       `json:"title" example:"Unprocessable Entity"`
+	Status int          `json:"status" example:"422"`
+	Detail string       `json:"detail" example:"one or more fields are invalid"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError names a field of the request and the rule it broke, like the
+// binding tag that failed.
+type FieldError struct {
+	Field   string `json:"field" example:"warehouse_id"`
+	Rule    string `json:"rule" example:"required"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message" example:"warehouse_id is required"`
+}
+
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// fieldName names struct fields in validation errors after their json, form
+// or uri tag, as the client sent them.
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form", "uri"} {
+		name := strings.Split(field.Tag.Get(key), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// New describes err answered with status. Validation errors from binding
+// and JSON decoding list the offending fields, and the errors of
+// apperrors name the field they refer to.
+func New(status int, err error) Problem {
+	p := Problem{
+		Type:   TypeBlank,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+	}
+
+	var (
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+		appErr         *apperrors.Error
+	)
+
+	switch {
+	case errors.As(err, &validationErrs):
+		p.Type = TypeValidation
+		p.Detail = invalidFields
+		for _, fe := range validationErrs {
+			p.Errors = append(p.Errors, FieldError{
+				Field:   fe.Field(),
+				Rule:    fe.Tag(),
+				Param:   fe.Param(),
+				Message: message(fe),
+			})
+		}
+
+	case errors.As(err, &typeErr):
+		p.Type = TypeValidation
+		p.Detail = invalidFields
+		p.Errors = []FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Param:   typeErr.Type.String(),
+			Message: typeErr.Field + " must be of type " + typeErr.Type.String(),
+		}}
+
+	case errors.As(err, &appErr):
+		p.Type = kindType(appErr.Kind)
+		if appErr.Field != "" {
+			p.Errors = []FieldError{{
+				Field:   appErr.Field,
+				Rule:    kindRule(appErr.Kind),
+				Param:   appErr.Entity,
+				Message: appErr.Message,
+			}}
+		}
+	}
+
+	return p
+}
+
+func kindType(kind error) string {
+	switch kind {
+	case apperrors.ErrNotFound:
+		return TypeNotFound
+	case apperrors.ErrConflict:
+		return TypeConflict
+	case apperrors.ErrValidation:
+		return TypeValidation
+	case apperrors.ErrForeignKey:
+		return TypeForeignKey
+	case apperrors.ErrUnauthorized:
+		return TypeUnauthorized
+	case apperrors.ErrForbidden:
+		return TypeForbidden
+	default:
+		return TypeBlank
+	}
+}
+
+func kindRule(kind error) string {
+	switch kind {
+	case apperrors.ErrConflict:
+		return "unique"
+	case apperrors.ErrForeignKey:
+		return "exists"
+	case apperrors.ErrForbidden:
+		return "owned"
+	default:
+		return "invalid"
+	}
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fe.Field() + " is required"
+	case "oneof":
+		return fe.Field() + " must be one of: " + fe.Param()
+	case "min", "gte":
+		return fe.Field() + " must be at least " + fe.Param()
+	case "max", "lte":
+		return fe.Field() + " must be at most " + fe.Param()
+	case "gt":
+		return fe.Field() + " must be greater than " + fe.Param()
+	case "lt":
+		return fe.Field() + " must be less than " + fe.Param()
+	default:
+		return fe.Field() + " failed the " + fe.Tag() + " rule"
+	}
+}
+
+// Abort stops the request answering err with status.
+func Abort(ctx *gin.Context, status int, err error) {
+	write(ctx, New(status, err))
+}
+
+// AbortDetail stops the request with a problem described by detail alone.
+func AbortDetail(ctx *gin.Context, status int, detail string) {
+	write(ctx, Problem{
+		Type:   TypeBlank,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	})
+}
+
+// AbortError stops the request answering err with the status given to its
+// kind by apperrors.Status.
+func AbortError(ctx *gin.Context, err error) {
+	Abort(ctx, apperrors.Status(err), err)
+}
+
+func write(ctx *gin.Context, p Problem) {
+	ctx.Header("Content-Type", ContentType)
+	ctx.AbortWithStatusJSON(p.Status, p)
+}
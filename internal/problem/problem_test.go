@@ -0,0 +1,129 @@
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+type request struct {
+	Description string `json:"description" binding:"required"`
+	Status      string `json:"status" binding:"omitempty,oneof=open closed"`
+	Quantity    int    `json:"quantity"`
+}
+
+func serve(body string, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(rec)
+	engine.POST("/", handler)
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body)))
+	return rec
+}
+
+func bind(ctx *gin.Context) {
+	var req request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Abort(ctx, http.StatusUnprocessableEntity, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder) Problem {
+	var p Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	return p
+}
+
+func TestAbort(t *testing.T) {
+	t.Run("validation errors", func(t *testing.T) {
+		rec := serve(`{"status":"lost"}`, bind)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+		assert.Equal(t, Problem{
+			Type:   TypeValidation,
+			Title:  "Unprocessable Entity",
+			Status: http.StatusUnprocessableEntity,
+			Detail: "one or more fields are invalid",
+			Errors: []FieldError{
+				{Field: "description", Rule: "required", Message: "description is required"},
+				{Field: "status", Rule: "oneof", Param: "open closed", Message: "status must be one of: open closed"},
+			},
+		}, decode(t, rec))
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		rec := serve(`{"description":"fruits","quantity":"ten"}`, bind)
+
+		p := decode(t, rec)
+		assert.Equal(t, TypeValidation, p.Type)
+		assert.Equal(t, []FieldError{
+			{Field: "quantity", Rule: "type", Param: "int", Message: "quantity must be of type int"},
+		}, p.Errors)
+	})
+
+	t.Run("plain error", func(t *testing.T) {
+		rec := serve(``, func(ctx *gin.Context) {
+			Abort(ctx, http.StatusInternalServerError, errors.New("connection refused"))
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, Problem{
+			Type:   TypeBlank,
+			Title:  "Internal Server Error",
+			Status: http.StatusInternalServerError,
+			Detail: "connection refused",
+		}, decode(t, rec))
+	})
+}
+
+func TestAbortDetail(t *testing.T) {
+	rec := serve(``, func(ctx *gin.Context) {
+		AbortDetail(ctx, http.StatusBadRequest, "invalid id")
+	})
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid id"}`, rec.Body.String())
+}
+
+func TestAbortError(t *testing.T) {
+	t.Run("foreign key", func(t *testing.T) {
+		rec := serve(``, func(ctx *gin.Context) {
+			AbortError(ctx, &apperrors.Error{
+				Kind:    apperrors.ErrForeignKey,
+				Entity:  "sellers",
+				Field:   "seller_id",
+				Message: "seller_id: no sellers with that id",
+			})
+		})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, Problem{
+			Type:   TypeForeignKey,
+			Title:  "Unprocessable Entity",
+			Status: http.StatusUnprocessableEntity,
+			Detail: "seller_id: no sellers with that id",
+			Errors: []FieldError{
+				{Field: "seller_id", Rule: "exists", Param: "sellers", Message: "seller_id: no sellers with that id"},
+			},
+		}, decode(t, rec))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		rec := serve(``, func(ctx *gin.Context) {
+			AbortError(ctx, apperrors.NotFound("seller id not found"))
+		})
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.JSONEq(t, `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"seller id not found"}`, rec.Body.String())
+	})
+}
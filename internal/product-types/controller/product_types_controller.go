@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/product-types/domain"
+)
+
+type ProductTypeController struct {
+	service domain.ProductTypeService
+}
+
+func NewProductTypeController(service domain.ProductTypeService) (*ProductTypeController, error) {
+	if service == nil {
+		return nil, errors.New("invalid service")
+	}
+
+	return &ProductTypeController{
+		service: service,
+	}, nil
+}
+
+// @Summary List product types
+// @Tags ProductTypes
+// @Description get all product types
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.ProductType}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /productTypes [get]
+func (c ProductTypeController) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.ProductTypeListFields)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		productTypes, total, err := c.service.GetAll(ctx.Request.Context(), params)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": productTypes,
+			"meta": listing.NewMeta(params, total),
+		})
+	}
+}
+
+// @Summary Product type by id
+// @Tags ProductTypes
+// @Description get a product type by its id
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product type ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.ProductType}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /productTypes/{id} [get]
+func (c ProductTypeController) GetById() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestProductTypeId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		productType, err := c.service.GetById(ctx.Request.Context(), req.Id)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": productType})
+	}
+}
+
+// @Summary Create product type
+// @Tags ProductTypes
+// @Description Create a new product type
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param productType body domain.RequestProductType true "Product type to create"
+// @Success 201 {object} schemas.JSONSuccessResult{data=domain.ProductType}
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /productTypes [post]
+func (c ProductTypeController) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestProductType
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		productType, err := c.service.Create(ctx.Request.Context(), req.Description)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, gin.H{"data": productType})
+	}
+}
+
+// @Summary Update product type
+// @Tags ProductTypes
+// @Description Update the description of a product type
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product type ID"
+// @Param productType body domain.RequestProductType true "Product type to update"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.ProductType}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /productTypes/{id} [patch]
+func (c ProductTypeController) Update() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var reqId domain.RequestProductTypeId
+		if err := ctx.ShouldBindUri(&reqId); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		var req domain.RequestProductType
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		productType, err := c.service.Update(ctx.Request.Context(), reqId.Id, req.Description)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": productType})
+	}
+}
+
+// @Summary Delete product type
+// @Tags ProductTypes
+// @Description Delete a product type no product or section refers to
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product type ID"
+// @Success 204
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /productTypes/{id} [delete]
+func (c ProductTypeController) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestProductTypeId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		if err := c.service.Delete(ctx.Request.Context(), req.Id); err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusNoContent, nil)
+	}
+}
+
+// @Summary Product type usage
+// @Tags ProductTypes
+// @Description Get the number of products and sections of every product type, or of one when id is given
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id query int false "Product type ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=[]domain.ProductTypeUsage}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /productTypes/reportUsage [get]
+func (c ProductTypeController) GetUsage() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestProductTypeUsage
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		if req.Id == 0 {
+			usages, err := c.service.GetAllUsage(ctx.Request.Context())
+			if err != nil {
+				problem.AbortError(ctx, err)
+				return
+			}
+			ctx.JSON(http.StatusOK, gin.H{"data": usages})
+			return
+		}
+
+		usage, err := c.service.GetUsageById(ctx.Request.Context(), req.Id)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": usage})
+	}
+}
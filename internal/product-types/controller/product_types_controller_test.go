@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/product-types/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/product-types/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func serve(serviceMock *mocks.ProductTypeService, method, path string, body *bytes.Buffer) *httptest.ResponseRecorder {
+	if body == nil {
+		body = &bytes.Buffer{}
+	}
+
+	req := httptest.NewRequest(method, path, body)
+	rec := httptest.NewRecorder()
+
+	_, engine := gin.CreateTestContext(rec)
+
+	productTypeController := ProductTypeController{service: serviceMock}
+
+	engine.GET("/api/v1/productTypes", productTypeController.GetAll())
+	engine.GET("/api/v1/productTypes/:id", productTypeController.GetById())
+	engine.POST("/api/v1/productTypes", productTypeController.Create())
+	engine.PATCH("/api/v1/productTypes/:id", productTypeController.Update())
+	engine.DELETE("/api/v1/productTypes/:id", productTypeController.Delete())
+	engine.GET("/api/v1/productTypes/reportUsage", productTypeController.GetUsage())
+
+	engine.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+		serviceMock.On("GetAll", mock.Anything, mock.Anything).Return(&[]domain.ProductType{{Id: 1}}, int64(1), nil).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/productTypes", nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"meta"`)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/productTypes?sort=products_count", nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+		serviceMock.On("GetById", mock.Anything, int64(1)).Return(&domain.ProductType{Id: 1}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/productTypes/1", nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+		serviceMock.On("GetById", mock.Anything, int64(1)).Return(nil, domain.ErrProductTypeNotFound).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/productTypes/1", nil)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+		serviceMock.On("Create", mock.Anything, "frozen").Return(&domain.ProductType{Id: 3, Description: "frozen"}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodPost, "/api/v1/productTypes", bytes.NewBufferString(`{"description": "frozen"}`))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("unprocessable entity", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+
+		rec := serve(serviceMock, http.MethodPost, "/api/v1/productTypes", bytes.NewBufferString(`{}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+		serviceMock.On("Create", mock.Anything, "frozen").Return(nil, errors.New("connection refused")).Once()
+
+		rec := serve(serviceMock, http.MethodPost, "/api/v1/productTypes", bytes.NewBufferString(`{"description": "frozen"}`))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+		serviceMock.On("Update", mock.Anything, int64(3), "chilled").Return(&domain.ProductType{Id: 3, Description: "chilled"}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodPatch, "/api/v1/productTypes/3", bytes.NewBufferString(`{"description": "chilled"}`))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+		serviceMock.On("Update", mock.Anything, int64(3), "chilled").Return(nil, domain.ErrProductTypeNotFound).Once()
+
+		rec := serve(serviceMock, http.MethodPatch, "/api/v1/productTypes/3", bytes.NewBufferString(`{"description": "chilled"}`))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("no content", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+		serviceMock.On("Delete", mock.Anything, int64(3)).Return(nil).Once()
+
+		rec := serve(serviceMock, http.MethodDelete, "/api/v1/productTypes/3", nil)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("in use", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+		serviceMock.On("Delete", mock.Anything, int64(3)).
+			Return(fmt.Errorf("%w: 2 products, 0 sections", domain.ErrProductTypeInUse)).Once()
+
+		rec := serve(serviceMock, http.MethodDelete, "/api/v1/productTypes/3", nil)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func TestGetUsage(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+		serviceMock.On("GetAllUsage", mock.Anything).Return(&[]domain.ProductTypeUsage{{ProductTypeId: 1}}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/productTypes/reportUsage", nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("by id", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+		serviceMock.On("GetUsageById", mock.Anything, int64(1)).Return(&domain.ProductTypeUsage{ProductTypeId: 1}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/productTypes/reportUsage?id=1", nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+		serviceMock.On("GetUsageById", mock.Anything, int64(1)).Return(nil, domain.ErrProductTypeNotFound).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/productTypes/reportUsage?id=1", nil)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		serviceMock := mocks.NewProductTypeService(t)
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/productTypes/reportUsage?id=abc", nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,180 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/product-types/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProductTypeRepository is an autogenerated mock type for the ProductTypeRepository type
+type ProductTypeRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, description
+func (_m *ProductTypeRepository) Create(ctx context.Context, description string) (int64, error) {
+	ret := _m.Called(ctx, description)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, description)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, description)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *ProductTypeRepository) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, params
+func (_m *ProductTypeRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.ProductType, int64, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *[]domain.ProductType
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.ProductType); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.ProductType)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAllUsage provides a mock function with given fields: ctx
+func (_m *ProductTypeRepository) GetAllUsage(ctx context.Context) (*[]domain.ProductTypeUsage, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *[]domain.ProductTypeUsage
+	if rf, ok := ret.Get(0).(func(context.Context) *[]domain.ProductTypeUsage); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.ProductTypeUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetById provides a mock function with given fields: ctx, id
+func (_m *ProductTypeRepository) GetById(ctx context.Context, id int64) (*domain.ProductType, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.ProductType
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.ProductType); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProductType)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsageById provides a mock function with given fields: ctx, id
+func (_m *ProductTypeRepository) GetUsageById(ctx context.Context, id int64) (*domain.ProductTypeUsage, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.ProductTypeUsage
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.ProductTypeUsage); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProductTypeUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, description
+func (_m *ProductTypeRepository) Update(ctx context.Context, id int64, description string) error {
+	ret := _m.Called(ctx, id, description)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, description)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewProductTypeRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewProductTypeRepository creates a new instance of ProductTypeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewProductTypeRepository(t mockConstructorTestingTNewProductTypeRepository) *ProductTypeRepository {
+	mock := &ProductTypeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
+
+type ProductType struct {
+	Id          int64  `json:"id"`
+	Description string `json:"description"`
+}
+
+var ProductTypeListFields = listing.Fields{
+	Sort: []string{"id", "description"},
+}
+
+type RequestProductType struct {
+	Description string `json:"description" binding:"required,max=255"`
+}
+
+type RequestProductTypeId struct {
+	Id int64 `uri:"id" binding:"required,min=1"`
+}
+
+type RequestProductTypeUsage struct {
+	Id int64 `form:"id" binding:"omitempty,min=1"`
+}
+
+// ProductTypeUsage counts the products and sections of a product type.
+type ProductTypeUsage struct {
+	ProductTypeId int64  `json:"product_type_id"`
+	Description   string `json:"description"`
+	ProductsCount int64  `json:"products_count"`
+	SectionsCount int64  `json:"sections_count"`
+}
+
+// InUse tells whether products or sections still refer to the type.
+func (u ProductTypeUsage) InUse() bool {
+	return u.ProductsCount > 0 || u.SectionsCount > 0
+}
+
+type ProductTypeRepository interface {
+	GetAll(ctx context.Context, params listing.Params) (*[]ProductType, int64, error)
+	GetById(ctx context.Context, id int64) (*ProductType, error)
+	Create(ctx context.Context, description string) (int64, error)
+	Update(ctx context.Context, id int64, description string) error
+	Delete(ctx context.Context, id int64) error
+	GetAllUsage(ctx context.Context) (*[]ProductTypeUsage, error)
+	GetUsageById(ctx context.Context, id int64) (*ProductTypeUsage, error)
+}
+
+type ProductTypeService interface {
+	GetAll(ctx context.Context, params listing.Params) (*[]ProductType, int64, error)
+	GetById(ctx context.Context, id int64) (*ProductType, error)
+	Create(ctx context.Context, description string) (*ProductType, error)
+	Update(ctx context.Context, id int64, description string) (*ProductType, error)
+	Delete(ctx context.Context, id int64) error
+	GetAllUsage(ctx context.Context) (*[]ProductTypeUsage, error)
+	GetUsageById(ctx context.Context, id int64) (*ProductTypeUsage, error)
+}
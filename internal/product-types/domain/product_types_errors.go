@@ -0,0 +1,8 @@
+package domain
+
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+
+var (
+	ErrProductTypeNotFound = apperrors.NotFound("product type not found")
+	ErrProductTypeInUse    = apperrors.Conflict("product type is used by products or sections")
+)
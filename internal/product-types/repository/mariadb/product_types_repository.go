@@ -0,0 +1,135 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/product-types/domain"
+)
+
+type mariadbRepository struct {
+	db *sql.DB
+}
+
+func NewMariaDBRepository(db *sql.DB) domain.ProductTypeRepository {
+	return mariadbRepository{db: db}
+}
+
+func (m mariadbRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.ProductType, int64, error) {
+	productTypes := []domain.ProductType{}
+
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAll, params, nil)
+	if err := m.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &productTypes, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAll, params, nil)
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return &productTypes, 0, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var productType domain.ProductType
+
+		if err := rows.Scan(&productType.Id, &productType.Description); err != nil {
+			return &productTypes, 0, err
+		}
+
+		productTypes = append(productTypes, productType)
+	}
+
+	return &productTypes, total, rows.Err()
+}
+
+func (m mariadbRepository) GetById(ctx context.Context, id int64) (*domain.ProductType, error) {
+	var productType domain.ProductType
+
+	err := m.db.QueryRowContext(ctx, sqlGetById, id).Scan(&productType.Id, &productType.Description)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &productType, nil
+}
+
+func (m mariadbRepository) Create(ctx context.Context, description string) (int64, error) {
+	result, err := m.db.ExecContext(ctx, sqlInsert, description)
+	if err != nil {
+		return 0, apperrors.FromMySQL(err)
+	}
+
+	return result.LastInsertId()
+}
+
+func (m mariadbRepository) Update(ctx context.Context, id int64, description string) error {
+	_, err := m.db.ExecContext(ctx, sqlUpdate, description, id)
+	return apperrors.FromMySQL(err)
+}
+
+func (m mariadbRepository) Delete(ctx context.Context, id int64) error {
+	result, err := m.db.ExecContext(ctx, sqlDelete, id)
+	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrProductTypeNotFound
+	}
+
+	return nil
+}
+
+func (m mariadbRepository) GetAllUsage(ctx context.Context) (*[]domain.ProductTypeUsage, error) {
+	usages := []domain.ProductTypeUsage{}
+
+	rows, err := m.db.QueryContext(ctx, sqlGetAllUsage)
+	if err != nil {
+		return &usages, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var usage domain.ProductTypeUsage
+
+		if err := rows.Scan(&usage.ProductTypeId, &usage.Description, &usage.ProductsCount, &usage.SectionsCount); err != nil {
+			return &usages, err
+		}
+
+		usages = append(usages, usage)
+	}
+
+	return &usages, rows.Err()
+}
+
+func (m mariadbRepository) GetUsageById(ctx context.Context, id int64) (*domain.ProductTypeUsage, error) {
+	var usage domain.ProductTypeUsage
+
+	err := m.db.QueryRowContext(ctx, sqlGetUsageById, id).
+		Scan(&usage.ProductTypeId, &usage.Description, &usage.ProductsCount, &usage.SectionsCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &usage, nil
+}
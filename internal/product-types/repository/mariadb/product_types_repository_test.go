@@ -0,0 +1,187 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/product-types/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	queryGetAll       = regexp.QuoteMeta(sqlGetAll)
+	queryCountAll     = regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)
+	queryGetById      = regexp.QuoteMeta(sqlGetById)
+	queryInsert       = regexp.QuoteMeta(sqlInsert)
+	queryUpdate       = regexp.QuoteMeta(sqlUpdate)
+	queryDelete       = regexp.QuoteMeta(sqlDelete)
+	queryGetAllUsage  = regexp.QuoteMeta(sqlGetAllUsage)
+	queryGetUsageById = regexp.QuoteMeta(sqlGetUsageById)
+)
+
+var (
+	rowsProductTypeStruct = []string{"id", "description"}
+	rowsUsageStruct       = []string{"id", "description", "products_count", "sections_count"}
+)
+
+func TestGetAll(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryCountAll).WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(2))
+		mock.ExpectQuery(queryGetAll).WillReturnRows(sqlmock.NewRows(rowsProductTypeStruct).
+			AddRow(1, "frozen").
+			AddRow(2, "fresh"))
+
+		productTypes, total, err := NewMariaDBRepository(db).GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []domain.ProductType{{Id: 1, Description: "frozen"}, {Id: 2, Description: "fresh"}}, *productTypes)
+	})
+
+	t.Run("fail to select product types", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryCountAll).WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(2))
+		mock.ExpectQuery(queryGetAll).WillReturnError(errors.New("connection refused"))
+
+		_, _, err = NewMariaDBRepository(db).GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetById).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows(rowsProductTypeStruct).AddRow(1, "frozen"))
+
+		productType, err := NewMariaDBRepository(db).GetById(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.ProductType{Id: 1, Description: "frozen"}, productType)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetById).WithArgs(int64(1)).WillReturnError(sql.ErrNoRows)
+
+		productType, err := NewMariaDBRepository(db).GetById(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, productType)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(queryInsert).WithArgs("frozen").WillReturnResult(sqlmock.NewResult(3, 1))
+
+	id, err := NewMariaDBRepository(db).Create(context.Background(), "frozen")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(queryUpdate).WithArgs("chilled", int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewMariaDBRepository(db).Update(context.Background(), 3, "chilled")
+
+	assert.NoError(t, err)
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(queryDelete).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = NewMariaDBRepository(db).Delete(context.Background(), 3)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(queryDelete).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = NewMariaDBRepository(db).Delete(context.Background(), 3)
+
+		assert.ErrorIs(t, err, domain.ErrProductTypeNotFound)
+	})
+}
+
+func TestGetAllUsage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(queryGetAllUsage).WillReturnRows(sqlmock.NewRows(rowsUsageStruct).
+		AddRow(1, "frozen", 4, 2).
+		AddRow(2, "fresh", 0, 0))
+
+	usages, err := NewMariaDBRepository(db).GetAllUsage(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ProductTypeUsage{
+		{ProductTypeId: 1, Description: "frozen", ProductsCount: 4, SectionsCount: 2},
+		{ProductTypeId: 2, Description: "fresh"},
+	}, *usages)
+}
+
+func TestGetUsageById(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetUsageById).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows(rowsUsageStruct).AddRow(1, "frozen", 4, 2))
+
+		usage, err := NewMariaDBRepository(db).GetUsageById(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.ProductTypeUsage{ProductTypeId: 1, Description: "frozen", ProductsCount: 4, SectionsCount: 2}, usage)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetUsageById).WithArgs(int64(1)).WillReturnError(sql.ErrNoRows)
+
+		usage, err := NewMariaDBRepository(db).GetUsageById(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, usage)
+	})
+}
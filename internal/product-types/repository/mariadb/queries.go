@@ -0,0 +1,16 @@
+package mariadb
+
+const (
+	sqlGetAll  = "SELECT id, description FROM products_types"
+	sqlGetById = "SELECT id, description FROM products_types WHERE id = ?;"
+	sqlInsert  = "INSERT INTO products_types (description) VALUES (?);"
+	sqlUpdate  = "UPDATE products_types SET description = ? WHERE id = ?;"
+	sqlDelete  = "DELETE FROM products_types WHERE id = ?;"
+
+	sqlGetUsage = `SELECT t.id, t.description,
+		(SELECT COUNT(*) FROM products p WHERE p.product_type_id = t.id),
+		(SELECT COUNT(*) FROM sections s WHERE s.product_type_id = t.id)
+		FROM products_types t`
+	sqlGetAllUsage  = sqlGetUsage + " ORDER BY t.id;"
+	sqlGetUsageById = sqlGetUsage + " WHERE t.id = ?;"
+)
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/product-types/domain"
+)
+
+type productTypeService struct {
+	repository domain.ProductTypeRepository
+}
+
+func NewProductTypeService(r domain.ProductTypeRepository) domain.ProductTypeService {
+	return &productTypeService{repository: r}
+}
+
+func (s productTypeService) GetAll(ctx context.Context, params listing.Params) (*[]domain.ProductType, int64, error) {
+	return s.repository.GetAll(ctx, params)
+}
+
+func (s productTypeService) GetById(ctx context.Context, id int64) (*domain.ProductType, error) {
+	productType, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if productType == nil {
+		return nil, domain.ErrProductTypeNotFound
+	}
+
+	return productType, nil
+}
+
+func (s productTypeService) Create(ctx context.Context, description string) (*domain.ProductType, error) {
+	id, err := s.repository.Create(ctx, description)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ProductType{Id: id, Description: description}, nil
+}
+
+func (s productTypeService) Update(ctx context.Context, id int64, description string) (*domain.ProductType, error) {
+	productType, err := s.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, id, description); err != nil {
+		return nil, err
+	}
+
+	productType.Description = description
+
+	return productType, nil
+}
+
+// Delete removes a product type no product or section refers to.
+func (s productTypeService) Delete(ctx context.Context, id int64) error {
+	usage, err := s.GetUsageById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if usage.InUse() {
+		return fmt.Errorf(
+			"%w: %d products, %d sections",
+			domain.ErrProductTypeInUse, usage.ProductsCount, usage.SectionsCount,
+		)
+	}
+
+	return s.repository.Delete(ctx, id)
+}
+
+func (s productTypeService) GetAllUsage(ctx context.Context) (*[]domain.ProductTypeUsage, error) {
+	return s.repository.GetAllUsage(ctx)
+}
+
+func (s productTypeService) GetUsageById(ctx context.Context, id int64) (*domain.ProductTypeUsage, error) {
+	usage, err := s.repository.GetUsageById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if usage == nil {
+		return nil, domain.ErrProductTypeNotFound
+	}
+
+	return usage, nil
+}
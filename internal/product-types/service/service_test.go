@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/product-types/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/product-types/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetById(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		repositoryMock := mocks.NewProductTypeRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(1)).Return(&domain.ProductType{Id: 1, Description: "frozen"}, nil).Once()
+
+		productType, err := NewProductTypeService(repositoryMock).GetById(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "frozen", productType.Description)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repositoryMock := mocks.NewProductTypeRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(1)).Return(nil, nil).Once()
+
+		_, err := NewProductTypeService(repositoryMock).GetById(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domain.ErrProductTypeNotFound)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	repositoryMock := mocks.NewProductTypeRepository(t)
+	repositoryMock.On("Create", mock.Anything, "frozen").Return(int64(3), nil).Once()
+
+	productType, err := NewProductTypeService(repositoryMock).Create(context.Background(), "frozen")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.ProductType{Id: 3, Description: "frozen"}, productType)
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repositoryMock := mocks.NewProductTypeRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(3)).Return(&domain.ProductType{Id: 3, Description: "frozen"}, nil).Once()
+		repositoryMock.On("Update", mock.Anything, int64(3), "chilled").Return(nil).Once()
+
+		productType, err := NewProductTypeService(repositoryMock).Update(context.Background(), 3, "chilled")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "chilled", productType.Description)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repositoryMock := mocks.NewProductTypeRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(3)).Return(nil, nil).Once()
+
+		_, err := NewProductTypeService(repositoryMock).Update(context.Background(), 3, "chilled")
+
+		assert.ErrorIs(t, err, domain.ErrProductTypeNotFound)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("unused", func(t *testing.T) {
+		repositoryMock := mocks.NewProductTypeRepository(t)
+		repositoryMock.On("GetUsageById", mock.Anything, int64(3)).Return(&domain.ProductTypeUsage{ProductTypeId: 3}, nil).Once()
+		repositoryMock.On("Delete", mock.Anything, int64(3)).Return(nil).Once()
+
+		err := NewProductTypeService(repositoryMock).Delete(context.Background(), 3)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("in use", func(t *testing.T) {
+		repositoryMock := mocks.NewProductTypeRepository(t)
+		repositoryMock.On("GetUsageById", mock.Anything, int64(3)).
+			Return(&domain.ProductTypeUsage{ProductTypeId: 3, SectionsCount: 1}, nil).Once()
+
+		err := NewProductTypeService(repositoryMock).Delete(context.Background(), 3)
+
+		assert.ErrorIs(t, err, domain.ErrProductTypeInUse)
+		assert.EqualError(t, err, "product type is used by products or sections: 0 products, 1 sections")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repositoryMock := mocks.NewProductTypeRepository(t)
+		repositoryMock.On("GetUsageById", mock.Anything, int64(3)).Return(nil, nil).Once()
+
+		err := NewProductTypeService(repositoryMock).Delete(context.Background(), 3)
+
+		assert.ErrorIs(t, err, domain.ErrProductTypeNotFound)
+	})
+}
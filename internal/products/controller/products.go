@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
 )
 
@@ -23,20 +25,33 @@ func NewProduct(p domain.Service) *Controller {
 // @Description get all products
 // @Accept json
 // @Produce json
-// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Product}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Param seller_id query int false "Seller ID"
+// @Param product_type_id query int false "Product type ID"
+// @Param product_code query string false "Product code"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.Product}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /products [get]
 func (c *Controller) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		data, err := c.service.GetAll(ctx.Request.Context())
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.ProductListFields)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+		data, total, err := c.service.GetAll(ctx.Request.Context(), params)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, gin.H{
 			"data": data,
+			"meta": listing.NewMeta(params, total),
 		})
 	}
 }
@@ -46,21 +61,22 @@ func (c *Controller) GetAll() gin.HandlerFunc {
 // @Description get product by it's id
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Product ID"
 // @Success 200 {object} domain.Product
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
 // @Router /products/{id} [get]
 func (c *Controller) GetById() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req domain.RequestProductId
 		if err := ctx.ShouldBindUri(&req); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid id")
 			return
 		}
 		product, err := c.service.GetById(ctx.Request.Context(), req.Id)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": "invalid id"})
+			problem.AbortDetail(ctx, http.StatusNotFound, "invalid id")
 			return
 		}
 		ctx.JSON(http.StatusOK, product)
@@ -72,16 +88,17 @@ func (c *Controller) GetById() gin.HandlerFunc {
 // @Description Add a new product to the list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param product body domain.RequestProducts true "Product to create"
 // @Success 201 {object} domain.Product
-// @Failure 409 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /products [post]
 func (c *Controller) CreateNewProduct() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req domain.RequestProducts
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid inputs"})
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 		product, err := c.service.CreateNewProduct(
@@ -101,7 +118,7 @@ func (c *Controller) CreateNewProduct() gin.HandlerFunc {
 			},
 		)
 		if err != nil {
-			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusCreated, product)
@@ -113,23 +130,25 @@ func (c *Controller) CreateNewProduct() gin.HandlerFunc {
 // @Description Update existing product in list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Product ID"
 // @Param product body domain.RequestProductsUpdated true "Product to update"
 // @Success 200 {object} domain.Product
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /products/{id} [patch]
 func (c *Controller) Update() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req domain.RequestProductsUpdated
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid inputs"})
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 		var reqId domain.RequestProductId
 		if err := ctx.ShouldBindUri(&reqId); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid id")
 			return
 		}
 
@@ -151,7 +170,7 @@ func (c *Controller) Update() gin.HandlerFunc {
 			},
 		)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, product)
@@ -163,22 +182,24 @@ func (c *Controller) Update() gin.HandlerFunc {
 // @Description Delete existing product in list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "product ID"
 // @Success 204 {object} schemas.JSONSuccessResult{data=string}
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
 // @Router /products/{id} [delete]
 func (c *Controller) Delete() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req domain.RequestProductId
 		if err := ctx.ShouldBindUri(&req); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid id")
 			return
 		}
 
 		err := c.service.Delete(ctx.Request.Context(), req.Id)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -191,17 +212,19 @@ func (c *Controller) Delete() gin.HandlerFunc {
 // @Description Create a new product records
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param product body domain.RequestProductRecords true "Create a new product record"
 // @Success 201 {object} schemas.JSONSuccessResult{data=domain.ProductRecords}
-// @Failure 409 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 500 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /productRecords [post]
 func (c *Controller) CreateProductRecords() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req domain.RequestProductRecords
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid inputs"})
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 		recordId, err := c.service.CreateProductRecords(
@@ -211,9 +234,10 @@ func (c *Controller) CreateProductRecords() gin.HandlerFunc {
 				SalePrice:     req.SalePrice,
 				ProductId:     req.ProductId,
 			},
+			req.AllowBelowCost,
 		)
 		if err != nil {
-			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 		record, err := c.service.GetProductRecordsById(
@@ -221,7 +245,7 @@ func (c *Controller) CreateProductRecords() gin.HandlerFunc {
 			recordId,
 		)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusInternalServerError, err)
 			return
 		}
 		ctx.JSON(http.StatusCreated, gin.H{"data": record})
@@ -233,10 +257,11 @@ func (c *Controller) CreateProductRecords() gin.HandlerFunc {
 // @Description Get quantity of product records
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id query int false "records ID"
 // @Success 201 {object} schemas.JSONSuccessResult{data=domain.QtyOfRecords}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 500 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /products/reportRecords [get]
 func (c *Controller) GetQtyOfRecords() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -244,7 +269,7 @@ func (c *Controller) GetQtyOfRecords() gin.HandlerFunc {
 		if err := ctx.ShouldBindQuery(&req); err != nil {
 			products, err := c.service.GetQtyOfAllRecords(ctx.Request.Context())
 			if err != nil {
-				ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				problem.Abort(ctx, http.StatusInternalServerError, err)
 				return
 			}
 			ctx.JSON(http.StatusOK, gin.H{"data": products})
@@ -252,29 +277,93 @@ func (c *Controller) GetQtyOfRecords() gin.HandlerFunc {
 		}
 		product, err := c.service.GetQtyOfRecordsById(ctx.Request.Context(), req.Id)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": "invalid id"})
+			problem.AbortDetail(ctx, http.StatusNotFound, "invalid id")
 			return
 		}
 		ctx.JSON(http.StatusOK, gin.H{"data": product})
 	}
 }
 
-// @Summary Create product records
+// @Summary Product price history
 // @Tags Products
-// @Description Create a new product records
+// @Description get every price a product had, from the oldest to the latest
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=[]domain.ProductPrice}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /products/{id}/prices [get]
+func (c *Controller) GetPriceHistory() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestProductId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid id")
+			return
+		}
+		prices, err := c.service.GetPriceHistory(ctx.Request.Context(), req.Id)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"data": prices})
+	}
+}
+
+// @Summary Product price at a date
+// @Tags Products
+// @Description get the price of a product in effect at the given moment
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param at query string true "RFC 3339 timestamp"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.ProductPrice}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /products/{id}/price [get]
+func (c *Controller) GetPriceAt() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestProductId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid id")
+			return
+		}
+		var query domain.RequestPriceAt
+		if err := ctx.ShouldBindQuery(&query); err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+		price, err := c.service.GetPriceAt(ctx.Request.Context(), req.Id, query.At)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"data": price})
+	}
+}
+
+// @Summary Create product batches
+// @Tags Products
+// @Description Create a new product batch in a section of the same product type with room for its quantity
+// @Accept json
+// @Produce json
+// @Security BearerAuth
 // @Param product body domain.RequestProductBatches true "Product Batche to create"
 // @Success 201 {object} domain.ProductBatches
-// @Failure 409 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 500 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /productBatches [post]
 func (c *Controller) CreateProductBatches() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req domain.RequestProductBatches
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid inputs"})
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 		batchId, err := c.service.CreateProductBatches(
@@ -293,7 +382,7 @@ func (c *Controller) CreateProductBatches() gin.HandlerFunc {
 			},
 		)
 		if err != nil {
-			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 		batch, err := c.service.GetProductBatchesById(
@@ -301,22 +390,162 @@ func (c *Controller) CreateProductBatches() gin.HandlerFunc {
 			batchId,
 		)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusInternalServerError, err)
 			return
 		}
 		ctx.JSON(http.StatusCreated, gin.H{"data": batch})
 	}
 }
 
+// @Summary List product batches
+// @Tags Products
+// @Description get all product batches
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Param product_id query int false "Product ID"
+// @Param section_id query int false "Section ID"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param due_date_from query string false "Earliest due date"
+// @Param due_date_to query string false "Latest due date"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.ProductBatches}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /productBatches [get]
+func (c *Controller) GetAllProductBatches() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.ProductBatchListFields)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+		data, total, err := c.service.GetAllProductBatches(ctx.Request.Context(), params)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": data,
+			"meta": listing.NewMeta(params, total),
+		})
+	}
+}
+
+// @Summary Product batch by id
+// @Tags Products
+// @Description get product batch by it's id
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product batch ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.ProductBatches}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /productBatches/{id} [get]
+func (c *Controller) GetProductBatchById() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestProductBatchId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid id")
+			return
+		}
+		batch, err := c.service.GetProductBatchesById(ctx.Request.Context(), req.Id)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"data": batch})
+	}
+}
+
+// @Summary Adjust product batch
+// @Tags Products
+// @Description Set the current quantity or temperature of a batch, giving the reason of the adjustment. Returned stock needs the purchase order it comes back from
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product batch ID"
+// @Param adjustment body domain.RequestProductBatchAdjustment true "Adjustment to apply"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.ProductBatches}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /productBatches/{id} [patch]
+func (c *Controller) AdjustProductBatch() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var reqId domain.RequestProductBatchId
+		if err := ctx.ShouldBindUri(&reqId); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid id")
+			return
+		}
+		var req domain.RequestProductBatchAdjustment
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if req.CurrentQuantity == nil && req.CurrentTemperature == nil {
+			problem.AbortDetail(ctx, http.StatusUnprocessableEntity, "current_quantity or current_temperature is required")
+			return
+		}
+		batch, err := c.service.AdjustProductBatch(ctx.Request.Context(), reqId.Id, domain.BatchAdjustment{
+			CurrentQuantity:    req.CurrentQuantity,
+			CurrentTemperature: req.CurrentTemperature,
+			Reason:             req.Reason,
+			PurchaseOrderId:    req.PurchaseOrderId,
+		})
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"data": batch})
+	}
+}
+
+// @Summary Delete product batch
+// @Tags Products
+// @Description Delete a product batch that no inbound order, purchase order or transfer refers to
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product batch ID"
+// @Success 204 {object} schemas.JSONSuccessResult{data=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /productBatches/{id} [delete]
+func (c *Controller) DeleteProductBatch() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestProductBatchId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid id")
+			return
+		}
+		if err := c.service.DeleteProductBatch(ctx.Request.Context(), req.Id); err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusNoContent, gin.H{"data": fmt.Sprintf("product batch %d removed", req.Id)})
+	}
+}
+
 // @Summary Quantity of products report
 // @Tags Products
 // @Description Get quantity of product in sections
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id query int false "section ID"
 // @Success 200 {object} domain.ProductRecords
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
 // @Router /products/reportProducts [get]
 func (c *Controller) GetQtdProductsBySectionId() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -324,7 +553,7 @@ func (c *Controller) GetQtdProductsBySectionId() gin.HandlerFunc {
 		if err := ctx.ShouldBindQuery(&req); err != nil {
 			products, err := c.service.GetQtdOfAllProducts(ctx.Request.Context())
 			if err != nil {
-				ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				problem.Abort(ctx, http.StatusInternalServerError, err)
 				return
 			}
 			ctx.JSON(http.StatusOK, gin.H{"data": products})
@@ -332,9 +561,45 @@ func (c *Controller) GetQtdProductsBySectionId() gin.HandlerFunc {
 		}
 		batch, err := c.service.GetQtdProductsBySectionId(ctx.Request.Context(), req.Id)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": "invalid id"})
+			problem.AbortDetail(ctx, http.StatusNotFound, "invalid id")
 			return
 		}
 		ctx.JSON(http.StatusOK, gin.H{"data": batch})
 	}
 }
+
+// @Summary Expiring batches report
+// @Tags Products
+// @Description List batches with stock that expire within the next days, including the expired ones
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Days ahead to look for, 7 by default (max 365)"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param section_id query int false "Section ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=[]domain.ExpiringBatch}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /productBatches/expiring [get]
+func (c *Controller) GetExpiringBatches() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestExpiringBatches
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		days := int64(domain.DefaultExpiringDays)
+		if req.Days != nil {
+			days = *req.Days
+		}
+
+		batches, err := c.service.GetExpiringBatches(ctx.Request.Context(), days, req.WarehouseId, req.SectionId)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": batches})
+	}
+}
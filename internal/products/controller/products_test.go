@@ -8,8 +8,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain/mocks"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
@@ -82,7 +85,12 @@ func TestCreateNewProduct(t *testing.T) {
 		productsServiceMock.On("CreateNewProduct",
 			mock.Anything,
 			mock.Anything,
-		).Return(nil, errors.New("expected conflict error")).Maybe()
+		).Return(nil, &apperrors.Error{
+			Kind:    apperrors.ErrConflict,
+			Entity:  "products",
+			Field:   "product_code",
+			Message: `product_code "PRD-1" is already in use`,
+		}).Maybe()
 
 		payload, err := json.Marshal(mockProduct)
 		assert.NoError(t, err)
@@ -102,6 +110,46 @@ func TestCreateNewProduct(t *testing.T) {
 
 		productsServiceMock.AssertExpectations(t)
 	})
+
+	t.Run("fail with unknown seller", func(t *testing.T) {
+		mockProduct := utils.CreateRandomProduct()
+		productsServiceMock := mocks.NewService(t)
+
+		productsServiceMock.On("CreateNewProduct",
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, &apperrors.Error{
+			Kind:    apperrors.ErrForeignKey,
+			Entity:  "sellers",
+			Field:   "seller_id",
+			Message: "seller_id: no sellers with that id",
+		}).Once()
+
+		payload, err := json.Marshal(mockProduct)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.POST("/api/v1/products", productController.CreateNewProduct())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.JSONEq(t, `{
+			"type": "/problems/foreign-key",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "seller_id: no sellers with that id",
+			"errors": [{"field": "seller_id", "rule": "exists", "param": "sellers", "message": "seller_id: no sellers with that id"}]
+		}`, rec.Body.String())
+
+		productsServiceMock.AssertExpectations(t)
+	})
 }
 
 func TestGetAll(t *testing.T) {
@@ -111,7 +159,8 @@ func TestGetAll(t *testing.T) {
 
 		productsServiceMock.On("GetAll",
 			mock.Anything,
-		).Return(&mockProduct, nil).Once()
+			mock.Anything,
+		).Return(&mockProduct, int64(len(mockProduct)), nil).Once()
 
 		payload, err := json.Marshal(mockProduct)
 		assert.NoError(t, err)
@@ -138,7 +187,8 @@ func TestGetAll(t *testing.T) {
 
 		productsServiceMock.On("GetAll",
 			mock.Anything,
-		).Return(mockProductBad, errors.New("Internal server error")).Maybe()
+			mock.Anything,
+		).Return(mockProductBad, int64(0), errors.New("Internal server error")).Maybe()
 
 		payload, err := json.Marshal(mockProductBad)
 		assert.NoError(t, err)
@@ -158,6 +208,25 @@ func TestGetAll(t *testing.T) {
 
 		productsServiceMock.AssertExpectations(t)
 	})
+
+	t.Run("In case of invalid sort field", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products?sort=-unknown", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.GET("/api/v1/products", productController.GetAll())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		productsServiceMock.AssertExpectations(t)
+	})
 }
 
 func TestGetById(t *testing.T) {
@@ -342,7 +411,7 @@ func TestUpdate(t *testing.T) {
 		productsServiceMock.On("Update",
 			mock.Anything,
 			mock.Anything,
-		).Return(nil, errors.New("expected not found error")).Maybe()
+		).Return(nil, domain.ErrIDNotFound).Maybe()
 
 		payload, err := json.Marshal(mockProduct)
 		assert.NoError(t, err)
@@ -423,7 +492,7 @@ func TestDelete(t *testing.T) {
 		productsServiceMock.On("Delete",
 			mock.Anything,
 			mock.AnythingOfType("int64"),
-		).Return(errors.New("expected conflict error")).Maybe()
+		).Return(domain.ErrIDNotFound).Maybe()
 
 		PATH := fmt.Sprintf("/api/v1/products/%v", utils.RandomInt64())
 		req := httptest.NewRequest(http.MethodDelete, PATH, nil)
@@ -452,6 +521,7 @@ func TestCreateProductRecords(t *testing.T) {
 		productsServiceMock.On("CreateProductRecords",
 			mock.Anything,
 			mock.Anything,
+			false,
 		).Return(mockProductRecordsId, nil).Once().
 			On("GetProductRecordsById",
 				mock.Anything,
@@ -486,6 +556,7 @@ func TestCreateProductRecords(t *testing.T) {
 		productsServiceMock.On("CreateProductRecords",
 			mock.Anything,
 			mock.Anything,
+			false,
 		).Return(mockProductRecordsId, nil).
 			On("GetProductRecordsById",
 				mock.Anything,
@@ -519,6 +590,7 @@ func TestCreateProductRecords(t *testing.T) {
 		productsServiceMock.On("CreateProductRecords",
 			mock.Anything,
 			mock.Anything,
+			false,
 		).Return(mockProductRecordsId, errors.New("bad request")).Maybe().
 			On("GetProductRecordsById",
 				mock.Anything,
@@ -541,7 +613,7 @@ func TestCreateProductRecords(t *testing.T) {
 		productsServiceMock.AssertExpectations(t)
 	})
 
-	t.Run("fail with status conflict", func(t *testing.T) {
+	t.Run("fail with unknown product", func(t *testing.T) {
 		productsServiceMock := mocks.NewService(t)
 		mockProductRecordsBad := &domain.ProductRecords{}
 		mockProductRecords := utils.CreateRandomProductRecords()
@@ -549,7 +621,13 @@ func TestCreateProductRecords(t *testing.T) {
 		productsServiceMock.On("CreateProductRecords",
 			mock.Anything,
 			mock.Anything,
-		).Return(int64(0), errors.New("bad request")).
+			false,
+		).Return(int64(0), &apperrors.Error{
+			Kind:    apperrors.ErrForeignKey,
+			Entity:  "products",
+			Field:   "product_id",
+			Message: "product_id: no products with that id",
+		}).
 			On("GetProductRecordsById",
 				mock.Anything,
 				mock.AnythingOfType("int64"),
@@ -569,12 +647,125 @@ func TestCreateProductRecords(t *testing.T) {
 
 		engine.ServeHTTP(rec, req)
 
-		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
 
 		productsServiceMock.AssertExpectations(t)
 	})
 }
 
+func TestCreateProductRecordsBelowCost(t *testing.T) {
+	productsServiceMock := mocks.NewService(t)
+
+	productsServiceMock.On("CreateProductRecords", mock.Anything, mock.Anything, false).
+		Return(int64(0), domain.ErrSalePriceBelowCost).Once()
+
+	body := `{"purchase_price": 10, "sale_price": 8, "product_id": 1}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/productRecords", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	_, engine := gin.CreateTestContext(rec)
+
+	productController := Controller{service: productsServiceMock}
+
+	engine.POST("/api/v1/productRecords", productController.CreateProductRecords())
+
+	engine.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func TestGetPriceHistory(t *testing.T) {
+	serve := func(productsServiceMock *mocks.Service, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.GET("/api/v1/products/:id/prices", productController.GetPriceHistory())
+
+		engine.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		productsServiceMock.On("GetPriceHistory", mock.Anything, int64(1)).
+			Return(&[]domain.ProductPrice{{RecordId: 1, ProductId: 1}}, nil).Once()
+
+		rec := serve(productsServiceMock, "/api/v1/products/1/prices")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		rec := serve(mocks.NewService(t), "/api/v1/products/abc/prices")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		productsServiceMock.On("GetPriceHistory", mock.Anything, int64(1)).
+			Return(nil, domain.ErrIDNotFound).Once()
+
+		rec := serve(productsServiceMock, "/api/v1/products/1/prices")
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetPriceAt(t *testing.T) {
+	at := time.Date(2022, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	serve := func(productsServiceMock *mocks.Service, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.GET("/api/v1/products/:id/price", productController.GetPriceAt())
+
+		engine.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		productsServiceMock.On("GetPriceAt", mock.Anything, int64(1), at).
+			Return(&domain.ProductPrice{RecordId: 1, ProductId: 1, SalePrice: 10}, nil).Once()
+
+		rec := serve(productsServiceMock, "/api/v1/products/1/price?at=2022-05-15T00:00:00Z")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("missing date", func(t *testing.T) {
+		rec := serve(mocks.NewService(t), "/api/v1/products/1/price")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("no price at that date", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		productsServiceMock.On("GetPriceAt", mock.Anything, int64(1), at).
+			Return(nil, domain.ErrNoPriceAt).Once()
+
+		rec := serve(productsServiceMock, "/api/v1/products/1/price?at=2022-05-15T00:00:00Z")
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
 func TestGetQtyOfRecords(t *testing.T) {
 	t.Run("success - GetQtyOfAllRecords", func(t *testing.T) {
 		productsServiceMock := mocks.NewService(t)
@@ -787,7 +978,7 @@ func TestCreateProductBatches(t *testing.T) {
 		productsServiceMock.On("CreateProductBatches",
 			mock.Anything,
 			mock.Anything,
-		).Return(int64(0), errors.New("bad request")).
+		).Return(int64(0), domain.ErrSectionCapacityExceeded).
 			On("GetProductBatchesById",
 				mock.Anything,
 				mock.AnythingOfType("int64"),
@@ -811,6 +1002,297 @@ func TestCreateProductBatches(t *testing.T) {
 
 		productsServiceMock.AssertExpectations(t)
 	})
+
+	t.Run("fail with status conflict when the section is full", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+		mockProductBatches := utils.CreateRandomProductBatches()
+
+		productsServiceMock.On("CreateProductBatches",
+			mock.Anything,
+			mock.Anything,
+		).Return(int64(0), domain.ErrSectionCapacityExceeded).Once()
+
+		payload, err := json.Marshal(mockProductBatches)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/productBatches", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.POST("/api/v1/productBatches", productController.CreateProductBatches())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		productsServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("fail with status not found", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+		mockProductBatches := utils.CreateRandomProductBatches()
+
+		productsServiceMock.On("CreateProductBatches",
+			mock.Anything,
+			mock.Anything,
+		).Return(int64(0), domain.ErrSectionNotFound).Once()
+
+		payload, err := json.Marshal(mockProductBatches)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/productBatches", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.POST("/api/v1/productBatches", productController.CreateProductBatches())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		productsServiceMock.AssertExpectations(t)
+	})
+}
+
+func TestGetAllProductBatches(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+		mockProductBatches := []domain.ProductBatches{utils.CreateRandomProductBatches()}
+
+		productsServiceMock.On("GetAllProductBatches",
+			mock.Anything,
+			mock.MatchedBy(func(params listing.Params) bool {
+				return len(params.Filters) == 2 &&
+					params.Filters[0] == listing.Filter{Field: "warehouse_id", Value: "2"} &&
+					params.Filters[1] == listing.Filter{Field: "due_date", Op: "<=", Value: "2022-12-31"}
+			}),
+		).Return(&mockProductBatches, int64(1), nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches?warehouse_id=2&due_date_to=2022-12-31", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.GET("/api/v1/productBatches", productController.GetAllProductBatches())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		productsServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches?sort=warehouse_id", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.GET("/api/v1/productBatches", productController.GetAllProductBatches())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestGetProductBatchById(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+		mockProductBatches := utils.CreateRandomProductBatches()
+
+		productsServiceMock.On("GetProductBatchesById", mock.Anything, mockProductBatches.Id).
+			Return(&mockProductBatches, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/productBatches/%d", mockProductBatches.Id), nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.GET("/api/v1/productBatches/:id", productController.GetProductBatchById())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var body struct {
+			Data domain.ProductBatches `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, mockProductBatches.Id, body.Data.Id)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		productsServiceMock.On("GetProductBatchesById", mock.Anything, int64(1)).
+			Return(nil, domain.ErrBatchNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches/1", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.GET("/api/v1/productBatches/:id", productController.GetProductBatchById())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestAdjustProductBatch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+		mockProductBatches := utils.CreateRandomProductBatches()
+
+		productsServiceMock.On("AdjustProductBatch",
+			mock.Anything,
+			int64(1),
+			mock.MatchedBy(func(adjustment domain.BatchAdjustment) bool {
+				return *adjustment.CurrentQuantity == 8 &&
+					adjustment.CurrentTemperature == nil &&
+					adjustment.Reason == domain.AdjustmentDamaged
+			}),
+		).Return(&mockProductBatches, nil).Once()
+
+		payload := []byte(`{"current_quantity": 8, "reason": "damaged"}`)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/productBatches/1", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.PATCH("/api/v1/productBatches/:id", productController.AdjustProductBatch())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("fail with status conflict", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		productsServiceMock.On("AdjustProductBatch", mock.Anything, int64(1), mock.Anything).
+			Return(nil, fmt.Errorf("%w: 4 reserved, 3 requested", domain.ErrBelowReserved)).Once()
+
+		payload := []byte(`{"current_quantity": 3, "reason": "recount"}`)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/productBatches/1", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.PATCH("/api/v1/productBatches/:id", productController.AdjustProductBatch())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("fail with status unprocessable entity", func(t *testing.T) {
+		for _, payload := range []string{
+			`{"current_quantity": 3}`,
+			`{"current_quantity": 3, "reason": "lost"}`,
+			`{"current_quantity": -1, "reason": "recount"}`,
+			`{"current_quantity": 3, "reason": "returned"}`,
+			`{"reason": "recount"}`,
+		} {
+			productsServiceMock := mocks.NewService(t)
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/productBatches/1", bytes.NewBufferString(payload))
+			rec := httptest.NewRecorder()
+
+			_, engine := gin.CreateTestContext(rec)
+
+			productController := Controller{service: productsServiceMock}
+
+			engine.PATCH("/api/v1/productBatches/:id", productController.AdjustProductBatch())
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, payload)
+		}
+	})
+}
+
+func TestDeleteProductBatch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		productsServiceMock.On("DeleteProductBatch", mock.Anything, int64(1)).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/productBatches/1", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.DELETE("/api/v1/productBatches/:id", productController.DeleteProductBatch())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("fail with status conflict", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		productsServiceMock.On("DeleteProductBatch", mock.Anything, int64(1)).Return(domain.ErrBatchReferenced).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/productBatches/1", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.DELETE("/api/v1/productBatches/:id", productController.DeleteProductBatch())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("fail with status not found", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		productsServiceMock.On("DeleteProductBatch", mock.Anything, int64(1)).Return(domain.ErrBatchNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/productBatches/1", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.DELETE("/api/v1/productBatches/:id", productController.DeleteProductBatch())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
 }
 
 func TestGetQtdProductsBySectionId(t *testing.T) {
@@ -918,3 +1400,102 @@ func TestGetQtdProductsBySectionId(t *testing.T) {
 		productsServiceMock.AssertExpectations(t)
 	})
 }
+
+func TestGetExpiringBatches(t *testing.T) {
+	t.Run("success with default days", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+		mockBatches := []domain.ExpiringBatch{{Id: 1, CurrentQuantity: 10, Expired: true}}
+
+		productsServiceMock.On("GetExpiringBatches",
+			mock.Anything,
+			int64(domain.DefaultExpiringDays),
+			int64(2),
+			int64(0),
+		).Return(&mockBatches, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches/expiring?warehouse_id=2", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.GET("/api/v1/productBatches/expiring", productController.GetExpiringBatches())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		productsServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("success with expired only", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+		mockBatches := []domain.ExpiringBatch{}
+
+		productsServiceMock.On("GetExpiringBatches",
+			mock.Anything,
+			int64(0),
+			int64(0),
+			int64(3),
+		).Return(&mockBatches, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches/expiring?days=0&section_id=3", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.GET("/api/v1/productBatches/expiring", productController.GetExpiringBatches())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		productsServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches/expiring?days=-1", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.GET("/api/v1/productBatches/expiring", productController.GetExpiringBatches())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		productsServiceMock := mocks.NewService(t)
+
+		productsServiceMock.On("GetExpiringBatches",
+			mock.Anything,
+			mock.AnythingOfType("int64"),
+			mock.AnythingOfType("int64"),
+			mock.AnythingOfType("int64"),
+		).Return(nil, errors.New("couldn`t return a list")).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches/expiring", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		productController := Controller{service: productsServiceMock}
+
+		engine.GET("/api/v1/productBatches/expiring", productController.GetExpiringBatches())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		productsServiceMock.AssertExpectations(t)
+	})
+}
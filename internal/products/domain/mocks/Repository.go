@@ -5,8 +5,12 @@ package mocks
 import (
 	context "context"
 
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
+
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -14,6 +18,20 @@ type Repository struct {
 	mock.Mock
 }
 
+// AdjustProductBatch provides a mock function with given fields: ctx, id, adjustment
+func (_m *Repository) AdjustProductBatch(ctx context.Context, id int64, adjustment domain.BatchAdjustment) error {
+	ret := _m.Called(ctx, id, adjustment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.BatchAdjustment) error); ok {
+		r0 = rf(ctx, id, adjustment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateNewProduct provides a mock function with given fields: ctx, product
 func (_m *Repository) CreateNewProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
 	ret := _m.Called(ctx, product)
@@ -93,27 +111,78 @@ func (_m *Repository) Delete(ctx context.Context, id int64) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields: ctx
-func (_m *Repository) GetAll(ctx context.Context) (*[]domain.Product, error) {
-	ret := _m.Called(ctx)
+// DeleteProductBatch provides a mock function with given fields: ctx, id
+func (_m *Repository) DeleteProductBatch(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, params
+func (_m *Repository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Product, int64, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 *[]domain.Product
-	if rf, ok := ret.Get(0).(func(context.Context) *[]domain.Product); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.Product); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*[]domain.Product)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
 
-	return r0, r1
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAllProductBatches provides a mock function with given fields: ctx, params
+func (_m *Repository) GetAllProductBatches(ctx context.Context, params listing.Params) (*[]domain.ProductBatches, int64, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *[]domain.ProductBatches
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.ProductBatches); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.ProductBatches)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // GetById provides a mock function with given fields: ctx, id
@@ -139,6 +208,75 @@ func (_m *Repository) GetById(ctx context.Context, id int64) (*domain.Product, e
 	return r0, r1
 }
 
+// GetExpiringBatches provides a mock function with given fields: ctx, filter
+func (_m *Repository) GetExpiringBatches(ctx context.Context, filter domain.ExpiringBatchesFilter) (*[]domain.ExpiringBatch, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 *[]domain.ExpiringBatch
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ExpiringBatchesFilter) *[]domain.ExpiringBatch); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.ExpiringBatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ExpiringBatchesFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPriceAt provides a mock function with given fields: ctx, productId, at
+func (_m *Repository) GetPriceAt(ctx context.Context, productId int64, at time.Time) (*domain.ProductPrice, error) {
+	ret := _m.Called(ctx, productId, at)
+
+	var r0 *domain.ProductPrice
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) *domain.ProductPrice); ok {
+		r0 = rf(ctx, productId, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProductPrice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, productId, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPriceHistory provides a mock function with given fields: ctx, productId
+func (_m *Repository) GetPriceHistory(ctx context.Context, productId int64) (*[]domain.ProductPrice, error) {
+	ret := _m.Called(ctx, productId)
+
+	var r0 *[]domain.ProductPrice
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *[]domain.ProductPrice); ok {
+		r0 = rf(ctx, productId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.ProductPrice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, productId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProductBatchesById provides a mock function with given fields: ctx, id
 func (_m *Repository) GetProductBatchesById(ctx context.Context, id int64) (*domain.ProductBatches, error) {
 	ret := _m.Called(ctx, id)
@@ -277,6 +415,48 @@ func (_m *Repository) GetQtyOfRecordsById(ctx context.Context, id int64) (*domai
 	return r0, r1
 }
 
+// GetSectionWarehouseId provides a mock function with given fields: ctx, sectionId
+func (_m *Repository) GetSectionWarehouseId(ctx context.Context, sectionId int64) (int64, error) {
+	ret := _m.Called(ctx, sectionId)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, sectionId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, sectionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuarantineExpiredBatches provides a mock function with given fields: ctx, now
+func (_m *Repository) QuarantineExpiredBatches(ctx context.Context, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, product
 func (_m *Repository) Update(ctx context.Context, product *domain.Product) (*domain.Product, error) {
 	ret := _m.Called(ctx, product)
@@ -5,8 +5,12 @@ package mocks
 import (
 	context "context"
 
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
+
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -14,6 +18,29 @@ type Service struct {
 	mock.Mock
 }
 
+// AdjustProductBatch provides a mock function with given fields: ctx, id, adjustment
+func (_m *Service) AdjustProductBatch(ctx context.Context, id int64, adjustment domain.BatchAdjustment) (*domain.ProductBatches, error) {
+	ret := _m.Called(ctx, id, adjustment)
+
+	var r0 *domain.ProductBatches
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.BatchAdjustment) *domain.ProductBatches); ok {
+		r0 = rf(ctx, id, adjustment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProductBatches)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.BatchAdjustment) error); ok {
+		r1 = rf(ctx, id, adjustment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateNewProduct provides a mock function with given fields: ctx, product
 func (_m *Service) CreateNewProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
 	ret := _m.Called(ctx, product)
@@ -58,20 +85,20 @@ func (_m *Service) CreateProductBatches(ctx context.Context, batch *domain.Produ
 	return r0, r1
 }
 
-// CreateProductRecords provides a mock function with given fields: ctx, record
-func (_m *Service) CreateProductRecords(ctx context.Context, record *domain.ProductRecords) (int64, error) {
-	ret := _m.Called(ctx, record)
+// CreateProductRecords provides a mock function with given fields: ctx, record, allowBelowCost
+func (_m *Service) CreateProductRecords(ctx context.Context, record *domain.ProductRecords, allowBelowCost bool) (int64, error) {
+	ret := _m.Called(ctx, record, allowBelowCost)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.ProductRecords) int64); ok {
-		r0 = rf(ctx, record)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ProductRecords, bool) int64); ok {
+		r0 = rf(ctx, record, allowBelowCost)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *domain.ProductRecords) error); ok {
-		r1 = rf(ctx, record)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.ProductRecords, bool) error); ok {
+		r1 = rf(ctx, record, allowBelowCost)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -93,27 +120,78 @@ func (_m *Service) Delete(ctx context.Context, id int64) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields: ctx
-func (_m *Service) GetAll(ctx context.Context) (*[]domain.Product, error) {
-	ret := _m.Called(ctx)
+// DeleteProductBatch provides a mock function with given fields: ctx, id
+func (_m *Service) DeleteProductBatch(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, params
+func (_m *Service) GetAll(ctx context.Context, params listing.Params) (*[]domain.Product, int64, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 *[]domain.Product
-	if rf, ok := ret.Get(0).(func(context.Context) *[]domain.Product); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.Product); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*[]domain.Product)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
 
-	return r0, r1
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAllProductBatches provides a mock function with given fields: ctx, params
+func (_m *Service) GetAllProductBatches(ctx context.Context, params listing.Params) (*[]domain.ProductBatches, int64, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *[]domain.ProductBatches
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.ProductBatches); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.ProductBatches)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // GetById provides a mock function with given fields: ctx, id
@@ -139,6 +217,75 @@ func (_m *Service) GetById(ctx context.Context, id int64) (*domain.Product, erro
 	return r0, r1
 }
 
+// GetExpiringBatches provides a mock function with given fields: ctx, days, warehouseId, sectionId
+func (_m *Service) GetExpiringBatches(ctx context.Context, days int64, warehouseId int64, sectionId int64) (*[]domain.ExpiringBatch, error) {
+	ret := _m.Called(ctx, days, warehouseId, sectionId)
+
+	var r0 *[]domain.ExpiringBatch
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) *[]domain.ExpiringBatch); ok {
+		r0 = rf(ctx, days, warehouseId, sectionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.ExpiringBatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = rf(ctx, days, warehouseId, sectionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPriceAt provides a mock function with given fields: ctx, productId, at
+func (_m *Service) GetPriceAt(ctx context.Context, productId int64, at time.Time) (*domain.ProductPrice, error) {
+	ret := _m.Called(ctx, productId, at)
+
+	var r0 *domain.ProductPrice
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) *domain.ProductPrice); ok {
+		r0 = rf(ctx, productId, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProductPrice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, productId, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPriceHistory provides a mock function with given fields: ctx, productId
+func (_m *Service) GetPriceHistory(ctx context.Context, productId int64) (*[]domain.ProductPrice, error) {
+	ret := _m.Called(ctx, productId)
+
+	var r0 *[]domain.ProductPrice
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *[]domain.ProductPrice); ok {
+		r0 = rf(ctx, productId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.ProductPrice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, productId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProductBatchesById provides a mock function with given fields: ctx, id
 func (_m *Service) GetProductBatchesById(ctx context.Context, id int64) (*domain.ProductBatches, error) {
 	ret := _m.Called(ctx, id)
@@ -277,6 +424,27 @@ func (_m *Service) GetQtyOfRecordsById(ctx context.Context, id int64) (*domain.Q
 	return r0, r1
 }
 
+// QuarantineExpiredBatches provides a mock function with given fields: ctx
+func (_m *Service) QuarantineExpiredBatches(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, product
 func (_m *Service) Update(ctx context.Context, product *domain.Product) (*domain.Product, error) {
 	ret := _m.Called(ctx, product)
@@ -2,6 +2,9 @@ package domain
 
 import (
 	"context"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 )
 
 type Product struct {
@@ -19,8 +22,16 @@ type Product struct {
 	SellerId                       int64   `json:"seller_id"`
 }
 
+var ProductListFields = listing.Fields{
+	Sort: []string{
+		"id", "description", "expiration_rate", "freezing_rate", "net_weight",
+		"product_code", "recommended_freezing_temperature", "product_type_id", "seller_id",
+	},
+	Filter: []string{"seller_id", "product_type_id", "product_code"},
+}
+
 type Repository interface {
-	GetAll(ctx context.Context) (*[]Product, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]Product, int64, error)
 	GetById(ctx context.Context, id int64) (*Product, error)
 	CreateNewProduct(ctx context.Context, product *Product) (*Product, error)
 	Update(ctx context.Context, product *Product) (*Product, error)
@@ -32,31 +43,50 @@ type Repository interface {
 	GetQtyOfRecordsById(ctx context.Context, id int64) (*QtyOfRecords, error)
 	GetQtyOfAllRecords(ctx context.Context) (*[]QtyOfRecords, error)
 
+	GetPriceHistory(ctx context.Context, productId int64) (*[]ProductPrice, error)
+	GetPriceAt(ctx context.Context, productId int64, at time.Time) (*ProductPrice, error)
+
+	GetAllProductBatches(ctx context.Context, params listing.Params) (*[]ProductBatches, int64, error)
 	CreateProductBatches(ctx context.Context, batch *ProductBatches) (int64, error)
 	GetProductBatchesById(ctx context.Context, id int64) (*ProductBatches, error)
+	AdjustProductBatch(ctx context.Context, id int64, adjustment BatchAdjustment) error
+	DeleteProductBatch(ctx context.Context, id int64) error
+	GetSectionWarehouseId(ctx context.Context, sectionId int64) (int64, error)
 
 	GetQtdProductsBySectionId(ctx context.Context, id int64) (*QtdOfProducts, error)
 	GetQtdOfAllProducts(ctx context.Context) (*[]QtdOfProducts, error)
+
+	GetExpiringBatches(ctx context.Context, filter ExpiringBatchesFilter) (*[]ExpiringBatch, error)
+	QuarantineExpiredBatches(ctx context.Context, now time.Time) (int64, error)
 }
 
 type Service interface {
-	GetAll(ctx context.Context) (*[]Product, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]Product, int64, error)
 	GetById(ctx context.Context, id int64) (*Product, error)
 	CreateNewProduct(ctx context.Context, product *Product) (*Product, error)
 	Update(ctx context.Context, product *Product) (*Product, error)
 	Delete(ctx context.Context, id int64) error
 
-	CreateProductRecords(ctx context.Context, record *ProductRecords) (int64, error)
+	CreateProductRecords(ctx context.Context, record *ProductRecords, allowBelowCost bool) (int64, error)
 	GetProductRecordsById(ctx context.Context, id int64) (*ProductRecords, error)
 
 	GetQtyOfRecordsById(ctx context.Context, id int64) (*QtyOfRecords, error)
 	GetQtyOfAllRecords(ctx context.Context) (*[]QtyOfRecords, error)
 
+	GetPriceHistory(ctx context.Context, productId int64) (*[]ProductPrice, error)
+	GetPriceAt(ctx context.Context, productId int64, at time.Time) (*ProductPrice, error)
+
+	GetAllProductBatches(ctx context.Context, params listing.Params) (*[]ProductBatches, int64, error)
 	CreateProductBatches(ctx context.Context, batch *ProductBatches) (int64, error)
 	GetProductBatchesById(ctx context.Context, id int64) (*ProductBatches, error)
+	AdjustProductBatch(ctx context.Context, id int64, adjustment BatchAdjustment) (*ProductBatches, error)
+	DeleteProductBatch(ctx context.Context, id int64) error
 
 	GetQtdProductsBySectionId(ctx context.Context, id int64) (*QtdOfProducts, error)
 	GetQtdOfAllProducts(ctx context.Context) (*[]QtdOfProducts, error)
+
+	GetExpiringBatches(ctx context.Context, days, warehouseId, sectionId int64) (*[]ExpiringBatch, error)
+	QuarantineExpiredBatches(ctx context.Context) (int64, error)
 }
 
 type RequestProducts struct {
@@ -98,10 +128,13 @@ type ProductRecords struct {
 	ProductId      int64   `json:"product_id"`
 }
 
+// RequestProductRecords sets new prices for a product. A sale price below
+// the purchase price is rejected unless AllowBelowCost is set.
 type RequestProductRecords struct {
-	PurchasePrice float64 `json:"purchase_price" binding:"required"`
-	SalePrice     float64 `json:"sale_price" binding:"required"`
-	ProductId     int64   `json:"product_id" binding:"required"`
+	PurchasePrice  float64 `json:"purchase_price" binding:"required"`
+	SalePrice      float64 `json:"sale_price" binding:"required"`
+	ProductId      int64   `json:"product_id" binding:"required"`
+	AllowBelowCost bool    `json:"allow_below_cost"`
 }
 
 type RequestProductRecordId struct {
@@ -113,6 +146,22 @@ type QtyOfRecords struct {
 	RecordsCount int64  `json:"records_count"`
 }
 
+// ProductPrice is a product record seen as a price, in effect from
+// EffectiveFrom until the next record of the product, EffectiveTo, or to
+// this day when it is the latest one.
+type ProductPrice struct {
+	RecordId      int64      `json:"record_id"`
+	ProductId     int64      `json:"product_id"`
+	PurchasePrice float64    `json:"purchase_price"`
+	SalePrice     float64    `json:"sale_price"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+}
+
+type RequestPriceAt struct {
+	At time.Time `form:"at" binding:"required"`
+}
+
 type RequestProductBatches struct {
 	BatchNumber        int64   `json:"batch_number" binding:"required"`
 	CurrentQuantity    int64   `json:"current_quantity" binding:"required"`
@@ -127,6 +176,7 @@ type RequestProductBatches struct {
 }
 
 type ProductBatches struct {
+	Id                 int64   `json:"id"`
 	BatchNumber        int64   `json:"batch_number"`
 	CurrentQuantity    int64   `json:"current_quantity"`
 	CurrentTemperature float64 `json:"current_temperature"`
@@ -139,6 +189,50 @@ type ProductBatches struct {
 	SectionId          int64   `json:"section_id"`
 }
 
+// BatchReceived is the payload of the event published when a batch is
+// received, with the warehouse of its section.
+type BatchReceived struct {
+	ProductBatches
+	WarehouseId int64 `json:"warehouse_id"`
+}
+
+var ProductBatchListFields = listing.Fields{
+	Sort:   []string{"id", "batch_number", "current_quantity", "due_date", "product_id", "section_id"},
+	Filter: []string{"product_id", "section_id", "warehouse_id"},
+	Range:  []string{"due_date"},
+}
+
+type RequestProductBatchId struct {
+	Id int64 `uri:"id" binding:"required,min=1"`
+}
+
+// Reasons accepted when a batch is adjusted by hand.
+const (
+	AdjustmentRecount          = "recount"
+	AdjustmentDamaged          = "damaged"
+	AdjustmentSpoiled          = "spoiled"
+	AdjustmentTemperatureCheck = "temperature_check"
+	AdjustmentCorrection       = "correction"
+	AdjustmentReturned         = "returned"
+)
+
+type RequestProductBatchAdjustment struct {
+	CurrentQuantity    *int64   `json:"current_quantity" binding:"omitempty,min=0"`
+	CurrentTemperature *float64 `json:"current_temperature"`
+	Reason             string   `json:"reason" binding:"required,oneof=recount damaged spoiled temperature_check correction returned"`
+	PurchaseOrderId    *int64   `json:"purchase_order_id" binding:"required_if=Reason returned,omitempty,min=1"`
+}
+
+// BatchAdjustment sets the stock or the temperature of a batch. Nil fields
+// keep their current value. Spoiled stock can only go down and returned
+// stock only up, coming back from PurchaseOrderId.
+type BatchAdjustment struct {
+	CurrentQuantity    *int64
+	CurrentTemperature *float64
+	Reason             string
+	PurchaseOrderId    *int64
+}
+
 type RequestQtdProductsBySectionId struct {
 	Id int64 `form:"id" binding:"required,min=1"`
 }
@@ -147,3 +241,34 @@ type QtdOfProducts struct {
 	SectionNumber int64 `json:"section_number"`
 	ProductsCount int64 `json:"products_count"`
 }
+
+// DefaultExpiringDays is the window used by the expiring report when no days
+// are given.
+const DefaultExpiringDays = 7
+
+type RequestExpiringBatches struct {
+	Days        *int64 `form:"days" binding:"omitempty,min=0,max=365"`
+	WarehouseId int64  `form:"warehouse_id" binding:"omitempty,min=1"`
+	SectionId   int64  `form:"section_id" binding:"omitempty,min=1"`
+}
+
+// ExpiringBatchesFilter selects the batches with stock whose due date is
+// before Until. Zero ids are not filtered.
+type ExpiringBatchesFilter struct {
+	Until       time.Time
+	WarehouseId int64
+	SectionId   int64
+}
+
+type ExpiringBatch struct {
+	Id              int64     `json:"id"`
+	BatchNumber     int64     `json:"batch_number"`
+	ProductId       int64     `json:"product_id"`
+	SectionId       int64     `json:"section_id"`
+	WarehouseId     int64     `json:"warehouse_id"`
+	CurrentQuantity int64     `json:"current_quantity"`
+	DueDate         time.Time `json:"due_date"`
+	DaysToExpire    int64     `json:"days_to_expire"`
+	Expired         bool      `json:"expired"`
+	Quarantined     bool      `json:"quarantined"`
+}
@@ -1,9 +1,18 @@
 package domain
 
 import (
-	"errors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
 )
 
 var (
-	ErrIDNotFound = errors.New("product id not found")
+	ErrIDNotFound              = apperrors.NotFound("product id not found")
+	ErrSectionNotFound         = apperrors.NotFound("section not found")
+	ErrSectionCapacityExceeded = apperrors.Conflict("section maximum capacity exceeded")
+	ErrProductTypeMismatch     = apperrors.Conflict("product type does not match the section")
+	ErrBatchNotFound           = apperrors.NotFound("product batch not found")
+	ErrBelowReserved           = apperrors.Conflict("quantity is below the reserved stock")
+	ErrBatchReferenced         = apperrors.Conflict("product batch is referenced by orders or transfers")
+	ErrInvalidAdjustment       = apperrors.Validation("quantity change does not match the adjustment reason")
+	ErrSalePriceBelowCost      = apperrors.Validation("sale price is below the purchase price")
+	ErrNoPriceAt               = apperrors.NotFound("product has no price at that date")
 )
@@ -4,7 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-
+	"fmt"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	auditlog "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	outbox "github.com/marcoglnd/mercado-fresco-packmain/internal/events/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	movements "github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+	ledger "github.com/marcoglnd/mercado-fresco-packmain/internal/movements/repository/mariadb"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
 )
 
@@ -14,12 +24,19 @@ func NewMariaDBRepository(db *sql.DB) domain.Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) GetAll(ctx context.Context) (*[]domain.Product, error) {
+func (r *repository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Product, int64, error) {
 	products := []domain.Product{}
 
-	rows, err := r.db.QueryContext(ctx, sqlGetAllProducts)
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAllProducts, params, nil)
+	if err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &products, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAllProducts, params, nil)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return &products, err
+		return &products, 0, err
 	}
 
 	defer rows.Close()
@@ -41,13 +58,13 @@ func (r *repository) GetAll(ctx context.Context) (*[]domain.Product, error) {
 			&product.ProductTypeId,
 			&product.SellerId,
 		); err != nil {
-			return &products, err
+			return &products, 0, err
 		}
 
 		products = append(products, product)
 	}
 
-	return &products, nil
+	return &products, total, nil
 }
 
 func (r *repository) GetById(ctx context.Context, id int64) (*domain.Product, error) {
@@ -94,7 +111,14 @@ func (r *repository) CreateNewProduct(ctx context.Context, product *domain.Produ
 		ProductTypeId:                  product.ProductTypeId,
 		SellerId:                       product.SellerId,
 	}
-	result, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &newProduct, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
 		ctx,
 		sqlInsertProduct,
 		&newProduct.Description,
@@ -110,14 +134,17 @@ func (r *repository) CreateNewProduct(ctx context.Context, product *domain.Produ
 		&newProduct.SellerId,
 	)
 	if err != nil {
-		return &newProduct, err
+		return &newProduct, apperrors.FromMySQL(err)
 	}
 	insertedId, err := result.LastInsertId()
 	if err != nil {
 		return &newProduct, err
 	}
 	newProduct.Id = insertedId
-	return &newProduct, nil
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "products", insertedId, nil); err != nil {
+		return &newProduct, err
+	}
+	return &newProduct, tx.Commit()
 }
 
 func (r *repository) Update(ctx context.Context, product *domain.Product) (*domain.Product, error) {
@@ -136,7 +163,19 @@ func (r *repository) Update(ctx context.Context, product *domain.Product) (*doma
 		SellerId:                       product.SellerId,
 	}
 
-	result, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &newProduct, err
+	}
+
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "products", newProduct.Id)
+	if err != nil {
+		return &newProduct, err
+	}
+
+	result, err := tx.ExecContext(
 		ctx,
 		sqlUpdateProduct,
 		&newProduct.Description,
@@ -153,7 +192,7 @@ func (r *repository) Update(ctx context.Context, product *domain.Product) (*doma
 		&newProduct.Id,
 	)
 	if err != nil {
-		return &newProduct, err
+		return &newProduct, apperrors.FromMySQL(err)
 	}
 
 	affectedRows, err := result.RowsAffected()
@@ -165,15 +204,31 @@ func (r *repository) Update(ctx context.Context, product *domain.Product) (*doma
 		return &newProduct, err
 	}
 
-	return product, nil
+	if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "products", newProduct.Id, before); err != nil {
+		return &newProduct, err
+	}
+
+	return product, tx.Commit()
 }
 
 func (r *repository) Delete(ctx context.Context, id int64) error {
-	result, err := r.db.ExecContext(ctx, sqlDeleteProduct, id)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "products", id)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, sqlDeleteProduct, id)
+	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
 	affectedRows, err := result.RowsAffected()
 
 	if affectedRows == 0 {
@@ -184,7 +239,11 @@ func (r *repository) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
-	return nil
+	if err := auditlog.Record(ctx, tx, audit.ActionDelete, "products", id, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *repository) CreateProductRecords(ctx context.Context, record *domain.ProductRecords) (int64, error) {
@@ -193,7 +252,14 @@ func (r *repository) CreateProductRecords(ctx context.Context, record *domain.Pr
 		SalePrice:     record.SalePrice,
 		ProductId:     record.ProductId,
 	}
-	result, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
 		ctx,
 		sqlCreateRecord,
 		&newRecord.PurchasePrice,
@@ -201,12 +267,18 @@ func (r *repository) CreateProductRecords(ctx context.Context, record *domain.Pr
 		&newRecord.ProductId,
 	)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromMySQL(err)
 	}
 	insertedId, err := result.LastInsertId()
 	if err != nil {
 		return 0, err
 	}
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "product_records", insertedId, nil); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
 	return insertedId, nil
 }
 
@@ -232,6 +304,71 @@ func (r *repository) GetProductRecordsById(ctx context.Context, id int64) (*doma
 	return &record, nil
 }
 
+// GetPriceHistory returns the prices of a product from the oldest to the
+// latest.
+func (r *repository) GetPriceHistory(ctx context.Context, productId int64) (*[]domain.ProductPrice, error) {
+	prices := []domain.ProductPrice{}
+
+	rows, err := r.db.QueryContext(ctx, sqlGetPriceHistory, productId)
+	if err != nil {
+		return &prices, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		price, err := scanPrice(rows)
+		if err != nil {
+			return &prices, err
+		}
+
+		prices = append(prices, *price)
+	}
+
+	return &prices, rows.Err()
+}
+
+// GetPriceAt returns the price of a product in effect at the given moment,
+// or nil when the product had no price yet.
+func (r *repository) GetPriceAt(ctx context.Context, productId int64, at time.Time) (*domain.ProductPrice, error) {
+	price, err := scanPrice(r.db.QueryRowContext(ctx, sqlGetPriceAt, productId, at))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return price, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPrice(row scanner) (*domain.ProductPrice, error) {
+	var price domain.ProductPrice
+	var effectiveTo sql.NullTime
+
+	if err := row.Scan(
+		&price.RecordId,
+		&price.ProductId,
+		&price.PurchasePrice,
+		&price.SalePrice,
+		&price.EffectiveFrom,
+		&effectiveTo,
+	); err != nil {
+		return nil, err
+	}
+
+	if effectiveTo.Valid {
+		price.EffectiveTo = &effectiveTo.Time
+	}
+
+	return &price, nil
+}
+
 func (r *repository) GetQtyOfRecordsById(ctx context.Context, id int64) (*domain.QtyOfRecords, error) {
 	row := r.db.QueryRowContext(ctx, sqlGetQtyOfRecordsById, id)
 
@@ -253,40 +390,111 @@ func (r *repository) GetQtyOfRecordsById(ctx context.Context, id int64) (*domain
 	return &report, nil
 }
 
+func (r *repository) GetAllProductBatches(ctx context.Context, params listing.Params) (*[]domain.ProductBatches, int64, error) {
+	batches := []domain.ProductBatches{}
+
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAllBatches, params, batchColumns)
+	if err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &batches, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAllBatches, params, batchColumns)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return &batches, 0, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var batch domain.ProductBatches
+
+		if err := rows.Scan(
+			&batch.Id,
+			&batch.BatchNumber,
+			&batch.CurrentQuantity,
+			&batch.CurrentTemperature,
+			&batch.DueDate,
+			&batch.InitialQuantity,
+			&batch.ManufacturingDate,
+			&batch.ManufacturingHour,
+			&batch.MinimumTemperature,
+			&batch.ProductId,
+			&batch.SectionId,
+		); err != nil {
+			return &batches, 0, err
+		}
+
+		batches = append(batches, batch)
+	}
+
+	return &batches, total, rows.Err()
+}
+
+// CreateProductBatches places the batch in its section, taking its quantity
+// from the section capacity, opens its ledger with an inbound receipt and
+// publishes that the batch was received, all in the same transaction.
 func (r *repository) CreateProductBatches(ctx context.Context, batch *domain.ProductBatches) (int64, error) {
-	newBatch := domain.ProductBatches{
-		BatchNumber:        batch.BatchNumber,
-		CurrentQuantity:    batch.CurrentQuantity,
-		CurrentTemperature: batch.CurrentTemperature,
-		DueDate:            batch.DueDate,
-		InitialQuantity:    batch.InitialQuantity,
-		ManufacturingDate:  batch.ManufacturingDate,
-		ManufacturingHour:  batch.ManufacturingHour,
-		MinimumTemperature: batch.MinimumTemperature,
-		ProductId:          batch.ProductId,
-		SectionId:          batch.SectionId,
-	}
-	result, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	if err := PlaceInSection(ctx, tx, batch.SectionId, batch.ProductId, batch.CurrentQuantity); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(
 		ctx,
 		sqlCreateBatch,
-		&newBatch.BatchNumber,
-		&newBatch.CurrentQuantity,
-		&newBatch.CurrentTemperature,
-		&newBatch.DueDate,
-		&newBatch.InitialQuantity,
-		&newBatch.ManufacturingDate,
-		&newBatch.ManufacturingHour,
-		&newBatch.MinimumTemperature,
-		&newBatch.ProductId,
-		&newBatch.SectionId,
+		batch.BatchNumber,
+		batch.CurrentQuantity,
+		batch.CurrentTemperature,
+		batch.DueDate,
+		batch.InitialQuantity,
+		batch.ManufacturingDate,
+		batch.ManufacturingHour,
+		batch.MinimumTemperature,
+		batch.ProductId,
+		batch.SectionId,
 	)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromMySQL(err)
 	}
 	insertedId, err := result.LastInsertId()
 	if err != nil {
 		return 0, err
 	}
+
+	if err := ledger.Record(ctx, tx, movements.Movement{
+		ProductBatchId: insertedId,
+		Type:           movements.TypeInboundReceipt,
+		Delta:          batch.CurrentQuantity,
+		Balance:        batch.CurrentQuantity,
+	}); err != nil {
+		return 0, err
+	}
+
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "product_batches", insertedId, nil); err != nil {
+		return 0, err
+	}
+
+	received := domain.BatchReceived{ProductBatches: *batch}
+	received.Id = insertedId
+	if err := tx.QueryRowContext(ctx, sqlGetSectionWarehouseId, batch.SectionId).Scan(&received.WarehouseId); err != nil {
+		return 0, err
+	}
+	if err := outbox.Publish(ctx, tx, events.TypeBatchReceived, "product_batches", insertedId, received); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
 	return insertedId, nil
 }
 
@@ -296,6 +504,7 @@ func (r *repository) GetProductBatchesById(ctx context.Context, id int64) (*doma
 	batch := domain.ProductBatches{}
 
 	err := row.Scan(
+		&batch.Id,
 		&batch.BatchNumber,
 		&batch.CurrentQuantity,
 		&batch.CurrentTemperature,
@@ -308,7 +517,7 @@ func (r *repository) GetProductBatchesById(ctx context.Context, id int64) (*doma
 		&batch.SectionId,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
-		return &batch, domain.ErrIDNotFound
+		return &batch, domain.ErrBatchNotFound
 	}
 
 	if err != nil {
@@ -318,6 +527,161 @@ func (r *repository) GetProductBatchesById(ctx context.Context, id int64) (*doma
 	return &batch, nil
 }
 
+// AdjustProductBatch sets the quantity and temperature of a batch and logs
+// the reason. The quantity difference is moved in or out of the section
+// capacity and recorded in the batch ledger, and the quantity cannot go
+// below what orders have reserved.
+func (r *repository) AdjustProductBatch(ctx context.Context, id int64, adjustment domain.BatchAdjustment) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var quantity, reserved, sectionId int64
+	var temperature float64
+	err = tx.QueryRowContext(ctx, sqlLockBatch, id).Scan(&quantity, &reserved, &temperature, &sectionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrBatchNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	before, err := auditlog.Snapshot(ctx, tx, "product_batches", id)
+	if err != nil {
+		return err
+	}
+
+	newQuantity, newTemperature := quantity, temperature
+	if adjustment.CurrentQuantity != nil {
+		newQuantity = *adjustment.CurrentQuantity
+	}
+	if adjustment.CurrentTemperature != nil {
+		newTemperature = *adjustment.CurrentTemperature
+	}
+
+	if newQuantity < reserved {
+		return fmt.Errorf("%w: %d reserved, %d requested", domain.ErrBelowReserved, reserved, newQuantity)
+	}
+
+	delta := newQuantity - quantity
+	if adjustment.Reason == domain.AdjustmentSpoiled && delta > 0 ||
+		adjustment.Reason == domain.AdjustmentReturned && delta < 0 {
+		return fmt.Errorf("%w: %s moving %d", domain.ErrInvalidAdjustment, adjustment.Reason, delta)
+	}
+
+	if delta != 0 {
+		if err := ResizeInSection(ctx, tx, sectionId, delta); err != nil {
+			return err
+		}
+
+		if err := ledger.Record(ctx, tx, movements.Movement{
+			ProductBatchId:  id,
+			Type:            adjustmentMovement(adjustment.Reason),
+			Delta:           delta,
+			Balance:         newQuantity,
+			PurchaseOrderId: adjustment.PurchaseOrderId,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlAdjustBatch, newQuantity, newTemperature, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		sqlInsertBatchAdjustment,
+		id,
+		adjustment.Reason,
+		quantity,
+		newQuantity,
+		temperature,
+		newTemperature,
+	); err != nil {
+		return err
+	}
+
+	if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "product_batches", id, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteProductBatch removes a batch no order or transfer refers to,
+// giving its stock back to the section capacity. Its ledger is closed with
+// a write-off of what the batch still holds, and outlives the batch.
+func (r *repository) DeleteProductBatch(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var quantity, reserved, sectionId int64
+	var temperature float64
+	err = tx.QueryRowContext(ctx, sqlLockBatch, id).Scan(&quantity, &reserved, &temperature, &sectionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrBatchNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	before, err := auditlog.Snapshot(ctx, tx, "product_batches", id)
+	if err != nil {
+		return err
+	}
+
+	var referenced bool
+	if err := tx.QueryRowContext(ctx, sqlBatchReferenced, id, id, id, id).Scan(&referenced); err != nil {
+		return err
+	}
+
+	if referenced {
+		return domain.ErrBatchReferenced
+	}
+
+	if err := ResizeInSection(ctx, tx, sectionId, -quantity); err != nil {
+		return err
+	}
+
+	if err := ledger.Record(ctx, tx, movements.Movement{
+		ProductBatchId: id,
+		Type:           movements.TypeWriteOff,
+		Delta:          -quantity,
+		Balance:        0,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlDeleteBatch, id); err != nil {
+		return err
+	}
+
+	if err := auditlog.Record(ctx, tx, audit.ActionDelete, "product_batches", id, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetSectionWarehouseId returns the warehouse a section belongs to.
+func (r *repository) GetSectionWarehouseId(ctx context.Context, sectionId int64) (int64, error) {
+	var warehouseId int64
+	err := r.db.QueryRowContext(ctx, sqlGetSectionWarehouseId, sectionId).Scan(&warehouseId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, domain.ErrSectionNotFound
+	}
+
+	return warehouseId, err
+}
+
 func (r *repository) GetQtyOfAllRecords(ctx context.Context) (*[]domain.QtyOfRecords, error) {
 	reports := []domain.QtyOfRecords{}
 
@@ -392,3 +756,117 @@ func (r *repository) GetQtdOfAllProducts(ctx context.Context) (*[]domain.QtdOfPr
 
 	return &reports, nil
 }
+
+func (r *repository) GetExpiringBatches(ctx context.Context, filter domain.ExpiringBatchesFilter) (*[]domain.ExpiringBatch, error) {
+	batches := []domain.ExpiringBatch{}
+
+	query := sqlGetExpiringBatches
+	args := []interface{}{filter.Until}
+
+	if filter.WarehouseId > 0 {
+		query += " AND s.warehouse_id = ?"
+		args = append(args, filter.WarehouseId)
+	}
+
+	if filter.SectionId > 0 {
+		query += " AND b.section_id = ?"
+		args = append(args, filter.SectionId)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query+" ORDER BY b.due_date, b.id;", args...)
+	if err != nil {
+		return &batches, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var batch domain.ExpiringBatch
+
+		if err := rows.Scan(
+			&batch.Id,
+			&batch.BatchNumber,
+			&batch.ProductId,
+			&batch.SectionId,
+			&batch.WarehouseId,
+			&batch.CurrentQuantity,
+			&batch.DueDate,
+			&batch.Quarantined,
+		); err != nil {
+			return &batches, err
+		}
+
+		batches = append(batches, batch)
+	}
+
+	return &batches, rows.Err()
+}
+
+// QuarantineExpiredBatches flags every batch due at or before now, returning
+// how many were newly quarantined. Each batch is flagged on its own so that
+// the audit log gets an update for it.
+func (r *repository) QuarantineExpiredBatches(ctx context.Context, now time.Time) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	ids, err := lockExpiredBatches(ctx, tx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		before, err := auditlog.Snapshot(ctx, tx, "product_batches", id)
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err := tx.ExecContext(ctx, sqlQuarantineBatch, now, id); err != nil {
+			return 0, err
+		}
+
+		if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "product_batches", id, before); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int64(len(ids)), nil
+}
+
+func lockExpiredBatches(ctx context.Context, tx *sql.Tx, now time.Time) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, sqlLockExpiredBatches, now)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func adjustmentMovement(reason string) string {
+	switch reason {
+	case domain.AdjustmentSpoiled:
+		return movements.TypeSpoilage
+	case domain.AdjustmentReturned:
+		return movements.TypeReturn
+	default:
+		return movements.TypeAdjustment
+	}
+}
@@ -6,33 +6,78 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/actor"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/eventstest"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	movements "github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	queryInsertProduct  = regexp.QuoteMeta(sqlInsertProduct)
-	queryGetAllProducts = regexp.QuoteMeta(sqlGetAllProducts)
-	queryGetProductById = regexp.QuoteMeta(sqlGetProductById)
-	queryUpdateProduct  = regexp.QuoteMeta(sqlUpdateProduct)
-	queryDeleteProduct  = regexp.QuoteMeta(sqlDeleteProduct)
+	queryInsertProduct    = regexp.QuoteMeta(sqlInsertProduct)
+	queryGetAllProducts   = regexp.QuoteMeta(sqlGetAllProducts)
+	queryCountAllProducts = regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAllProducts)
+	queryGetProductById   = regexp.QuoteMeta(sqlGetProductById)
+	queryUpdateProduct    = regexp.QuoteMeta(sqlUpdateProduct)
+	queryDeleteProduct    = regexp.QuoteMeta(sqlDeleteProduct)
 
 	queryInsertRecord   = regexp.QuoteMeta(sqlCreateRecord)
 	queryGetRecordsById = regexp.QuoteMeta(sqlGetRecord)
 
+	queryGetPriceHistory = regexp.QuoteMeta(sqlGetPriceHistory)
+	queryGetPriceAt      = regexp.QuoteMeta(sqlGetPriceAt)
+
 	queryGetQtyOfRecordsById = regexp.QuoteMeta(sqlGetQtyOfRecordsById)
 	queryGetQtyOfAllRecords  = regexp.QuoteMeta(sqlGetQtyOfRecords)
 
 	queryInsertBatch    = regexp.QuoteMeta(sqlCreateBatch)
 	queryGetBatchesById = regexp.QuoteMeta(sqlGetBatch)
 
+	queryGetAllBatches         = regexp.QuoteMeta(sqlGetAllBatches)
+	queryCountAllBatches       = regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAllBatches)
+	queryLockBatch             = regexp.QuoteMeta(sqlLockBatch)
+	queryAdjustBatch           = regexp.QuoteMeta(sqlAdjustBatch)
+	queryInsertBatchAdjustment = regexp.QuoteMeta(sqlInsertBatchAdjustment)
+	queryBatchReferenced       = regexp.QuoteMeta(sqlBatchReferenced)
+	queryDeleteBatch           = regexp.QuoteMeta(sqlDeleteBatch)
+	queryGetSectionWarehouseId = regexp.QuoteMeta(sqlGetSectionWarehouseId)
+
+	queryGetLastBalance = regexp.QuoteMeta("SELECT balance FROM stock_movements WHERE product_batch_id = ?")
+	queryInsertMovement = regexp.QuoteMeta("INSERT INTO stock_movements")
+
+	queryLockSection          = regexp.QuoteMeta(sqlLockSection)
+	queryGetProductTypeId     = regexp.QuoteMeta(sqlGetProductTypeId)
+	queryAddToSectionCapacity = regexp.QuoteMeta(sqlAddToSectionCapacity)
+
 	queryGetQtdProductsBySectionId = regexp.QuoteMeta(sqlGetQtdProductsBySectionId)
-	queryGetQtdProductsInSection  = regexp.QuoteMeta(sqlGetQtdProductsInSection)
+	queryGetQtdProductsInSection   = regexp.QuoteMeta(sqlGetQtdProductsInSection)
+
+	queryGetExpiringBatches = regexp.QuoteMeta(sqlGetExpiringBatches)
+	queryLockExpiredBatches = regexp.QuoteMeta(sqlLockExpiredBatches)
+	queryQuarantineBatch    = regexp.QuoteMeta(sqlQuarantineBatch)
 )
 
+var rowsExpiringBatchStruct = []string{
+	"id",
+	"batch_number",
+	"product_id",
+	"section_id",
+	"warehouse_id",
+	"current_quantity",
+	"due_date",
+	"quarantined",
+}
+
 var rowsProductStruct = []string{
 	"id",
 	"description",
@@ -62,6 +107,7 @@ var rowsQtyOfRecordsStruct = []string{
 }
 
 var rowsProductBatchesStruct = []string{
+	"id",
 	"batch_number",
 	"current_quantity",
 	"current_temperature",
@@ -88,6 +134,7 @@ func TestCreateNewProduct(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsertProduct).
 			WithArgs(
 				mockProduct.Description,
@@ -102,6 +149,8 @@ func TestCreateNewProduct(t *testing.T) {
 				mockProduct.ProductTypeId,
 				mockProduct.SellerId,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "products", 1)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 
@@ -116,6 +165,7 @@ func TestCreateNewProduct(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsertProduct).
 			WithArgs(0, 0, 0, 0, 0, 0, 0, 0).
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -125,6 +175,41 @@ func TestCreateNewProduct(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+
+	t.Run("unknown seller", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsertProduct).
+			WillReturnError(&mysql.MySQLError{
+				Number:  1452,
+				Message: "Cannot add or update a child row: a foreign key constraint fails (`mercado_fresco`.`products`, CONSTRAINT `products_ibfk_2` FOREIGN KEY (`seller_id`) REFERENCES `sellers` (`id`))",
+			})
+
+		repo := NewMariaDBRepository(db)
+		_, err = repo.CreateNewProduct(context.Background(), &mockProduct)
+
+		assert.ErrorIs(t, err, apperrors.ErrForeignKey)
+		assert.EqualError(t, err, "seller_id: no sellers with that id")
+	})
+
+	t.Run("duplicated product code", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsertProduct).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'PRD-1' for key 'product_code'"})
+
+		repo := NewMariaDBRepository(db)
+		_, err = repo.CreateNewProduct(context.Background(), &mockProduct)
+
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		assert.EqualError(t, err, `product_code "PRD-1" is already in use`)
+	})
 }
 
 func TestGetAll(t *testing.T) {
@@ -153,12 +238,14 @@ func TestGetAll(t *testing.T) {
 			)
 		}
 
+		mock.ExpectQuery(queryCountAllProducts).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(mockProducts)))
 		mock.ExpectQuery(queryGetAllProducts).WillReturnRows(rows)
 
 		productsRepo := NewMariaDBRepository(db)
 
-		result, err := productsRepo.GetAll(context.Background())
+		result, total, err := productsRepo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 		assert.NoError(t, err)
+		assert.Equal(t, int64(len(mockProducts)), total)
 
 		assert.Equal(t, result, &mockProducts)
 	})
@@ -170,11 +257,12 @@ func TestGetAll(t *testing.T) {
 
 		rows := sqlmock.NewRows(rowsProductStruct).AddRow("", "", "", "", "", "", "", "", "", "", "", "")
 
+		mock.ExpectQuery(queryCountAllProducts).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(queryGetAllProducts).WillReturnRows(rows)
 
 		productsRepo := NewMariaDBRepository(db)
 
-		_, err = productsRepo.GetAll(context.Background())
+		_, _, err = productsRepo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 		assert.Error(t, err)
 	})
 
@@ -183,11 +271,12 @@ func TestGetAll(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectQuery(queryCountAllProducts).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(queryGetAllProducts).WillReturnError(sql.ErrNoRows)
 
 		productsRepo := NewMariaDBRepository(db)
 
-		_, err = productsRepo.GetAll(context.Background())
+		_, _, err = productsRepo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 		assert.Error(t, err)
 	})
 }
@@ -262,6 +351,8 @@ func TestUpdateProduct(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "products", mockProduct.Id)
 		mock.ExpectExec(queryUpdateProduct).
 			WithArgs(
 				mockProduct.Description,
@@ -277,6 +368,8 @@ func TestUpdateProduct(t *testing.T) {
 				mockProduct.SellerId,
 				mockProduct.Id,
 			).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "products", mockProduct.Id)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 
@@ -291,6 +384,8 @@ func TestUpdateProduct(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "products", mockProduct.Id)
 		mock.ExpectExec(queryUpdateProduct).
 			WithArgs(0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0).
 			WillReturnResult(sqlmock.NewResult(0, 1))
@@ -305,6 +400,8 @@ func TestUpdateProduct(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "products", mockProduct.Id)
 		mock.ExpectExec(queryUpdateProduct).
 			WithArgs(
 				mockProduct.Description,
@@ -337,10 +434,14 @@ func TestDeleteProduct(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "products", mockProduct.Id)
 		mock.ExpectExec(queryDeleteProduct).
 			WithArgs(
 				mockProduct.Id,
 			).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectEntry(mock, audit.ActionDelete, "products", mockProduct.Id)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 
@@ -353,6 +454,8 @@ func TestDeleteProduct(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "products", mockProduct.Id)
 		mock.ExpectExec(queryDeleteProduct).
 			WithArgs(0).
 			WillReturnResult(sqlmock.NewResult(0, 1))
@@ -367,6 +470,8 @@ func TestDeleteProduct(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "products", mockProduct.Id)
 		mock.ExpectExec(queryDeleteProduct).
 			WithArgs(mockProduct.Id).
 			WillReturnResult(sqlmock.NewResult(0, 0))
@@ -386,12 +491,15 @@ func TestCreateProductRecords(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsertRecord).
 			WithArgs(
 				mockProductRecords.PurchasePrice,
 				mockProductRecords.SalePrice,
 				mockProductRecords.ProductId,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "product_records", 1)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 
@@ -406,6 +514,7 @@ func TestCreateProductRecords(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsertRecord).
 			WithArgs(0, 0, 0).
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -471,6 +580,75 @@ func TestGetProductRecordsById(t *testing.T) {
 	})
 }
 
+var rowsPriceStruct = []string{"id", "product_id", "purchase_price", "sale_price", "last_update_date", "effective_to"}
+
+func TestGetPriceHistory(t *testing.T) {
+	first := time.Date(2022, 5, 1, 9, 0, 0, 0, time.UTC)
+	second := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetPriceHistory).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows(rowsPriceStruct).
+			AddRow(1, 1, 8.5, 10.0, first, second).
+			AddRow(2, 1, 9.0, 11.5, second, nil))
+
+		prices, err := NewMariaDBRepository(db).GetPriceHistory(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ProductPrice{
+			{RecordId: 1, ProductId: 1, PurchasePrice: 8.5, SalePrice: 10, EffectiveFrom: first, EffectiveTo: &second},
+			{RecordId: 2, ProductId: 1, PurchasePrice: 9, SalePrice: 11.5, EffectiveFrom: second},
+		}, *prices)
+	})
+
+	t.Run("fail to select prices", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetPriceHistory).WillReturnError(sql.ErrConnDone)
+
+		_, err = NewMariaDBRepository(db).GetPriceHistory(context.Background(), 1)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetPriceAt(t *testing.T) {
+	from := time.Date(2022, 5, 1, 9, 0, 0, 0, time.UTC)
+	at := time.Date(2022, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetPriceAt).WithArgs(int64(1), at).
+			WillReturnRows(sqlmock.NewRows(rowsPriceStruct).AddRow(1, 1, 8.5, 10.0, from, nil))
+
+		price, err := NewMariaDBRepository(db).GetPriceAt(context.Background(), 1, at)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.ProductPrice{RecordId: 1, ProductId: 1, PurchasePrice: 8.5, SalePrice: 10, EffectiveFrom: from}, price)
+	})
+
+	t.Run("no price yet", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetPriceAt).WithArgs(int64(1), at).WillReturnError(sql.ErrNoRows)
+
+		price, err := NewMariaDBRepository(db).GetPriceAt(context.Background(), 1, at)
+
+		assert.NoError(t, err)
+		assert.Nil(t, price)
+	})
+}
+
 func TestGetQtyOfRecordsById(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
@@ -583,11 +761,30 @@ func TestGetQtyOfAllRecords(t *testing.T) {
 func TestCreateProductBatches(t *testing.T) {
 	mockProductBatches := utils.CreateRandomProductBatches()
 
+	lockSection := func(mock sqlmock.Sqlmock, currentCapacity, maximumCapacity, productTypeId int64) {
+		mock.ExpectQuery(queryLockSection).
+			WithArgs(mockProductBatches.SectionId).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity", "product_type_id"}).
+				AddRow(currentCapacity, maximumCapacity, productTypeId))
+	}
+
+	productType := func(mock sqlmock.Sqlmock, productTypeId int64) {
+		mock.ExpectQuery(queryGetProductTypeId).
+			WithArgs(mockProductBatches.ProductId).
+			WillReturnRows(sqlmock.NewRows([]string{"product_type_id"}).AddRow(productTypeId))
+	}
+
 	t.Run("success", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		lockSection(mock, 10, 10+mockProductBatches.CurrentQuantity, 3)
+		productType(mock, 3)
+		mock.ExpectExec(queryAddToSectionCapacity).
+			WithArgs(mockProductBatches.CurrentQuantity, mockProductBatches.SectionId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
 		mock.ExpectExec(queryInsertBatch).
 			WithArgs(
 				mockProductBatches.BatchNumber,
@@ -601,6 +798,25 @@ func TestCreateProductBatches(t *testing.T) {
 				mockProductBatches.ProductId,
 				mockProductBatches.SectionId,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(queryGetLastBalance).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"balance"}))
+		mock.ExpectExec(queryInsertMovement).
+			WithArgs(
+				1,
+				movements.TypeInboundReceipt,
+				mockProductBatches.CurrentQuantity,
+				mockProductBatches.CurrentQuantity,
+				actor.System,
+				nil,
+				nil,
+				nil,
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "product_batches", 1)
+		mock.ExpectQuery(queryGetSectionWarehouseId).WithArgs(mockProductBatches.SectionId).
+			WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(2))
+		received := domain.BatchReceived{ProductBatches: mockProductBatches, WarehouseId: 2}
+		received.Id = 1
+		eventstest.ExpectPublishPayload(mock, events.TypeBatchReceived, "product_batches", 1, received)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 
@@ -608,6 +824,56 @@ func TestCreateProductBatches(t *testing.T) {
 		assert.NoError(t, err)
 
 		assert.True(t, fmt.Sprintf("%T", batchId) == "int64" && batchId > 0)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("section capacity exceeded", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		lockSection(mock, 10, 9+mockProductBatches.CurrentQuantity, 3)
+		productType(mock, 3)
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+		_, err = repo.CreateProductBatches(context.Background(), &mockProductBatches)
+
+		assert.ErrorIs(t, err, domain.ErrSectionCapacityExceeded)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("product type mismatch", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		lockSection(mock, 0, 10+mockProductBatches.CurrentQuantity, 3)
+		productType(mock, 4)
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+		_, err = repo.CreateProductBatches(context.Background(), &mockProductBatches)
+
+		assert.ErrorIs(t, err, domain.ErrProductTypeMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("section not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockSection).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+		_, err = repo.CreateProductBatches(context.Background(), &mockProductBatches)
+
+		assert.ErrorIs(t, err, domain.ErrSectionNotFound)
 	})
 
 	t.Run("failed to create product batch", func(t *testing.T) {
@@ -615,14 +881,18 @@ func TestCreateProductBatches(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
-		mock.ExpectExec(queryInsertBatch).
-			WithArgs(0, 0, 0, 0, 0, 0, 0).
-			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectBegin()
+		lockSection(mock, 0, mockProductBatches.CurrentQuantity, 3)
+		productType(mock, 3)
+		mock.ExpectExec(queryAddToSectionCapacity).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryInsertBatch).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
 
 		repo := NewMariaDBRepository(db)
 		_, err = repo.CreateProductBatches(context.Background(), &mockProductBatches)
 
 		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
 
@@ -635,6 +905,7 @@ func TestGetProductBatchesById(t *testing.T) {
 		mockProductBatches := utils.CreateRandomProductBatches()
 
 		rows := sqlmock.NewRows(rowsProductBatchesStruct).AddRow(
+			mockProductBatches.Id,
 			mockProductBatches.BatchNumber,
 			mockProductBatches.CurrentQuantity,
 			mockProductBatches.CurrentTemperature,
@@ -662,7 +933,7 @@ func TestGetProductBatchesById(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
-		rows := sqlmock.NewRows(rowsProductBatchesStruct).AddRow("", "", "", "", "", "", "", "", "", "")
+		rows := sqlmock.NewRows(rowsProductBatchesStruct).AddRow("", "", "", "", "", "", "", "", "", "", "")
 
 		mock.ExpectQuery(queryGetBatchesById).WillReturnRows(rows)
 
@@ -682,10 +953,376 @@ func TestGetProductBatchesById(t *testing.T) {
 		productsRepo := NewMariaDBRepository(db)
 
 		_, err = productsRepo.GetProductBatchesById(context.Background(), 0)
+		assert.ErrorIs(t, err, domain.ErrBatchNotFound)
+	})
+}
+
+func TestGetAllProductBatches(t *testing.T) {
+	params := listing.Params{
+		Limit: 10,
+		Filters: []listing.Filter{
+			{Field: "warehouse_id", Value: "2"},
+			{Field: "due_date", Op: ">=", Value: "2022-01-01"},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mockProductBatches := utils.CreateRandomProductBatches()
+
+		rows := sqlmock.NewRows(rowsProductBatchesStruct).AddRow(
+			mockProductBatches.Id,
+			mockProductBatches.BatchNumber,
+			mockProductBatches.CurrentQuantity,
+			mockProductBatches.CurrentTemperature,
+			mockProductBatches.DueDate,
+			mockProductBatches.InitialQuantity,
+			mockProductBatches.ManufacturingDate,
+			mockProductBatches.ManufacturingHour,
+			mockProductBatches.MinimumTemperature,
+			mockProductBatches.ProductId,
+			mockProductBatches.SectionId,
+		)
+
+		mock.ExpectQuery(queryCountAllBatches+".*s.warehouse_id = \\? AND b.due_date >= \\?").
+			WithArgs("2", "2022-01-01").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(queryGetAllBatches+".*ORDER BY b.id ASC LIMIT").
+			WithArgs("2", "2022-01-01", int64(10), int64(0)).
+			WillReturnRows(rows)
+
+		productsRepo := NewMariaDBRepository(db)
+
+		result, total, err := productsRepo.GetAllProductBatches(context.Background(), params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []domain.ProductBatches{mockProductBatches}, *result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail to select product batches", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryCountAllBatches).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(queryGetAllBatches).WillReturnError(sql.ErrConnDone)
+
+		productsRepo := NewMariaDBRepository(db)
+
+		_, _, err = productsRepo.GetAllProductBatches(context.Background(), params)
 		assert.Error(t, err)
 	})
 }
 
+func TestAdjustProductBatch(t *testing.T) {
+	lockBatch := func(mock sqlmock.Sqlmock, quantity, reserved int64, temperature float64) {
+		mock.ExpectQuery(queryLockBatch).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "reserved_quantity", "current_temperature", "section_id"}).
+				AddRow(quantity, reserved, temperature, 3))
+		audittest.ExpectSnapshot(mock, "product_batches", 1)
+	}
+
+	quantity := func(value int64) *int64 { return &value }
+	temperature := func(value float64) *float64 { return &value }
+
+	t.Run("success growing the batch", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		lockBatch(mock, 10, 0, 2)
+		mock.ExpectQuery(queryLockSection).
+			WithArgs(int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity", "product_type_id"}).AddRow(50, 60, 1))
+		mock.ExpectExec(queryAddToSectionCapacity).WithArgs(int64(5), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(queryGetLastBalance).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(10))
+		mock.ExpectExec(queryInsertMovement).
+			WithArgs(int64(1), movements.TypeAdjustment, int64(5), int64(15), "ana", nil, nil, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(queryAdjustBatch).WithArgs(int64(15), float64(2), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryInsertBatchAdjustment).
+			WithArgs(int64(1), domain.AdjustmentRecount, int64(10), int64(15), float64(2), float64(2)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 1)
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+		err = repo.AdjustProductBatch(actor.NewContext(context.Background(), "ana"), 1, domain.BatchAdjustment{
+			CurrentQuantity: quantity(15),
+			Reason:          domain.AdjustmentRecount,
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success shrinking the batch", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		lockBatch(mock, 10, 2, 2)
+		mock.ExpectExec(queryAddToSectionCapacity).WithArgs(int64(-8), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(queryGetLastBalance).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(10))
+		mock.ExpectExec(queryInsertMovement).
+			WithArgs(int64(1), movements.TypeSpoilage, int64(-8), int64(2), actor.System, nil, nil, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(queryAdjustBatch).WithArgs(int64(2), float64(-1), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryInsertBatchAdjustment).
+			WithArgs(int64(1), domain.AdjustmentSpoiled, int64(10), int64(2), float64(2), float64(-1)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 1)
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+		err = repo.AdjustProductBatch(context.Background(), 1, domain.BatchAdjustment{
+			CurrentQuantity:    quantity(2),
+			CurrentTemperature: temperature(-1),
+			Reason:             domain.AdjustmentSpoiled,
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returned stock comes back from a purchase order", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		lockBatch(mock, 4, 0, 2)
+		mock.ExpectQuery(queryLockSection).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity", "product_type_id"}).AddRow(50, 60, 1))
+		mock.ExpectExec(queryAddToSectionCapacity).WithArgs(int64(2), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(queryGetLastBalance).WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(4))
+		mock.ExpectExec(queryInsertMovement).
+			WithArgs(int64(1), movements.TypeReturn, int64(2), int64(6), actor.System, nil, int64(7), nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(queryAdjustBatch).WithArgs(int64(6), float64(2), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryInsertBatchAdjustment).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 1)
+		mock.ExpectCommit()
+
+		purchaseOrderId := int64(7)
+		repo := NewMariaDBRepository(db)
+		err = repo.AdjustProductBatch(context.Background(), 1, domain.BatchAdjustment{
+			CurrentQuantity: quantity(6),
+			Reason:          domain.AdjustmentReturned,
+			PurchaseOrderId: &purchaseOrderId,
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("spoilage cannot add stock", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		lockBatch(mock, 4, 0, 2)
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+		err = repo.AdjustProductBatch(context.Background(), 1, domain.BatchAdjustment{
+			CurrentQuantity: quantity(6),
+			Reason:          domain.AdjustmentSpoiled,
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAdjustment)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("below reserved stock", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		lockBatch(mock, 10, 4, 2)
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+		err = repo.AdjustProductBatch(context.Background(), 1, domain.BatchAdjustment{
+			CurrentQuantity: quantity(3),
+			Reason:          domain.AdjustmentDamaged,
+		})
+
+		assert.ErrorIs(t, err, domain.ErrBelowReserved)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("section capacity exceeded", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		lockBatch(mock, 10, 0, 2)
+		mock.ExpectQuery(queryLockSection).
+			WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity", "product_type_id"}).AddRow(58, 60, 1))
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+		err = repo.AdjustProductBatch(context.Background(), 1, domain.BatchAdjustment{
+			CurrentQuantity: quantity(13),
+			Reason:          domain.AdjustmentCorrection,
+		})
+
+		assert.ErrorIs(t, err, domain.ErrSectionCapacityExceeded)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("batch not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockBatch).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+		err = repo.AdjustProductBatch(context.Background(), 1, domain.BatchAdjustment{
+			CurrentTemperature: temperature(4),
+			Reason:             domain.AdjustmentTemperatureCheck,
+		})
+
+		assert.ErrorIs(t, err, domain.ErrBatchNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteProductBatch(t *testing.T) {
+	lockBatch := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(queryLockBatch).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"current_quantity", "reserved_quantity", "current_temperature", "section_id"}).
+				AddRow(10, 0, 2, 3))
+		audittest.ExpectSnapshot(mock, "product_batches", 1)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		lockBatch(mock)
+		mock.ExpectQuery(queryBatchReferenced).
+			WithArgs(int64(1), int64(1), int64(1), int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"referenced"}).AddRow(false))
+		mock.ExpectExec(queryAddToSectionCapacity).WithArgs(int64(-10), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(queryGetLastBalance).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(10))
+		mock.ExpectExec(queryInsertMovement).
+			WithArgs(int64(1), movements.TypeWriteOff, int64(-10), int64(0), actor.System, nil, nil, nil).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectExec(queryDeleteBatch).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectEntry(mock, audit.ActionDelete, "product_batches", 1)
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+		err = repo.DeleteProductBatch(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("quantity outside the ledger", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		lockBatch(mock)
+		mock.ExpectQuery(queryBatchReferenced).
+			WithArgs(int64(1), int64(1), int64(1), int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"referenced"}).AddRow(false))
+		mock.ExpectExec(queryAddToSectionCapacity).WithArgs(int64(-10), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(queryGetLastBalance).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(8))
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+		err = repo.DeleteProductBatch(context.Background(), 1)
+
+		assert.ErrorIs(t, err, movements.ErrLedgerMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("referenced by an order", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		lockBatch(mock)
+		mock.ExpectQuery(queryBatchReferenced).
+			WithArgs(int64(1), int64(1), int64(1), int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"referenced"}).AddRow(true))
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+		err = repo.DeleteProductBatch(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domain.ErrBatchReferenced)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("batch not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockBatch).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+		err = repo.DeleteProductBatch(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domain.ErrBatchNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetSectionWarehouseId(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetSectionWarehouseId).WithArgs(int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(2))
+
+		warehouseId, err := NewMariaDBRepository(db).GetSectionWarehouseId(context.Background(), 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), warehouseId)
+	})
+
+	t.Run("section not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetSectionWarehouseId).WithArgs(int64(3)).WillReturnError(sql.ErrNoRows)
+
+		_, err = NewMariaDBRepository(db).GetSectionWarehouseId(context.Background(), 3)
+
+		assert.ErrorIs(t, err, domain.ErrSectionNotFound)
+	})
+}
+
 func TestGetQtdProductsBySectionId(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
@@ -793,4 +1430,138 @@ func TestGetQtdProductsInSection(t *testing.T) {
 		_, err = productsRepo.GetQtdOfAllProducts(context.Background())
 		assert.Error(t, err)
 	})
-}
\ No newline at end of file
+}
+
+func TestGetExpiringBatches(t *testing.T) {
+	until := time.Date(2022, 8, 17, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mockBatches := []domain.ExpiringBatch{
+			{Id: 1, BatchNumber: 10, ProductId: 1, SectionId: 2, WarehouseId: 3, CurrentQuantity: 5, DueDate: until.AddDate(0, 0, -9), Quarantined: true},
+			{Id: 2, BatchNumber: 11, ProductId: 1, SectionId: 2, WarehouseId: 3, CurrentQuantity: 8, DueDate: until.AddDate(0, 0, -1)},
+		}
+
+		rows := sqlmock.NewRows(rowsExpiringBatchStruct)
+		for _, batch := range mockBatches {
+			rows.AddRow(
+				batch.Id,
+				batch.BatchNumber,
+				batch.ProductId,
+				batch.SectionId,
+				batch.WarehouseId,
+				batch.CurrentQuantity,
+				batch.DueDate,
+				batch.Quarantined,
+			)
+		}
+
+		mock.ExpectQuery(queryGetExpiringBatches+regexp.QuoteMeta(" AND s.warehouse_id = ? AND b.section_id = ? ORDER BY b.due_date, b.id;")).
+			WithArgs(until, 3, 2).
+			WillReturnRows(rows)
+
+		productsRepo := NewMariaDBRepository(db)
+
+		result, err := productsRepo.GetExpiringBatches(context.Background(), domain.ExpiringBatchesFilter{
+			Until:       until,
+			WarehouseId: 3,
+			SectionId:   2,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, &mockBatches, result)
+	})
+
+	t.Run("without filters", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetExpiringBatches + regexp.QuoteMeta(" ORDER BY b.due_date, b.id;")).
+			WithArgs(until).
+			WillReturnRows(sqlmock.NewRows(rowsExpiringBatchStruct))
+
+		productsRepo := NewMariaDBRepository(db)
+
+		result, err := productsRepo.GetExpiringBatches(context.Background(), domain.ExpiringBatchesFilter{Until: until})
+		assert.NoError(t, err)
+
+		assert.Empty(t, *result)
+	})
+
+	t.Run("fail to scan expiring batches", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(rowsExpiringBatchStruct).AddRow("", "", "", "", "", "", "", "")
+
+		mock.ExpectQuery(queryGetExpiringBatches).WillReturnRows(rows)
+
+		productsRepo := NewMariaDBRepository(db)
+
+		_, err = productsRepo.GetExpiringBatches(context.Background(), domain.ExpiringBatchesFilter{Until: until})
+		assert.Error(t, err)
+	})
+
+	t.Run("fail to select expiring batches", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetExpiringBatches).WillReturnError(sql.ErrConnDone)
+
+		productsRepo := NewMariaDBRepository(db)
+
+		_, err = productsRepo.GetExpiringBatches(context.Background(), domain.ExpiringBatchesFilter{Until: until})
+		assert.Error(t, err)
+	})
+}
+
+func TestQuarantineExpiredBatches(t *testing.T) {
+	now := time.Date(2022, 8, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockExpiredBatches).
+			WithArgs(now).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4).AddRow(7))
+		for _, id := range []int64{4, 7} {
+			audittest.ExpectSnapshot(mock, "product_batches", id)
+			mock.ExpectExec(queryQuarantineBatch).
+				WithArgs(now, id).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", id)
+		}
+		mock.ExpectCommit()
+
+		productsRepo := NewMariaDBRepository(db)
+
+		quarantined, err := productsRepo.QuarantineExpiredBatches(context.Background(), now)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int64(2), quarantined)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail to quarantine batches", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockExpiredBatches).WillReturnError(sql.ErrConnDone)
+
+		productsRepo := NewMariaDBRepository(db)
+
+		_, err = productsRepo.QuarantineExpiredBatches(context.Background(), now)
+		assert.Error(t, err)
+	})
+}
@@ -2,7 +2,7 @@ package mariadb
 
 const (
 	sqlInsertProduct  = "INSERT INTO products (`description`, `expiration_rate`, `freezing_rate`, `height`, `length`, `net_weight`, `product_code`, `recommended_freezing_temperature`, `width`, `product_type_id`, `seller_id`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);"
-	sqlGetAllProducts = "SELECT `id`, `description`, `expiration_rate`, `freezing_rate`, `height`, `length`, `net_weight`, `product_code`, `recommended_freezing_temperature`, `width`, `product_type_id`, `seller_id` FROM products;"
+	sqlGetAllProducts = "SELECT `id`, `description`, `expiration_rate`, `freezing_rate`, `height`, `length`, `net_weight`, `product_code`, `recommended_freezing_temperature`, `width`, `product_type_id`, `seller_id` FROM products"
 	sqlGetProductById = "SELECT `id`, `description`, `expiration_rate`, `freezing_rate`, `height`, `length`, `net_weight`, `product_code`, `recommended_freezing_temperature`, `width`, `product_type_id`, `seller_id` FROM products WHERE ID = ?;"
 	sqlUpdateProduct  = "UPDATE products SET `description` = ?, `expiration_rate` = ?, `freezing_rate` = ?, `height` = ?, `length` = ?, `net_weight` = ?, `product_code` = ?, `recommended_freezing_temperature` = ?, `width` = ?, `product_type_id` = ?, `seller_id` = ? WHERE ID = ?;"
 	sqlDeleteProduct  = "DELETE FROM products WHERE id=?"
@@ -10,12 +10,55 @@ const (
 	sqlCreateRecord = "INSERT INTO `product_records` (`purchase_price`, `sale_price`, `product_id`) VALUES (?, ?, ?);"
 	sqlGetRecord    = "SELECT `last_update_date`, `purchase_price`, `sale_price`, `product_id` FROM `product_records` WHERE ID = ?;"
 
+	sqlGetPrices = `SELECT r.id, r.product_id, r.purchase_price, r.sale_price, r.last_update_date,
+		(SELECT n.last_update_date FROM product_records n
+			WHERE n.product_id = r.product_id AND (n.last_update_date > r.last_update_date OR (n.last_update_date = r.last_update_date AND n.id > r.id))
+			ORDER BY n.last_update_date, n.id LIMIT 1)
+		FROM product_records r`
+	sqlGetPriceHistory = sqlGetPrices + " WHERE r.product_id = ? ORDER BY r.last_update_date, r.id;"
+	sqlGetPriceAt      = sqlGetPrices + " WHERE r.product_id = ? AND r.last_update_date <= ? ORDER BY r.last_update_date DESC, r.id DESC LIMIT 1;"
+
 	sqlGetQtyOfRecordsById = "SELECT p.id, p.description, COUNT(r.id) records_count FROM products p INNER JOIN product_records r ON p.id = r.product_id WHERE p.id = ? GROUP BY p.id;"
 	sqlGetQtyOfRecords     = "SELECT p.id, p.description, COUNT(r.id) records_count FROM products p INNER JOIN product_records r ON p.id = r.product_id GROUP BY p.id;"
 
-	sqlCreateBatch = "INSERT INTO product_batches (batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, section_id) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?);"
-	sqlGetBatch = "SELECT `batch_number`, `current_quantity`, `current_temperature`, `due_date`, `initial_quantity`, `manufacturing_date`, `manufacturing_hour`, `minimum_temperature`, `product_id`, `section_id` FROM `product_batches`  WHERE ID=?;"
+	sqlCreateBatch   = "INSERT INTO product_batches (batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, section_id) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?);"
+	sqlGetBatch      = "SELECT `id`, `batch_number`, `current_quantity`, `current_temperature`, `due_date`, `initial_quantity`, `manufacturing_date`, `manufacturing_hour`, `minimum_temperature`, `product_id`, `section_id` FROM `product_batches`  WHERE ID=?;"
+	sqlGetAllBatches = `SELECT b.id, b.batch_number, b.current_quantity, b.current_temperature, b.due_date, b.initial_quantity, b.manufacturing_date, b.manufacturing_hour, b.minimum_temperature, b.product_id, b.section_id
+		FROM product_batches b
+		INNER JOIN sections s ON s.id = b.section_id`
+	sqlLockBatch             = "SELECT COALESCE(current_quantity, 0), reserved_quantity, COALESCE(current_temperature, 0), section_id FROM product_batches WHERE id = ? FOR UPDATE;"
+	sqlAdjustBatch           = "UPDATE product_batches SET current_quantity = ?, current_temperature = ? WHERE id = ?;"
+	sqlInsertBatchAdjustment = "INSERT INTO product_batch_adjustments (product_batch_id, reason, previous_quantity, current_quantity, previous_temperature, current_temperature) VALUES (?, ?, ?, ?, ?, ?);"
+	sqlBatchReferenced       = `SELECT EXISTS(SELECT 1 FROM inbound_orders WHERE product_batch_id = ?)
+		OR EXISTS(SELECT 1 FROM stock_reservations WHERE product_batch_id = ?)
+		OR EXISTS(SELECT 1 FROM stock_transfers WHERE product_batch_id = ? OR target_batch_id = ?);`
+	sqlDeleteBatch = "DELETE FROM product_batches WHERE id = ?;"
+
+	sqlGetSectionWarehouseId = "SELECT warehouse_id FROM sections WHERE id = ?;"
+
+	sqlLockSection          = "SELECT current_capacity, maximum_capacity, product_type_id FROM sections WHERE id = ? FOR UPDATE;"
+	sqlGetProductTypeId     = "SELECT product_type_id FROM products WHERE id = ?;"
+	sqlAddToSectionCapacity = "UPDATE sections SET current_capacity = current_capacity + ? WHERE id = ?;"
 
-	sqlGetQtdProductsBySectionId = "SELECT  b.section_id, SUM(b.current_quantity) AS products_count, s.section_number FROM product_batches b INNER JOIN sections s ON b.section_id = s.id WHERE b.section_id = ?;"
-	sqlGetQtdProductsInSection = "SELECT  b.section_id, SUM(b.current_quantity) AS products_count, s.section_number	FROM product_batches b INNER JOIN sections s ON b.section_id = s.id GROUP BY b.section_id;"
+	sqlGetQtdProductsBySectionId = "SELECT  b.section_id, SUM(IF(b.quarantined_at IS NULL, b.current_quantity, 0)) AS products_count, s.section_number FROM product_batches b INNER JOIN sections s ON b.section_id = s.id WHERE b.section_id = ?;"
+	sqlGetQtdProductsInSection   = "SELECT  b.section_id, SUM(IF(b.quarantined_at IS NULL, b.current_quantity, 0)) AS products_count, s.section_number	FROM product_batches b INNER JOIN sections s ON b.section_id = s.id GROUP BY b.section_id;"
+
+	sqlGetExpiringBatches = `SELECT b.id, b.batch_number, b.product_id, b.section_id, s.warehouse_id, b.current_quantity, b.due_date, b.quarantined_at IS NOT NULL
+		FROM product_batches b
+		INNER JOIN sections s ON b.section_id = s.id
+		WHERE b.current_quantity > 0 AND b.due_date <= ?`
+	sqlLockExpiredBatches = "SELECT id FROM product_batches WHERE quarantined_at IS NULL AND due_date <= ? FOR UPDATE;"
+	sqlQuarantineBatch    = "UPDATE product_batches SET quarantined_at = ? WHERE id = ?;"
 )
+
+// batchColumns maps the listing fields of product batches to the columns of
+// sqlGetAllBatches.
+var batchColumns = map[string]string{
+	"id":               "b.id",
+	"batch_number":     "b.batch_number",
+	"current_quantity": "b.current_quantity",
+	"due_date":         "b.due_date",
+	"product_id":       "b.product_id",
+	"section_id":       "b.section_id",
+	"warehouse_id":     "s.warehouse_id",
+}
@@ -0,0 +1,107 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
+)
+
+// PlaceInSection locks the section and adds quantity units of the product
+// to its current capacity. It fails when the section holds another product
+// type or would go over its maximum capacity.
+func PlaceInSection(ctx context.Context, tx *sql.Tx, sectionId, productId, quantity int64) error {
+	section, err := checkSection(ctx, tx, sectionId, productId, quantity)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlAddToSectionCapacity, quantity, section.id)
+	return err
+}
+
+// CheckSection runs the checks of PlaceInSection without taking the room,
+// for stock that will only arrive later.
+func CheckSection(ctx context.Context, tx *sql.Tx, sectionId, productId, quantity int64) error {
+	_, err := checkSection(ctx, tx, sectionId, productId, quantity)
+	return err
+}
+
+// ResizeInSection moves delta units in or out of the section capacity. The
+// maximum capacity is only checked when the stock grows.
+func ResizeInSection(ctx context.Context, tx *sql.Tx, sectionId, delta int64) error {
+	if delta <= 0 {
+		_, err := tx.ExecContext(ctx, sqlAddToSectionCapacity, delta, sectionId)
+		return err
+	}
+
+	section, err := lockSection(ctx, tx, sectionId)
+	if err != nil {
+		return err
+	}
+
+	if err := section.fits(delta); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlAddToSectionCapacity, delta, section.id)
+	return err
+}
+
+type sectionCapacity struct {
+	id              int64
+	currentCapacity int64
+	maximumCapacity int64
+	productTypeId   int64
+}
+
+func (s sectionCapacity) fits(quantity int64) error {
+	if s.currentCapacity+quantity > s.maximumCapacity {
+		return fmt.Errorf(
+			"%w: %d free, %d requested",
+			domain.ErrSectionCapacityExceeded, s.maximumCapacity-s.currentCapacity, quantity,
+		)
+	}
+	return nil
+}
+
+func checkSection(ctx context.Context, tx *sql.Tx, sectionId, productId, quantity int64) (sectionCapacity, error) {
+	section, err := lockSection(ctx, tx, sectionId)
+	if err != nil {
+		return section, err
+	}
+
+	var productTypeId int64
+	err = tx.QueryRowContext(ctx, sqlGetProductTypeId, productId).Scan(&productTypeId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return section, domain.ErrIDNotFound
+	}
+	if err != nil {
+		return section, err
+	}
+
+	if productTypeId != section.productTypeId {
+		return section, fmt.Errorf(
+			"%w: product type %d, section type %d",
+			domain.ErrProductTypeMismatch, productTypeId, section.productTypeId,
+		)
+	}
+
+	return section, section.fits(quantity)
+}
+
+func lockSection(ctx context.Context, tx *sql.Tx, sectionId int64) (sectionCapacity, error) {
+	section := sectionCapacity{id: sectionId}
+	err := tx.QueryRowContext(ctx, sqlLockSection, sectionId).Scan(
+		&section.currentCapacity,
+		&section.maximumCapacity,
+		&section.productTypeId,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return section, domain.ErrSectionNotFound
+	}
+
+	return section, err
+}
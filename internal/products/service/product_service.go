@@ -2,27 +2,33 @@ package service
 
 import (
 	"context"
+	"math"
+	"time"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
 )
 
 type service struct {
 	repository domain.Repository
+	now        func() time.Time
 }
 
 func NewService(r domain.Repository) domain.Service {
 	return &service{
 		repository: r,
+		now:        time.Now,
 	}
 }
 
-func (s *service) GetAll(ctx context.Context) (*[]domain.Product, error) {
-	listOfProducts, err := s.repository.GetAll(ctx)
+func (s *service) GetAll(ctx context.Context, params listing.Params) (*[]domain.Product, int64, error) {
+	listOfProducts, total, err := s.repository.GetAll(ctx, params)
 	if err != nil {
-		return listOfProducts, err
+		return listOfProducts, 0, err
 	}
 
-	return listOfProducts, nil
+	return listOfProducts, total, nil
 }
 
 func (s service) GetById(ctx context.Context, id int64) (*domain.Product, error) {
@@ -34,7 +40,28 @@ func (s service) GetById(ctx context.Context, id int64) (*domain.Product, error)
 	return product, nil
 }
 
+// ownProduct returns policy.ErrOtherSeller when the caller is restricted to
+// a seller other than the one of the product.
+func (s service) ownProduct(ctx context.Context, id int64) error {
+	if policy.Unrestricted(ctx) {
+		return nil
+	}
+
+	product, err := s.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return policy.OwnSeller(ctx, product.SellerId)
+}
+
+// CreateNewProduct creates a product, which sellers may only do for
+// themselves.
 func (s *service) CreateNewProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	if err := policy.OwnSeller(ctx, product.SellerId); err != nil {
+		return product, err
+	}
+
 	newProd, err := s.repository.CreateNewProduct(ctx, product)
 	if err != nil {
 		return newProd, err
@@ -48,6 +75,10 @@ func (s *service) Update(ctx context.Context, product *domain.Product) (*domain.
 		return product, err
 	}
 
+	if err := policy.OwnSeller(ctx, current.SellerId); err != nil {
+		return product, err
+	}
+
 	if len(product.Description) > 0 {
 		current.Description = product.Description
 	}
@@ -85,6 +116,10 @@ func (s *service) Update(ctx context.Context, product *domain.Product) (*domain.
 	}
 
 	if product.SellerId > 0 {
+		// Sellers cannot hand their products over to other sellers.
+		if err := policy.OwnSeller(ctx, product.SellerId); err != nil {
+			return product, err
+		}
 		current.SellerId = product.SellerId
 	}
 
@@ -101,6 +136,10 @@ func (s *service) Update(ctx context.Context, product *domain.Product) (*domain.
 }
 
 func (s service) Delete(ctx context.Context, id int64) error {
+	if err := s.ownProduct(ctx, id); err != nil {
+		return err
+	}
+
 	err := s.repository.Delete(ctx, id)
 	if err != nil {
 		return err
@@ -109,7 +148,18 @@ func (s service) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (s *service) CreateProductRecords(ctx context.Context, record *domain.ProductRecords) (int64, error) {
+// CreateProductRecords sets new prices for a product, refusing to sell below
+// cost unless allowBelowCost is set. Sellers may only price their own
+// products.
+func (s *service) CreateProductRecords(ctx context.Context, record *domain.ProductRecords, allowBelowCost bool) (int64, error) {
+	if record.SalePrice < record.PurchasePrice && !allowBelowCost {
+		return 0, domain.ErrSalePriceBelowCost
+	}
+
+	if err := s.ownProduct(ctx, record.ProductId); err != nil {
+		return 0, err
+	}
+
 	newRecordId, err := s.repository.CreateProductRecords(ctx, record)
 	if err != nil {
 		return newRecordId, err
@@ -125,6 +175,31 @@ func (s *service) GetProductRecordsById(ctx context.Context, id int64) (*domain.
 	return newRecord, nil
 }
 
+func (s service) GetPriceHistory(ctx context.Context, productId int64) (*[]domain.ProductPrice, error) {
+	if _, err := s.repository.GetById(ctx, productId); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetPriceHistory(ctx, productId)
+}
+
+func (s service) GetPriceAt(ctx context.Context, productId int64, at time.Time) (*domain.ProductPrice, error) {
+	if _, err := s.repository.GetById(ctx, productId); err != nil {
+		return nil, err
+	}
+
+	price, err := s.repository.GetPriceAt(ctx, productId, at)
+	if err != nil {
+		return nil, err
+	}
+
+	if price == nil {
+		return nil, domain.ErrNoPriceAt
+	}
+
+	return price, nil
+}
+
 func (s service) GetQtyOfRecordsById(ctx context.Context, id int64) (*domain.QtyOfRecords, error) {
 	report, err := s.repository.GetQtyOfRecordsById(ctx, id)
 	if err != nil {
@@ -143,7 +218,42 @@ func (s service) GetQtyOfAllRecords(ctx context.Context) (*[]domain.QtyOfRecords
 	return report, nil
 }
 
+// ownSection returns policy.ErrOtherWarehouse when the caller is restricted
+// to a warehouse other than the one of the section.
+func (s service) ownSection(ctx context.Context, sectionId int64) error {
+	if policy.Unrestricted(ctx) {
+		return nil
+	}
+
+	warehouseId, err := s.repository.GetSectionWarehouseId(ctx, sectionId)
+	if err != nil {
+		return err
+	}
+
+	return policy.OwnWarehouse(ctx, warehouseId)
+}
+
+// ownBatch is ownSection for the section holding the batch.
+func (s service) ownBatch(ctx context.Context, id int64) error {
+	if policy.Unrestricted(ctx) {
+		return nil
+	}
+
+	batch, err := s.repository.GetProductBatchesById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.ownSection(ctx, batch.SectionId)
+}
+
+// CreateProductBatches receives a batch, which employees may only do in the
+// sections of their own warehouse.
 func (s *service) CreateProductBatches(ctx context.Context, batche *domain.ProductBatches) (int64, error) {
+	if err := s.ownSection(ctx, batche.SectionId); err != nil {
+		return 0, err
+	}
+
 	newBatchId, err := s.repository.CreateProductBatches(ctx, batche)
 	if err != nil {
 		return newBatchId, err
@@ -159,6 +269,32 @@ func (s *service) GetProductBatchesById(ctx context.Context, id int64) (*domain.
 	return newBatch, nil
 }
 
+func (s *service) GetAllProductBatches(ctx context.Context, params listing.Params) (*[]domain.ProductBatches, int64, error) {
+	return s.repository.GetAllProductBatches(ctx, params)
+}
+
+// AdjustProductBatch changes the stock or temperature of a batch and returns
+// it as stored afterwards.
+func (s *service) AdjustProductBatch(ctx context.Context, id int64, adjustment domain.BatchAdjustment) (*domain.ProductBatches, error) {
+	if err := s.ownBatch(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.AdjustProductBatch(ctx, id, adjustment); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetProductBatchesById(ctx, id)
+}
+
+func (s *service) DeleteProductBatch(ctx context.Context, id int64) error {
+	if err := s.ownBatch(ctx, id); err != nil {
+		return err
+	}
+
+	return s.repository.DeleteProductBatch(ctx, id)
+}
+
 func (s service) GetQtdProductsBySectionId(ctx context.Context, id int64) (*domain.QtdOfProducts, error) {
 	report, err := s.repository.GetQtdProductsBySectionId(ctx, id)
 	if err != nil {
@@ -176,3 +312,30 @@ func (s service) GetQtdOfAllProducts(ctx context.Context) (*[]domain.QtdOfProduc
 
 	return report, nil
 }
+
+// GetExpiringBatches lists the batches with stock that expire within the next
+// days, including the ones already expired.
+func (s *service) GetExpiringBatches(ctx context.Context, days, warehouseId, sectionId int64) (*[]domain.ExpiringBatch, error) {
+	now := s.now()
+
+	batches, err := s.repository.GetExpiringBatches(ctx, domain.ExpiringBatchesFilter{
+		Until:       now.AddDate(0, 0, int(days)),
+		WarehouseId: warehouseId,
+		SectionId:   sectionId,
+	})
+	if err != nil {
+		return batches, err
+	}
+
+	for i := range *batches {
+		batch := &(*batches)[i]
+		batch.Expired = !batch.DueDate.After(now)
+		batch.DaysToExpire = int64(math.Floor(batch.DueDate.Sub(now).Hours() / 24))
+	}
+
+	return batches, nil
+}
+
+func (s *service) QuarantineExpiredBatches(ctx context.Context) (int64, error) {
+	return s.repository.QuarantineExpiredBatches(ctx, s.now())
+}
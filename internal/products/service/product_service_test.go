@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain/mocks"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
@@ -56,11 +60,11 @@ func TestGetAll(t *testing.T) {
 		mockProductsRepo := mocks.NewRepository(t)
 		mockProducts := utils.CreateRandomListProduct()
 
-		mockProductsRepo.On("GetAll", mock.Anything).
-			Return(&mockProducts, nil).Once()
+		mockProductsRepo.On("GetAll", mock.Anything, mock.Anything).
+			Return(&mockProducts, int64(len(mockProducts)), nil).Once()
 
 		s := NewService(mockProductsRepo)
-		list, err := s.GetAll(context.Background())
+		list, _, err := s.GetAll(context.Background(), listing.Params{})
 
 		assert.NoError(t, err)
 
@@ -72,12 +76,12 @@ func TestGetAll(t *testing.T) {
 	t.Run("In case of error", func(t *testing.T) {
 		mockProductsRepo := mocks.NewRepository(t)
 
-		mockProductsRepo.On("GetAll", mock.Anything).
-			Return(nil, errors.New("failed to retrieve products")).
+		mockProductsRepo.On("GetAll", mock.Anything, mock.Anything).
+			Return(nil, int64(0), errors.New("failed to retrieve products")).
 			Once()
 
 		s := NewService(mockProductsRepo)
-		_, err := s.GetAll(context.Background())
+		_, _, err := s.GetAll(context.Background(), listing.Params{})
 
 		assert.NotNil(t, err)
 
@@ -228,7 +232,7 @@ func TestCreateProductRecords(t *testing.T) {
 
 		s := NewService(mockProductsRepo)
 
-		newRecordId, err := s.CreateProductRecords(context.Background(), &mockProductRecords)
+		newRecordId, err := s.CreateProductRecords(context.Background(), &mockProductRecords, false)
 
 		assert.NoError(t, err)
 		assert.Equal(t, mockProductRecordsId, newRecordId)
@@ -247,7 +251,7 @@ func TestCreateProductRecords(t *testing.T) {
 
 		s := NewService(mockProductsRepo)
 
-		_, err := s.CreateProductRecords(context.Background(), &mockProductRecords)
+		_, err := s.CreateProductRecords(context.Background(), &mockProductRecords, false)
 
 		assert.Error(t, err)
 
@@ -255,6 +259,180 @@ func TestCreateProductRecords(t *testing.T) {
 	})
 }
 
+func TestCreateProductRecordsBelowCost(t *testing.T) {
+	record := domain.ProductRecords{PurchasePrice: 10, SalePrice: 8, ProductId: 1}
+
+	t.Run("rejected", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+
+		_, err := NewService(mockProductsRepo).CreateProductRecords(context.Background(), &record, false)
+
+		assert.ErrorIs(t, err, domain.ErrSalePriceBelowCost)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProductsRepo.On("CreateProductRecords", mock.Anything, &record).Return(int64(3), nil).Once()
+
+		id, err := NewService(mockProductsRepo).CreateProductRecords(context.Background(), &record, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), id)
+	})
+}
+
+func TestSellerScope(t *testing.T) {
+	sellerId := int64(2)
+	ctx := auth.NewContext(context.Background(), auth.Claims{Roles: []string{policy.Seller}, SellerId: &sellerId})
+
+	t.Run("create for another seller", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+
+		_, err := NewService(mockProductsRepo).CreateNewProduct(ctx, &domain.Product{SellerId: 5})
+
+		assert.ErrorIs(t, err, policy.ErrOtherSeller)
+	})
+
+	t.Run("update own product", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProductsRepo.On("GetById", mock.Anything, int64(1)).Return(&domain.Product{Id: 1, SellerId: 2}, nil).Once()
+		mockProductsRepo.On("Update", mock.Anything, &domain.Product{Id: 1, SellerId: 2, Description: "frozen peas"}).
+			Return(&domain.Product{Id: 1, SellerId: 2, Description: "frozen peas"}, nil).Once()
+
+		_, err := NewService(mockProductsRepo).Update(ctx, &domain.Product{Id: 1, Description: "frozen peas"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("hand product over to another seller", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProductsRepo.On("GetById", mock.Anything, int64(1)).Return(&domain.Product{Id: 1, SellerId: 2}, nil).Once()
+
+		_, err := NewService(mockProductsRepo).Update(ctx, &domain.Product{Id: 1, SellerId: 5})
+
+		assert.ErrorIs(t, err, policy.ErrOtherSeller)
+	})
+
+	t.Run("delete product of another seller", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProductsRepo.On("GetById", mock.Anything, int64(1)).Return(&domain.Product{Id: 1, SellerId: 5}, nil).Once()
+
+		err := NewService(mockProductsRepo).Delete(ctx, 1)
+
+		assert.ErrorIs(t, err, policy.ErrOtherSeller)
+	})
+
+	t.Run("price product of another seller", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProductsRepo.On("GetById", mock.Anything, int64(1)).Return(&domain.Product{Id: 1, SellerId: 5}, nil).Once()
+
+		_, err := NewService(mockProductsRepo).CreateProductRecords(ctx, &domain.ProductRecords{PurchasePrice: 8, SalePrice: 10, ProductId: 1}, false)
+
+		assert.ErrorIs(t, err, policy.ErrOtherSeller)
+	})
+}
+
+func TestWarehouseScope(t *testing.T) {
+	warehouseId := int64(2)
+	ctx := auth.NewContext(context.Background(), auth.Claims{Roles: []string{policy.Employee}, WarehouseId: &warehouseId})
+
+	t.Run("receive batch in another warehouse", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProductsRepo.On("GetSectionWarehouseId", mock.Anything, int64(3)).Return(int64(5), nil).Once()
+
+		_, err := NewService(mockProductsRepo).CreateProductBatches(ctx, &domain.ProductBatches{SectionId: 3})
+
+		assert.ErrorIs(t, err, policy.ErrOtherWarehouse)
+	})
+
+	t.Run("receive batch in own warehouse", func(t *testing.T) {
+		batch := &domain.ProductBatches{SectionId: 3}
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProductsRepo.On("GetSectionWarehouseId", mock.Anything, int64(3)).Return(int64(2), nil).Once()
+		mockProductsRepo.On("CreateProductBatches", mock.Anything, batch).Return(int64(1), nil).Once()
+
+		id, err := NewService(mockProductsRepo).CreateProductBatches(ctx, batch)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), id)
+	})
+
+	t.Run("adjust batch of another warehouse", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProductsRepo.On("GetProductBatchesById", mock.Anything, int64(1)).Return(&domain.ProductBatches{Id: 1, SectionId: 3}, nil).Once()
+		mockProductsRepo.On("GetSectionWarehouseId", mock.Anything, int64(3)).Return(int64(5), nil).Once()
+
+		_, err := NewService(mockProductsRepo).AdjustProductBatch(ctx, 1, domain.BatchAdjustment{Reason: domain.AdjustmentRecount})
+
+		assert.ErrorIs(t, err, policy.ErrOtherWarehouse)
+	})
+
+	t.Run("delete batch of another warehouse", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProductsRepo.On("GetProductBatchesById", mock.Anything, int64(1)).Return(&domain.ProductBatches{Id: 1, SectionId: 3}, nil).Once()
+		mockProductsRepo.On("GetSectionWarehouseId", mock.Anything, int64(3)).Return(int64(5), nil).Once()
+
+		err := NewService(mockProductsRepo).DeleteProductBatch(ctx, 1)
+
+		assert.ErrorIs(t, err, policy.ErrOtherWarehouse)
+	})
+}
+
+func TestGetPriceHistory(t *testing.T) {
+	t.Run("In case of success", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProduct := utils.CreateRandomProduct()
+		prices := []domain.ProductPrice{{RecordId: 1, ProductId: mockProduct.Id}}
+
+		mockProductsRepo.On("GetById", mock.Anything, mockProduct.Id).Return(&mockProduct, nil).Once()
+		mockProductsRepo.On("GetPriceHistory", mock.Anything, mockProduct.Id).Return(&prices, nil).Once()
+
+		result, err := NewService(mockProductsRepo).GetPriceHistory(context.Background(), mockProduct.Id)
+
+		assert.NoError(t, err)
+		assert.Equal(t, prices, *result)
+	})
+
+	t.Run("In case of unknown product", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProductsRepo.On("GetById", mock.Anything, int64(1)).Return(&domain.Product{}, domain.ErrIDNotFound).Once()
+
+		_, err := NewService(mockProductsRepo).GetPriceHistory(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domain.ErrIDNotFound)
+	})
+}
+
+func TestGetPriceAt(t *testing.T) {
+	at := time.Date(2022, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("In case of success", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProduct := utils.CreateRandomProduct()
+		price := domain.ProductPrice{RecordId: 1, ProductId: mockProduct.Id, SalePrice: 10}
+
+		mockProductsRepo.On("GetById", mock.Anything, mockProduct.Id).Return(&mockProduct, nil).Once()
+		mockProductsRepo.On("GetPriceAt", mock.Anything, mockProduct.Id, at).Return(&price, nil).Once()
+
+		result, err := NewService(mockProductsRepo).GetPriceAt(context.Background(), mockProduct.Id, at)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &price, result)
+	})
+
+	t.Run("In case of no price at that date", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProduct := utils.CreateRandomProduct()
+
+		mockProductsRepo.On("GetById", mock.Anything, mockProduct.Id).Return(&mockProduct, nil).Once()
+		mockProductsRepo.On("GetPriceAt", mock.Anything, mockProduct.Id, at).Return(nil, nil).Once()
+
+		_, err := NewService(mockProductsRepo).GetPriceAt(context.Background(), mockProduct.Id, at)
+
+		assert.ErrorIs(t, err, domain.ErrNoPriceAt)
+	})
+}
+
 func TestGetProductRecordsById(t *testing.T) {
 	t.Run("In case of success", func(t *testing.T) {
 		mockProductsRepo := mocks.NewRepository(t)
@@ -415,6 +593,72 @@ func TestGetProductBatchesById(t *testing.T) {
 	})
 }
 
+func TestGetAllProductBatches(t *testing.T) {
+	mockProductsRepo := mocks.NewRepository(t)
+	mockProductBatches := []domain.ProductBatches{utils.CreateRandomProductBatches()}
+	params := listing.Params{Limit: 10, Filters: []listing.Filter{{Field: "section_id", Value: "1"}}}
+
+	mockProductsRepo.On("GetAllProductBatches", mock.Anything, params).
+		Return(&mockProductBatches, int64(1), nil).Once()
+
+	service := NewService(mockProductsRepo)
+
+	productBatches, total, err := service.GetAllProductBatches(context.Background(), params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, &mockProductBatches, productBatches)
+}
+
+func TestAdjustProductBatch(t *testing.T) {
+	quantity := int64(5)
+	adjustment := domain.BatchAdjustment{CurrentQuantity: &quantity, Reason: domain.AdjustmentRecount}
+
+	t.Run("returns the adjusted batch", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockProductBatches := utils.CreateRandomProductBatches()
+
+		mockProductsRepo.On("AdjustProductBatch", mock.Anything, mockProductBatches.Id, adjustment).
+			Return(nil).Once()
+		mockProductsRepo.On("GetProductBatchesById", mock.Anything, mockProductBatches.Id).
+			Return(&mockProductBatches, nil).Once()
+
+		service := NewService(mockProductsRepo)
+
+		productBatches, err := service.AdjustProductBatch(context.Background(), mockProductBatches.Id, adjustment)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &mockProductBatches, productBatches)
+	})
+
+	t.Run("In case of error", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+
+		mockProductsRepo.On("AdjustProductBatch", mock.Anything, int64(1), adjustment).
+			Return(domain.ErrBelowReserved).Once()
+
+		service := NewService(mockProductsRepo)
+
+		productBatches, err := service.AdjustProductBatch(context.Background(), 1, adjustment)
+
+		assert.ErrorIs(t, err, domain.ErrBelowReserved)
+		assert.Nil(t, productBatches)
+	})
+}
+
+func TestDeleteProductBatch(t *testing.T) {
+	mockProductsRepo := mocks.NewRepository(t)
+
+	mockProductsRepo.On("DeleteProductBatch", mock.Anything, int64(1)).
+		Return(domain.ErrBatchReferenced).Once()
+
+	service := NewService(mockProductsRepo)
+
+	err := service.DeleteProductBatch(context.Background(), 1)
+
+	assert.ErrorIs(t, err, domain.ErrBatchReferenced)
+}
+
 func TestGetQtyOfAllRecords(t *testing.T) {
 	t.Run("In case of success", func(t *testing.T) {
 		mockReportsRepo := mocks.NewRepository(t)
@@ -522,3 +766,62 @@ func TestGetQtdProductsInSection(t *testing.T) {
 		mockReportsRepo.AssertExpectations(t)
 	})
 }
+
+func TestGetExpiringBatches(t *testing.T) {
+	now := time.Date(2022, 8, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("In case of success", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+		mockBatches := []domain.ExpiringBatch{
+			{Id: 1, CurrentQuantity: 10, DueDate: now.AddDate(0, 0, -2)},
+			{Id: 2, CurrentQuantity: 5, DueDate: now.Add(time.Hour)},
+			{Id: 3, CurrentQuantity: 3, DueDate: now.AddDate(0, 0, 5)},
+		}
+
+		mockProductsRepo.On("GetExpiringBatches", mock.Anything, domain.ExpiringBatchesFilter{
+			Until:       now.AddDate(0, 0, 7),
+			WarehouseId: 2,
+			SectionId:   0,
+		}).Return(&mockBatches, nil).Once()
+
+		s := &service{repository: mockProductsRepo, now: func() time.Time { return now }}
+		batches, err := s.GetExpiringBatches(context.Background(), 7, 2, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{true, false, false}, []bool{(*batches)[0].Expired, (*batches)[1].Expired, (*batches)[2].Expired})
+		assert.Equal(t, []int64{-2, 0, 5}, []int64{(*batches)[0].DaysToExpire, (*batches)[1].DaysToExpire, (*batches)[2].DaysToExpire})
+
+		mockProductsRepo.AssertExpectations(t)
+	})
+
+	t.Run("In case of error", func(t *testing.T) {
+		mockProductsRepo := mocks.NewRepository(t)
+
+		mockProductsRepo.On("GetExpiringBatches", mock.Anything, mock.Anything).
+			Return(nil, errors.New("failed to retrieve batches")).
+			Once()
+
+		s := NewService(mockProductsRepo)
+		_, err := s.GetExpiringBatches(context.Background(), 7, 0, 0)
+
+		assert.Error(t, err)
+
+		mockProductsRepo.AssertExpectations(t)
+	})
+}
+
+func TestQuarantineExpiredBatches(t *testing.T) {
+	now := time.Date(2022, 8, 10, 12, 0, 0, 0, time.UTC)
+
+	mockProductsRepo := mocks.NewRepository(t)
+	mockProductsRepo.On("QuarantineExpiredBatches", mock.Anything, now).
+		Return(int64(3), nil).Once()
+
+	s := &service{repository: mockProductsRepo, now: func() time.Time { return now }}
+	quarantined, err := s.QuarantineExpiredBatches(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), quarantined)
+
+	mockProductsRepo.AssertExpectations(t)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
+)
+
+// RunQuarantineSweep quarantines expired batches right away and then once per
+// interval, until ctx is cancelled.
+func RunQuarantineSweep(ctx context.Context, s domain.Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		quarantined, err := s.QuarantineExpiredBatches(ctx)
+		if err != nil {
+			log.Printf("quarantine sweep: %v", err)
+		} else if quarantined > 0 {
+			log.Printf("quarantine sweep: %d expired batches quarantined", quarantined)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,9 +2,13 @@ package controller
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/domain"
 )
 
@@ -18,13 +22,14 @@ type AppError struct {
 }
 
 type request struct {
-	OrderNumber   string `json:"order_number" binding:"required"`
-	OrderDate     string `json:"order_date" binding:"required"`
-	TrackingCode  string `json:"tracking_code" binding:"required"`
-	BuyerId       int64  `json:"buyer_id" binding:"required"`
-	CarrierId     int64  `json:"carrier_id" binding:"required"`
-	OrderStatusId int64  `json:"order_status_id" binding:"required"`
-	WarehouseId   int64  `json:"warehouse_id" binding:"required"`
+	OrderNumber  string `json:"order_number" binding:"required"`
+	OrderDate    string `json:"order_date" binding:"required"`
+	TrackingCode string `json:"tracking_code" binding:"required"`
+	BuyerId      int64  `json:"buyer_id" binding:"required"`
+	CarrierId    int64  `json:"carrier_id" binding:"required"`
+	WarehouseId  int64  `json:"warehouse_id" binding:"required"`
+
+	OrderDetails []domain.OrderDetailRequest `json:"order_details" binding:"dive"`
 }
 
 func NewPurchaseOrderController(purchaseOrder domain.PurchaseOrderService) (*PurchaseOrderController, error) {
@@ -37,48 +42,90 @@ func NewPurchaseOrderController(purchaseOrder domain.PurchaseOrderService) (*Pur
 	}, nil
 }
 
+// @Summary List purchase orders
+// @Tags Purchase Orders
+// @Description List purchase orders without their lines
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Param buyer_id query int false "Buyer ID"
+// @Param carrier_id query int false "Carrier ID"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param order_status_id query int false "Order status ID"
+// @Param order_date_from query string false "Orders placed on or after this date"
+// @Param order_date_to query string false "Orders placed on or before this date"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.PurchaseOrder}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /purchaseOrders [get]
+func (c PurchaseOrderController) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.PurchaseOrderListFields)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		purchaseOrders, total, err := c.purchaseOrder.GetAll(ctx.Request.Context(), params)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": purchaseOrders,
+			"meta": listing.NewMeta(params, total),
+		})
+	}
+}
+
 // @Summary Create purchase order
 // @Tags Purchase Orders
-// @Description Create a new purchase order
+// @Description Create a new purchase order, reserving stock for its lines in the order warehouse
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param purchaseOrder body domain.PurchaseOrderRequest true "Purchase Order to create"
-// @Success 201 {object} domain.PurchaseOrder
-// @Failure 409 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 500 {object} schemas.JSONBadReqResult{error=string}
+// @Success 201 {object} schemas.JSONSuccessResult{data=domain.PurchaseOrderWithDetails}
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /purchaseOrders [post]
 func (c PurchaseOrderController) Create() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req request
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
-				"message": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 
+		orderDetails := make([]domain.OrderDetail, 0, len(req.OrderDetails))
+		for _, orderDetail := range req.OrderDetails {
+			orderDetails = append(orderDetails, domain.OrderDetail{
+				CleanLinessStatus: orderDetail.CleanLinessStatus,
+				Quantity:          orderDetail.Quantity,
+				Temperature:       orderDetail.Temperature,
+				ProductRecordId:   orderDetail.ProductRecordId,
+			})
+		}
+
 		purchaseOrder, err := c.purchaseOrder.Create(
-			ctx,
+			ctx.Request.Context(),
 			req.OrderNumber,
 			req.OrderDate,
 			req.TrackingCode,
 			req.BuyerId,
 			req.CarrierId,
-			req.OrderStatusId,
 			req.WarehouseId,
+			orderDetails,
 		)
 
 		if err != nil {
-			if errors.Is(err, domain.ErrDuplicatedOrderNumber) {
-				ctx.JSON(http.StatusConflict, gin.H{
-					"message": err.Error(),
-				})
-				return
-			}
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"message": err.Error(),
-			})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -87,3 +134,378 @@ func (c PurchaseOrderController) Create() gin.HandlerFunc {
 		})
 	}
 }
+
+// @Summary Purchase order by id
+// @Tags Purchase Orders
+// @Description Get a purchase order with its lines and totals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.PurchaseOrderWithDetails}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /purchaseOrders/{id} [get]
+func (c PurchaseOrderController) GetById() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		purchaseOrder, err := c.purchaseOrder.GetById(ctx.Request.Context(), id)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": purchaseOrder,
+		})
+	}
+}
+
+// @Summary Purchase order by tracking code
+// @Tags Purchase Orders
+// @Description Get a purchase order with its lines and totals by its tracking code
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code path string true "Tracking code"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.PurchaseOrderWithDetails}
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /purchaseOrders/tracking/{code} [get]
+func (c PurchaseOrderController) GetByTrackingCode() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		purchaseOrder, err := c.purchaseOrder.GetByTrackingCode(ctx.Request.Context(), ctx.Param("code"))
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": purchaseOrder,
+		})
+	}
+}
+
+// @Summary Update purchase order
+// @Tags Purchase Orders
+// @Description Change a purchase order that has not shipped yet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Param purchaseOrder body domain.PurchaseOrderUpdateRequest true "Fields to update"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.PurchaseOrderWithDetails}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Router /purchaseOrders/{id} [patch]
+func (c PurchaseOrderController) Update() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		var req domain.PurchaseOrderUpdateRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		purchaseOrder, err := c.purchaseOrder.Update(ctx.Request.Context(), id, req)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": purchaseOrder,
+		})
+	}
+}
+
+// @Summary Delete purchase order
+// @Tags Purchase Orders
+// @Description Remove a purchase order that has not shipped yet, with its lines and history
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Success 204 {object} schemas.JSONSuccessResult{data=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Router /purchaseOrders/{id} [delete]
+func (c PurchaseOrderController) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := c.purchaseOrder.Delete(ctx.Request.Context(), id); err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusNoContent, gin.H{
+			"data": fmt.Sprintf("purchase order %d removed", id),
+		})
+	}
+}
+
+// @Summary Create order detail
+// @Tags Purchase Orders
+// @Description Add a line to a purchase order that is still open
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Param orderDetail body domain.OrderDetailRequest true "Order detail to create"
+// @Success 201 {object} schemas.JSONSuccessResult{data=domain.OrderDetail}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Router /purchaseOrders/{id}/orderDetails [post]
+func (c PurchaseOrderController) CreateOrderDetail() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		var req domain.OrderDetailRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		orderDetail, err := c.purchaseOrder.CreateOrderDetail(ctx.Request.Context(), id, domain.OrderDetail{
+			CleanLinessStatus: req.CleanLinessStatus,
+			Quantity:          req.Quantity,
+			Temperature:       req.Temperature,
+			ProductRecordId:   req.ProductRecordId,
+		})
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, gin.H{
+			"data": orderDetail,
+		})
+	}
+}
+
+// @Summary Update order detail
+// @Tags Purchase Orders
+// @Description Change a line of a purchase order that is still open
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Param detailId path int true "Order detail ID"
+// @Param orderDetail body domain.OrderDetailUpdateRequest true "Fields to update"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.OrderDetail}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Router /purchaseOrders/{id}/orderDetails/{detailId} [patch]
+func (c PurchaseOrderController) UpdateOrderDetail() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, detailId, err := parseOrderDetailIds(ctx)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		var req domain.OrderDetailUpdateRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		orderDetail, err := c.purchaseOrder.UpdateOrderDetail(ctx.Request.Context(), id, detailId, req)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": orderDetail,
+		})
+	}
+}
+
+// @Summary Delete order detail
+// @Tags Purchase Orders
+// @Description Remove a line from a purchase order that is still open
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Param detailId path int true "Order detail ID"
+// @Success 204 {object} schemas.JSONSuccessResult{data=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Router /purchaseOrders/{id}/orderDetails/{detailId} [delete]
+func (c PurchaseOrderController) DeleteOrderDetail() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, detailId, err := parseOrderDetailIds(ctx)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := c.purchaseOrder.DeleteOrderDetail(ctx.Request.Context(), id, detailId); err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusNoContent, gin.H{
+			"data": fmt.Sprintf("order detail %d removed", detailId),
+		})
+	}
+}
+
+// @Summary Start picking purchase order
+// @Tags Purchase Orders
+// @Description Move a created purchase order to picking
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.PurchaseOrderWithDetails}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Router /purchaseOrders/{id}/pick [post]
+func (c PurchaseOrderController) Pick() gin.HandlerFunc {
+	return c.updateStatus(domain.OrderStatusPicking)
+}
+
+// @Summary Ship purchase order
+// @Tags Purchase Orders
+// @Description Move a purchase order that is being picked to shipped, taking its reserved stock out of the batches
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.PurchaseOrderWithDetails}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Router /purchaseOrders/{id}/ship [post]
+func (c PurchaseOrderController) Ship() gin.HandlerFunc {
+	return c.updateStatus(domain.OrderStatusShipped)
+}
+
+// @Summary Deliver purchase order
+// @Tags Purchase Orders
+// @Description Move a shipped purchase order to delivered
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.PurchaseOrderWithDetails}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Router /purchaseOrders/{id}/deliver [post]
+func (c PurchaseOrderController) Deliver() gin.HandlerFunc {
+	return c.updateStatus(domain.OrderStatusDelivered)
+}
+
+// @Summary Cancel purchase order
+// @Tags Purchase Orders
+// @Description Cancel a purchase order that has not been shipped, releasing its reserved stock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.PurchaseOrderWithDetails}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Router /purchaseOrders/{id}/cancel [post]
+func (c PurchaseOrderController) Cancel() gin.HandlerFunc {
+	return c.updateStatus(domain.OrderStatusCancelled)
+}
+
+func (c PurchaseOrderController) updateStatus(toStatusId int64) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		purchaseOrder, err := c.purchaseOrder.UpdateStatus(ctx.Request.Context(), id, toStatusId)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": purchaseOrder,
+		})
+	}
+}
+
+// @Summary Purchase order status history
+// @Tags Purchase Orders
+// @Description List every status a purchase order went through
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=[]domain.OrderStatusHistory}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Router /purchaseOrders/{id}/history [get]
+func (c PurchaseOrderController) GetStatusHistory() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		history, err := c.purchaseOrder.GetStatusHistory(ctx.Request.Context(), id)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": history,
+		})
+	}
+}
+
+func parseOrderDetailIds(ctx *gin.Context) (int64, int64, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	detailId, err := strconv.ParseInt(ctx.Param("detailId"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return id, detailId, nil
+}
@@ -4,13 +4,20 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/mocks"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/service"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -32,7 +39,7 @@ func TestCreateNewPurchaseOrder(t *testing.T) {
 			mock.Anything,
 			mock.Anything,
 			mock.Anything,
-		).Return(&mockPurchaseOrder, nil).Once()
+		).Return(&domain.PurchaseOrderWithDetails{PurchaseOrder: mockPurchaseOrder}, nil).Once()
 
 		payload, err := json.Marshal(mockPurchaseOrder)
 		assert.NoError(t, err)
@@ -123,4 +130,683 @@ func TestCreateNewPurchaseOrder(t *testing.T) {
 
 		purchaseOrderServiceMock.AssertExpectations(t)
 	})
+
+	t.Run("fail with insufficient stock", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("Create",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, domain.ErrInsufficientStock).Once()
+
+		payload, err := json.Marshal(mockPurchaseOrder)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/purchaseOrders", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.POST("/api/v1/purchaseOrders", purchaseOrderController.Create())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("fail with unknown buyer", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("Create",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, &apperrors.Error{
+			Kind:    apperrors.ErrForeignKey,
+			Entity:  "buyers",
+			Field:   "buyer_id",
+			Message: "buyer_id: no buyers with that id",
+		}).Once()
+
+		payload, err := json.Marshal(mockPurchaseOrder)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/purchaseOrders", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.POST("/api/v1/purchaseOrders", purchaseOrderController.Create())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.JSONEq(t, `{
+			"type": "/problems/foreign-key",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "buyer_id: no buyers with that id",
+			"errors": [{"field": "buyer_id", "rule": "exists", "param": "buyers", "message": "buyer_id: no buyers with that id"}]
+		}`, rec.Body.String())
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("GetById",
+			mock.Anything,
+			mockPurchaseOrder.ID,
+		).Return(&domain.PurchaseOrderWithDetails{
+			PurchaseOrder: mockPurchaseOrder,
+			OrderDetails:  utils.CreateRandomListOrderDetails(),
+		}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/purchaseOrders/%d", mockPurchaseOrder.ID), nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.GET("/api/v1/purchaseOrders/:id", purchaseOrderController.GetById())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("fail with bad request", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/purchaseOrders/abc", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.GET("/api/v1/purchaseOrders/:id", purchaseOrderController.GetById())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("fail with not found", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("GetById",
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, domain.ErrPurchaseOrderNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/purchaseOrders/1", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.GET("/api/v1/purchaseOrders/:id", purchaseOrderController.GetById())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("success with filters", func(t *testing.T) {
+		mockPurchaseOrders := []domain.PurchaseOrder{utils.CreateRandomPurchaseOrder()}
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("GetAll",
+			mock.Anything,
+			mock.MatchedBy(func(params listing.Params) bool {
+				return len(params.Filters) == 3
+			}),
+		).Return(&mockPurchaseOrders, int64(1), nil).Once()
+
+		req := httptest.NewRequest(
+			http.MethodGet,
+			"/api/v1/purchaseOrders?buyer_id=1&order_date_from=2022-01-01&order_date_to=2022-01-31",
+			nil,
+		)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.GET("/api/v1/purchaseOrders", purchaseOrderController.GetAll())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("fail with bad request", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/purchaseOrders?sort=unknown", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.GET("/api/v1/purchaseOrders", purchaseOrderController.GetAll())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestGetByTrackingCode(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("GetByTrackingCode",
+			mock.Anything,
+			mockPurchaseOrder.TrackingCode,
+		).Return(&domain.PurchaseOrderWithDetails{PurchaseOrder: mockPurchaseOrder}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/purchaseOrders/tracking/"+mockPurchaseOrder.TrackingCode, nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.GET("/api/v1/purchaseOrders/tracking/:code", purchaseOrderController.GetByTrackingCode())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("fail with not found", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("GetByTrackingCode",
+			mock.Anything,
+			"unknown",
+		).Return(nil, domain.ErrPurchaseOrderNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/purchaseOrders/tracking/unknown", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.GET("/api/v1/purchaseOrders/tracking/:code", purchaseOrderController.GetByTrackingCode())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestUpdatePurchaseOrder(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("Update",
+			mock.Anything,
+			mockPurchaseOrder.ID,
+			domain.PurchaseOrderUpdateRequest{CarrierId: 2},
+		).Return(&domain.PurchaseOrderWithDetails{PurchaseOrder: mockPurchaseOrder}, nil).Once()
+
+		req := httptest.NewRequest(
+			http.MethodPatch,
+			fmt.Sprintf("/api/v1/purchaseOrders/%d", mockPurchaseOrder.ID),
+			bytes.NewBufferString(`{"carrier_id": 2}`),
+		)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.PATCH("/api/v1/purchaseOrders/:id", purchaseOrderController.Update())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("fail with conflict when shipped", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("Update",
+			mock.Anything,
+			int64(1),
+			mock.Anything,
+		).Return(nil, domain.ErrPurchaseOrderShipped).Once()
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/purchaseOrders/1", bytes.NewBufferString(`{"carrier_id": 2}`))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.PATCH("/api/v1/purchaseOrders/:id", purchaseOrderController.Update())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("fail with unprocessable entity", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/purchaseOrders/1", bytes.NewBufferString(`{"carrier_id": "abc"}`))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.PATCH("/api/v1/purchaseOrders/:id", purchaseOrderController.Update())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
+
+func TestDeletePurchaseOrder(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("Delete", mock.Anything, int64(1)).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/purchaseOrders/1", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.DELETE("/api/v1/purchaseOrders/:id", purchaseOrderController.Delete())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("fail with conflict when shipped", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("Delete", mock.Anything, int64(1)).Return(domain.ErrPurchaseOrderShipped).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/purchaseOrders/1", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.DELETE("/api/v1/purchaseOrders/:id", purchaseOrderController.Delete())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func TestCreateOrderDetail(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockOrderDetail := utils.CreateRandomOrderDetail()
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("CreateOrderDetail",
+			mock.Anything,
+			int64(1),
+			mock.Anything,
+		).Return(&mockOrderDetail, nil).Once()
+
+		payload, err := json.Marshal(mockOrderDetail)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/purchaseOrders/1/orderDetails", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.POST("/api/v1/purchaseOrders/:id/orderDetails", purchaseOrderController.CreateOrderDetail())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("fail with unprocessable entity", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		payload, err := json.Marshal(domain.OrderDetailRequest{Quantity: -1})
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/purchaseOrders/1/orderDetails", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.POST("/api/v1/purchaseOrders/:id/orderDetails", purchaseOrderController.CreateOrderDetail())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("fail with status conflict", func(t *testing.T) {
+		mockOrderDetail := utils.CreateRandomOrderDetail()
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("CreateOrderDetail",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, domain.ErrPurchaseOrderNotOpen).Once()
+
+		payload, err := json.Marshal(mockOrderDetail)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/purchaseOrders/1/orderDetails", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.POST("/api/v1/purchaseOrders/:id/orderDetails", purchaseOrderController.CreateOrderDetail())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+}
+
+func TestUpdateOrderDetail(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockOrderDetail := utils.CreateRandomOrderDetail()
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("UpdateOrderDetail",
+			mock.Anything,
+			int64(1),
+			mockOrderDetail.ID,
+			mock.Anything,
+		).Return(&mockOrderDetail, nil).Once()
+
+		payload, err := json.Marshal(domain.OrderDetailUpdateRequest{Quantity: mockOrderDetail.Quantity})
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/purchaseOrders/1/orderDetails/%d", mockOrderDetail.ID), bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.PATCH("/api/v1/purchaseOrders/:id/orderDetails/:detailId", purchaseOrderController.UpdateOrderDetail())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("fail with not found", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("UpdateOrderDetail",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, domain.ErrOrderDetailNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/purchaseOrders/1/orderDetails/2", bytes.NewBufferString("{}"))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.PATCH("/api/v1/purchaseOrders/:id/orderDetails/:detailId", purchaseOrderController.UpdateOrderDetail())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+}
+
+func TestDeleteOrderDetail(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("DeleteOrderDetail",
+			mock.Anything,
+			int64(1),
+			int64(2),
+		).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/purchaseOrders/1/orderDetails/2", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.DELETE("/api/v1/purchaseOrders/:id/orderDetails/:detailId", purchaseOrderController.DeleteOrderDetail())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("fail with bad request", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/purchaseOrders/1/orderDetails/abc", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.DELETE("/api/v1/purchaseOrders/:id/orderDetails/:detailId", purchaseOrderController.DeleteOrderDetail())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestUpdateStatus(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		mockPurchaseOrder.OrderStatusId = domain.OrderStatusShipped
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("UpdateStatus",
+			mock.Anything,
+			mockPurchaseOrder.ID,
+			domain.OrderStatusShipped,
+		).Return(&domain.PurchaseOrderWithDetails{PurchaseOrder: mockPurchaseOrder}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/purchaseOrders/%d/ship", mockPurchaseOrder.ID), nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.POST("/api/v1/purchaseOrders/:id/ship", purchaseOrderController.Ship())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("fail with status conflict", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("UpdateStatus",
+			mock.Anything,
+			int64(1),
+			domain.OrderStatusCancelled,
+		).Return(nil, domain.ErrInvalidStatusTransition).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/purchaseOrders/1/cancel", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.POST("/api/v1/purchaseOrders/:id/cancel", purchaseOrderController.Cancel())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+}
+
+func TestGetStatusHistory(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("GetStatusHistory",
+			mock.Anything,
+			int64(1),
+		).Return(&[]domain.OrderStatusHistory{{ID: 1, PurchaseOrderId: 1, ToStatusId: domain.OrderStatusCreated}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/purchaseOrders/1/history", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.GET("/api/v1/purchaseOrders/:id/history", purchaseOrderController.GetStatusHistory())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("fail with not found", func(t *testing.T) {
+		purchaseOrderServiceMock := mocks.NewPurchaseOrderService(t)
+
+		purchaseOrderServiceMock.On("GetStatusHistory",
+			mock.Anything,
+			int64(1),
+		).Return(nil, domain.ErrPurchaseOrderNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/purchaseOrders/1/history", nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		purchaseOrderController := PurchaseOrderController{purchaseOrder: purchaseOrderServiceMock}
+
+		engine.GET("/api/v1/purchaseOrders/:id/history", purchaseOrderController.GetStatusHistory())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		purchaseOrderServiceMock.AssertExpectations(t)
+	})
+}
+
+func TestWarehouseScope(t *testing.T) {
+	signer := auth.NewSigner([]byte("secret"), time.Hour)
+	warehouseId := int64(1)
+	token, err := signer.Sign(auth.Claims{Subject: "ana", Roles: []string{policy.Employee}, WarehouseId: &warehouseId})
+	assert.NoError(t, err)
+
+	repositoryMock := mocks.NewPurchaseOrderRepository(t)
+	repositoryMock.On("GetById", mock.Anything, int64(1)).
+		Return(&domain.PurchaseOrder{ID: 1, OrderStatusId: domain.OrderStatusCreated, WarehouseId: 99}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/purchaseOrders/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	_, engine := gin.CreateTestContext(rec)
+
+	purchaseOrderController := PurchaseOrderController{purchaseOrder: service.NewPurchaseOrderService(repositoryMock)}
+
+	engine.DELETE("/api/v1/purchaseOrders/:id", auth.Middleware(signer), purchaseOrderController.Delete())
+
+	engine.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
 }
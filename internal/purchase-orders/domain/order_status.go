@@ -0,0 +1,66 @@
+package domain
+
+// Ids of the rows seeded in the order_status table.
+const (
+	OrderStatusCreated int64 = iota + 1
+	OrderStatusPicking
+	OrderStatusShipped
+	OrderStatusDelivered
+	OrderStatusCancelled
+)
+
+var orderStatusNames = map[int64]string{
+	OrderStatusCreated:   "created",
+	OrderStatusPicking:   "picking",
+	OrderStatusShipped:   "shipped",
+	OrderStatusDelivered: "delivered",
+	OrderStatusCancelled: "cancelled",
+}
+
+// orderStatusTransitions lists the statuses an order can move to from each
+// status. Delivered and cancelled orders are final.
+var orderStatusTransitions = map[int64][]int64{
+	OrderStatusCreated: {OrderStatusPicking, OrderStatusCancelled},
+	OrderStatusPicking: {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped: {OrderStatusDelivered},
+}
+
+type OrderStatusHistory struct {
+	ID              int64  `json:"id"`
+	PurchaseOrderId int64  `json:"purchase_order_id"`
+	FromStatusId    int64  `json:"from_status_id,omitempty"`
+	FromStatus      string `json:"from_status,omitempty"`
+	ToStatusId      int64  `json:"to_status_id"`
+	ToStatus        string `json:"to_status"`
+	ChangedAt       string `json:"changed_at"`
+}
+
+// PurchaseOrderStatusChanged is the payload of the event published when an
+// order changes status.
+type PurchaseOrderStatusChanged struct {
+	PurchaseOrderId int64  `json:"purchase_order_id"`
+	WarehouseId     int64  `json:"warehouse_id"`
+	FromStatusId    int64  `json:"from_status_id"`
+	FromStatus      string `json:"from_status"`
+	ToStatusId      int64  `json:"to_status_id"`
+	ToStatus        string `json:"to_status"`
+}
+
+func OrderStatusName(status int64) string {
+	return orderStatusNames[status]
+}
+
+func CanTransition(from, to int64) bool {
+	for _, status := range orderStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// HasShipped reports whether an order in status has already left the
+// warehouse, after which it can no longer be edited or removed.
+func HasShipped(status int64) bool {
+	return status == OrderStatusShipped || status == OrderStatusDelivered
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 )
 
 type PurchaseOrder struct {
@@ -16,22 +18,104 @@ type PurchaseOrder struct {
 }
 
 type PurchaseOrderRequest struct {
-	OrderNumber   string `json:"order_number" binding:"required"`
-	OrderDate     string `json:"order_date" binding:"required"`
-	TrackingCode  string `json:"tracking_code" binding:"required"`
-	BuyerId       int64  `json:"buyer_id" binding:"required"`
-	CarrierId     int64  `json:"carrier_id" binding:"required"`
-	OrderStatusId int64  `json:"order_status_id" binding:"required"`
-	WarehouseId   int64  `json:"warehouse_id" binding:"required"`
+	OrderNumber  string               `json:"order_number" binding:"required"`
+	OrderDate    string               `json:"order_date" binding:"required"`
+	TrackingCode string               `json:"tracking_code" binding:"required"`
+	BuyerId      int64                `json:"buyer_id" binding:"required"`
+	CarrierId    int64                `json:"carrier_id" binding:"required"`
+	WarehouseId  int64                `json:"warehouse_id" binding:"required"`
+	OrderDetails []OrderDetailRequest `json:"order_details" binding:"dive"`
+}
+
+// PurchaseOrderUpdateRequest only changes the fields that are sent.
+type PurchaseOrderUpdateRequest struct {
+	OrderNumber  string `json:"order_number"`
+	OrderDate    string `json:"order_date"`
+	TrackingCode string `json:"tracking_code"`
+	BuyerId      int64  `json:"buyer_id"`
+	CarrierId    int64  `json:"carrier_id"`
+	WarehouseId  int64  `json:"warehouse_id"`
+}
+
+type OrderDetail struct {
+	ID                int64   `json:"id"`
+	CleanLinessStatus string  `json:"clean_liness_status"`
+	Quantity          int64   `json:"quantity"`
+	Temperature       float64 `json:"temperature"`
+	ProductRecordId   int64   `json:"product_record_id"`
+	PurchaseOrderId   int64   `json:"purchase_order_id"`
+	SalePrice         float64 `json:"sale_price"`
+	Total             float64 `json:"total"`
+}
+
+// PurchaseOrderCreated is the payload of the event published when an order
+// is created with its lines.
+type PurchaseOrderCreated struct {
+	PurchaseOrder
+	OrderDetails []OrderDetail `json:"order_details"`
+}
+
+type OrderDetailRequest struct {
+	CleanLinessStatus string  `json:"clean_liness_status" binding:"required"`
+	Quantity          int64   `json:"quantity" binding:"required,gt=0"`
+	Temperature       float64 `json:"temperature"`
+	ProductRecordId   int64   `json:"product_record_id" binding:"required"`
+}
+
+// OrderDetailUpdateRequest only changes the fields that are sent.
+type OrderDetailUpdateRequest struct {
+	CleanLinessStatus string   `json:"clean_liness_status"`
+	Quantity          int64    `json:"quantity" binding:"omitempty,gt=0"`
+	Temperature       *float64 `json:"temperature"`
+	ProductRecordId   int64    `json:"product_record_id"`
+}
+
+// PurchaseOrderWithDetails is an order with its lines. Totals are computed
+// from the quantity and the sale price of the product record of each line.
+type PurchaseOrderWithDetails struct {
+	PurchaseOrder
+	OrderDetails  []OrderDetail `json:"order_details"`
+	TotalQuantity int64         `json:"total_quantity"`
+	TotalPrice    float64       `json:"total_price"`
+}
+
+// PurchaseOrderListFields are the fields accepted by GET /purchaseOrders.
+// order_date is filtered with order_date_from and order_date_to.
+var PurchaseOrderListFields = listing.Fields{
+	Sort:   []string{"id", "order_number", "order_date", "buyer_id", "carrier_id", "warehouse_id", "order_status_id"},
+	Filter: []string{"buyer_id", "carrier_id", "warehouse_id", "order_status_id"},
+	Range:  []string{"order_date"},
 }
 
 type PurchaseOrderRepository interface {
+	GetAll(ctx context.Context, params listing.Params) (*[]PurchaseOrder, int64, error)
 	Create(
-		ctx context.Context, orderNumber, orderDate, trackingCode string, buyerId, carrierId, orderStatusId, warehouseId int64) (*PurchaseOrder, error)
+		ctx context.Context, orderNumber, orderDate, trackingCode string, buyerId, carrierId, orderStatusId, warehouseId int64, orderDetails []OrderDetail) (*PurchaseOrder, error)
+	GetById(ctx context.Context, id int64) (*PurchaseOrder, error)
 	GetByOrderNumber(ctx context.Context, orderNumber string) (*PurchaseOrder, error)
+	GetByTrackingCode(ctx context.Context, trackingCode string) (*PurchaseOrder, error)
+	Update(ctx context.Context, purchaseOrder *PurchaseOrder) (*PurchaseOrder, error)
+	Delete(ctx context.Context, id int64) error
+	GetOrderDetails(ctx context.Context, purchaseOrderId int64) (*[]OrderDetail, error)
+	GetOrderDetailById(ctx context.Context, id int64) (*OrderDetail, error)
+	CreateOrderDetail(ctx context.Context, warehouseId int64, orderDetail *OrderDetail) (*OrderDetail, error)
+	UpdateOrderDetail(ctx context.Context, warehouseId int64, orderDetail *OrderDetail) (*OrderDetail, error)
+	DeleteOrderDetail(ctx context.Context, id int64) error
+	UpdateStatus(ctx context.Context, id, fromStatusId, toStatusId int64) error
+	GetStatusHistory(ctx context.Context, id int64) (*[]OrderStatusHistory, error)
 }
 
 type PurchaseOrderService interface {
+	GetAll(ctx context.Context, params listing.Params) (*[]PurchaseOrder, int64, error)
 	Create(
-		ctx context.Context, orderNumber, orderDate, trackingCode string, buyerId, carrierId, orderStatusId, warehouseId int64) (*PurchaseOrder, error)
+		ctx context.Context, orderNumber, orderDate, trackingCode string, buyerId, carrierId, warehouseId int64, orderDetails []OrderDetail) (*PurchaseOrderWithDetails, error)
+	GetById(ctx context.Context, id int64) (*PurchaseOrderWithDetails, error)
+	GetByTrackingCode(ctx context.Context, trackingCode string) (*PurchaseOrderWithDetails, error)
+	Update(ctx context.Context, id int64, purchaseOrder PurchaseOrderUpdateRequest) (*PurchaseOrderWithDetails, error)
+	Delete(ctx context.Context, id int64) error
+	CreateOrderDetail(ctx context.Context, purchaseOrderId int64, orderDetail OrderDetail) (*OrderDetail, error)
+	UpdateOrderDetail(ctx context.Context, purchaseOrderId, id int64, orderDetail OrderDetailUpdateRequest) (*OrderDetail, error)
+	DeleteOrderDetail(ctx context.Context, purchaseOrderId, id int64) error
+	UpdateStatus(ctx context.Context, id, toStatusId int64) (*PurchaseOrderWithDetails, error)
+	GetStatusHistory(ctx context.Context, id int64) (*[]OrderStatusHistory, error)
 }
@@ -1,7 +1,13 @@
 package domain
 
-import "errors"
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
 
 var (
-	ErrDuplicatedOrderNumber = errors.New("duplicated order number")
+	ErrDuplicatedOrderNumber   = apperrors.Conflict("duplicated order number")
+	ErrPurchaseOrderNotFound   = apperrors.NotFound("purchase order not found")
+	ErrOrderDetailNotFound     = apperrors.NotFound("order detail not found")
+	ErrPurchaseOrderNotOpen    = apperrors.Conflict("purchase order is no longer open")
+	ErrInvalidStatusTransition = apperrors.Conflict("invalid order status transition")
+	ErrPurchaseOrderShipped    = apperrors.Conflict("purchase order has already shipped")
+	ErrInsufficientStock       = apperrors.Conflict("insufficient stock")
 )
@@ -5,7 +5,9 @@ package mocks
 import (
 	context "context"
 
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/domain"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,13 +16,13 @@ type PurchaseOrderRepository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, orderStatusId, warehouseId
-func (_m *PurchaseOrderRepository) Create(ctx context.Context, orderNumber string, orderDate string, trackingCode string, buyerId int64, carrierId int64, orderStatusId int64, warehouseId int64) (*domain.PurchaseOrder, error) {
-	ret := _m.Called(ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, orderStatusId, warehouseId)
+// Create provides a mock function with given fields: ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, orderStatusId, warehouseId, orderDetails
+func (_m *PurchaseOrderRepository) Create(ctx context.Context, orderNumber string, orderDate string, trackingCode string, buyerId int64, carrierId int64, orderStatusId int64, warehouseId int64, orderDetails []domain.OrderDetail) (*domain.PurchaseOrder, error) {
+	ret := _m.Called(ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, orderStatusId, warehouseId, orderDetails)
 
 	var r0 *domain.PurchaseOrder
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int64, int64, int64, int64) *domain.PurchaseOrder); ok {
-		r0 = rf(ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, orderStatusId, warehouseId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int64, int64, int64, int64, []domain.OrderDetail) *domain.PurchaseOrder); ok {
+		r0 = rf(ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, orderStatusId, warehouseId, orderDetails)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.PurchaseOrder)
@@ -28,8 +30,112 @@ func (_m *PurchaseOrderRepository) Create(ctx context.Context, orderNumber strin
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int64, int64, int64, int64) error); ok {
-		r1 = rf(ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, orderStatusId, warehouseId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int64, int64, int64, int64, []domain.OrderDetail) error); ok {
+		r1 = rf(ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, orderStatusId, warehouseId, orderDetails)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateOrderDetail provides a mock function with given fields: ctx, warehouseId, orderDetail
+func (_m *PurchaseOrderRepository) CreateOrderDetail(ctx context.Context, warehouseId int64, orderDetail *domain.OrderDetail) (*domain.OrderDetail, error) {
+	ret := _m.Called(ctx, warehouseId, orderDetail)
+
+	var r0 *domain.OrderDetail
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.OrderDetail) *domain.OrderDetail); ok {
+		r0 = rf(ctx, warehouseId, orderDetail)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OrderDetail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *domain.OrderDetail) error); ok {
+		r1 = rf(ctx, warehouseId, orderDetail)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *PurchaseOrderRepository) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOrderDetail provides a mock function with given fields: ctx, id
+func (_m *PurchaseOrderRepository) DeleteOrderDetail(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, params
+func (_m *PurchaseOrderRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.PurchaseOrder, int64, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *[]domain.PurchaseOrder
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.PurchaseOrder); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.PurchaseOrder)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetById provides a mock function with given fields: ctx, id
+func (_m *PurchaseOrderRepository) GetById(ctx context.Context, id int64) (*domain.PurchaseOrder, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.PurchaseOrder
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.PurchaseOrder); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PurchaseOrder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,6 +166,158 @@ func (_m *PurchaseOrderRepository) GetByOrderNumber(ctx context.Context, orderNu
 	return r0, r1
 }
 
+// GetByTrackingCode provides a mock function with given fields: ctx, trackingCode
+func (_m *PurchaseOrderRepository) GetByTrackingCode(ctx context.Context, trackingCode string) (*domain.PurchaseOrder, error) {
+	ret := _m.Called(ctx, trackingCode)
+
+	var r0 *domain.PurchaseOrder
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.PurchaseOrder); ok {
+		r0 = rf(ctx, trackingCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PurchaseOrder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, trackingCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrderDetailById provides a mock function with given fields: ctx, id
+func (_m *PurchaseOrderRepository) GetOrderDetailById(ctx context.Context, id int64) (*domain.OrderDetail, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.OrderDetail
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.OrderDetail); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OrderDetail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrderDetails provides a mock function with given fields: ctx, purchaseOrderId
+func (_m *PurchaseOrderRepository) GetOrderDetails(ctx context.Context, purchaseOrderId int64) (*[]domain.OrderDetail, error) {
+	ret := _m.Called(ctx, purchaseOrderId)
+
+	var r0 *[]domain.OrderDetail
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *[]domain.OrderDetail); ok {
+		r0 = rf(ctx, purchaseOrderId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.OrderDetail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, purchaseOrderId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusHistory provides a mock function with given fields: ctx, id
+func (_m *PurchaseOrderRepository) GetStatusHistory(ctx context.Context, id int64) (*[]domain.OrderStatusHistory, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *[]domain.OrderStatusHistory
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *[]domain.OrderStatusHistory); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.OrderStatusHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, purchaseOrder
+func (_m *PurchaseOrderRepository) Update(ctx context.Context, purchaseOrder *domain.PurchaseOrder) (*domain.PurchaseOrder, error) {
+	ret := _m.Called(ctx, purchaseOrder)
+
+	var r0 *domain.PurchaseOrder
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.PurchaseOrder) *domain.PurchaseOrder); ok {
+		r0 = rf(ctx, purchaseOrder)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PurchaseOrder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.PurchaseOrder) error); ok {
+		r1 = rf(ctx, purchaseOrder)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOrderDetail provides a mock function with given fields: ctx, warehouseId, orderDetail
+func (_m *PurchaseOrderRepository) UpdateOrderDetail(ctx context.Context, warehouseId int64, orderDetail *domain.OrderDetail) (*domain.OrderDetail, error) {
+	ret := _m.Called(ctx, warehouseId, orderDetail)
+
+	var r0 *domain.OrderDetail
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.OrderDetail) *domain.OrderDetail); ok {
+		r0 = rf(ctx, warehouseId, orderDetail)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OrderDetail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *domain.OrderDetail) error); ok {
+		r1 = rf(ctx, warehouseId, orderDetail)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, fromStatusId, toStatusId
+func (_m *PurchaseOrderRepository) UpdateStatus(ctx context.Context, id int64, fromStatusId int64, toStatusId int64) error {
+	ret := _m.Called(ctx, id, fromStatusId, toStatusId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) error); ok {
+		r0 = rf(ctx, id, fromStatusId, toStatusId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewPurchaseOrderRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -5,7 +5,9 @@ package mocks
 import (
 	context "context"
 
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/domain"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,22 +16,241 @@ type PurchaseOrderService struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, orderStatusId, warehouseId
-func (_m *PurchaseOrderService) Create(ctx context.Context, orderNumber string, orderDate string, trackingCode string, buyerId int64, carrierId int64, orderStatusId int64, warehouseId int64) (*domain.PurchaseOrder, error) {
-	ret := _m.Called(ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, orderStatusId, warehouseId)
+// Create provides a mock function with given fields: ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, warehouseId, orderDetails
+func (_m *PurchaseOrderService) Create(ctx context.Context, orderNumber string, orderDate string, trackingCode string, buyerId int64, carrierId int64, warehouseId int64, orderDetails []domain.OrderDetail) (*domain.PurchaseOrderWithDetails, error) {
+	ret := _m.Called(ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, warehouseId, orderDetails)
+
+	var r0 *domain.PurchaseOrderWithDetails
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int64, int64, int64, []domain.OrderDetail) *domain.PurchaseOrderWithDetails); ok {
+		r0 = rf(ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, warehouseId, orderDetails)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PurchaseOrderWithDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int64, int64, int64, []domain.OrderDetail) error); ok {
+		r1 = rf(ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, warehouseId, orderDetails)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateOrderDetail provides a mock function with given fields: ctx, purchaseOrderId, orderDetail
+func (_m *PurchaseOrderService) CreateOrderDetail(ctx context.Context, purchaseOrderId int64, orderDetail domain.OrderDetail) (*domain.OrderDetail, error) {
+	ret := _m.Called(ctx, purchaseOrderId, orderDetail)
+
+	var r0 *domain.OrderDetail
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.OrderDetail) *domain.OrderDetail); ok {
+		r0 = rf(ctx, purchaseOrderId, orderDetail)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OrderDetail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.OrderDetail) error); ok {
+		r1 = rf(ctx, purchaseOrderId, orderDetail)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *PurchaseOrderService) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOrderDetail provides a mock function with given fields: ctx, purchaseOrderId, id
+func (_m *PurchaseOrderService) DeleteOrderDetail(ctx context.Context, purchaseOrderId int64, id int64) error {
+	ret := _m.Called(ctx, purchaseOrderId, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, purchaseOrderId, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, params
+func (_m *PurchaseOrderService) GetAll(ctx context.Context, params listing.Params) (*[]domain.PurchaseOrder, int64, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *[]domain.PurchaseOrder
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.PurchaseOrder); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.PurchaseOrder)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetById provides a mock function with given fields: ctx, id
+func (_m *PurchaseOrderService) GetById(ctx context.Context, id int64) (*domain.PurchaseOrderWithDetails, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.PurchaseOrderWithDetails
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.PurchaseOrderWithDetails); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PurchaseOrderWithDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByTrackingCode provides a mock function with given fields: ctx, trackingCode
+func (_m *PurchaseOrderService) GetByTrackingCode(ctx context.Context, trackingCode string) (*domain.PurchaseOrderWithDetails, error) {
+	ret := _m.Called(ctx, trackingCode)
+
+	var r0 *domain.PurchaseOrderWithDetails
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.PurchaseOrderWithDetails); ok {
+		r0 = rf(ctx, trackingCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PurchaseOrderWithDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, trackingCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusHistory provides a mock function with given fields: ctx, id
+func (_m *PurchaseOrderService) GetStatusHistory(ctx context.Context, id int64) (*[]domain.OrderStatusHistory, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *[]domain.OrderStatusHistory
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *[]domain.OrderStatusHistory); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.OrderStatusHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, purchaseOrder
+func (_m *PurchaseOrderService) Update(ctx context.Context, id int64, purchaseOrder domain.PurchaseOrderUpdateRequest) (*domain.PurchaseOrderWithDetails, error) {
+	ret := _m.Called(ctx, id, purchaseOrder)
+
+	var r0 *domain.PurchaseOrderWithDetails
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.PurchaseOrderUpdateRequest) *domain.PurchaseOrderWithDetails); ok {
+		r0 = rf(ctx, id, purchaseOrder)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PurchaseOrderWithDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.PurchaseOrderUpdateRequest) error); ok {
+		r1 = rf(ctx, id, purchaseOrder)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOrderDetail provides a mock function with given fields: ctx, purchaseOrderId, id, orderDetail
+func (_m *PurchaseOrderService) UpdateOrderDetail(ctx context.Context, purchaseOrderId int64, id int64, orderDetail domain.OrderDetailUpdateRequest) (*domain.OrderDetail, error) {
+	ret := _m.Called(ctx, purchaseOrderId, id, orderDetail)
+
+	var r0 *domain.OrderDetail
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, domain.OrderDetailUpdateRequest) *domain.OrderDetail); ok {
+		r0 = rf(ctx, purchaseOrderId, id, orderDetail)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OrderDetail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, domain.OrderDetailUpdateRequest) error); ok {
+		r1 = rf(ctx, purchaseOrderId, id, orderDetail)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, toStatusId
+func (_m *PurchaseOrderService) UpdateStatus(ctx context.Context, id int64, toStatusId int64) (*domain.PurchaseOrderWithDetails, error) {
+	ret := _m.Called(ctx, id, toStatusId)
 
-	var r0 *domain.PurchaseOrder
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int64, int64, int64, int64) *domain.PurchaseOrder); ok {
-		r0 = rf(ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, orderStatusId, warehouseId)
+	var r0 *domain.PurchaseOrderWithDetails
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.PurchaseOrderWithDetails); ok {
+		r0 = rf(ctx, id, toStatusId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.PurchaseOrder)
+			r0 = ret.Get(0).(*domain.PurchaseOrderWithDetails)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int64, int64, int64, int64) error); ok {
-		r1 = rf(ctx, orderNumber, orderDate, trackingCode, buyerId, carrierId, orderStatusId, warehouseId)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, toStatusId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4,7 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	auditlog "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	outbox "github.com/marcoglnd/mercado-fresco-packmain/internal/events/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	movements "github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+	ledger "github.com/marcoglnd/mercado-fresco-packmain/internal/movements/repository/mariadb"
+	picking "github.com/marcoglnd/mercado-fresco-packmain/internal/picking/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/domain"
 )
 
@@ -16,25 +27,75 @@ func NewMariaDBRepository(db *sql.DB) domain.PurchaseOrderRepository {
 	return mariadbRepository{db: db}
 }
 
+func (m mariadbRepository) GetAll(
+	ctx context.Context,
+	params listing.Params,
+) (*[]domain.PurchaseOrder, int64, error) {
+	purchaseOrders := []domain.PurchaseOrder{}
+
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAll, params, nil)
+	if err := m.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &purchaseOrders, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAll, params, nil)
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return &purchaseOrders, 0, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var purchaseOrder domain.PurchaseOrder
+
+		if err := scanPurchaseOrder(rows, &purchaseOrder); err != nil {
+			return &purchaseOrders, 0, err
+		}
+
+		purchaseOrders = append(purchaseOrders, purchaseOrder)
+	}
+
+	if err := rows.Err(); err != nil {
+		return &purchaseOrders, 0, err
+	}
+
+	return &purchaseOrders, total, nil
+}
+
+func (m mariadbRepository) GetById(
+	ctx context.Context,
+	id int64,
+) (*domain.PurchaseOrder, error) {
+	return m.getOne(ctx, sqlGetById, id)
+}
+
 func (m mariadbRepository) GetByOrderNumber(
 	ctx context.Context,
 	orderNumber string,
+) (*domain.PurchaseOrder, error) {
+	return m.getOne(ctx, sqlGetByOrderNumber, orderNumber)
+}
+
+func (m mariadbRepository) GetByTrackingCode(
+	ctx context.Context,
+	trackingCode string,
+) (*domain.PurchaseOrder, error) {
+	return m.getOne(ctx, sqlGetByTrackingCode, trackingCode)
+}
+
+func (m mariadbRepository) getOne(
+	ctx context.Context,
+	query string,
+	args ...interface{},
 ) (*domain.PurchaseOrder, error) {
 	row := m.db.QueryRowContext(
-		ctx, sqlGetByOrderNumber, orderNumber,
+		ctx, query, args...,
 	)
 
 	foundPurchaseOrder := &domain.PurchaseOrder{}
-	err := row.Scan(
-		&foundPurchaseOrder.ID,
-		&foundPurchaseOrder.OrderNumber,
-		&foundPurchaseOrder.OrderDate,
-		&foundPurchaseOrder.TrackingCode,
-		&foundPurchaseOrder.BuyerId,
-		&foundPurchaseOrder.CarrierId,
-		&foundPurchaseOrder.OrderStatusId,
-		&foundPurchaseOrder.WarehouseId,
-	)
+	err := scanPurchaseOrder(row, foundPurchaseOrder)
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
@@ -47,6 +108,9 @@ func (m mariadbRepository) GetByOrderNumber(
 	return foundPurchaseOrder, nil
 }
 
+// Create inserts the order and its lines in a single transaction, so an
+// order is never stored without the lines it was placed with nor without the
+// stock reserved for them.
 func (m mariadbRepository) Create(
 	ctx context.Context,
 	orderNumber,
@@ -56,6 +120,7 @@ func (m mariadbRepository) Create(
 	carrierId,
 	orderStatusId,
 	warehouseId int64,
+	orderDetails []domain.OrderDetail,
 ) (*domain.PurchaseOrder, error) {
 	var newPurchaseOrder = domain.PurchaseOrder{
 		OrderNumber:   orderNumber,
@@ -67,11 +132,15 @@ func (m mariadbRepository) Create(
 		WarehouseId:   warehouseId,
 	}
 
-	query := sqlInsert
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &newPurchaseOrder, err
+	}
+	defer tx.Rollback()
 
-	result, err := m.db.ExecContext(
+	result, err := tx.ExecContext(
 		ctx,
-		query,
+		sqlInsert,
 		&newPurchaseOrder.OrderNumber,
 		&newPurchaseOrder.OrderDate,
 		&newPurchaseOrder.TrackingCode,
@@ -81,7 +150,7 @@ func (m mariadbRepository) Create(
 		&newPurchaseOrder.WarehouseId,
 	)
 	if err != nil {
-		return &newPurchaseOrder, err
+		return &newPurchaseOrder, apperrors.FromMySQL(err)
 	}
 
 	lastID, err := result.LastInsertId()
@@ -91,5 +160,706 @@ func (m mariadbRepository) Create(
 
 	newPurchaseOrder.ID = lastID
 
+	if _, err := tx.ExecContext(ctx, sqlInsertStatusHistory, lastID, nil, orderStatusId); err != nil {
+		return &newPurchaseOrder, err
+	}
+
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "purchase_orders", lastID, nil); err != nil {
+		return &newPurchaseOrder, err
+	}
+
+	for i := range orderDetails {
+		orderDetails[i].PurchaseOrderId = lastID
+		if err := insertOrderDetail(ctx, tx, &orderDetails[i]); err != nil {
+			return &newPurchaseOrder, err
+		}
+
+		if err := reserveOrderDetail(ctx, tx, warehouseId, &orderDetails[i]); err != nil {
+			return &newPurchaseOrder, err
+		}
+	}
+
+	if err := outbox.Publish(
+		ctx, tx, events.TypePurchaseOrderCreated, "purchase_orders", lastID,
+		domain.PurchaseOrderCreated{PurchaseOrder: newPurchaseOrder, OrderDetails: orderDetails},
+	); err != nil {
+		return &newPurchaseOrder, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &newPurchaseOrder, err
+	}
+
 	return &newPurchaseOrder, nil
 }
+
+// Update saves the header of the order. When the order moves to another
+// warehouse the stock of its lines is reserved again from that warehouse.
+func (m mariadbRepository) Update(
+	ctx context.Context,
+	purchaseOrder *domain.PurchaseOrder,
+) (*domain.PurchaseOrder, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return purchaseOrder, err
+	}
+	defer tx.Rollback()
+
+	var warehouseId int64
+	if err := tx.QueryRowContext(ctx, sqlLockWarehouseId, purchaseOrder.ID).Scan(&warehouseId); err != nil {
+		return purchaseOrder, err
+	}
+
+	before, err := auditlog.Snapshot(ctx, tx, "purchase_orders", purchaseOrder.ID)
+	if err != nil {
+		return purchaseOrder, err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		sqlUpdate,
+		&purchaseOrder.OrderNumber,
+		&purchaseOrder.OrderDate,
+		&purchaseOrder.TrackingCode,
+		&purchaseOrder.BuyerId,
+		&purchaseOrder.CarrierId,
+		&purchaseOrder.WarehouseId,
+		&purchaseOrder.ID,
+	)
+	if err != nil {
+		return purchaseOrder, apperrors.FromMySQL(err)
+	}
+
+	if warehouseId != purchaseOrder.WarehouseId {
+		if err := moveReservations(ctx, tx, purchaseOrder); err != nil {
+			return purchaseOrder, err
+		}
+	}
+
+	if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "purchase_orders", purchaseOrder.ID, before); err != nil {
+		return purchaseOrder, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return purchaseOrder, err
+	}
+
+	return purchaseOrder, nil
+}
+
+// Delete removes the order together with its lines and status history,
+// releasing the stock reserved for it.
+func (m mariadbRepository) Delete(ctx context.Context, id int64) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "purchase_orders", id)
+	if err != nil {
+		return err
+	}
+
+	if err := settleReservations(
+		ctx, tx, sqlGetOrderReservedBatches, sqlReleaseOrderReservations, sqlDeleteOrderReservations, id,
+	); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlDeleteOrderDetails, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlDeleteStatusHistory, id); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, sqlDelete, id)
+	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affectedRows == 0 {
+		return domain.ErrPurchaseOrderNotFound
+	}
+
+	if err := auditlog.Record(ctx, tx, audit.ActionDelete, "purchase_orders", id, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m mariadbRepository) GetOrderDetails(
+	ctx context.Context,
+	purchaseOrderId int64,
+) (*[]domain.OrderDetail, error) {
+	orderDetails := []domain.OrderDetail{}
+
+	rows, err := m.db.QueryContext(ctx, sqlGetOrderDetails, purchaseOrderId)
+	if err != nil {
+		return &orderDetails, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderDetail domain.OrderDetail
+
+		if err := scanOrderDetail(rows, &orderDetail); err != nil {
+			return &orderDetails, err
+		}
+
+		orderDetails = append(orderDetails, orderDetail)
+	}
+
+	if err := rows.Err(); err != nil {
+		return &orderDetails, err
+	}
+
+	return &orderDetails, nil
+}
+
+func (m mariadbRepository) GetOrderDetailById(
+	ctx context.Context,
+	id int64,
+) (*domain.OrderDetail, error) {
+	row := m.db.QueryRowContext(ctx, sqlGetOrderDetailById, id)
+
+	orderDetail := &domain.OrderDetail{}
+	err := scanOrderDetail(row, orderDetail)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return orderDetail, nil
+}
+
+// CreateOrderDetail inserts the line and reserves its stock from the
+// batches of warehouseId.
+func (m mariadbRepository) CreateOrderDetail(
+	ctx context.Context,
+	warehouseId int64,
+	orderDetail *domain.OrderDetail,
+) (*domain.OrderDetail, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return orderDetail, err
+	}
+	defer tx.Rollback()
+
+	if err := insertOrderDetail(ctx, tx, orderDetail); err != nil {
+		return orderDetail, err
+	}
+
+	if err := reserveOrderDetail(ctx, tx, warehouseId, orderDetail); err != nil {
+		return orderDetail, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return orderDetail, err
+	}
+
+	return orderDetail, nil
+}
+
+// UpdateOrderDetail saves the line and replaces its reservations with new
+// ones for the updated product and quantity.
+func (m mariadbRepository) UpdateOrderDetail(
+	ctx context.Context,
+	warehouseId int64,
+	orderDetail *domain.OrderDetail,
+) (*domain.OrderDetail, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return orderDetail, err
+	}
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "order_details", orderDetail.ID)
+	if err != nil {
+		return orderDetail, err
+	}
+
+	if err := settleReservations(
+		ctx, tx, sqlGetOrderDetailReservedBatches, sqlReleaseOrderDetailReservations, sqlDeleteOrderDetailReservations, orderDetail.ID,
+	); err != nil {
+		return orderDetail, err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		sqlUpdateOrderDetail,
+		&orderDetail.CleanLinessStatus,
+		&orderDetail.Quantity,
+		&orderDetail.Temperature,
+		&orderDetail.ProductRecordId,
+		&orderDetail.ID,
+	)
+	if err != nil {
+		return orderDetail, apperrors.FromMySQL(err)
+	}
+
+	if err := reserveOrderDetail(ctx, tx, warehouseId, orderDetail); err != nil {
+		return orderDetail, err
+	}
+
+	if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "order_details", orderDetail.ID, before); err != nil {
+		return orderDetail, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return orderDetail, err
+	}
+
+	return orderDetail, nil
+}
+
+// DeleteOrderDetail removes the line and releases the stock reserved for it.
+func (m mariadbRepository) DeleteOrderDetail(ctx context.Context, id int64) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "order_details", id)
+	if err != nil {
+		return err
+	}
+
+	if err := settleReservations(
+		ctx, tx, sqlGetOrderDetailReservedBatches, sqlReleaseOrderDetailReservations, sqlDeleteOrderDetailReservations, id,
+	); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, sqlDeleteOrderDetail, id)
+	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affectedRows == 0 {
+		return domain.ErrOrderDetailNotFound
+	}
+
+	if err := auditlog.Record(ctx, tx, audit.ActionDelete, "order_details", id, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateStatus moves the order to a new status and records the transition.
+// Shipping takes the reserved stock out of the batches, freeing its room in
+// their sections, and cancelling releases it. It fails with
+// ErrInvalidStatusTransition when the order is no longer in fromStatusId,
+// which happens when two transitions race.
+func (m mariadbRepository) UpdateStatus(ctx context.Context, id, fromStatusId, toStatusId int64) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "purchase_orders", id)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, sqlUpdateStatus, toStatusId, id, fromStatusId)
+	if err != nil {
+		return err
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affectedRows == 0 {
+		return domain.ErrInvalidStatusTransition
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlInsertStatusHistory, id, fromStatusId, toStatusId); err != nil {
+		return err
+	}
+
+	switch toStatusId {
+	case domain.OrderStatusShipped:
+		err = shipReservations(ctx, tx, id)
+	case domain.OrderStatusCancelled:
+		err = settleReservations(ctx, tx, sqlGetOrderReservedBatches, sqlReleaseOrderReservations, sqlDeleteOrderReservations, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	var warehouseId int64
+	if err := tx.QueryRowContext(ctx, sqlLockWarehouseId, id).Scan(&warehouseId); err != nil {
+		return err
+	}
+
+	if err := outbox.Publish(ctx, tx, events.TypePurchaseOrderStatusChanged, "purchase_orders", id, domain.PurchaseOrderStatusChanged{
+		PurchaseOrderId: id,
+		WarehouseId:     warehouseId,
+		FromStatusId:    fromStatusId,
+		FromStatus:      domain.OrderStatusName(fromStatusId),
+		ToStatusId:      toStatusId,
+		ToStatus:        domain.OrderStatusName(toStatusId),
+	}); err != nil {
+		return err
+	}
+
+	if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "purchase_orders", id, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m mariadbRepository) GetStatusHistory(ctx context.Context, id int64) (*[]domain.OrderStatusHistory, error) {
+	history := []domain.OrderStatusHistory{}
+
+	rows, err := m.db.QueryContext(ctx, sqlGetStatusHistory, id)
+	if err != nil {
+		return &history, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry domain.OrderStatusHistory
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.PurchaseOrderId,
+			&entry.FromStatusId,
+			&entry.ToStatusId,
+			&entry.ChangedAt,
+		); err != nil {
+			return &history, err
+		}
+
+		entry.FromStatus = domain.OrderStatusName(entry.FromStatusId)
+		entry.ToStatus = domain.OrderStatusName(entry.ToStatusId)
+
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return &history, err
+	}
+
+	return &history, nil
+}
+
+// insertOrderDetail inserts the line inside tx and records its creation.
+func insertOrderDetail(ctx context.Context, tx *sql.Tx, orderDetail *domain.OrderDetail) error {
+	result, err := tx.ExecContext(
+		ctx,
+		sqlInsertOrderDetail,
+		&orderDetail.CleanLinessStatus,
+		&orderDetail.Quantity,
+		&orderDetail.Temperature,
+		&orderDetail.ProductRecordId,
+		&orderDetail.PurchaseOrderId,
+	)
+	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
+	lastID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	orderDetail.ID = lastID
+
+	return auditlog.Record(ctx, tx, audit.ActionCreate, "order_details", lastID, nil)
+}
+
+// reserveOrderDetail holds the quantity of a line in the batches of the
+// warehouse chosen by the picking engine, first expired first out and
+// honouring the shelf life the buyer needs. The batches are locked until the
+// transaction ends so concurrent orders can't oversell.
+func reserveOrderDetail(ctx context.Context, tx *sql.Tx, warehouseId int64, orderDetail *domain.OrderDetail) error {
+	var minimumShelfLifeDays int64
+	if err := tx.QueryRowContext(ctx, sqlGetMinimumShelfLife, orderDetail.PurchaseOrderId).Scan(&minimumShelfLifeDays); err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, sqlGetAvailableBatches, orderDetail.ProductRecordId, warehouseId)
+	if err != nil {
+		return err
+	}
+
+	batches := []picking.Batch{}
+	for rows.Next() {
+		var batch picking.Batch
+		var dueDate sql.NullTime
+
+		if err := rows.Scan(&batch.ID, &batch.BatchNumber, &batch.SectionId, &dueDate, &batch.Available); err != nil {
+			rows.Close()
+			return err
+		}
+
+		batch.DueDate = dueDate.Time
+		batches = append(batches, batch)
+	}
+
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	lines, err := picking.Pick(batches, orderDetail.Quantity, picking.Cutoff(time.Now(), minimumShelfLifeDays))
+	if errors.Is(err, picking.ErrInsufficientStock) {
+		return fmt.Errorf("%w: product record %d: %v", domain.ErrInsufficientStock, orderDetail.ProductRecordId, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		before, err := auditlog.Snapshot(ctx, tx, "product_batches", line.ProductBatchId)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, sqlReserveBatch, line.Quantity, line.ProductBatchId); err != nil {
+			return err
+		}
+
+		if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "product_batches", line.ProductBatchId, before); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(
+			ctx, sqlInsertReservation, orderDetail.PurchaseOrderId, orderDetail.ID, line.ProductBatchId, line.Quantity,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// settleReservations applies settleQuery to the batches batchesQuery finds
+// held by the reservations of id, auditing them, then removes those
+// reservations.
+func settleReservations(ctx context.Context, tx *sql.Tx, batchesQuery, settleQuery, deleteQuery string, id int64) error {
+	batches, err := snapshotRows(ctx, tx, "product_batches", batchesQuery, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, settleQuery, id); err != nil {
+		return err
+	}
+
+	if err := recordUpdates(ctx, tx, batches); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, deleteQuery, id)
+	return err
+}
+
+// shipReservations takes the stock reserved by the order out of its batches
+// and sections, recording a pick in the ledger of every batch and auditing
+// the batches and sections.
+func shipReservations(ctx context.Context, tx *sql.Tx, id int64) error {
+	sections, err := snapshotRows(ctx, tx, "sections", sqlGetOrderReservedSections, id)
+	if err != nil {
+		return err
+	}
+
+	batches, err := snapshotRows(ctx, tx, "product_batches", sqlGetOrderReservedBatches, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlFreeOrderSectionCapacity, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlConsumeOrderReservations, id); err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, sqlGetOrderPicks, id)
+	if err != nil {
+		return err
+	}
+
+	picks := []movements.Movement{}
+	for rows.Next() {
+		pick := movements.Movement{Type: movements.TypePick, PurchaseOrderId: &id}
+		if err := rows.Scan(&pick.ProductBatchId, &pick.Delta, &pick.Balance); err != nil {
+			rows.Close()
+			return err
+		}
+		pick.Delta = -pick.Delta
+		picks = append(picks, pick)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, pick := range picks {
+		if err := ledger.Record(ctx, tx, pick); err != nil {
+			return err
+		}
+	}
+
+	if err := recordUpdates(ctx, tx, append(sections, batches...)); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlDeleteOrderReservations, id)
+	return err
+}
+
+// rowSnapshot is a row read ahead of a change, to be audited after it.
+type rowSnapshot struct {
+	table  string
+	id     int64
+	before audit.Row
+}
+
+// snapshotRows snapshots the rows of table whose ids idsQuery returns for
+// id, locking them until tx ends.
+func snapshotRows(ctx context.Context, tx *sql.Tx, table, idsQuery string, id int64) ([]rowSnapshot, error) {
+	rows, err := tx.QueryContext(ctx, idsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int64{}
+	for rows.Next() {
+		var rowId int64
+		if err := rows.Scan(&rowId); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, rowId)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]rowSnapshot, 0, len(ids))
+	for _, rowId := range ids {
+		before, err := auditlog.Snapshot(ctx, tx, table, rowId)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, rowSnapshot{table: table, id: rowId, before: before})
+	}
+
+	return snapshots, nil
+}
+
+// recordUpdates audits the update of every snapshotted row.
+func recordUpdates(ctx context.Context, tx *sql.Tx, snapshots []rowSnapshot) error {
+	for _, snapshot := range snapshots {
+		if err := auditlog.Record(ctx, tx, audit.ActionUpdate, snapshot.table, snapshot.id, snapshot.before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveReservations releases the stock held by the order and reserves it
+// again from the warehouse the order now belongs to.
+func moveReservations(ctx context.Context, tx *sql.Tx, purchaseOrder *domain.PurchaseOrder) error {
+	if err := settleReservations(
+		ctx, tx, sqlGetOrderReservedBatches, sqlReleaseOrderReservations, sqlDeleteOrderReservations, purchaseOrder.ID,
+	); err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, sqlGetOrderDetails, purchaseOrder.ID)
+	if err != nil {
+		return err
+	}
+
+	orderDetails := []domain.OrderDetail{}
+	for rows.Next() {
+		var orderDetail domain.OrderDetail
+		if err := scanOrderDetail(rows, &orderDetail); err != nil {
+			rows.Close()
+			return err
+		}
+		orderDetails = append(orderDetails, orderDetail)
+	}
+
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	for i := range orderDetails {
+		if err := reserveOrderDetail(ctx, tx, purchaseOrder.WarehouseId, &orderDetails[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPurchaseOrder(row scanner, purchaseOrder *domain.PurchaseOrder) error {
+	return row.Scan(
+		&purchaseOrder.ID,
+		&purchaseOrder.OrderNumber,
+		&purchaseOrder.OrderDate,
+		&purchaseOrder.TrackingCode,
+		&purchaseOrder.BuyerId,
+		&purchaseOrder.CarrierId,
+		&purchaseOrder.OrderStatusId,
+		&purchaseOrder.WarehouseId,
+	)
+}
+
+func scanOrderDetail(row scanner, orderDetail *domain.OrderDetail) error {
+	if err := row.Scan(
+		&orderDetail.ID,
+		&orderDetail.CleanLinessStatus,
+		&orderDetail.Quantity,
+		&orderDetail.Temperature,
+		&orderDetail.ProductRecordId,
+		&orderDetail.PurchaseOrderId,
+		&orderDetail.SalePrice,
+	); err != nil {
+		return err
+	}
+
+	orderDetail.Total = float64(orderDetail.Quantity) * orderDetail.SalePrice
+
+	return nil
+}
@@ -2,18 +2,101 @@ package mariadb
 
 import (
 	"context"
+	"database/sql"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/actor"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/eventstest"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	movements "github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	queryInsert = regexp.QuoteMeta(sqlInsert)
+	queryGetAll              = regexp.QuoteMeta(sqlGetAll)
+	queryCountAll            = regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)
+	queryInsert              = regexp.QuoteMeta(sqlInsert)
+	queryGetById             = regexp.QuoteMeta(sqlGetById)
+	queryGetByTrackingCode   = regexp.QuoteMeta(sqlGetByTrackingCode)
+	queryUpdate              = regexp.QuoteMeta(sqlUpdate)
+	queryDelete              = regexp.QuoteMeta(sqlDelete)
+	queryDeleteOrderDetails  = regexp.QuoteMeta(sqlDeleteOrderDetails)
+	queryDeleteStatusHistory = regexp.QuoteMeta(sqlDeleteStatusHistory)
+	queryInsertOrderDetail   = regexp.QuoteMeta(sqlInsertOrderDetail)
+	queryGetOrderDetails     = regexp.QuoteMeta(sqlGetOrderDetails)
+	queryGetOrderDetailById  = regexp.QuoteMeta(sqlGetOrderDetailById)
+	queryUpdateOrderDetail   = regexp.QuoteMeta(sqlUpdateOrderDetail)
+	queryDeleteOrderDetail   = regexp.QuoteMeta(sqlDeleteOrderDetail)
+	queryUpdateStatus        = regexp.QuoteMeta(sqlUpdateStatus)
+	queryInsertStatusHistory = regexp.QuoteMeta(sqlInsertStatusHistory)
+	queryGetStatusHistory    = regexp.QuoteMeta(sqlGetStatusHistory)
+	queryLockWarehouseId     = regexp.QuoteMeta(sqlLockWarehouseId)
+
+	queryGetAvailableBatches            = regexp.QuoteMeta(sqlGetAvailableBatches)
+	queryGetMinimumShelfLife            = regexp.QuoteMeta(sqlGetMinimumShelfLife)
+	queryReserveBatch                   = regexp.QuoteMeta(sqlReserveBatch)
+	queryInsertReservation              = regexp.QuoteMeta(sqlInsertReservation)
+	queryReleaseOrderReservations       = regexp.QuoteMeta(sqlReleaseOrderReservations)
+	queryConsumeOrderReservations       = regexp.QuoteMeta(sqlConsumeOrderReservations)
+	queryFreeOrderSectionCapacity       = regexp.QuoteMeta(sqlFreeOrderSectionCapacity)
+	queryReleaseOrderDetailReservations = regexp.QuoteMeta(sqlReleaseOrderDetailReservations)
+	queryDeleteOrderReservations        = regexp.QuoteMeta(sqlDeleteOrderReservations)
+	queryDeleteOrderDetailReservations  = regexp.QuoteMeta(sqlDeleteOrderDetailReservations)
+	queryGetOrderPicks                  = regexp.QuoteMeta(sqlGetOrderPicks)
+	queryGetOrderReservedBatches        = regexp.QuoteMeta(sqlGetOrderReservedBatches)
+	queryGetOrderDetailReservedBatches  = regexp.QuoteMeta(sqlGetOrderDetailReservedBatches)
+	queryGetOrderReservedSections       = regexp.QuoteMeta(sqlGetOrderReservedSections)
+
+	queryGetLastBalance = regexp.QuoteMeta("SELECT balance FROM stock_movements WHERE product_batch_id = ?")
+	queryInsertMovement = regexp.QuoteMeta("INSERT INTO stock_movements")
 )
 
+var rowsPurchaseOrderStruct = []string{
+	"id",
+	"order_number",
+	"order_date",
+	"tracking_code",
+	"buyer_id",
+	"carrier_id",
+	"order_status_id",
+	"warehouse_id",
+}
+
+var rowsAvailableBatchStruct = []string{"id", "batch_number", "section_id", "due_date", "available"}
+
+var batchDueDate = time.Now().AddDate(0, 1, 0)
+
+// idRows answers the ids of the rows a change is about to touch.
+func idRows(ids ...int64) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id"})
+	for _, id := range ids {
+		rows.AddRow(id)
+	}
+	return rows
+}
+
+func shelfLifeRows(days int64) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"minimum_shelf_life_days"}).AddRow(days)
+}
+
+var rowsOrderDetailStruct = []string{
+	"id",
+	"clean_liness_status",
+	"quantity",
+	"temperature",
+	"product_record_id",
+	"purchase_order_id",
+	"sale_price",
+}
+
 func TestCreatePurchaseOrder(t *testing.T) {
 	mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
 
@@ -22,6 +105,7 @@ func TestCreatePurchaseOrder(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsert).
 			WithArgs(
 				mockPurchaseOrder.OrderNumber,
@@ -32,6 +116,12 @@ func TestCreatePurchaseOrder(t *testing.T) {
 				mockPurchaseOrder.OrderStatusId,
 				mockPurchaseOrder.WarehouseId,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(queryInsertStatusHistory).
+			WithArgs(1, nil, mockPurchaseOrder.OrderStatusId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "purchase_orders", 1)
+		eventstest.ExpectPublish(mock, events.TypePurchaseOrderCreated, "purchase_orders", 1)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 
@@ -44,10 +134,139 @@ func TestCreatePurchaseOrder(t *testing.T) {
 			mockPurchaseOrder.CarrierId,
 			mockPurchaseOrder.OrderStatusId,
 			mockPurchaseOrder.WarehouseId,
+			nil,
 		)
 		assert.NoError(t, err)
 
 		assert.Equal(t, &mockPurchaseOrder, sec)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success with order details", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mockOrderDetail := utils.CreateRandomOrderDetail()
+		mockOrderDetail.Quantity = 5
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsert).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(queryInsertStatusHistory).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "purchase_orders", 1)
+		mock.ExpectExec(queryInsertOrderDetail).
+			WithArgs(
+				mockOrderDetail.CleanLinessStatus,
+				mockOrderDetail.Quantity,
+				mockOrderDetail.Temperature,
+				mockOrderDetail.ProductRecordId,
+				1,
+			).WillReturnResult(sqlmock.NewResult(7, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "order_details", 7)
+		mock.ExpectQuery(queryGetMinimumShelfLife).WillReturnRows(shelfLifeRows(0))
+		mock.ExpectQuery(queryGetAvailableBatches).
+			WithArgs(mockOrderDetail.ProductRecordId, mockPurchaseOrder.WarehouseId).
+			WillReturnRows(sqlmock.NewRows(rowsAvailableBatchStruct).
+				AddRow(10, 110, 1, batchDueDate, 1).
+				AddRow(11, 111, 1, batchDueDate, mockOrderDetail.Quantity))
+		audittest.ExpectSnapshot(mock, "product_batches", 10)
+		mock.ExpectExec(queryReserveBatch).WithArgs(1, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 10)
+		mock.ExpectExec(queryInsertReservation).WithArgs(1, 7, 10, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectSnapshot(mock, "product_batches", 11)
+		mock.ExpectExec(queryReserveBatch).
+			WithArgs(mockOrderDetail.Quantity-1, 11).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 11)
+		mock.ExpectExec(queryInsertReservation).
+			WithArgs(1, 7, 11, mockOrderDetail.Quantity-1).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		eventstest.ExpectPublish(mock, events.TypePurchaseOrderCreated, "purchase_orders", 1)
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+
+		orderDetails := []domain.OrderDetail{mockOrderDetail}
+		_, err = repo.Create(
+			context.Background(),
+			mockPurchaseOrder.OrderNumber,
+			mockPurchaseOrder.OrderDate,
+			mockPurchaseOrder.TrackingCode,
+			mockPurchaseOrder.BuyerId,
+			mockPurchaseOrder.CarrierId,
+			mockPurchaseOrder.OrderStatusId,
+			mockPurchaseOrder.WarehouseId,
+			orderDetails,
+		)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int64(7), orderDetails[0].ID)
+		assert.Equal(t, int64(1), orderDetails[0].PurchaseOrderId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback when stock is insufficient", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mockOrderDetail := utils.CreateRandomOrderDetail()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsert).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(queryInsertStatusHistory).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "purchase_orders", 1)
+		mock.ExpectExec(queryInsertOrderDetail).WillReturnResult(sqlmock.NewResult(7, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "order_details", 7)
+		mock.ExpectQuery(queryGetMinimumShelfLife).WillReturnRows(shelfLifeRows(0))
+		mock.ExpectQuery(queryGetAvailableBatches).
+			WillReturnRows(sqlmock.NewRows(rowsAvailableBatchStruct).AddRow(10, 110, 1, batchDueDate, mockOrderDetail.Quantity-1))
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+		_, err = repo.Create(
+			context.Background(),
+			mockPurchaseOrder.OrderNumber,
+			mockPurchaseOrder.OrderDate,
+			mockPurchaseOrder.TrackingCode,
+			mockPurchaseOrder.BuyerId,
+			mockPurchaseOrder.CarrierId,
+			mockPurchaseOrder.OrderStatusId,
+			mockPurchaseOrder.WarehouseId,
+			[]domain.OrderDetail{mockOrderDetail},
+		)
+
+		assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback when an order detail fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsert).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(queryInsertStatusHistory).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "purchase_orders", 1)
+		mock.ExpectExec(queryInsertOrderDetail).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+		_, err = repo.Create(
+			context.Background(),
+			mockPurchaseOrder.OrderNumber,
+			mockPurchaseOrder.OrderDate,
+			mockPurchaseOrder.TrackingCode,
+			mockPurchaseOrder.BuyerId,
+			mockPurchaseOrder.CarrierId,
+			mockPurchaseOrder.OrderStatusId,
+			mockPurchaseOrder.WarehouseId,
+			[]domain.OrderDetail{utils.CreateRandomOrderDetail()},
+		)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("failed to create purchase order", func(t *testing.T) {
@@ -55,9 +274,11 @@ func TestCreatePurchaseOrder(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsert).
 			WithArgs(0, 0, 0, 0, 0, 0, 0).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectRollback()
 
 		repo := NewMariaDBRepository(db)
 		_, err = repo.Create(
@@ -69,8 +290,806 @@ func TestCreatePurchaseOrder(t *testing.T) {
 			mockPurchaseOrder.CarrierId,
 			mockPurchaseOrder.OrderStatusId,
 			mockPurchaseOrder.WarehouseId,
+			nil,
+		)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(rowsPurchaseOrderStruct).AddRow(
+			mockPurchaseOrder.ID,
+			mockPurchaseOrder.OrderNumber,
+			mockPurchaseOrder.OrderDate,
+			mockPurchaseOrder.TrackingCode,
+			mockPurchaseOrder.BuyerId,
+			mockPurchaseOrder.CarrierId,
+			mockPurchaseOrder.OrderStatusId,
+			mockPurchaseOrder.WarehouseId,
+		)
+
+		mock.ExpectQuery(queryGetById).WithArgs(mockPurchaseOrder.ID).WillReturnRows(rows)
+
+		repo := NewMariaDBRepository(db)
+
+		result, err := repo.GetById(context.Background(), mockPurchaseOrder.ID)
+		assert.NoError(t, err)
+
+		assert.Equal(t, &mockPurchaseOrder, result)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetById).WithArgs(mockPurchaseOrder.ID).WillReturnError(sql.ErrNoRows)
+
+		repo := NewMariaDBRepository(db)
+
+		result, err := repo.GetById(context.Background(), mockPurchaseOrder.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestGetAll(t *testing.T) {
+	mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+
+	t.Run("success with filters", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		params := listing.Params{
+			Limit: listing.DefaultLimit,
+			Filters: []listing.Filter{
+				{Field: "buyer_id", Value: "1"},
+				{Field: "order_date", Op: ">=", Value: "2022-01-01"},
+			},
+		}
+
+		rows := sqlmock.NewRows(rowsPurchaseOrderStruct).AddRow(
+			mockPurchaseOrder.ID,
+			mockPurchaseOrder.OrderNumber,
+			mockPurchaseOrder.OrderDate,
+			mockPurchaseOrder.TrackingCode,
+			mockPurchaseOrder.BuyerId,
+			mockPurchaseOrder.CarrierId,
+			mockPurchaseOrder.OrderStatusId,
+			mockPurchaseOrder.WarehouseId,
+		)
+
+		mock.ExpectQuery(queryCountAll).
+			WithArgs("1", "2022-01-01").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAll+" WHERE buyer_id = ? AND order_date >= ?")).
+			WithArgs("1", "2022-01-01", int64(listing.DefaultLimit), int64(0)).
+			WillReturnRows(rows)
+
+		repo := NewMariaDBRepository(db)
+
+		result, total, err := repo.GetAll(context.Background(), params)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []domain.PurchaseOrder{mockPurchaseOrder}, *result)
+	})
+
+	t.Run("fail when count fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryCountAll).WillReturnError(sql.ErrConnDone)
+
+		repo := NewMariaDBRepository(db)
+
+		_, _, err = repo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
+		assert.Error(t, err)
+	})
+
+	t.Run("fail to scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id"}).AddRow("")
+
+		mock.ExpectQuery(queryCountAll).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(queryGetAll).WillReturnRows(rows)
+
+		repo := NewMariaDBRepository(db)
+
+		_, _, err = repo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
+		assert.Error(t, err)
+	})
+}
+
+func TestGetByTrackingCode(t *testing.T) {
+	mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(rowsPurchaseOrderStruct).AddRow(
+			mockPurchaseOrder.ID,
+			mockPurchaseOrder.OrderNumber,
+			mockPurchaseOrder.OrderDate,
+			mockPurchaseOrder.TrackingCode,
+			mockPurchaseOrder.BuyerId,
+			mockPurchaseOrder.CarrierId,
+			mockPurchaseOrder.OrderStatusId,
+			mockPurchaseOrder.WarehouseId,
+		)
+
+		mock.ExpectQuery(queryGetByTrackingCode).WithArgs(mockPurchaseOrder.TrackingCode).WillReturnRows(rows)
+
+		repo := NewMariaDBRepository(db)
+
+		result, err := repo.GetByTrackingCode(context.Background(), mockPurchaseOrder.TrackingCode)
+		assert.NoError(t, err)
+		assert.Equal(t, &mockPurchaseOrder, result)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetByTrackingCode).WithArgs(mockPurchaseOrder.TrackingCode).WillReturnError(sql.ErrNoRows)
+
+		repo := NewMariaDBRepository(db)
+
+		result, err := repo.GetByTrackingCode(context.Background(), mockPurchaseOrder.TrackingCode)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestUpdatePurchaseOrder(t *testing.T) {
+	mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockWarehouseId).
+			WithArgs(mockPurchaseOrder.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(mockPurchaseOrder.WarehouseId))
+		audittest.ExpectSnapshot(mock, "purchase_orders", mockPurchaseOrder.ID)
+		mock.ExpectExec(queryUpdate).
+			WithArgs(
+				mockPurchaseOrder.OrderNumber,
+				mockPurchaseOrder.OrderDate,
+				mockPurchaseOrder.TrackingCode,
+				mockPurchaseOrder.BuyerId,
+				mockPurchaseOrder.CarrierId,
+				mockPurchaseOrder.WarehouseId,
+				mockPurchaseOrder.ID,
+			).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "purchase_orders", mockPurchaseOrder.ID)
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+
+		result, err := repo.Update(context.Background(), &mockPurchaseOrder)
+		assert.NoError(t, err)
+		assert.Equal(t, &mockPurchaseOrder, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success moving to another warehouse", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mockOrderDetail := utils.CreateRandomOrderDetail()
+		mockOrderDetail.PurchaseOrderId = mockPurchaseOrder.ID
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockWarehouseId).
+			WithArgs(mockPurchaseOrder.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(mockPurchaseOrder.WarehouseId + 1))
+		audittest.ExpectSnapshot(mock, "purchase_orders", mockPurchaseOrder.ID)
+		mock.ExpectExec(queryUpdate).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(queryGetOrderReservedBatches).WithArgs(mockPurchaseOrder.ID).WillReturnRows(idRows(10))
+		audittest.ExpectSnapshot(mock, "product_batches", 10)
+		mock.ExpectExec(queryReleaseOrderReservations).WithArgs(mockPurchaseOrder.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 10)
+		mock.ExpectExec(queryDeleteOrderReservations).WithArgs(mockPurchaseOrder.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(queryGetOrderDetails).
+			WithArgs(mockPurchaseOrder.ID).
+			WillReturnRows(sqlmock.NewRows(rowsOrderDetailStruct).AddRow(
+				mockOrderDetail.ID,
+				mockOrderDetail.CleanLinessStatus,
+				mockOrderDetail.Quantity,
+				mockOrderDetail.Temperature,
+				mockOrderDetail.ProductRecordId,
+				mockOrderDetail.PurchaseOrderId,
+				mockOrderDetail.SalePrice,
+			))
+		mock.ExpectQuery(queryGetMinimumShelfLife).WillReturnRows(shelfLifeRows(0))
+		mock.ExpectQuery(queryGetAvailableBatches).
+			WithArgs(mockOrderDetail.ProductRecordId, mockPurchaseOrder.WarehouseId).
+			WillReturnRows(sqlmock.NewRows(rowsAvailableBatchStruct).AddRow(20, 120, 1, batchDueDate, mockOrderDetail.Quantity))
+		audittest.ExpectSnapshot(mock, "product_batches", 20)
+		mock.ExpectExec(queryReserveBatch).WithArgs(mockOrderDetail.Quantity, 20).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 20)
+		mock.ExpectExec(queryInsertReservation).
+			WithArgs(mockPurchaseOrder.ID, mockOrderDetail.ID, 20, mockOrderDetail.Quantity).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "purchase_orders", mockPurchaseOrder.ID)
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+
+		_, err = repo.Update(context.Background(), &mockPurchaseOrder)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockWarehouseId).
+			WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(mockPurchaseOrder.WarehouseId))
+		audittest.ExpectSnapshot(mock, "purchase_orders", mockPurchaseOrder.ID)
+		mock.ExpectExec(queryUpdate).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+
+		_, err = repo.Update(context.Background(), &mockPurchaseOrder)
+		assert.Error(t, err)
+	})
+}
+
+func TestDeletePurchaseOrder(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "purchase_orders", 1)
+		mock.ExpectQuery(queryGetOrderReservedBatches).WithArgs(1).WillReturnRows(idRows(5, 6))
+		audittest.ExpectSnapshot(mock, "product_batches", 5)
+		audittest.ExpectSnapshot(mock, "product_batches", 6)
+		mock.ExpectExec(queryReleaseOrderReservations).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 5)
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 6)
+		mock.ExpectExec(queryDeleteOrderReservations).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(queryDeleteOrderDetails).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(queryDeleteStatusHistory).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryDelete).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectEntry(mock, audit.ActionDelete, "purchase_orders", 1)
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+
+		err = repo.Delete(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "purchase_orders", 1)
+		mock.ExpectQuery(queryGetOrderReservedBatches).WithArgs(1).WillReturnRows(idRows())
+		mock.ExpectExec(queryReleaseOrderReservations).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(queryDeleteOrderReservations).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(queryDeleteOrderDetails).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(queryDeleteStatusHistory).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(queryDelete).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+
+		err = repo.Delete(context.Background(), 1)
+		assert.ErrorIs(t, err, domain.ErrPurchaseOrderNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetOrderDetails(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mockOrderDetails := utils.CreateRandomListOrderDetails()
+
+		rows := sqlmock.NewRows(rowsOrderDetailStruct)
+		for _, mockOrderDetail := range mockOrderDetails {
+			rows.AddRow(
+				mockOrderDetail.ID,
+				mockOrderDetail.CleanLinessStatus,
+				mockOrderDetail.Quantity,
+				mockOrderDetail.Temperature,
+				mockOrderDetail.ProductRecordId,
+				mockOrderDetail.PurchaseOrderId,
+				mockOrderDetail.SalePrice,
+			)
+		}
+
+		mock.ExpectQuery(queryGetOrderDetails).WithArgs(1).WillReturnRows(rows)
+
+		repo := NewMariaDBRepository(db)
+
+		result, err := repo.GetOrderDetails(context.Background(), 1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, &mockOrderDetails, result)
+	})
+
+	t.Run("fail to scan order detail", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(rowsOrderDetailStruct).AddRow("", "", "", "", "", "", "")
+
+		mock.ExpectQuery(queryGetOrderDetails).WillReturnRows(rows)
+
+		repo := NewMariaDBRepository(db)
+
+		_, err = repo.GetOrderDetails(context.Background(), 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetOrderDetailById(t *testing.T) {
+	mockOrderDetail := utils.CreateRandomOrderDetail()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(rowsOrderDetailStruct).AddRow(
+			mockOrderDetail.ID,
+			mockOrderDetail.CleanLinessStatus,
+			mockOrderDetail.Quantity,
+			mockOrderDetail.Temperature,
+			mockOrderDetail.ProductRecordId,
+			mockOrderDetail.PurchaseOrderId,
+			mockOrderDetail.SalePrice,
 		)
 
+		mock.ExpectQuery(queryGetOrderDetailById).WithArgs(mockOrderDetail.ID).WillReturnRows(rows)
+
+		repo := NewMariaDBRepository(db)
+
+		result, err := repo.GetOrderDetailById(context.Background(), mockOrderDetail.ID)
+		assert.NoError(t, err)
+
+		assert.Equal(t, &mockOrderDetail, result)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetOrderDetailById).WillReturnError(sql.ErrNoRows)
+
+		repo := NewMariaDBRepository(db)
+
+		result, err := repo.GetOrderDetailById(context.Background(), mockOrderDetail.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestCreateOrderDetail(t *testing.T) {
+	mockOrderDetail := utils.CreateRandomOrderDetail()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsertOrderDetail).
+			WithArgs(
+				mockOrderDetail.CleanLinessStatus,
+				mockOrderDetail.Quantity,
+				mockOrderDetail.Temperature,
+				mockOrderDetail.ProductRecordId,
+				mockOrderDetail.PurchaseOrderId,
+			).WillReturnResult(sqlmock.NewResult(3, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "order_details", 3)
+		mock.ExpectQuery(queryGetMinimumShelfLife).WillReturnRows(shelfLifeRows(0))
+		mock.ExpectQuery(queryGetAvailableBatches).
+			WithArgs(mockOrderDetail.ProductRecordId, 2).
+			WillReturnRows(sqlmock.NewRows(rowsAvailableBatchStruct).AddRow(10, 110, 1, batchDueDate, mockOrderDetail.Quantity+5))
+		audittest.ExpectSnapshot(mock, "product_batches", 10)
+		mock.ExpectExec(queryReserveBatch).WithArgs(mockOrderDetail.Quantity, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 10)
+		mock.ExpectExec(queryInsertReservation).
+			WithArgs(mockOrderDetail.PurchaseOrderId, 3, 10, mockOrderDetail.Quantity).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+
+		result, err := repo.CreateOrderDetail(context.Background(), 2, &mockOrderDetail)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), result.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("skips batches expiring within the buyer shelf life", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsertOrderDetail).WillReturnResult(sqlmock.NewResult(3, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "order_details", 3)
+		mock.ExpectQuery(queryGetMinimumShelfLife).
+			WithArgs(mockOrderDetail.PurchaseOrderId).
+			WillReturnRows(shelfLifeRows(7))
+		mock.ExpectQuery(queryGetAvailableBatches).
+			WillReturnRows(sqlmock.NewRows(rowsAvailableBatchStruct).
+				AddRow(10, 110, 1, time.Now().AddDate(0, 0, 3), mockOrderDetail.Quantity).
+				AddRow(11, 111, 1, batchDueDate, mockOrderDetail.Quantity))
+		audittest.ExpectSnapshot(mock, "product_batches", 11)
+		mock.ExpectExec(queryReserveBatch).WithArgs(mockOrderDetail.Quantity, 11).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 11)
+		mock.ExpectExec(queryInsertReservation).
+			WithArgs(mockOrderDetail.PurchaseOrderId, 3, 11, mockOrderDetail.Quantity).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+
+		_, err = repo.CreateOrderDetail(context.Background(), 2, &mockOrderDetail)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail with insufficient stock", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsertOrderDetail).WillReturnResult(sqlmock.NewResult(3, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "order_details", 3)
+		mock.ExpectQuery(queryGetMinimumShelfLife).WillReturnRows(shelfLifeRows(0))
+		mock.ExpectQuery(queryGetAvailableBatches).WillReturnRows(sqlmock.NewRows(rowsAvailableBatchStruct))
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+
+		_, err = repo.CreateOrderDetail(context.Background(), 2, &mockOrderDetail)
+		assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail to create order detail", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsertOrderDetail).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+
+		_, err = repo.CreateOrderDetail(context.Background(), 2, &mockOrderDetail)
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateOrderDetail(t *testing.T) {
+	mockOrderDetail := utils.CreateRandomOrderDetail()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "order_details", mockOrderDetail.ID)
+		mock.ExpectQuery(queryGetOrderDetailReservedBatches).WithArgs(mockOrderDetail.ID).WillReturnRows(idRows(10))
+		audittest.ExpectSnapshot(mock, "product_batches", 10)
+		mock.ExpectExec(queryReleaseOrderDetailReservations).WithArgs(mockOrderDetail.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 10)
+		mock.ExpectExec(queryDeleteOrderDetailReservations).WithArgs(mockOrderDetail.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryUpdateOrderDetail).
+			WithArgs(
+				mockOrderDetail.CleanLinessStatus,
+				mockOrderDetail.Quantity,
+				mockOrderDetail.Temperature,
+				mockOrderDetail.ProductRecordId,
+				mockOrderDetail.ID,
+			).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(queryGetMinimumShelfLife).WillReturnRows(shelfLifeRows(0))
+		mock.ExpectQuery(queryGetAvailableBatches).
+			WithArgs(mockOrderDetail.ProductRecordId, 2).
+			WillReturnRows(sqlmock.NewRows(rowsAvailableBatchStruct).AddRow(10, 110, 1, batchDueDate, mockOrderDetail.Quantity))
+		audittest.ExpectSnapshot(mock, "product_batches", 10)
+		mock.ExpectExec(queryReserveBatch).WithArgs(mockOrderDetail.Quantity, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 10)
+		mock.ExpectExec(queryInsertReservation).
+			WithArgs(mockOrderDetail.PurchaseOrderId, mockOrderDetail.ID, 10, mockOrderDetail.Quantity).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "order_details", mockOrderDetail.ID)
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+
+		result, err := repo.UpdateOrderDetail(context.Background(), 2, &mockOrderDetail)
+		assert.NoError(t, err)
+		assert.Equal(t, &mockOrderDetail, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail to update order detail", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "order_details", mockOrderDetail.ID)
+		mock.ExpectQuery(queryGetOrderDetailReservedBatches).WillReturnRows(idRows())
+		mock.ExpectExec(queryReleaseOrderDetailReservations).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryDeleteOrderDetailReservations).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryUpdateOrderDetail).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+
+		_, err = repo.UpdateOrderDetail(context.Background(), 2, &mockOrderDetail)
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteOrderDetail(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "order_details", 1)
+		mock.ExpectQuery(queryGetOrderDetailReservedBatches).WithArgs(1).WillReturnRows(idRows(10))
+		audittest.ExpectSnapshot(mock, "product_batches", 10)
+		mock.ExpectExec(queryReleaseOrderDetailReservations).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 10)
+		mock.ExpectExec(queryDeleteOrderDetailReservations).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryDeleteOrderDetail).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectEntry(mock, audit.ActionDelete, "order_details", 1)
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+
+		err = repo.DeleteOrderDetail(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "order_details", 1)
+		mock.ExpectQuery(queryGetOrderDetailReservedBatches).WithArgs(1).WillReturnRows(idRows())
+		mock.ExpectExec(queryReleaseOrderDetailReservations).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(queryDeleteOrderDetailReservations).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(queryDeleteOrderDetail).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+
+		err = repo.DeleteOrderDetail(context.Background(), 1)
+		assert.ErrorIs(t, err, domain.ErrOrderDetailNotFound)
+	})
+}
+
+func TestUpdateStatus(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "purchase_orders", 1)
+		mock.ExpectExec(queryUpdateStatus).
+			WithArgs(domain.OrderStatusPicking, 1, domain.OrderStatusCreated).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryInsertStatusHistory).
+			WithArgs(1, domain.OrderStatusCreated, domain.OrderStatusPicking).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(queryLockWarehouseId).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(2))
+		eventstest.ExpectPublish(mock, events.TypePurchaseOrderStatusChanged, "purchase_orders", 1)
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "purchase_orders", 1)
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+
+		err = repo.UpdateStatus(context.Background(), 1, domain.OrderStatusCreated, domain.OrderStatusPicking)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("shipping consumes the reserved stock", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "purchase_orders", 1)
+		mock.ExpectExec(queryUpdateStatus).
+			WithArgs(domain.OrderStatusShipped, 1, domain.OrderStatusPicking).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryInsertStatusHistory).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(queryGetOrderReservedSections).WithArgs(1).WillReturnRows(idRows(4))
+		audittest.ExpectSnapshot(mock, "sections", 4)
+		mock.ExpectQuery(queryGetOrderReservedBatches).WithArgs(1).WillReturnRows(idRows(5, 6))
+		audittest.ExpectSnapshot(mock, "product_batches", 5)
+		audittest.ExpectSnapshot(mock, "product_batches", 6)
+		mock.ExpectExec(queryFreeOrderSectionCapacity).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryConsumeOrderReservations).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(queryGetOrderPicks).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "quantity", "current_quantity"}).AddRow(5, 3, 7).AddRow(6, 2, 0))
+		mock.ExpectQuery(queryGetLastBalance).WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(10))
+		mock.ExpectExec(queryInsertMovement).
+			WithArgs(5, movements.TypePick, -3, 7, actor.System, nil, 1, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(queryGetLastBalance).WithArgs(6).
+			WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(2))
+		mock.ExpectExec(queryInsertMovement).
+			WithArgs(6, movements.TypePick, -2, 0, actor.System, nil, 1, nil).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "sections", 4)
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 5)
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 6)
+		mock.ExpectExec(queryDeleteOrderReservations).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(queryLockWarehouseId).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(2))
+		eventstest.ExpectPublish(mock, events.TypePurchaseOrderStatusChanged, "purchase_orders", 1)
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "purchase_orders", 1)
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+
+		err = repo.UpdateStatus(context.Background(), 1, domain.OrderStatusPicking, domain.OrderStatusShipped)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("shipping fails when a batch ledger does not match", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "purchase_orders", 1)
+		mock.ExpectExec(queryUpdateStatus).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryInsertStatusHistory).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(queryGetOrderReservedSections).WillReturnRows(idRows(4))
+		audittest.ExpectSnapshot(mock, "sections", 4)
+		mock.ExpectQuery(queryGetOrderReservedBatches).WillReturnRows(idRows(5))
+		audittest.ExpectSnapshot(mock, "product_batches", 5)
+		mock.ExpectExec(queryFreeOrderSectionCapacity).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryConsumeOrderReservations).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(queryGetOrderPicks).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "quantity", "current_quantity"}).AddRow(5, 3, 7))
+		mock.ExpectQuery(queryGetLastBalance).
+			WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(9))
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+
+		err = repo.UpdateStatus(context.Background(), 1, domain.OrderStatusPicking, domain.OrderStatusShipped)
+		assert.ErrorIs(t, err, movements.ErrLedgerMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("cancelling releases the reserved stock", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "purchase_orders", 1)
+		mock.ExpectExec(queryUpdateStatus).
+			WithArgs(domain.OrderStatusCancelled, 1, domain.OrderStatusCreated).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryInsertStatusHistory).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(queryGetOrderReservedBatches).WithArgs(1).WillReturnRows(idRows(5))
+		audittest.ExpectSnapshot(mock, "product_batches", 5)
+		mock.ExpectExec(queryReleaseOrderReservations).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 5)
+		mock.ExpectExec(queryDeleteOrderReservations).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(queryLockWarehouseId).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(2))
+		eventstest.ExpectPublish(mock, events.TypePurchaseOrderStatusChanged, "purchase_orders", 1)
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "purchase_orders", 1)
+		mock.ExpectCommit()
+
+		repo := NewMariaDBRepository(db)
+
+		err = repo.UpdateStatus(context.Background(), 1, domain.OrderStatusCreated, domain.OrderStatusCancelled)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("status changed concurrently", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "purchase_orders", 1)
+		mock.ExpectExec(queryUpdateStatus).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := NewMariaDBRepository(db)
+
+		err = repo.UpdateStatus(context.Background(), 1, domain.OrderStatusCreated, domain.OrderStatusPicking)
+		assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetStatusHistory(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "purchase_order_id", "from_status_id", "to_status_id", "changed_at"}).
+			AddRow(1, 1, 0, domain.OrderStatusCreated, "2022-01-01 10:00:00").
+			AddRow(2, 1, domain.OrderStatusCreated, domain.OrderStatusCancelled, "2022-01-02 10:00:00")
+
+		mock.ExpectQuery(queryGetStatusHistory).WithArgs(1).WillReturnRows(rows)
+
+		repo := NewMariaDBRepository(db)
+
+		history, err := repo.GetStatusHistory(context.Background(), 1)
+		assert.NoError(t, err)
+
+		assert.Len(t, *history, 2)
+		assert.Equal(t, "", (*history)[0].FromStatus)
+		assert.Equal(t, "created", (*history)[1].FromStatus)
+		assert.Equal(t, "cancelled", (*history)[1].ToStatus)
+	})
+
+	t.Run("fail to select history", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetStatusHistory).WillReturnError(sql.ErrConnDone)
+
+		repo := NewMariaDBRepository(db)
+
+		_, err = repo.GetStatusHistory(context.Background(), 1)
 		assert.Error(t, err)
 	})
 }
@@ -1,6 +1,82 @@
 package mariadb
 
 const (
-	sqlInsert           = "INSERT INTO purchase_orders (order_number, order_date, tracking_code, buyer_id, carrier_id, order_status_id, warehouse_id) VALUES (?, ?, ?, ?, ?, ?, ?);"
-	sqlGetByOrderNumber = "SELECT * FROM purchase_orders WHERE order_number = ?;"
+	sqlGetAll            = "SELECT * FROM purchase_orders"
+	sqlInsert            = "INSERT INTO purchase_orders (order_number, order_date, tracking_code, buyer_id, carrier_id, order_status_id, warehouse_id) VALUES (?, ?, ?, ?, ?, ?, ?);"
+	sqlGetById           = "SELECT * FROM purchase_orders WHERE id = ?;"
+	sqlGetByOrderNumber  = "SELECT * FROM purchase_orders WHERE order_number = ?;"
+	sqlGetByTrackingCode = "SELECT * FROM purchase_orders WHERE tracking_code = ?;"
+	sqlUpdate            = "UPDATE purchase_orders SET order_number=?, order_date=?, tracking_code=?, buyer_id=?, carrier_id=?, warehouse_id=? WHERE id=?;"
+	sqlDelete            = "DELETE FROM purchase_orders WHERE id=?;"
+	sqlLockWarehouseId   = "SELECT warehouse_id FROM purchase_orders WHERE id = ? FOR UPDATE;"
+
+	sqlInsertOrderDetail = "INSERT INTO order_details (clean_liness_status, quantity, temperature, product_record_id, purchase_order_id) VALUES (?, ?, ?, ?, ?);"
+	sqlGetOrderDetails   = `SELECT od.id, od.clean_liness_status, od.quantity, COALESCE(od.temperature, 0), od.product_record_id, od.purchase_order_id, pr.sale_price
+		FROM order_details od
+		INNER JOIN product_records pr ON pr.id = od.product_record_id
+		WHERE od.purchase_order_id = ?
+		ORDER BY od.id;`
+	sqlGetOrderDetailById = `SELECT od.id, od.clean_liness_status, od.quantity, COALESCE(od.temperature, 0), od.product_record_id, od.purchase_order_id, pr.sale_price
+		FROM order_details od
+		INNER JOIN product_records pr ON pr.id = od.product_record_id
+		WHERE od.id = ?;`
+	sqlUpdateOrderDetail  = "UPDATE order_details SET clean_liness_status=?, quantity=?, temperature=?, product_record_id=? WHERE id=?;"
+	sqlDeleteOrderDetail  = "DELETE FROM order_details WHERE id=?;"
+	sqlDeleteOrderDetails = "DELETE FROM order_details WHERE purchase_order_id=?;"
+
+	sqlUpdateStatus        = "UPDATE purchase_orders SET order_status_id=? WHERE id=? AND order_status_id=?;"
+	sqlInsertStatusHistory = "INSERT INTO order_status_history (purchase_order_id, from_status_id, to_status_id) VALUES (?, ?, ?);"
+	sqlDeleteStatusHistory = "DELETE FROM order_status_history WHERE purchase_order_id=?;"
+	sqlGetStatusHistory    = `SELECT id, purchase_order_id, COALESCE(from_status_id, 0), to_status_id, changed_at
+		FROM order_status_history
+		WHERE purchase_order_id = ?
+		ORDER BY changed_at, id;`
+
+	sqlGetAvailableBatches = `SELECT pb.id, pb.batch_number, pb.section_id, pb.due_date, pb.current_quantity - pb.reserved_quantity
+		FROM product_batches pb
+		INNER JOIN sections s ON s.id = pb.section_id
+		INNER JOIN product_records pr ON pr.product_id = pb.product_id
+		WHERE pr.id = ? AND s.warehouse_id = ? AND pb.quarantined_at IS NULL AND pb.current_quantity > pb.reserved_quantity
+		ORDER BY pb.id
+		FOR UPDATE;`
+	sqlGetMinimumShelfLife = `SELECT COALESCE(sl.minimum_shelf_life_days, 0)
+		FROM purchase_orders po
+		LEFT JOIN buyer_shelf_life_policies sl ON sl.buyer_id = po.buyer_id
+		WHERE po.id = ?;`
+	sqlReserveBatch      = "UPDATE product_batches SET reserved_quantity = reserved_quantity + ? WHERE id = ?;"
+	sqlInsertReservation = "INSERT INTO stock_reservations (purchase_order_id, order_detail_id, product_batch_id, quantity) VALUES (?, ?, ?, ?);"
+
+	sqlReleaseOrderReservations = `UPDATE product_batches pb
+		INNER JOIN (SELECT product_batch_id, SUM(quantity) AS quantity FROM stock_reservations WHERE purchase_order_id = ? GROUP BY product_batch_id) r
+		ON r.product_batch_id = pb.id
+		SET pb.reserved_quantity = pb.reserved_quantity - r.quantity;`
+	sqlConsumeOrderReservations = `UPDATE product_batches pb
+		INNER JOIN (SELECT product_batch_id, SUM(quantity) AS quantity FROM stock_reservations WHERE purchase_order_id = ? GROUP BY product_batch_id) r
+		ON r.product_batch_id = pb.id
+		SET pb.reserved_quantity = pb.reserved_quantity - r.quantity, pb.current_quantity = pb.current_quantity - r.quantity;`
+	sqlFreeOrderSectionCapacity = `UPDATE sections s
+		INNER JOIN (SELECT pb.section_id, SUM(sr.quantity) AS quantity FROM stock_reservations sr
+			INNER JOIN product_batches pb ON pb.id = sr.product_batch_id
+			WHERE sr.purchase_order_id = ? GROUP BY pb.section_id) r
+		ON r.section_id = s.id
+		SET s.current_capacity = s.current_capacity - r.quantity;`
+	sqlGetOrderPicks = `SELECT pb.id, SUM(sr.quantity), COALESCE(pb.current_quantity, 0)
+		FROM stock_reservations sr
+		INNER JOIN product_batches pb ON pb.id = sr.product_batch_id
+		WHERE sr.purchase_order_id = ?
+		GROUP BY pb.id, pb.current_quantity
+		ORDER BY pb.id;`
+	sqlReleaseOrderDetailReservations = `UPDATE product_batches pb
+		INNER JOIN (SELECT product_batch_id, SUM(quantity) AS quantity FROM stock_reservations WHERE order_detail_id = ? GROUP BY product_batch_id) r
+		ON r.product_batch_id = pb.id
+		SET pb.reserved_quantity = pb.reserved_quantity - r.quantity;`
+	sqlDeleteOrderReservations       = "DELETE FROM stock_reservations WHERE purchase_order_id = ?;"
+	sqlGetOrderReservedBatches       = "SELECT DISTINCT product_batch_id FROM stock_reservations WHERE purchase_order_id = ? ORDER BY product_batch_id;"
+	sqlGetOrderDetailReservedBatches = "SELECT DISTINCT product_batch_id FROM stock_reservations WHERE order_detail_id = ? ORDER BY product_batch_id;"
+	sqlGetOrderReservedSections      = `SELECT DISTINCT pb.section_id
+		FROM stock_reservations sr
+		INNER JOIN product_batches pb ON pb.id = sr.product_batch_id
+		WHERE sr.purchase_order_id = ?
+		ORDER BY pb.section_id;`
+	sqlDeleteOrderDetailReservations = "DELETE FROM stock_reservations WHERE order_detail_id = ?;"
 )
@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/domain"
 )
 
@@ -14,15 +17,28 @@ func NewPurchaseOrderService(sr domain.PurchaseOrderRepository) domain.PurchaseO
 	return &purchaseOrderService{repository: sr}
 }
 
+func (s purchaseOrderService) GetAll(
+	ctx context.Context,
+	params listing.Params,
+) (*[]domain.PurchaseOrder, int64, error) {
+	return s.repository.GetAll(ctx, params)
+}
+
+// Create registers an order, which employees may only do for their own
+// warehouse.
 func (s purchaseOrderService) Create(ctx context.Context,
 	orderNumber,
 	orderDate,
 	trackingCode string,
 	buyerId,
 	carrierId,
-	orderStatusId,
 	warehouseId int64,
-) (*domain.PurchaseOrder, error) {
+	orderDetails []domain.OrderDetail,
+) (*domain.PurchaseOrderWithDetails, error) {
+	if err := policy.OwnWarehouse(ctx, warehouseId); err != nil {
+		return nil, err
+	}
+
 	foundPurchaseOrder, err := s.repository.GetByOrderNumber(ctx, orderNumber)
 	if err != nil {
 		return nil, err
@@ -39,12 +55,305 @@ func (s purchaseOrderService) Create(ctx context.Context,
 		trackingCode,
 		buyerId,
 		carrierId,
-		orderStatusId,
+		domain.OrderStatusCreated,
 		warehouseId,
+		orderDetails,
 	)
 	if err != nil {
-		return purchaseOrder, err
+		return nil, err
+	}
+
+	return s.withDetails(ctx, purchaseOrder)
+}
+
+func (s purchaseOrderService) GetById(ctx context.Context, id int64) (*domain.PurchaseOrderWithDetails, error) {
+	purchaseOrder, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if purchaseOrder == nil {
+		return nil, domain.ErrPurchaseOrderNotFound
+	}
+
+	return s.withDetails(ctx, purchaseOrder)
+}
+
+func (s purchaseOrderService) GetByTrackingCode(ctx context.Context, trackingCode string) (*domain.PurchaseOrderWithDetails, error) {
+	purchaseOrder, err := s.repository.GetByTrackingCode(ctx, trackingCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if purchaseOrder == nil {
+		return nil, domain.ErrPurchaseOrderNotFound
+	}
+
+	return s.withDetails(ctx, purchaseOrder)
+}
+
+// Update changes the header of an order that has not shipped yet.
+func (s purchaseOrderService) Update(
+	ctx context.Context,
+	id int64,
+	purchaseOrder domain.PurchaseOrderUpdateRequest,
+) (*domain.PurchaseOrderWithDetails, error) {
+	current, err := s.getUnshippedPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(purchaseOrder.OrderNumber) > 0 && purchaseOrder.OrderNumber != current.OrderNumber {
+		foundPurchaseOrder, err := s.repository.GetByOrderNumber(ctx, purchaseOrder.OrderNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		if foundPurchaseOrder != nil {
+			return nil, domain.ErrDuplicatedOrderNumber
+		}
+
+		current.OrderNumber = purchaseOrder.OrderNumber
+	}
+
+	if len(purchaseOrder.OrderDate) > 0 {
+		current.OrderDate = purchaseOrder.OrderDate
+	}
+
+	if len(purchaseOrder.TrackingCode) > 0 {
+		current.TrackingCode = purchaseOrder.TrackingCode
+	}
+
+	if purchaseOrder.BuyerId > 0 {
+		current.BuyerId = purchaseOrder.BuyerId
+	}
+
+	if purchaseOrder.CarrierId > 0 {
+		current.CarrierId = purchaseOrder.CarrierId
+	}
+
+	if purchaseOrder.WarehouseId > 0 {
+		// Employees cannot hand their orders over to other warehouses.
+		if err := policy.OwnWarehouse(ctx, purchaseOrder.WarehouseId); err != nil {
+			return nil, err
+		}
+		current.WarehouseId = purchaseOrder.WarehouseId
+	}
+
+	updated, err := s.repository.Update(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withDetails(ctx, updated)
+}
+
+// Delete removes an order that has not shipped yet.
+func (s purchaseOrderService) Delete(ctx context.Context, id int64) error {
+	if _, err := s.getUnshippedPurchaseOrder(ctx, id); err != nil {
+		return err
+	}
+
+	return s.repository.Delete(ctx, id)
+}
+
+func (s purchaseOrderService) CreateOrderDetail(
+	ctx context.Context,
+	purchaseOrderId int64,
+	orderDetail domain.OrderDetail,
+) (*domain.OrderDetail, error) {
+	purchaseOrder, err := s.getOpenPurchaseOrder(ctx, purchaseOrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	orderDetail.PurchaseOrderId = purchaseOrderId
+
+	newOrderDetail, err := s.repository.CreateOrderDetail(ctx, purchaseOrder.WarehouseId, &orderDetail)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetOrderDetailById(ctx, newOrderDetail.ID)
+}
+
+func (s purchaseOrderService) UpdateOrderDetail(
+	ctx context.Context,
+	purchaseOrderId,
+	id int64,
+	orderDetail domain.OrderDetailUpdateRequest,
+) (*domain.OrderDetail, error) {
+	purchaseOrder, current, err := s.getOrderDetail(ctx, purchaseOrderId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orderDetail.CleanLinessStatus) > 0 {
+		current.CleanLinessStatus = orderDetail.CleanLinessStatus
+	}
+
+	if orderDetail.Quantity > 0 {
+		current.Quantity = orderDetail.Quantity
+	}
+
+	if orderDetail.Temperature != nil {
+		current.Temperature = *orderDetail.Temperature
+	}
+
+	if orderDetail.ProductRecordId > 0 {
+		current.ProductRecordId = orderDetail.ProductRecordId
+	}
+
+	if _, err := s.repository.UpdateOrderDetail(ctx, purchaseOrder.WarehouseId, current); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetOrderDetailById(ctx, id)
+}
+
+func (s purchaseOrderService) DeleteOrderDetail(ctx context.Context, purchaseOrderId, id int64) error {
+	if _, _, err := s.getOrderDetail(ctx, purchaseOrderId, id); err != nil {
+		return err
+	}
+
+	return s.repository.DeleteOrderDetail(ctx, id)
+}
+
+// UpdateStatus moves the order to toStatusId, rejecting moves that are not
+// allowed from its current status.
+func (s purchaseOrderService) UpdateStatus(ctx context.Context, id, toStatusId int64) (*domain.PurchaseOrderWithDetails, error) {
+	purchaseOrder, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if purchaseOrder == nil {
+		return nil, domain.ErrPurchaseOrderNotFound
+	}
+
+	if err := policy.OwnWarehouse(ctx, purchaseOrder.WarehouseId); err != nil {
+		return nil, err
+	}
+
+	if !domain.CanTransition(purchaseOrder.OrderStatusId, toStatusId) {
+		return nil, fmt.Errorf(
+			"%w: %s to %s",
+			domain.ErrInvalidStatusTransition,
+			domain.OrderStatusName(purchaseOrder.OrderStatusId),
+			domain.OrderStatusName(toStatusId),
+		)
+	}
+
+	if err := s.repository.UpdateStatus(ctx, id, purchaseOrder.OrderStatusId, toStatusId); err != nil {
+		return nil, err
+	}
+
+	purchaseOrder.OrderStatusId = toStatusId
+
+	return s.withDetails(ctx, purchaseOrder)
+}
+
+func (s purchaseOrderService) GetStatusHistory(ctx context.Context, id int64) (*[]domain.OrderStatusHistory, error) {
+	purchaseOrder, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if purchaseOrder == nil {
+		return nil, domain.ErrPurchaseOrderNotFound
+	}
+
+	return s.repository.GetStatusHistory(ctx, id)
+}
+
+// getOpenPurchaseOrder returns an order that still takes changes to its
+// lines, failing when it belongs to a warehouse the caller does not work for.
+func (s purchaseOrderService) getOpenPurchaseOrder(ctx context.Context, id int64) (*domain.PurchaseOrder, error) {
+	purchaseOrder, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if purchaseOrder == nil {
+		return nil, domain.ErrPurchaseOrderNotFound
+	}
+
+	if err := policy.OwnWarehouse(ctx, purchaseOrder.WarehouseId); err != nil {
+		return nil, err
+	}
+
+	if purchaseOrder.OrderStatusId != domain.OrderStatusCreated {
+		return nil, domain.ErrPurchaseOrderNotOpen
 	}
 
 	return purchaseOrder, nil
 }
+
+// getUnshippedPurchaseOrder returns an order that has not shipped yet,
+// failing when it belongs to a warehouse the caller does not work for.
+func (s purchaseOrderService) getUnshippedPurchaseOrder(ctx context.Context, id int64) (*domain.PurchaseOrder, error) {
+	purchaseOrder, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if purchaseOrder == nil {
+		return nil, domain.ErrPurchaseOrderNotFound
+	}
+
+	if err := policy.OwnWarehouse(ctx, purchaseOrder.WarehouseId); err != nil {
+		return nil, err
+	}
+
+	if domain.HasShipped(purchaseOrder.OrderStatusId) {
+		return nil, domain.ErrPurchaseOrderShipped
+	}
+
+	return purchaseOrder, nil
+}
+
+// getOrderDetail returns an open order and one of its lines, failing when
+// the line belongs to another order.
+func (s purchaseOrderService) getOrderDetail(
+	ctx context.Context,
+	purchaseOrderId,
+	id int64,
+) (*domain.PurchaseOrder, *domain.OrderDetail, error) {
+	purchaseOrder, err := s.getOpenPurchaseOrder(ctx, purchaseOrderId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orderDetail, err := s.repository.GetOrderDetailById(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if orderDetail == nil || orderDetail.PurchaseOrderId != purchaseOrderId {
+		return nil, nil, domain.ErrOrderDetailNotFound
+	}
+
+	return purchaseOrder, orderDetail, nil
+}
+
+func (s purchaseOrderService) withDetails(
+	ctx context.Context,
+	purchaseOrder *domain.PurchaseOrder,
+) (*domain.PurchaseOrderWithDetails, error) {
+	orderDetails, err := s.repository.GetOrderDetails(ctx, purchaseOrder.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.PurchaseOrderWithDetails{
+		PurchaseOrder: *purchaseOrder,
+		OrderDetails:  *orderDetails,
+	}
+
+	for _, orderDetail := range *orderDetails {
+		result.TotalQuantity += orderDetail.Quantity
+		result.TotalPrice += orderDetail.Total
+	}
+
+	return result, nil
+}
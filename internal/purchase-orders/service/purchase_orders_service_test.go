@@ -5,6 +5,9 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	. "github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/mocks"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
@@ -15,6 +18,7 @@ import (
 func TestCreatePurchaseOrder(t *testing.T) {
 	mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
 	mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+	mockOrderDetails := utils.CreateRandomListOrderDetails()
 
 	t.Run("In case of success", func(t *testing.T) {
 		mockPurchaseOrderRepo.On("Create",
@@ -29,6 +33,8 @@ func TestCreatePurchaseOrder(t *testing.T) {
 			mock.Anything,
 		).Return(&mockPurchaseOrder, nil).Once()
 		mockPurchaseOrderRepo.On("GetByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+		mockPurchaseOrderRepo.On("GetOrderDetails", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockOrderDetails, nil).Once()
 
 		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
 
@@ -39,12 +45,13 @@ func TestCreatePurchaseOrder(t *testing.T) {
 			mockPurchaseOrder.TrackingCode,
 			mockPurchaseOrder.BuyerId,
 			mockPurchaseOrder.CarrierId,
-			mockPurchaseOrder.OrderStatusId,
 			mockPurchaseOrder.WarehouseId,
+			mockOrderDetails,
 		)
 
 		assert.NoError(t, err)
-		assert.Equal(t, &mockPurchaseOrder, newPurchaseOrder)
+		assert.Equal(t, mockPurchaseOrder, newPurchaseOrder.PurchaseOrder)
+		assert.Equal(t, mockOrderDetails, newPurchaseOrder.OrderDetails)
 
 		mockPurchaseOrderRepo.AssertExpectations(t)
 	})
@@ -71,8 +78,8 @@ func TestCreatePurchaseOrder(t *testing.T) {
 			mockPurchaseOrder.TrackingCode,
 			mockPurchaseOrder.BuyerId,
 			mockPurchaseOrder.CarrierId,
-			mockPurchaseOrder.OrderStatusId,
 			mockPurchaseOrder.WarehouseId,
+			nil,
 		)
 
 		assert.Error(t, err)
@@ -80,3 +87,462 @@ func TestCreatePurchaseOrder(t *testing.T) {
 		mockPurchaseOrderRepo.AssertExpectations(t)
 	})
 }
+
+func TestGetById(t *testing.T) {
+	mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+	mockOrderDetails := utils.CreateRandomListOrderDetails()
+
+	t.Run("In case of success", func(t *testing.T) {
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+		mockPurchaseOrderRepo.On("GetOrderDetails", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockOrderDetails, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		result, err := s.GetById(context.Background(), mockPurchaseOrder.ID)
+
+		assert.NoError(t, err)
+
+		var totalQuantity int64
+		var totalPrice float64
+		for _, orderDetail := range mockOrderDetails {
+			totalQuantity += orderDetail.Quantity
+			totalPrice += float64(orderDetail.Quantity) * orderDetail.SalePrice
+		}
+
+		assert.Equal(t, totalQuantity, result.TotalQuantity)
+		assert.InDelta(t, totalPrice, result.TotalPrice, 0.001)
+	})
+
+	t.Run("In case of not found", func(t *testing.T) {
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(nil, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		_, err := s.GetById(context.Background(), mockPurchaseOrder.ID)
+
+		assert.ErrorIs(t, err, ErrPurchaseOrderNotFound)
+	})
+}
+
+func TestGetAll(t *testing.T) {
+	mockPurchaseOrders := []PurchaseOrder{utils.CreateRandomPurchaseOrder(), utils.CreateRandomPurchaseOrder()}
+	params := listing.Params{Limit: listing.DefaultLimit}
+
+	t.Run("In case of success", func(t *testing.T) {
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetAll", mock.Anything, params).
+			Return(&mockPurchaseOrders, int64(len(mockPurchaseOrders)), nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		result, total, err := s.GetAll(context.Background(), params)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(mockPurchaseOrders)), total)
+		assert.Equal(t, mockPurchaseOrders, *result)
+	})
+
+	t.Run("In case of error", func(t *testing.T) {
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetAll", mock.Anything, params).
+			Return(nil, int64(0), errors.New("failed to list purchase orders")).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		_, _, err := s.GetAll(context.Background(), params)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetByTrackingCode(t *testing.T) {
+	mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+	mockOrderDetails := utils.CreateRandomListOrderDetails()
+
+	t.Run("In case of success", func(t *testing.T) {
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetByTrackingCode", mock.Anything, mockPurchaseOrder.TrackingCode).
+			Return(&mockPurchaseOrder, nil).Once()
+		mockPurchaseOrderRepo.On("GetOrderDetails", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockOrderDetails, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		result, err := s.GetByTrackingCode(context.Background(), mockPurchaseOrder.TrackingCode)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockPurchaseOrder, result.PurchaseOrder)
+	})
+
+	t.Run("In case of not found", func(t *testing.T) {
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetByTrackingCode", mock.Anything, mockPurchaseOrder.TrackingCode).
+			Return(nil, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		_, err := s.GetByTrackingCode(context.Background(), mockPurchaseOrder.TrackingCode)
+
+		assert.ErrorIs(t, err, ErrPurchaseOrderNotFound)
+	})
+}
+
+func TestUpdatePurchaseOrder(t *testing.T) {
+	mockOrderDetails := utils.CreateRandomListOrderDetails()
+
+	t.Run("In case of success", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+		mockPurchaseOrderRepo.On("GetByOrderNumber", mock.Anything, "NEW-ORDER").
+			Return(nil, nil).Once()
+		mockPurchaseOrderRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.PurchaseOrder")).
+			Return(func(_ context.Context, purchaseOrder *PurchaseOrder) *PurchaseOrder {
+				return purchaseOrder
+			}, nil).Once()
+		mockPurchaseOrderRepo.On("GetOrderDetails", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockOrderDetails, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		result, err := s.Update(context.Background(), mockPurchaseOrder.ID, PurchaseOrderUpdateRequest{
+			OrderNumber: "NEW-ORDER",
+			CarrierId:   99,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "NEW-ORDER", result.OrderNumber)
+		assert.Equal(t, int64(99), result.CarrierId)
+		assert.Equal(t, mockPurchaseOrder.BuyerId, result.BuyerId)
+	})
+
+	t.Run("In case of duplicated order number", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		otherPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+		mockPurchaseOrderRepo.On("GetByOrderNumber", mock.Anything, "NEW-ORDER").
+			Return(&otherPurchaseOrder, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		_, err := s.Update(context.Background(), mockPurchaseOrder.ID, PurchaseOrderUpdateRequest{OrderNumber: "NEW-ORDER"})
+
+		assert.ErrorIs(t, err, ErrDuplicatedOrderNumber)
+	})
+
+	t.Run("In case of shipped order", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		mockPurchaseOrder.OrderStatusId = OrderStatusShipped
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		_, err := s.Update(context.Background(), mockPurchaseOrder.ID, PurchaseOrderUpdateRequest{CarrierId: 99})
+
+		assert.ErrorIs(t, err, ErrPurchaseOrderShipped)
+	})
+}
+
+func TestDeletePurchaseOrder(t *testing.T) {
+	t.Run("In case of success", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		mockPurchaseOrder.OrderStatusId = OrderStatusPicking
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+		mockPurchaseOrderRepo.On("Delete", mock.Anything, mockPurchaseOrder.ID).
+			Return(nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		err := s.Delete(context.Background(), mockPurchaseOrder.ID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("In case of not found", func(t *testing.T) {
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, int64(1)).
+			Return(nil, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		err := s.Delete(context.Background(), 1)
+
+		assert.ErrorIs(t, err, ErrPurchaseOrderNotFound)
+	})
+
+	t.Run("In case of delivered order", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		mockPurchaseOrder.OrderStatusId = OrderStatusDelivered
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		err := s.Delete(context.Background(), mockPurchaseOrder.ID)
+
+		assert.ErrorIs(t, err, ErrPurchaseOrderShipped)
+	})
+}
+
+func TestCreateOrderDetail(t *testing.T) {
+	mockOrderDetail := utils.CreateRandomOrderDetail()
+
+	t.Run("In case of success", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+		mockPurchaseOrderRepo.On("CreateOrderDetail", mock.Anything, mockPurchaseOrder.WarehouseId, mock.Anything).
+			Return(&mockOrderDetail, nil).Once()
+		mockPurchaseOrderRepo.On("GetOrderDetailById", mock.Anything, mockOrderDetail.ID).
+			Return(&mockOrderDetail, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		result, err := s.CreateOrderDetail(context.Background(), mockPurchaseOrder.ID, mockOrderDetail)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &mockOrderDetail, result)
+	})
+
+	t.Run("In case of insufficient stock", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+		mockPurchaseOrderRepo.On("CreateOrderDetail", mock.Anything, mockPurchaseOrder.WarehouseId, mock.Anything).
+			Return(nil, ErrInsufficientStock).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		_, err := s.CreateOrderDetail(context.Background(), mockPurchaseOrder.ID, mockOrderDetail)
+
+		assert.ErrorIs(t, err, ErrInsufficientStock)
+	})
+
+	t.Run("In case of order no longer open", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		mockPurchaseOrder.OrderStatusId = OrderStatusCreated + 1
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		_, err := s.CreateOrderDetail(context.Background(), mockPurchaseOrder.ID, mockOrderDetail)
+
+		assert.ErrorIs(t, err, ErrPurchaseOrderNotOpen)
+	})
+}
+
+func TestUpdateOrderDetail(t *testing.T) {
+	mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+
+	t.Run("In case of success", func(t *testing.T) {
+		mockOrderDetail := utils.CreateRandomOrderDetail()
+		temperature := 0.0
+
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+		mockPurchaseOrderRepo.On("GetOrderDetailById", mock.Anything, mockOrderDetail.ID).
+			Return(&mockOrderDetail, nil).Twice()
+		mockPurchaseOrderRepo.On("UpdateOrderDetail", mock.Anything, mockPurchaseOrder.WarehouseId, mock.MatchedBy(func(orderDetail *OrderDetail) bool {
+			return orderDetail.Quantity == 42 && orderDetail.Temperature == 0
+		})).Return(&mockOrderDetail, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		_, err := s.UpdateOrderDetail(context.Background(), mockPurchaseOrder.ID, mockOrderDetail.ID, OrderDetailUpdateRequest{
+			Quantity:    42,
+			Temperature: &temperature,
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("In case of detail of another order", func(t *testing.T) {
+		mockOrderDetail := utils.CreateRandomOrderDetail()
+		mockOrderDetail.PurchaseOrderId = mockPurchaseOrder.ID + 1
+
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+		mockPurchaseOrderRepo.On("GetOrderDetailById", mock.Anything, mockOrderDetail.ID).
+			Return(&mockOrderDetail, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		_, err := s.UpdateOrderDetail(context.Background(), mockPurchaseOrder.ID, mockOrderDetail.ID, OrderDetailUpdateRequest{})
+
+		assert.ErrorIs(t, err, ErrOrderDetailNotFound)
+	})
+}
+
+func TestDeleteOrderDetail(t *testing.T) {
+	mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+	mockOrderDetail := utils.CreateRandomOrderDetail()
+
+	t.Run("In case of success", func(t *testing.T) {
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+		mockPurchaseOrderRepo.On("GetOrderDetailById", mock.Anything, mockOrderDetail.ID).
+			Return(&mockOrderDetail, nil).Once()
+		mockPurchaseOrderRepo.On("DeleteOrderDetail", mock.Anything, mockOrderDetail.ID).
+			Return(nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		err := s.DeleteOrderDetail(context.Background(), mockPurchaseOrder.ID, mockOrderDetail.ID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("In case of order not found", func(t *testing.T) {
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(nil, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		err := s.DeleteOrderDetail(context.Background(), mockPurchaseOrder.ID, mockOrderDetail.ID)
+
+		assert.ErrorIs(t, err, ErrPurchaseOrderNotFound)
+	})
+}
+
+func TestUpdateStatus(t *testing.T) {
+	t.Run("In case of success", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		mockOrderDetails := utils.CreateRandomListOrderDetails()
+
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+		mockPurchaseOrderRepo.On("UpdateStatus", mock.Anything, mockPurchaseOrder.ID, OrderStatusCreated, OrderStatusPicking).
+			Return(nil).Once()
+		mockPurchaseOrderRepo.On("GetOrderDetails", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockOrderDetails, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		result, err := s.UpdateStatus(context.Background(), mockPurchaseOrder.ID, OrderStatusPicking)
+
+		assert.NoError(t, err)
+		assert.Equal(t, OrderStatusPicking, result.OrderStatusId)
+	})
+
+	t.Run("In case of illegal transition", func(t *testing.T) {
+		mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+		mockPurchaseOrder.OrderStatusId = OrderStatusDelivered
+
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		_, err := s.UpdateStatus(context.Background(), mockPurchaseOrder.ID, OrderStatusCancelled)
+
+		assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+	})
+
+	t.Run("In case of not found", func(t *testing.T) {
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, int64(1)).
+			Return(nil, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		_, err := s.UpdateStatus(context.Background(), 1, OrderStatusShipped)
+
+		assert.ErrorIs(t, err, ErrPurchaseOrderNotFound)
+	})
+}
+
+func TestGetStatusHistory(t *testing.T) {
+	mockPurchaseOrder := utils.CreateRandomPurchaseOrder()
+
+	t.Run("In case of success", func(t *testing.T) {
+		history := []OrderStatusHistory{
+			{ID: 1, PurchaseOrderId: mockPurchaseOrder.ID, ToStatusId: OrderStatusCreated},
+		}
+
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(&mockPurchaseOrder, nil).Once()
+		mockPurchaseOrderRepo.On("GetStatusHistory", mock.Anything, mockPurchaseOrder.ID).
+			Return(&history, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		result, err := s.GetStatusHistory(context.Background(), mockPurchaseOrder.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &history, result)
+	})
+
+	t.Run("In case of not found", func(t *testing.T) {
+		mockPurchaseOrderRepo := mocks.NewPurchaseOrderRepository(t)
+		mockPurchaseOrderRepo.On("GetById", mock.Anything, mockPurchaseOrder.ID).
+			Return(nil, nil).Once()
+
+		s := NewPurchaseOrderService(mockPurchaseOrderRepo)
+		_, err := s.GetStatusHistory(context.Background(), mockPurchaseOrder.ID)
+
+		assert.ErrorIs(t, err, ErrPurchaseOrderNotFound)
+	})
+}
+
+func TestWarehouseScope(t *testing.T) {
+	warehouseId := int64(2)
+	ctx := auth.NewContext(context.Background(), auth.Claims{Roles: []string{policy.Employee}, WarehouseId: &warehouseId})
+	otherOrder := &PurchaseOrder{ID: 1, OrderStatusId: OrderStatusCreated, WarehouseId: 5}
+
+	t.Run("create for another warehouse", func(t *testing.T) {
+		repository := mocks.NewPurchaseOrderRepository(t)
+
+		_, err := NewPurchaseOrderService(repository).Create(ctx, "order#1", "2022-06-01", "abc123", 1, 1, 5, nil)
+
+		assert.ErrorIs(t, err, policy.ErrOtherWarehouse)
+	})
+
+	t.Run("update order of another warehouse", func(t *testing.T) {
+		repository := mocks.NewPurchaseOrderRepository(t)
+		repository.On("GetById", mock.Anything, int64(1)).Return(otherOrder, nil).Once()
+
+		_, err := NewPurchaseOrderService(repository).Update(ctx, 1, PurchaseOrderUpdateRequest{TrackingCode: "xyz789"})
+
+		assert.ErrorIs(t, err, policy.ErrOtherWarehouse)
+	})
+
+	t.Run("hand order over to another warehouse", func(t *testing.T) {
+		repository := mocks.NewPurchaseOrderRepository(t)
+		repository.On("GetById", mock.Anything, int64(1)).
+			Return(&PurchaseOrder{ID: 1, OrderStatusId: OrderStatusCreated, WarehouseId: 2}, nil).Once()
+
+		_, err := NewPurchaseOrderService(repository).Update(ctx, 1, PurchaseOrderUpdateRequest{WarehouseId: 5})
+
+		assert.ErrorIs(t, err, policy.ErrOtherWarehouse)
+	})
+
+	t.Run("update status of another warehouse", func(t *testing.T) {
+		repository := mocks.NewPurchaseOrderRepository(t)
+		repository.On("GetById", mock.Anything, int64(1)).Return(otherOrder, nil).Once()
+
+		_, err := NewPurchaseOrderService(repository).UpdateStatus(ctx, 1, OrderStatusCancelled)
+
+		assert.ErrorIs(t, err, policy.ErrOtherWarehouse)
+	})
+
+	t.Run("delete order of another warehouse", func(t *testing.T) {
+		repository := mocks.NewPurchaseOrderRepository(t)
+		repository.On("GetById", mock.Anything, int64(1)).Return(otherOrder, nil).Once()
+
+		err := NewPurchaseOrderService(repository).Delete(ctx, 1)
+
+		assert.ErrorIs(t, err, policy.ErrOtherWarehouse)
+	})
+
+	t.Run("delete order of own warehouse", func(t *testing.T) {
+		repository := mocks.NewPurchaseOrderRepository(t)
+		repository.On("GetById", mock.Anything, int64(1)).
+			Return(&PurchaseOrder{ID: 1, OrderStatusId: OrderStatusCreated, WarehouseId: 2}, nil).Once()
+		repository.On("Delete", mock.Anything, int64(1)).Return(nil).Once()
+
+		err := NewPurchaseOrderService(repository).Delete(ctx, 1)
+
+		assert.NoError(t, err)
+	})
+}
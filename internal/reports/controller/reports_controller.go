@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/reports/domain"
+)
+
+type ReportController struct {
+	service domain.ReportService
+}
+
+func NewReportController(service domain.ReportService) (*ReportController, error) {
+	if service == nil {
+		return nil, errors.New("invalid service")
+	}
+
+	return &ReportController{
+		service: service,
+	}, nil
+}
+
+// @Summary Margin report
+// @Tags Reports
+// @Description get the revenue, cost and gross margin of the orders placed in a date range, priced at each order date. Cancelled orders are left out. Send format=csv or Accept: text/csv for CSV
+// @Accept json
+// @Produce json,text/csv
+// @Security BearerAuth
+// @Param from query string true "RFC 3339 start, inclusive"
+// @Param to query string true "RFC 3339 end, exclusive"
+// @Param group_by query string false "product (default), seller or product_type"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} schemas.JSONSuccessResult{data=[]domain.Margin}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /reports/margins [get]
+func (c ReportController) GetMargins() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestMarginReport
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		if req.GroupBy == "" {
+			req.GroupBy = domain.GroupByProduct
+		}
+
+		margins, err := c.service.GetMargins(ctx.Request.Context(), req.GroupBy, req.From, req.To)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidRange) {
+				problem.Abort(ctx, http.StatusBadRequest, err)
+				return
+			}
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		if !wantsCSV(ctx, req.Format) {
+			ctx.JSON(http.StatusOK, gin.H{"data": margins})
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := domain.WriteCSV(&buf, req.GroupBy, *margins); err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.Header("Content-Disposition", `attachment; filename="margins.csv"`)
+		ctx.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+	}
+}
+
+// wantsCSV prefers the format query parameter over the Accept header.
+func wantsCSV(ctx *gin.Context, format string) bool {
+	if format != "" {
+		return format == domain.FormatCSV
+	}
+	return strings.Contains(ctx.GetHeader("Accept"), "text/csv")
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/reports/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/reports/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const marginsPath = "/api/v1/reports/margins?from=2022-05-01T00:00:00Z&to=2022-06-01T00:00:00Z"
+
+var (
+	from = time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	to   = time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func serve(serviceMock *mocks.ReportService, path, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+
+	_, engine := gin.CreateTestContext(rec)
+
+	reportController := ReportController{service: serviceMock}
+
+	engine.GET("/api/v1/reports/margins", reportController.GetMargins())
+
+	engine.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestGetMargins(t *testing.T) {
+	margins := &[]domain.Margin{
+		{GroupBy: domain.GroupByProduct, Id: 1, Quantity: 4, Revenue: 40, Cost: 30, Margin: 10, MarginPercent: 25},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		serviceMock := mocks.NewReportService(t)
+		serviceMock.On("GetMargins", mock.Anything, domain.GroupByProduct, from, to).Return(margins, nil).Once()
+
+		rec := serve(serviceMock, marginsPath, "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"margin_percent":25`)
+	})
+
+	t.Run("csv by format", func(t *testing.T) {
+		serviceMock := mocks.NewReportService(t)
+		serviceMock.On("GetMargins", mock.Anything, domain.GroupBySeller, from, to).Return(margins, nil).Once()
+
+		rec := serve(serviceMock, marginsPath+"&group_by=seller&format=csv", "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "seller_id,quantity,revenue,cost,margin,margin_percent\n1,4,40.00,30.00,10.00,25.00\n", rec.Body.String())
+	})
+
+	t.Run("csv by accept header", func(t *testing.T) {
+		serviceMock := mocks.NewReportService(t)
+		serviceMock.On("GetMargins", mock.Anything, domain.GroupByProduct, from, to).Return(margins, nil).Once()
+
+		rec := serve(serviceMock, marginsPath, "text/csv")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "product_id,quantity")
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		serviceMock := mocks.NewReportService(t)
+
+		rec := serve(serviceMock, marginsPath+"&group_by=buyer", "")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		serviceMock := mocks.NewReportService(t)
+		serviceMock.On("GetMargins", mock.Anything, domain.GroupByProduct, from, to).Return(nil, domain.ErrInvalidRange).Once()
+
+		rec := serve(serviceMock, marginsPath, "")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		serviceMock := mocks.NewReportService(t)
+		serviceMock.On("GetMargins", mock.Anything, domain.GroupByProduct, from, to).Return(nil, errors.New("connection refused")).Once()
+
+		rec := serve(serviceMock, marginsPath, "")
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
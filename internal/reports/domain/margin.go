@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Complete fills the margin and its percentage of the revenue, rounded to
+// cents and hundredths of a percent.
+func (m *Margin) Complete() {
+	m.Revenue = round(m.Revenue)
+	m.Cost = round(m.Cost)
+	m.Margin = round(m.Revenue - m.Cost)
+	m.MarginPercent = 0
+	if m.Revenue != 0 {
+		m.MarginPercent = round(m.Margin / m.Revenue * 100)
+	}
+}
+
+// WriteCSV writes the margins with a header naming the id column after the
+// grouping.
+func WriteCSV(w io.Writer, groupBy string, margins []Margin) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{groupBy + "_id", "quantity", "revenue", "cost", "margin", "margin_percent"}); err != nil {
+		return err
+	}
+
+	for _, m := range margins {
+		if err := writer.Write([]string{
+			strconv.FormatInt(m.Id, 10),
+			strconv.FormatInt(m.Quantity, 10),
+			strconv.FormatFloat(m.Revenue, 'f', 2, 64),
+			strconv.FormatFloat(m.Cost, 'f', 2, 64),
+			strconv.FormatFloat(m.Margin, 'f', 2, 64),
+			strconv.FormatFloat(m.MarginPercent, 'f', 2, 64),
+		}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplete(t *testing.T) {
+	t.Run("margin", func(t *testing.T) {
+		m := Margin{Revenue: 150.004, Cost: 100.001}
+		m.Complete()
+
+		assert.Equal(t, 150.0, m.Revenue)
+		assert.Equal(t, 100.0, m.Cost)
+		assert.Equal(t, 50.0, m.Margin)
+		assert.Equal(t, 33.33, m.MarginPercent)
+	})
+
+	t.Run("no revenue", func(t *testing.T) {
+		m := Margin{Cost: 10}
+		m.Complete()
+
+		assert.Equal(t, -10.0, m.Margin)
+		assert.Equal(t, 0.0, m.MarginPercent)
+	})
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteCSV(&buf, GroupBySeller, []Margin{
+		{GroupBy: GroupBySeller, Id: 3, Quantity: 4, Revenue: 40, Cost: 30, Margin: 10, MarginPercent: 25},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "seller_id,quantity,revenue,cost,margin,margin_percent\n3,4,40.00,30.00,10.00,25.00\n", buf.String())
+}
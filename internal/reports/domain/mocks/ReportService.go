@@ -0,0 +1,55 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/reports/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ReportService is an autogenerated mock type for the ReportService type
+type ReportService struct {
+	mock.Mock
+}
+
+// GetMargins provides a mock function with given fields: ctx, groupBy, from, to
+func (_m *ReportService) GetMargins(ctx context.Context, groupBy string, from time.Time, to time.Time) (*[]domain.Margin, error) {
+	ret := _m.Called(ctx, groupBy, from, to)
+
+	var r0 *[]domain.Margin
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) *[]domain.Margin); ok {
+		r0 = rf(ctx, groupBy, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Margin)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, groupBy, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewReportService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewReportService creates a new instance of ReportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewReportService(t mockConstructorTestingTNewReportService) *ReportService {
+	mock := &ReportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
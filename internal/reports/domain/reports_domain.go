@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Margin report groupings.
+const (
+	GroupByProduct     = "product"
+	GroupBySeller      = "seller"
+	GroupByProductType = "product_type"
+)
+
+// Margin report formats.
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+type RequestMarginReport struct {
+	From    time.Time `form:"from" binding:"required"`
+	To      time.Time `form:"to" binding:"required"`
+	GroupBy string    `form:"group_by" binding:"omitempty,oneof=product seller product_type"`
+	Format  string    `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// Margin aggregates the order lines of a product, seller or product type,
+// identified by Id. Revenue and Cost use the sale and purchase prices in
+// effect at the order date of each line.
+type Margin struct {
+	GroupBy       string  `json:"group_by"`
+	Id            int64   `json:"id"`
+	Quantity      int64   `json:"quantity"`
+	Revenue       float64 `json:"revenue"`
+	Cost          float64 `json:"cost"`
+	Margin        float64 `json:"margin"`
+	MarginPercent float64 `json:"margin_percent"`
+}
+
+type ReportRepository interface {
+	// GetMargins returns the quantity, revenue and cost of the orders placed
+	// between from and to, leaving cancelled orders out.
+	GetMargins(ctx context.Context, groupBy string, from, to time.Time) (*[]Margin, error)
+}
+
+type ReportService interface {
+	GetMargins(ctx context.Context, groupBy string, from, to time.Time) (*[]Margin, error)
+}
@@ -0,0 +1,5 @@
+package domain
+
+import "errors"
+
+var ErrInvalidRange = errors.New("to must be after from")
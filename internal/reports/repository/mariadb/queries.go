@@ -0,0 +1,20 @@
+package mariadb
+
+// sqlGetMargins prices each order line with the product record in effect at
+// its order date, falling back to the record the line was ordered with when
+// the product had no price yet. %[1]s is the grouping column.
+const sqlGetMargins = `SELECT %[1]s, SUM(od.quantity),
+		SUM(od.quantity * COALESCE(pr.sale_price, ordered.sale_price)),
+		SUM(od.quantity * COALESCE(pr.purchase_price, ordered.purchase_price))
+	FROM order_details od
+	INNER JOIN purchase_orders po ON po.id = od.purchase_order_id
+	INNER JOIN product_records ordered ON ordered.id = od.product_record_id
+	INNER JOIN products p ON p.id = ordered.product_id
+	LEFT JOIN product_records pr ON pr.id = (
+		SELECT r.id FROM product_records r
+		WHERE r.product_id = p.id AND r.last_update_date <= po.order_date
+		ORDER BY r.last_update_date DESC, r.id DESC
+		LIMIT 1)
+	WHERE po.order_date >= ? AND po.order_date < ? AND po.order_status_id <> ?
+	GROUP BY %[1]s
+	ORDER BY %[1]s;`
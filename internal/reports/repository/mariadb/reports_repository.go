@@ -0,0 +1,53 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	orders "github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/reports/domain"
+)
+
+var groupColumns = map[string]string{
+	domain.GroupByProduct:     "p.id",
+	domain.GroupBySeller:      "p.seller_id",
+	domain.GroupByProductType: "p.product_type_id",
+}
+
+type mariadbRepository struct {
+	db *sql.DB
+}
+
+func NewMariaDBRepository(db *sql.DB) domain.ReportRepository {
+	return mariadbRepository{db: db}
+}
+
+func (m mariadbRepository) GetMargins(ctx context.Context, groupBy string, from, to time.Time) (*[]domain.Margin, error) {
+	margins := []domain.Margin{}
+
+	column, ok := groupColumns[groupBy]
+	if !ok {
+		return &margins, fmt.Errorf("unknown margin grouping %q", groupBy)
+	}
+
+	rows, err := m.db.QueryContext(ctx, fmt.Sprintf(sqlGetMargins, column), from, to, orders.OrderStatusCancelled)
+	if err != nil {
+		return &margins, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		margin := domain.Margin{GroupBy: groupBy}
+
+		if err := rows.Scan(&margin.Id, &margin.Quantity, &margin.Revenue, &margin.Cost); err != nil {
+			return &margins, err
+		}
+
+		margins = append(margins, margin)
+	}
+
+	return &margins, rows.Err()
+}
@@ -0,0 +1,62 @@
+package mariadb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	orders "github.com/marcoglnd/mercado-fresco-packmain/internal/purchase-orders/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/reports/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var rowsMarginStruct = []string{"id", "quantity", "revenue", "cost"}
+
+func TestGetMargins(t *testing.T) {
+	from := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("by seller", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(sqlGetMargins, "p.seller_id"))).
+			WithArgs(from, to, orders.OrderStatusCancelled).
+			WillReturnRows(sqlmock.NewRows(rowsMarginStruct).AddRow(1, 10, 120.0, 80.0).AddRow(2, 3, 30.0, 27.0))
+
+		margins, err := NewMariaDBRepository(db).GetMargins(context.Background(), domain.GroupBySeller, from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Margin{
+			{GroupBy: domain.GroupBySeller, Id: 1, Quantity: 10, Revenue: 120, Cost: 80},
+			{GroupBy: domain.GroupBySeller, Id: 2, Quantity: 3, Revenue: 30, Cost: 27},
+		}, *margins)
+	})
+
+	t.Run("unknown grouping", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		_, err = NewMariaDBRepository(db).GetMargins(context.Background(), "buyer", from, to)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("fail to select margins", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(sqlGetMargins, "p.id"))).WillReturnError(errors.New("connection refused"))
+
+		_, err = NewMariaDBRepository(db).GetMargins(context.Background(), domain.GroupByProduct, from, to)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/reports/domain"
+)
+
+type reportService struct {
+	repository domain.ReportRepository
+}
+
+func NewReportService(r domain.ReportRepository) domain.ReportService {
+	return &reportService{repository: r}
+}
+
+// GetMargins returns the gross margin of the orders placed between from and
+// to, grouped by groupBy.
+func (s reportService) GetMargins(ctx context.Context, groupBy string, from, to time.Time) (*[]domain.Margin, error) {
+	if !to.After(from) {
+		return nil, domain.ErrInvalidRange
+	}
+
+	margins, err := s.repository.GetMargins(ctx, groupBy, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range *margins {
+		(*margins)[i].Complete()
+	}
+
+	return margins, nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/reports/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/reports/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetMargins(t *testing.T) {
+	from := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		repositoryMock := mocks.NewReportRepository(t)
+		repositoryMock.On("GetMargins", mock.Anything, domain.GroupByProductType, from, to).
+			Return(&[]domain.Margin{{GroupBy: domain.GroupByProductType, Id: 2, Quantity: 4, Revenue: 40, Cost: 30}}, nil).Once()
+
+		margins, err := NewReportService(repositoryMock).GetMargins(context.Background(), domain.GroupByProductType, from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10.0, (*margins)[0].Margin)
+		assert.Equal(t, 25.0, (*margins)[0].MarginPercent)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		repositoryMock := mocks.NewReportRepository(t)
+
+		_, err := NewReportService(repositoryMock).GetMargins(context.Background(), domain.GroupByProduct, to, from)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidRange)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		repositoryMock := mocks.NewReportRepository(t)
+		repositoryMock.On("GetMargins", mock.Anything, domain.GroupByProduct, from, to).
+			Return(nil, errors.New("connection refused")).Once()
+
+		_, err := NewReportService(repositoryMock).GetMargins(context.Background(), domain.GroupByProduct, from, to)
+
+		assert.Error(t, err)
+	})
+}
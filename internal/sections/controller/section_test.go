@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sections/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sections/domain/mocks"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
@@ -71,6 +73,17 @@ func TestCreateNewSections(t *testing.T) {
 		engine.ServeHTTP(rec, req)
 
 		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+
+		var body problem.Problem
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, problem.TypeValidation, body.Type)
+		assert.Len(t, body.Errors, 8)
+		assert.Equal(t, problem.FieldError{
+			Field:   "section_number",
+			Rule:    "required",
+			Message: "section_number is required",
+		}, body.Errors[0])
 
 		sectionsServiceMock.AssertExpectations(t)
 	})
@@ -82,7 +95,7 @@ func TestCreateNewSections(t *testing.T) {
 		sectionsServiceMock.On("Create",
 			mock.Anything,
 			mock.Anything,
-		).Return(nil, errors.New("expected conflict error")).Maybe()
+		).Return(nil, domain.ErrDuplicatedID).Maybe()
 
 		payload, err := json.Marshal(mockSection)
 		assert.NoError(t, err)
@@ -103,6 +116,46 @@ func TestCreateNewSections(t *testing.T) {
 		sectionsServiceMock.AssertExpectations(t)
 	})
 
+	t.Run("fail with unknown warehouse", func(t *testing.T) {
+		mockSection := utils.CreateRandomSection()
+		sectionsServiceMock := mocks.NewService(t)
+
+		sectionsServiceMock.On("Create",
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, &apperrors.Error{
+			Kind:    apperrors.ErrForeignKey,
+			Entity:  "warehouses",
+			Field:   "warehouse_id",
+			Message: "warehouse_id: no warehouses with that id",
+		}).Once()
+
+		payload, err := json.Marshal(mockSection)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/sections", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		sectionController := SectionsController{service: sectionsServiceMock}
+
+		engine.POST("/api/v1/sections", sectionController.Create())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.JSONEq(t, `{
+			"type": "/problems/foreign-key",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "warehouse_id: no warehouses with that id",
+			"errors": [{"field": "warehouse_id", "rule": "exists", "param": "warehouses", "message": "warehouse_id: no warehouses with that id"}]
+		}`, rec.Body.String())
+
+		sectionsServiceMock.AssertExpectations(t)
+	})
+
 }
 
 func TestGetAll(t *testing.T) {
@@ -112,7 +165,8 @@ func TestGetAll(t *testing.T) {
 
 		sectionsServiceMock.On("GetAll",
 			mock.Anything,
-		).Return(&mockSection, nil).Once()
+			mock.Anything,
+		).Return(&mockSection, int64(len(mockSection)), nil).Once()
 
 		payload, err := json.Marshal(mockSection)
 		assert.NoError(t, err)
@@ -139,7 +193,8 @@ func TestGetAll(t *testing.T) {
 
 		sectionsServiceMock.On("GetAll",
 			mock.Anything,
-		).Return(mockSectionBad, errors.New("Internal server error")).Maybe()
+			mock.Anything,
+		).Return(mockSectionBad, int64(0), errors.New("Internal server error")).Maybe()
 
 		payload, err := json.Marshal(mockSectionBad)
 		assert.NoError(t, err)
@@ -342,7 +397,7 @@ func TestUpdate(t *testing.T) {
 		sectionsServiceMock.On("Update",
 			mock.Anything,
 			mock.Anything,
-		).Return(nil, errors.New("expected not found error")).Maybe()
+		).Return(nil, domain.ErrIDNotFound).Maybe()
 
 		payload, err := json.Marshal(mockSection)
 		assert.NoError(t, err)
@@ -423,7 +478,7 @@ func TestDelete(t *testing.T) {
 		sectionsServiceMock.On("Delete",
 			mock.Anything,
 			mock.AnythingOfType("int64"),
-		).Return(errors.New("expected conflict error")).Maybe()
+		).Return(domain.ErrIDNotFound).Maybe()
 
 		PATH := fmt.Sprintf("/api/v1/sections/%v", utils.RandomInt64())
 		req := httptest.NewRequest(http.MethodDelete, PATH, nil)
@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sections/domain"
 )
 
@@ -23,20 +25,33 @@ func NewSection(s domain.Service) *SectionsController {
 // @Description get all sections
 // @Accept json
 // @Produce json
-// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Section}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param product_type_id query int false "Product type ID"
+// @Param section_number query int false "Section number"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.Section}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /sections [get]
 func (c *SectionsController) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		data, err := c.service.GetAll(ctx.Request.Context())
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.SectionListFields)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+		data, total, err := c.service.GetAll(ctx.Request.Context(), params)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, gin.H{
 			"data": data,
+			"meta": listing.NewMeta(params, total),
 		})
 	}
 }
@@ -46,21 +61,22 @@ func (c *SectionsController) GetAll() gin.HandlerFunc {
 // @Description get section by its id
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Section ID"
 // @Success 200 {object} domain.Section
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
 // @Router /sections/{id} [get]
 func (c *SectionsController) GetById() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req domain.RequestSectionId
 		if err := ctx.ShouldBindUri(&req); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
 			return
 		}
 		section, err := c.service.GetById(ctx.Request.Context(), req.ID)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusNotFound, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, section)
@@ -72,16 +88,18 @@ func (c *SectionsController) GetById() gin.HandlerFunc {
 // @Description Add a new section to the list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param section body domain.RequestSections true "Section to create"
 // @Success 201 {object} domain.Section
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /sections [post]
 func (c *SectionsController) Create() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req domain.RequestSections
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 		section, err := c.service.Create(
@@ -98,7 +116,7 @@ func (c *SectionsController) Create() gin.HandlerFunc {
 			},
 		)
 		if err != nil {
-			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusCreated, section)
@@ -110,22 +128,25 @@ func (c *SectionsController) Create() gin.HandlerFunc {
 // @Description Update existing section in list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Section ID"
 // @Param section body domain.RequestSections true "Section to update"
 // @Success 200 {object} domain.Section
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /sections/{id} [patch]
 func (c *SectionsController) Update() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req domain.RequestSectionsUpdated
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid inputs"})
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 		var reqId domain.RequestSectionId
 		if err := ctx.ShouldBindUri(&reqId); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
 			return
 		}
 
@@ -145,7 +166,7 @@ func (c *SectionsController) Update() gin.HandlerFunc {
 		)
 
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, section)
@@ -157,21 +178,23 @@ func (c *SectionsController) Update() gin.HandlerFunc {
 // @Description Delete existing sections in list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Section ID"
 // @Success 204 {object} schemas.JSONSuccessResult{data=string}
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
 // @Router /sections/{id} [delete]
 func (c *SectionsController) Delete() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req domain.RequestSectionId
 		if err := ctx.ShouldBindUri(&req); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
 		}
 
 		err := c.service.Delete(ctx.Request.Context(), req.ID)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 )
 
 type Section struct {
@@ -11,13 +13,21 @@ type Section struct {
 	MinimumTemperature float64 `json:"minimum_temperature"`
 	CurrentCapacity    int64   `json:"current_capacity"`
 	MinimumCapacity    int64   `json:"minimum_capacity"`
-	MaximumCapacity    int64 `json:"maximum_capacity"`
-	WarehouseId        int64 `json:"warehouse_id"`
-	ProductTypeId      int64 `json:"product_type_id"`
+	MaximumCapacity    int64   `json:"maximum_capacity"`
+	WarehouseId        int64   `json:"warehouse_id"`
+	ProductTypeId      int64   `json:"product_type_id"`
+}
+
+var SectionListFields = listing.Fields{
+	Sort: []string{
+		"id", "section_number", "current_temperature", "minimum_temperature", "current_capacity",
+		"minimum_capacity", "maximum_capacity", "warehouse_id", "product_type_id",
+	},
+	Filter: []string{"warehouse_id", "product_type_id", "section_number"},
 }
 
 type Service interface {
-	GetAll(ctx context.Context) (*[]Section, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]Section, int64, error)
 	GetById(ctx context.Context, id int64) (*Section, error)
 	Create(ctx context.Context, section *Section) (*Section, error)
 	Update(ctx context.Context, section *Section) (*Section, error)
@@ -25,7 +35,7 @@ type Service interface {
 }
 
 type Repository interface {
-	GetAll(ctx context.Context) (*[]Section, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]Section, int64, error)
 	GetById(ctx context.Context, id int64) (*Section, error)
 	Create(ctx context.Context, section *Section) (*Section, error)
 	Update(ctx context.Context, section *Section) (*Section, error)
@@ -42,9 +52,9 @@ type RequestSections struct {
 	MinimumTemperature float64 `json:"minimum_temperature" binding:"required"`
 	CurrentCapacity    int64   `json:"current_capacity" binding:"required"`
 	MinimumCapacity    int64   `json:"minimum_capacity" binding:"required"`
-	MaximumCapacity int64 `json:"maximum_capacity" binding:"required"`
-	WarehouseId     int64 `json:"warehouse_id" binding:"required"`
-	ProductTypeId   int64 `json:"product_type_id" binding:"required"`
+	MaximumCapacity    int64   `json:"maximum_capacity" binding:"required"`
+	WarehouseId        int64   `json:"warehouse_id" binding:"required"`
+	ProductTypeId      int64   `json:"product_type_id" binding:"required"`
 }
 
 type RequestSectionsUpdated struct {
@@ -53,7 +63,7 @@ type RequestSectionsUpdated struct {
 	MinimumTemperature float64 `json:"minimum_temperature"`
 	CurrentCapacity    int64   `json:"current_capacity"`
 	MinimumCapacity    int64   `json:"minimum_capacity"`
-	MaximumCapacity    int64 `json:"maximum_capacity"`
-	WarehouseId        int64 `json:"warehouse_id"`
-	ProductTypeId      int64 `json:"product_type_id"`
+	MaximumCapacity    int64   `json:"maximum_capacity"`
+	WarehouseId        int64   `json:"warehouse_id"`
+	ProductTypeId      int64   `json:"product_type_id"`
 }
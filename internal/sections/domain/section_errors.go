@@ -1,8 +1,8 @@
 package domain
 
-import "errors"
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
 
 var (
-	ErrIDNotFound   = errors.New("section id not found")
-	ErrDuplicatedID = errors.New("duplicated batch_number")
+	ErrIDNotFound   = apperrors.NotFound("section id not found")
+	ErrDuplicatedID = apperrors.Conflict("duplicated batch_number")
 )
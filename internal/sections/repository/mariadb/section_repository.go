@@ -5,6 +5,10 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	auditlog "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sections/domain"
 )
 
@@ -14,12 +18,19 @@ func NewMariaDBRepository(db *sql.DB) domain.Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) GetAll(ctx context.Context) (*[]domain.Section, error) {
+func (r *repository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Section, int64, error) {
 	sections := []domain.Section{}
 
-	rows, err := r.db.QueryContext(ctx, sqlGetAllSections)
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAllSections, params, nil)
+	if err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &sections, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAllSections, params, nil)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return &sections, err
+		return &sections, 0, err
 	}
 
 	defer rows.Close()
@@ -38,13 +49,13 @@ func (r *repository) GetAll(ctx context.Context) (*[]domain.Section, error) {
 			&section.WarehouseId,
 			&section.ProductTypeId,
 		); err != nil {
-			return &sections, err
+			return &sections, 0, err
 		}
 
 		sections = append(sections, section)
 	}
 
-	return &sections, nil
+	return &sections, total, nil
 }
 
 func (r *repository) GetById(ctx context.Context, id int64) (*domain.Section, error) {
@@ -86,7 +97,14 @@ func (r *repository) Create(ctx context.Context, section *domain.Section) (*doma
 		ProductTypeId:      section.ProductTypeId,
 	}
 
-	result, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &newSection, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
 		ctx,
 		sqlInsertSection,
 		&newSection.SectionNumber,
@@ -99,14 +117,17 @@ func (r *repository) Create(ctx context.Context, section *domain.Section) (*doma
 		&newSection.ProductTypeId,
 	)
 	if err != nil {
-		return &newSection, err
+		return &newSection, apperrors.FromMySQL(err)
 	}
 	insertedId, err := result.LastInsertId()
 	if err != nil {
 		return &newSection, err
 	}
 	newSection.ID = insertedId
-	return &newSection, nil
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "sections", insertedId, nil); err != nil {
+		return &newSection, err
+	}
+	return &newSection, tx.Commit()
 }
 
 func (r *repository) Update(ctx context.Context, section *domain.Section) (*domain.Section, error) {
@@ -122,7 +143,19 @@ func (r *repository) Update(ctx context.Context, section *domain.Section) (*doma
 		ProductTypeId:      section.ProductTypeId,
 	}
 
-	result, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &newSection, err
+	}
+
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "sections", newSection.ID)
+	if err != nil {
+		return &newSection, err
+	}
+
+	result, err := tx.ExecContext(
 		ctx,
 		sqlUpdateSection,
 		&newSection.SectionNumber,
@@ -136,7 +169,7 @@ func (r *repository) Update(ctx context.Context, section *domain.Section) (*doma
 		&newSection.ID,
 	)
 	if err != nil {
-		return &newSection, err
+		return &newSection, apperrors.FromMySQL(err)
 	}
 
 	affectedRows, err := result.RowsAffected()
@@ -148,15 +181,31 @@ func (r *repository) Update(ctx context.Context, section *domain.Section) (*doma
 		return &newSection, err
 	}
 
-	return section, nil
+	if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "sections", newSection.ID, before); err != nil {
+		return &newSection, err
+	}
+
+	return section, tx.Commit()
 }
 
 func (r *repository) Delete(ctx context.Context, id int64) error {
-	result, err := r.db.ExecContext(ctx, sqlDeleteSection, id)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "sections", id)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, sqlDeleteSection, id)
+	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
 	affectedRows, err := result.RowsAffected()
 
 	if affectedRows == 0 {
@@ -167,5 +216,9 @@ func (r *repository) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
-	return nil
+	if err := auditlog.Record(ctx, tx, audit.ActionDelete, "sections", id, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
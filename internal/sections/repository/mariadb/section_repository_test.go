@@ -8,17 +8,23 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sections/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	queryInsertSection  = regexp.QuoteMeta(sqlInsertSection)
-	queryGetAllSections = regexp.QuoteMeta(sqlGetAllSections)
-	queryGetSectionById = regexp.QuoteMeta(sqlGetSectionById)
-	queryUpdateSection  = regexp.QuoteMeta(sqlUpdateSection)
-	queryDeleteSection  = regexp.QuoteMeta(sqlDeleteSection)
+	queryInsertSection    = regexp.QuoteMeta(sqlInsertSection)
+	queryGetAllSections   = regexp.QuoteMeta(sqlGetAllSections)
+	queryCountAllSections = regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAllSections)
+	queryGetSectionById   = regexp.QuoteMeta(sqlGetSectionById)
+	queryUpdateSection    = regexp.QuoteMeta(sqlUpdateSection)
+	queryDeleteSection    = regexp.QuoteMeta(sqlDeleteSection)
 )
 
 var rowsSectionStruct = []string{
@@ -41,6 +47,7 @@ func TestCreateNewSection(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsertSection).
 			WithArgs(
 				mockSection.SectionNumber,
@@ -52,6 +59,8 @@ func TestCreateNewSection(t *testing.T) {
 				mockSection.WarehouseId,
 				mockSection.ProductTypeId,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "sections", 1)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 
@@ -66,6 +75,7 @@ func TestCreateNewSection(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsertSection).
 			WithArgs(0, 0, 0, 0, 0, 0, 0, 0).
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -75,6 +85,25 @@ func TestCreateNewSection(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+
+	t.Run("unknown warehouse", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsertSection).
+			WillReturnError(&mysql.MySQLError{
+				Number:  1452,
+				Message: "Cannot add or update a child row: a foreign key constraint fails (`mercado_fresco`.`sections`, CONSTRAINT `sections_ibfk_1` FOREIGN KEY (`warehouse_id`) REFERENCES `warehouses` (`id`))",
+			})
+
+		repo := NewMariaDBRepository(db)
+		_, err = repo.Create(context.Background(), &mockSection)
+
+		assert.ErrorIs(t, err, apperrors.ErrForeignKey)
+		assert.EqualError(t, err, "warehouse_id: no warehouses with that id")
+	})
 }
 
 func TestGetAll(t *testing.T) {
@@ -100,12 +129,14 @@ func TestGetAll(t *testing.T) {
 			)
 		}
 
+		mock.ExpectQuery(queryCountAllSections).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(mockSections)))
 		mock.ExpectQuery(queryGetAllSections).WillReturnRows(rows)
 
 		sectionsRepo := NewMariaDBRepository(db)
 
-		result, err := sectionsRepo.GetAll(context.Background())
+		result, total, err := sectionsRepo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 		assert.NoError(t, err)
+		assert.Equal(t, int64(len(mockSections)), total)
 
 		assert.Equal(t, result, &mockSections)
 	})
@@ -117,11 +148,12 @@ func TestGetAll(t *testing.T) {
 
 		rows := sqlmock.NewRows(rowsSectionStruct).AddRow("", "", "", "", "", "", "", "", "")
 
+		mock.ExpectQuery(queryCountAllSections).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(queryGetAllSections).WillReturnRows(rows)
 
 		sectionsRepo := NewMariaDBRepository(db)
 
-		_, err = sectionsRepo.GetAll(context.Background())
+		_, _, err = sectionsRepo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 		assert.Error(t, err)
 	})
 
@@ -130,11 +162,12 @@ func TestGetAll(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectQuery(queryCountAllSections).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(queryGetAllSections).WillReturnError(sql.ErrNoRows)
 
 		sectionsRepo := NewMariaDBRepository(db)
 
-		_, err = sectionsRepo.GetAll(context.Background())
+		_, _, err = sectionsRepo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 		assert.Error(t, err)
 	})
 }
@@ -206,6 +239,8 @@ func TestUpdateSection(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "sections", mockSection.ID)
 		mock.ExpectExec(queryUpdateSection).
 			WithArgs(
 				mockSection.SectionNumber,
@@ -218,6 +253,8 @@ func TestUpdateSection(t *testing.T) {
 				mockSection.ProductTypeId,
 				mockSection.ID,
 			).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "sections", mockSection.ID)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 
@@ -232,6 +269,8 @@ func TestUpdateSection(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "sections", mockSection.ID)
 		mock.ExpectExec(queryUpdateSection).
 			WithArgs(0, 0, 0, 0, 0, 0, 0, 0, 0).
 			WillReturnResult(sqlmock.NewResult(0, 1))
@@ -246,6 +285,8 @@ func TestUpdateSection(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "sections", mockSection.ID)
 		mock.ExpectExec(queryUpdateSection).
 			WithArgs(
 				mockSection.SectionNumber,
@@ -275,10 +316,14 @@ func TestDeleteSection(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "sections", mockSection.ID)
 		mock.ExpectExec(queryDeleteSection).
 			WithArgs(
 				mockSection.ID,
 			).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectEntry(mock, audit.ActionDelete, "sections", mockSection.ID)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 
@@ -291,6 +336,8 @@ func TestDeleteSection(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "sections", mockSection.ID)
 		mock.ExpectExec(queryDeleteSection).
 			WithArgs(0).
 			WillReturnResult(sqlmock.NewResult(0, 1))
@@ -305,6 +352,8 @@ func TestDeleteSection(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "sections", mockSection.ID)
 		mock.ExpectExec(queryDeleteSection).
 			WithArgs(mockSection.ID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
@@ -314,4 +363,25 @@ func TestDeleteSection(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, domain.ErrIDNotFound, err)
 	})
+
+	t.Run("section still referenced", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "sections", mockSection.ID)
+		mock.ExpectExec(queryDeleteSection).
+			WithArgs(mockSection.ID).
+			WillReturnError(&mysql.MySQLError{
+				Number:  1451,
+				Message: "Cannot delete or update a parent row: a foreign key constraint fails (`mercado_fresco`.`product_batches`, CONSTRAINT `product_batches_ibfk_2` FOREIGN KEY (`section_id`) REFERENCES `sections` (`id`))",
+			})
+
+		repo := NewMariaDBRepository(db)
+		err = repo.Delete(context.Background(), mockSection.ID)
+
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		assert.EqualError(t, err, "still referenced by product_batches through section_id")
+	})
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sections/domain"
 )
 
@@ -16,12 +17,12 @@ func NewService(r domain.Repository) domain.Service {
 	}
 }
 
-func (s service) GetAll(ctx context.Context) (*[]domain.Section, error) {
-	sectionsList, err := s.repository.GetAll(ctx)
+func (s service) GetAll(ctx context.Context, params listing.Params) (*[]domain.Section, int64, error) {
+	sectionsList, total, err := s.repository.GetAll(ctx, params)
 	if err != nil {
-		return sectionsList, err
+		return sectionsList, 0, err
 	}
-	return sectionsList, nil
+	return sectionsList, total, nil
 }
 
 func (s service) GetById(ctx context.Context, id int64) (*domain.Section, error) {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sections/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sections/domain/mocks"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
@@ -56,11 +57,11 @@ func TestGetAll(t *testing.T) {
 		mockSectionRepo := mocks.NewRepository(t)
 		mockSections := utils.CreateRandomListSection()
 
-		mockSectionRepo.On("GetAll", mock.Anything).
-			Return(&mockSections, nil).Once()
+		mockSectionRepo.On("GetAll", mock.Anything, mock.Anything).
+			Return(&mockSections, int64(len(mockSections)), nil).Once()
 
 		s := NewService(mockSectionRepo)
-		list, err := s.GetAll(context.Background())
+		list, _, err := s.GetAll(context.Background(), listing.Params{})
 
 		assert.NoError(t, err)
 
@@ -72,12 +73,12 @@ func TestGetAll(t *testing.T) {
 	t.Run("In case of error", func(t *testing.T) {
 		mockSectionRepo := mocks.NewRepository(t)
 
-		mockSectionRepo.On("GetAll", mock.Anything).
-			Return(nil, errors.New("failed to retrieve sections")).
+		mockSectionRepo.On("GetAll", mock.Anything, mock.Anything).
+			Return(nil, int64(0), errors.New("failed to retrieve sections")).
 			Once()
 
 		s := NewService(mockSectionRepo)
-		_, err := s.GetAll(context.Background())
+		_, _, err := s.GetAll(context.Background(), listing.Params{})
 
 		assert.NotNil(t, err)
 
@@ -6,6 +6,8 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sellers/domain"
 )
 
@@ -30,21 +32,36 @@ func NewSellerController(service domain.SellerService) (*SellerController, error
 // @Description get all sellers
 // @Accept json
 // @Produce json
-// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Seller}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Param locality_id query int false "Locality ID"
+// @Param cid query int false "Company ID"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.Seller}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /sellers [get]
 func (c SellerController) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		sellers, err := c.service.GetAll(ctx.Request.Context())
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.SellerListFields)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		sellers, total, err := c.service.GetAll(ctx.Request.Context(), params)
 
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"message": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusInternalServerError, err)
 			return
 		}
 
-		ctx.JSON(http.StatusOK, sellers)
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": sellers,
+			"meta": listing.NewMeta(params, total),
+		})
 	}
 }
 
@@ -53,10 +70,11 @@ func (c SellerController) GetAll() gin.HandlerFunc {
 // @Description get Seller by it's id
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Seller ID"
 // @Success 200 {object} domain.Seller
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
 // @Router /sellers/{id} [get]
 func (c SellerController) GetByID() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -64,17 +82,13 @@ func (c SellerController) GetByID() gin.HandlerFunc {
 		strId := ctx.Param("id")
 		intId, err := strconv.ParseInt(strId, 10, 64)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"message": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		seller, err := c.service.GetByID(ctx, intId)
+		seller, err := c.service.GetByID(ctx.Request.Context(), intId)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{
-				"message": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusNotFound, err)
 			return
 		}
 
@@ -95,10 +109,12 @@ type requestCreate struct {
 // @Description Add a new Seller to the list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param Seller body requestCreate true "seller to create"
 // @Success 201 {object} domain.Seller
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /sellers [post]
 func (c SellerController) Create() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -106,13 +122,11 @@ func (c SellerController) Create() gin.HandlerFunc {
 		var req requestCreate
 
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-				"message": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		seller, err := c.service.Create(ctx, &domain.Seller{
+		seller, err := c.service.Create(ctx.Request.Context(), &domain.Seller{
 			Cid:          req.Cid,
 			Company_name: req.Company_name,
 			Address:      req.Address,
@@ -120,40 +134,28 @@ func (c SellerController) Create() gin.HandlerFunc {
 			LocalityID:   req.LocalityID,
 		})
 		if err != nil {
-			if errors.Is(err, domain.ErrDuplicatedCID) {
-				ctx.JSON(http.StatusConflict, gin.H{
-					"message": err.Error(),
-				})
-				return
-			}
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-				"message": err.Error(),
-			})
+			problem.AbortError(ctx, err)
 			return
 		}
 
-		if err != nil {
-			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
 		if req.Cid == 0 {
-			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": "A identificação da empresa (cid) é obrigatória"})
+			problem.AbortDetail(ctx, http.StatusUnprocessableEntity, "A identificação da empresa (cid) é obrigatória")
 			return
 		}
 		if req.Company_name == "" {
-			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": "O nome da empresa é obrigatório"})
+			problem.AbortDetail(ctx, http.StatusUnprocessableEntity, "O nome da empresa é obrigatório")
 			return
 		}
 		if req.Address == "" {
-			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": "O endereço da empresa é obrigatório"})
+			problem.AbortDetail(ctx, http.StatusUnprocessableEntity, "O endereço da empresa é obrigatório")
 			return
 		}
 		if req.Telephone == "" {
-			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": "O telefone da empresa é obrigatório"})
+			problem.AbortDetail(ctx, http.StatusUnprocessableEntity, "O telefone da empresa é obrigatório")
 			return
 		}
 		if req.LocalityID == 0 {
-			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": "O id da localidade é obrigatório"})
+			problem.AbortDetail(ctx, http.StatusUnprocessableEntity, "O id da localidade é obrigatório")
 			return
 		}
 
@@ -174,33 +176,32 @@ type requestUpdate struct {
 // @Description Update existing Seller in list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Seller ID"
 // @Param seller body requestUpdate true "Seller to update"
 // @Success 200 {object} domain.Seller
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /sellers/{id} [patch]
 func (c *SellerController) Update() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		strId := ctx.Param("id")
 		intId, err := strconv.ParseInt(strId, 10, 64)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"message": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
 		var req requestUpdate
 
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		seller, err := c.service.Update(ctx, &domain.Seller{
+		seller, err := c.service.Update(ctx.Request.Context(), &domain.Seller{
 			ID:           intId,
 			Cid:          req.Cid,
 			Company_name: req.Company_name,
@@ -209,9 +210,7 @@ func (c *SellerController) Update() gin.HandlerFunc {
 			LocalityID:   req.LocalityID,
 		})
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{
-				"message": err.Error(),
-			})
+			problem.AbortError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, seller)
@@ -223,27 +222,25 @@ func (c *SellerController) Update() gin.HandlerFunc {
 // @Description Delete existing seller in list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Seller ID"
 // @Success 204 {object} schemas.JSONSuccessResult{data=string}
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
 // @Router /sellers/{id} [delete]
 func (c *SellerController) Delete() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		strId := ctx.Param("id")
 		intId, err := strconv.ParseInt(strId, 10, 64)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"message": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusBadRequest, err)
 			return
 		}
 
-		err = c.service.Delete(ctx, intId)
+		err = c.service.Delete(ctx.Request.Context(), intId)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{
-				"message": err.Error(),
-			})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -7,11 +7,21 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sellers/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sellers/domain/mocks"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/sellers/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/sellers/service"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -25,7 +35,8 @@ func TestGetAll(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		sellerServiceMock.On("GetAll",
 			mock.Anything,
-		).Return(&mockSeller, nil).Once()
+			mock.Anything,
+		).Return(&mockSeller, int64(len(mockSeller)), nil).Once()
 
 		payload, err := json.Marshal(mockSeller)
 		assert.NoError(t, err)
@@ -51,7 +62,8 @@ func TestGetAll(t *testing.T) {
 
 		sellerServiceMock.On("GetAll",
 			mock.Anything,
-		).Return(mockSellerBad, errors.New("Internal server error")).Maybe()
+			mock.Anything,
+		).Return(mockSellerBad, int64(0), errors.New("Internal server error")).Maybe()
 
 		payload, err := json.Marshal(mockSellerBad)
 		assert.NoError(t, err)
@@ -280,6 +292,46 @@ func TestCreate(t *testing.T) {
 
 		sellerServiceMock.AssertExpectations(t)
 	})
+
+	t.Run("unknown locality", func(t *testing.T) {
+		mockSeller := utils.CreateRandomSeller()
+		sellerServiceMock := mocks.NewSellerService(t)
+
+		sellerServiceMock.On("Create",
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, &apperrors.Error{
+			Kind:    apperrors.ErrForeignKey,
+			Entity:  "localities",
+			Field:   "locality_id",
+			Message: "locality_id: no localities with that id",
+		}).Once()
+
+		payload, err := json.Marshal(mockSeller)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/sellers", bytes.NewBuffer(payload))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		sellerController := SellerController{service: sellerServiceMock}
+
+		engine.POST("/api/v1/sellers", sellerController.Create())
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.JSONEq(t, `{
+			"type": "/problems/foreign-key",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "locality_id: no localities with that id",
+			"errors": [{"field": "locality_id", "rule": "exists", "param": "localities", "message": "locality_id: no localities with that id"}]
+		}`, rec.Body.String())
+
+		sellerServiceMock.AssertExpectations(t)
+	})
 }
 
 func TestUpdate(t *testing.T) {
@@ -323,7 +375,7 @@ func TestUpdate(t *testing.T) {
 			mock.Anything,
 			mock.Anything,
 			mock.Anything,
-		).Return(nil, errors.New("id not found error")).Maybe()
+		).Return(nil, domain.ErrIDNotFound).Maybe()
 
 		payload, err := json.Marshal(mockSeller)
 		assert.NoError(t, err)
@@ -430,7 +482,7 @@ func TestDelete(t *testing.T) {
 		sellerServiceMock.On("Delete",
 			mock.Anything,
 			mock.AnythingOfType("int64"),
-		).Return(errors.New("expected conflict error")).Maybe()
+		).Return(domain.ErrIDNotFound).Maybe()
 
 		PATH := fmt.Sprintf("/api/v1/sellers/%v", utils.RandomInt(0, 999))
 		req := httptest.NewRequest(http.MethodDelete, PATH, nil)
@@ -472,3 +524,38 @@ func TestDelete(t *testing.T) {
 		sellerServiceMock.AssertExpectations(t)
 	})
 }
+
+// TestAuditActor goes from the token of the request down to the audit
+// entry the repository writes, which must name the user of the token.
+func TestAuditActor(t *testing.T) {
+	db, dbMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	dbMock.ExpectBegin()
+	audittest.ExpectSnapshot(dbMock, "sellers", 1)
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM sellers WHERE id=?")).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_log")).
+		WithArgs("ana", "sellers", int64(1), audit.ActionDelete, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	signer := auth.NewSigner([]byte("secret"), time.Hour)
+	token, err := signer.Sign(auth.Claims{Subject: "ana", Roles: []string{policy.Admin}})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/sellers/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	_, engine := gin.CreateTestContext(rec)
+
+	sellerController := SellerController{service: service.NewService(mariadb.NewMariaDBRepository(db))}
+
+	engine.DELETE("/api/v1/sellers/:id", auth.Middleware(signer), sellerController.Delete())
+
+	engine.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
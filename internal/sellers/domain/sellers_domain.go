@@ -1,6 +1,10 @@
 package domain
 
-import "context"
+import (
+	"context"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
 
 // Modelo de sellers
 type Seller struct {
@@ -12,8 +16,13 @@ type Seller struct {
 	LocalityID   int64  `json:"locality_id"`
 }
 
+var SellerListFields = listing.Fields{
+	Sort:   []string{"id", "cid", "company_name", "locality_id"},
+	Filter: []string{"locality_id", "cid"},
+}
+
 type SellerRepository interface {
-	GetAll(ctx context.Context) (*[]Seller, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]Seller, int64, error)
 	GetByID(ctx context.Context, id int64) (*Seller, error)
 	Create(ctx context.Context, seller *Seller) (*Seller, error)
 	Update(ctx context.Context, seller *Seller) (*Seller, error)
@@ -21,7 +30,7 @@ type SellerRepository interface {
 }
 
 type SellerService interface {
-	GetAll(ctx context.Context) (*[]Seller, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]Seller, int64, error)
 	GetByID(ctx context.Context, id int64) (*Seller, error)
 	Create(ctx context.Context, seller *Seller) (*Seller, error)
 	Update(ctx context.Context, seller *Seller) (*Seller, error)
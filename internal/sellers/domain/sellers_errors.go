@@ -1,8 +1,8 @@
 package domain
 
-import "errors"
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
 
 var (
-	ErrIDNotFound    = errors.New("seller id not found")
-	ErrDuplicatedCID = errors.New("duplicated cid")
+	ErrIDNotFound    = apperrors.NotFound("seller id not found")
+	ErrDuplicatedCID = apperrors.Conflict("duplicated cid")
 )
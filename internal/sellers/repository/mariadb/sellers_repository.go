@@ -5,6 +5,10 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	auditlog "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sellers/domain"
 )
 
@@ -16,12 +20,19 @@ func NewMariaDBRepository(db *sql.DB) domain.SellerRepository {
 	return &mariadbRepository{db: db}
 }
 
-func (m mariadbRepository) GetAll(ctx context.Context) (*[]domain.Seller, error) {
+func (m mariadbRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Seller, int64, error) {
 	sellers := []domain.Seller{}
 
-	rows, err := m.db.QueryContext(ctx, sqlGetAllSellers)
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAllSellers, params, nil)
+	if err := m.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &sellers, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAllSellers, params, nil)
+	rows, err := m.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return &sellers, err
+		return &sellers, 0, err
 	}
 
 	defer rows.Close()
@@ -38,13 +49,13 @@ func (m mariadbRepository) GetAll(ctx context.Context) (*[]domain.Seller, error)
 			&seller.LocalityID,
 		)
 		if err != nil {
-			return &sellers, err
+			return &sellers, 0, err
 		}
 
 		sellers = append(sellers, seller)
 	}
 
-	return &sellers, nil
+	return &sellers, total, nil
 }
 
 func (m mariadbRepository) GetByID(ctx context.Context, id int64) (*domain.Seller, error) {
@@ -83,9 +94,16 @@ func (m mariadbRepository) Create(ctx context.Context, seller *domain.Seller) (*
 		LocalityID:   seller.LocalityID,
 	}
 
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &newSeller, err
+	}
+
+	defer tx.Rollback()
+
 	query := sqlInsertSeller
 
-	result, err := m.db.ExecContext(
+	result, err := tx.ExecContext(
 		ctx,
 		query,
 		&newSeller.Cid,
@@ -95,7 +113,7 @@ func (m mariadbRepository) Create(ctx context.Context, seller *domain.Seller) (*
 		&newSeller.LocalityID,
 	)
 	if err != nil {
-		return &newSeller, err
+		return &newSeller, apperrors.FromMySQL(err)
 	}
 
 	lastID, err := result.LastInsertId()
@@ -105,7 +123,11 @@ func (m mariadbRepository) Create(ctx context.Context, seller *domain.Seller) (*
 
 	newSeller.ID = lastID
 
-	return &newSeller, nil
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "sellers", lastID, nil); err != nil {
+		return &newSeller, err
+	}
+
+	return &newSeller, tx.Commit()
 }
 
 func (m mariadbRepository) Update(ctx context.Context, seller *domain.Seller) (*domain.Seller, error) {
@@ -118,9 +140,21 @@ func (m mariadbRepository) Update(ctx context.Context, seller *domain.Seller) (*
 		LocalityID:   seller.LocalityID,
 	}
 
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &newSeller, err
+	}
+
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "sellers", newSeller.ID)
+	if err != nil {
+		return &newSeller, err
+	}
+
 	query := sqlUpdateSeller
 
-	result, err := m.db.ExecContext(
+	result, err := tx.ExecContext(
 		ctx,
 		query,
 		&newSeller.Cid,
@@ -131,7 +165,7 @@ func (m mariadbRepository) Update(ctx context.Context, seller *domain.Seller) (*
 		&newSeller.ID,
 	)
 	if err != nil {
-		return &newSeller, err
+		return &newSeller, apperrors.FromMySQL(err)
 	}
 
 	affectedRows, err := result.RowsAffected()
@@ -145,15 +179,31 @@ func (m mariadbRepository) Update(ctx context.Context, seller *domain.Seller) (*
 		return &newSeller, err
 	}
 
-	return &newSeller, nil
+	if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "sellers", newSeller.ID, before); err != nil {
+		return &newSeller, err
+	}
+
+	return &newSeller, tx.Commit()
 }
 
 func (m mariadbRepository) Delete(ctx context.Context, id int64) error {
-	result, err := m.db.ExecContext(ctx, sqlDeleteSeller, id)
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "sellers", id)
 	if err != nil {
 		return err
 	}
 
+	result, err := tx.ExecContext(ctx, sqlDeleteSeller, id)
+	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
 	affectedRows, err := result.RowsAffected()
 
 	// ID not found
@@ -165,5 +215,10 @@ func (m mariadbRepository) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if err := auditlog.Record(ctx, tx, audit.ActionDelete, "sellers", id, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
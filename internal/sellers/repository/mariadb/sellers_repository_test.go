@@ -7,17 +7,21 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sellers/domain"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	queryInsertSeller  = regexp.QuoteMeta(sqlInsertSeller)
-	queryGetAllSellers = regexp.QuoteMeta(sqlGetAllSellers)
-	queryGetSellerById = regexp.QuoteMeta(sqlGetSellerById)
-	queryUpdateSeller  = regexp.QuoteMeta(sqlUpdateSeller)
-	queryDeleteSeller  = regexp.QuoteMeta(sqlDeleteSeller)
+	queryInsertSeller    = regexp.QuoteMeta(sqlInsertSeller)
+	queryGetAllSellers   = regexp.QuoteMeta(sqlGetAllSellers)
+	queryCountAllSellers = regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAllSellers)
+	queryGetSellerById   = regexp.QuoteMeta(sqlGetSellerById)
+	queryUpdateSeller    = regexp.QuoteMeta(sqlUpdateSeller)
+	queryDeleteSeller    = regexp.QuoteMeta(sqlDeleteSeller)
 )
 
 var rowsStruct = []string{
@@ -49,12 +53,14 @@ func TestGetAll(t *testing.T) {
 			)
 		}
 
+		mock.ExpectQuery(queryCountAllSellers).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(mockSellers)))
 		mock.ExpectQuery(queryGetAllSellers).WillReturnRows(rows)
 
 		sellersRepo := NewMariaDBRepository(db)
 
-		result, err := sellersRepo.GetAll(context.Background())
+		result, total, err := sellersRepo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 		assert.NoError(t, err)
+		assert.Equal(t, int64(len(mockSellers)), total)
 
 		assert.Equal(t, result, &mockSellers)
 	})
@@ -66,11 +72,12 @@ func TestGetAll(t *testing.T) {
 
 		rows := sqlmock.NewRows(rowsStruct).AddRow("", "", "", "", "", "")
 
+		mock.ExpectQuery(queryCountAllSellers).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(queryGetAllSellers).WillReturnRows(rows)
 
 		sellersRepo := NewMariaDBRepository(db)
 
-		_, err = sellersRepo.GetAll(context.Background())
+		_, _, err = sellersRepo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 		assert.Error(t, err)
 	})
 
@@ -79,11 +86,12 @@ func TestGetAll(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectQuery(queryCountAllSellers).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(queryGetAllSellers).WillReturnError(sql.ErrNoRows)
 
 		sellersRepo := NewMariaDBRepository(db)
 
-		_, err = sellersRepo.GetAll(context.Background())
+		_, _, err = sellersRepo.GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
 		assert.Error(t, err)
 	})
 }
@@ -153,6 +161,7 @@ func TestCreate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsertSeller).
 			WithArgs(
 				mockSeller.Cid,
@@ -161,6 +170,8 @@ func TestCreate(t *testing.T) {
 				mockSeller.Telephone,
 				mockSeller.LocalityID,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "sellers", 1)
+		mock.ExpectCommit()
 
 		repo := NewMariaDBRepository(db)
 		seller, err := repo.Create(context.Background(), &mockSeller)
@@ -174,6 +185,7 @@ func TestCreate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(queryInsertSeller).
 			WithArgs(0, 0, 0, 0, 0, 0).
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -193,6 +205,8 @@ func TestUpdate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "sellers", mockSeller.ID)
 		mock.ExpectExec(queryUpdateSeller).
 			WithArgs(
 				mockSeller.Cid,
@@ -202,6 +216,8 @@ func TestUpdate(t *testing.T) {
 				mockSeller.LocalityID,
 				mockSeller.ID,
 			).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "sellers", mockSeller.ID)
+		mock.ExpectCommit()
 
 		sellersRepo := NewMariaDBRepository(db)
 
@@ -216,6 +232,8 @@ func TestUpdate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "sellers", mockSeller.ID)
 		mock.ExpectExec(queryUpdateSeller).
 			WithArgs(0, 0, 0, 0, 0, 0).
 			WillReturnResult(sqlmock.NewResult(0, 1))
@@ -230,6 +248,8 @@ func TestUpdate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "sellers", mockSeller.ID)
 		mock.ExpectExec(queryUpdateSeller).
 			WithArgs(
 				mockSeller.Cid,
@@ -256,10 +276,14 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "sellers", mockSeller.ID)
 		mock.ExpectExec(queryDeleteSeller).
 			WithArgs(
 				mockSeller.ID,
 			).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectEntry(mock, audit.ActionDelete, "sellers", mockSeller.ID)
+		mock.ExpectCommit()
 
 		sellersRepo := NewMariaDBRepository(db)
 
@@ -272,6 +296,8 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "sellers", mockSeller.ID)
 		mock.ExpectExec(queryDeleteSeller).
 			WithArgs(0).
 			WillReturnResult(sqlmock.NewResult(0, 1))
@@ -286,6 +312,8 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "sellers", mockSeller.ID)
 		mock.ExpectExec(queryDeleteSeller).
 			WithArgs(mockSeller.ID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sellers/domain"
 )
 
@@ -16,12 +17,12 @@ func NewService(r domain.SellerRepository) domain.SellerService {
 	}
 }
 
-func (s sellerService) GetAll(ctx context.Context) (*[]domain.Seller, error) {
-	sellers, err := s.repository.GetAll(ctx)
+func (s sellerService) GetAll(ctx context.Context, params listing.Params) (*[]domain.Seller, int64, error) {
+	sellers, total, err := s.repository.GetAll(ctx, params)
 	if err != nil {
-		return sellers, err
+		return sellers, 0, err
 	}
-	return sellers, nil
+	return sellers, total, nil
 }
 
 func (s sellerService) GetByID(ctx context.Context, id int64) (*domain.Seller, error) {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/sellers/domain/mocks"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
@@ -17,11 +18,11 @@ func TestGetAll(t *testing.T) {
 	sellerRepositoryMock := mocks.NewSellerRepository(t)
 
 	t.Run("ok", func(t *testing.T) {
-		sellerRepositoryMock.On("GetAll", mock.Anything).
-			Return(&mockSeller, nil).Once()
+		sellerRepositoryMock.On("GetAll", mock.Anything, mock.Anything).
+			Return(&mockSeller, int64(len(mockSeller)), nil).Once()
 
 		service := NewService(sellerRepositoryMock)
-		list, err := service.GetAll(context.Background())
+		list, _, err := service.GetAll(context.Background(), listing.Params{})
 
 		assert.NoError(t, err)
 
@@ -31,12 +32,12 @@ func TestGetAll(t *testing.T) {
 	})
 
 	t.Run("fail", func(t *testing.T) {
-		sellerRepositoryMock.On("GetAll", mock.Anything).
-			Return(nil, errors.New("failed to retrieve sellers")).
+		sellerRepositoryMock.On("GetAll", mock.Anything, mock.Anything).
+			Return(nil, int64(0), errors.New("failed to retrieve sellers")).
 			Once()
 
 		service := NewService(sellerRepositoryMock)
-		_, err := service.GetAll(context.Background())
+		_, _, err := service.GetAll(context.Background(), listing.Params{})
 
 		assert.NotNil(t, err)
 
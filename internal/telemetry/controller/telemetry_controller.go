@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/domain"
+)
+
+type TelemetryController struct {
+	service domain.TelemetryService
+}
+
+func NewTelemetryController(service domain.TelemetryService) (*TelemetryController, error) {
+	if service == nil {
+		return nil, errors.New("invalid service")
+	}
+
+	return &TelemetryController{
+		service: service,
+	}, nil
+}
+
+// @Summary Ingest temperature readings
+// @Tags Sections
+// @Description Store a batch of sensor readings, updating the hourly rollups and the current temperature of each section
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param readings body domain.IngestRequest true "Readings to store"
+// @Success 201 {object} schemas.JSONSuccessResult{data=domain.IngestResult}
+// @Failure 404 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /sections/temperatures [post]
+func (c TelemetryController) Ingest() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.IngestRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		readings := make([]domain.Reading, len(req.Readings))
+		for i, reading := range req.Readings {
+			readings[i] = domain.Reading{
+				SectionId:   reading.SectionId,
+				RecordedAt:  reading.RecordedAt,
+				Temperature: *reading.Temperature,
+			}
+		}
+
+		result, err := c.service.Ingest(ctx.Request.Context(), readings)
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrSectionNotFound):
+				problem.Abort(ctx, http.StatusNotFound, err)
+			case errors.Is(err, domain.ErrFutureReading):
+				problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			default:
+				problem.Abort(ctx, http.StatusInternalServerError, err)
+			}
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, gin.H{"data": result})
+	}
+}
+
+// @Summary Section temperature report
+// @Tags Sections
+// @Description Minimum, maximum and average temperature of a section over a time window, hour by hour
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Section ID"
+// @Param from query string true "Window start, RFC 3339, rounded down to the hour"
+// @Param to query string true "Window end, RFC 3339"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.TemperatureReport}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /sections/{id}/temperatures [get]
+func (c TelemetryController) GetTemperatureReport() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var reqId domain.RequestSectionId
+		if err := ctx.ShouldBindUri(&reqId); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		var req domain.RequestTemperatureReport
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		report, err := c.service.GetTemperatureReport(ctx.Request.Context(), reqId.ID, req.From, req.To)
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrSectionNotFound):
+				problem.Abort(ctx, http.StatusNotFound, err)
+			case errors.Is(err, domain.ErrInvalidWindow):
+				problem.Abort(ctx, http.StatusBadRequest, err)
+			default:
+				problem.Abort(ctx, http.StatusInternalServerError, err)
+			}
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": report})
+	}
+}
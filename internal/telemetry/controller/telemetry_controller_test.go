@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIngest(t *testing.T) {
+	recordedAt := time.Date(2022, 6, 1, 10, 30, 0, 0, time.UTC)
+	body := `{"readings": [{"section_id": 1, "recorded_at": "2022-06-01T10:30:00Z", "temperature": 0}]}`
+
+	serve := func(serviceMock *mocks.TelemetryService, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/sections/temperatures", bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		telemetryController := TelemetryController{service: serviceMock}
+
+		engine.POST("/api/v1/sections/temperatures", telemetryController.Ingest())
+
+		engine.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	t.Run("created", func(t *testing.T) {
+		serviceMock := mocks.NewTelemetryService(t)
+		serviceMock.On("Ingest", mock.Anything, []domain.Reading{{SectionId: 1, RecordedAt: recordedAt, Temperature: 0}}).
+			Return(&domain.IngestResult{Stored: 1}, nil).Once()
+
+		rec := serve(serviceMock, body)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("unprocessable entity", func(t *testing.T) {
+		serviceMock := mocks.NewTelemetryService(t)
+
+		rec := serve(serviceMock, `{"readings": [{"section_id": 1, "recorded_at": "2022-06-01T10:30:00Z"}]}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		serviceMock := mocks.NewTelemetryService(t)
+
+		rec := serve(serviceMock, `{"readings": []}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("future reading", func(t *testing.T) {
+		serviceMock := mocks.NewTelemetryService(t)
+		serviceMock.On("Ingest", mock.Anything, mock.Anything).Return(nil, domain.ErrFutureReading).Once()
+
+		rec := serve(serviceMock, body)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("section not found", func(t *testing.T) {
+		serviceMock := mocks.NewTelemetryService(t)
+		serviceMock.On("Ingest", mock.Anything, mock.Anything).Return(nil, domain.ErrSectionNotFound).Once()
+
+		rec := serve(serviceMock, body)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetTemperatureReport(t *testing.T) {
+	from := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	query := "?from=2022-06-01T10:00:00Z&to=2022-06-01T12:00:00Z"
+
+	serve := func(serviceMock *mocks.TelemetryService, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+
+		_, engine := gin.CreateTestContext(rec)
+
+		telemetryController := TelemetryController{service: serviceMock}
+
+		engine.GET("/api/v1/sections/:id/temperatures", telemetryController.GetTemperatureReport())
+
+		engine.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewTelemetryService(t)
+		serviceMock.On("GetTemperatureReport", mock.Anything, int64(1), from, to).
+			Return(&domain.TemperatureReport{SectionId: 1, From: from, To: to}, nil).Once()
+
+		rec := serve(serviceMock, "/api/v1/sections/1/temperatures"+query)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		serviceMock := mocks.NewTelemetryService(t)
+
+		rec := serve(serviceMock, "/api/v1/sections/1/temperatures?from=yesterday")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		serviceMock := mocks.NewTelemetryService(t)
+		serviceMock.On("GetTemperatureReport", mock.Anything, int64(1), from, to).
+			Return(nil, domain.ErrInvalidWindow).Once()
+
+		rec := serve(serviceMock, "/api/v1/sections/1/temperatures"+query)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		serviceMock := mocks.NewTelemetryService(t)
+		serviceMock.On("GetTemperatureReport", mock.Anything, int64(1), from, to).
+			Return(nil, domain.ErrSectionNotFound).Once()
+
+		rec := serve(serviceMock, "/api/v1/sections/1/temperatures"+query)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,34 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SectionObserver is an autogenerated mock type for the SectionObserver type
+type SectionObserver struct {
+	mock.Mock
+}
+
+// SectionsUpdated provides a mock function with given fields: ctx, sectionIds
+func (_m *SectionObserver) SectionsUpdated(ctx context.Context, sectionIds []int64) {
+	_m.Called(ctx, sectionIds)
+}
+
+type mockConstructorTestingTNewSectionObserver interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSectionObserver creates a new instance of SectionObserver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSectionObserver(t mockConstructorTestingTNewSectionObserver) *SectionObserver {
+	mock := &SectionObserver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,99 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TelemetryRepository is an autogenerated mock type for the TelemetryRepository type
+type TelemetryRepository struct {
+	mock.Mock
+}
+
+// GetHourlyRollups provides a mock function with given fields: ctx, sectionId, from, to
+func (_m *TelemetryRepository) GetHourlyRollups(ctx context.Context, sectionId int64, from time.Time, to time.Time) (*[]domain.HourlyRollup, error) {
+	ret := _m.Called(ctx, sectionId, from, to)
+
+	var r0 *[]domain.HourlyRollup
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) *[]domain.HourlyRollup); ok {
+		r0 = rf(ctx, sectionId, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.HourlyRollup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, sectionId, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveReadings provides a mock function with given fields: ctx, readings
+func (_m *TelemetryRepository) SaveReadings(ctx context.Context, readings []domain.Reading) (*domain.IngestResult, error) {
+	ret := _m.Called(ctx, readings)
+
+	var r0 *domain.IngestResult
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Reading) *domain.IngestResult); ok {
+		r0 = rf(ctx, readings)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IngestResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.Reading) error); ok {
+		r1 = rf(ctx, readings)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SectionExists provides a mock function with given fields: ctx, sectionId
+func (_m *TelemetryRepository) SectionExists(ctx context.Context, sectionId int64) (bool, error) {
+	ret := _m.Called(ctx, sectionId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, sectionId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, sectionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTelemetryRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTelemetryRepository creates a new instance of TelemetryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTelemetryRepository(t mockConstructorTestingTNewTelemetryRepository) *TelemetryRepository {
+	mock := &TelemetryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
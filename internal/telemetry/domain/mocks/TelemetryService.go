@@ -0,0 +1,78 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TelemetryService is an autogenerated mock type for the TelemetryService type
+type TelemetryService struct {
+	mock.Mock
+}
+
+// GetTemperatureReport provides a mock function with given fields: ctx, sectionId, from, to
+func (_m *TelemetryService) GetTemperatureReport(ctx context.Context, sectionId int64, from time.Time, to time.Time) (*domain.TemperatureReport, error) {
+	ret := _m.Called(ctx, sectionId, from, to)
+
+	var r0 *domain.TemperatureReport
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) *domain.TemperatureReport); ok {
+		r0 = rf(ctx, sectionId, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TemperatureReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, sectionId, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ingest provides a mock function with given fields: ctx, readings
+func (_m *TelemetryService) Ingest(ctx context.Context, readings []domain.Reading) (*domain.IngestResult, error) {
+	ret := _m.Called(ctx, readings)
+
+	var r0 *domain.IngestResult
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Reading) *domain.IngestResult); ok {
+		r0 = rf(ctx, readings)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IngestResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.Reading) error); ok {
+		r1 = rf(ctx, readings)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTelemetryService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTelemetryService creates a new instance of TelemetryService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTelemetryService(t mockConstructorTestingTNewTelemetryService) *TelemetryService {
+	mock := &TelemetryService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
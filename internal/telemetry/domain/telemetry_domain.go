@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Reading is one temperature measured by a sensor in a section.
+type Reading struct {
+	SectionId   int64     `json:"section_id"`
+	RecordedAt  time.Time `json:"recorded_at"`
+	Temperature float64   `json:"temperature"`
+}
+
+// HourlyRollup summarises the readings of a section within one hour.
+type HourlyRollup struct {
+	Hour           time.Time `json:"hour"`
+	MinTemperature float64   `json:"min_temperature"`
+	MaxTemperature float64   `json:"max_temperature"`
+	AvgTemperature float64   `json:"avg_temperature"`
+	ReadingsCount  int64     `json:"readings_count"`
+}
+
+// TemperatureReport summarises the readings of a section between From and
+// To, hour by hour.
+type TemperatureReport struct {
+	SectionId      int64          `json:"section_id"`
+	From           time.Time      `json:"from"`
+	To             time.Time      `json:"to"`
+	MinTemperature float64        `json:"min_temperature"`
+	MaxTemperature float64        `json:"max_temperature"`
+	AvgTemperature float64        `json:"avg_temperature"`
+	ReadingsCount  int64          `json:"readings_count"`
+	Hours          []HourlyRollup `json:"hours"`
+}
+
+// IngestResult tells how many readings were stored and how many were
+// already known and skipped.
+type IngestResult struct {
+	Stored     int64 `json:"stored"`
+	Duplicated int64 `json:"duplicated"`
+}
+
+type ReadingRequest struct {
+	SectionId   int64     `json:"section_id" binding:"required,min=1"`
+	RecordedAt  time.Time `json:"recorded_at" binding:"required"`
+	Temperature *float64  `json:"temperature" binding:"required"`
+}
+
+type IngestRequest struct {
+	Readings []ReadingRequest `json:"readings" binding:"required,min=1,max=1000,dive"`
+}
+
+type RequestSectionId struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type RequestTemperatureReport struct {
+	From time.Time `form:"from" binding:"required"`
+	To   time.Time `form:"to" binding:"required"`
+}
+
+type TelemetryRepository interface {
+	// SaveReadings stores the readings, updating the hourly rollups and the
+	// current temperature of their sections. Readings already stored for the
+	// same section and time are skipped.
+	SaveReadings(ctx context.Context, readings []Reading) (*IngestResult, error)
+	GetHourlyRollups(ctx context.Context, sectionId int64, from, to time.Time) (*[]HourlyRollup, error)
+	SectionExists(ctx context.Context, sectionId int64) (bool, error)
+}
+
+// SectionObserver is told which sections got new readings once they are
+// stored.
+type SectionObserver interface {
+	SectionsUpdated(ctx context.Context, sectionIds []int64)
+}
+
+type TelemetryService interface {
+	Ingest(ctx context.Context, readings []Reading) (*IngestResult, error)
+	GetTemperatureReport(ctx context.Context, sectionId int64, from, to time.Time) (*TemperatureReport, error)
+}
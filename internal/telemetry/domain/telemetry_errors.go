@@ -0,0 +1,9 @@
+package domain
+
+import "errors"
+
+var (
+	ErrSectionNotFound = errors.New("section not found")
+	ErrFutureReading   = errors.New("reading recorded in the future")
+	ErrInvalidWindow   = errors.New("from must be before to")
+)
@@ -0,0 +1,21 @@
+package mariadb
+
+const (
+	sqlSectionExists = "SELECT EXISTS(SELECT 1 FROM sections WHERE id = ?);"
+
+	sqlInsertReading = "INSERT IGNORE INTO section_temperature_readings (section_id, recorded_at, temperature) VALUES (?, ?, ?);"
+	sqlUpsertRollup  = `INSERT INTO section_temperature_rollups (section_id, hour, min_temperature, max_temperature, sum_temperature, readings_count)
+		VALUES (?, ?, ?, ?, ?, 1)
+		ON DUPLICATE KEY UPDATE
+			min_temperature = LEAST(min_temperature, VALUES(min_temperature)),
+			max_temperature = GREATEST(max_temperature, VALUES(max_temperature)),
+			sum_temperature = sum_temperature + VALUES(sum_temperature),
+			readings_count = readings_count + 1;`
+	sqlUpdateCurrentTemperature = `UPDATE sections SET current_temperature = ?
+		WHERE id = ? AND NOT EXISTS (SELECT 1 FROM section_temperature_readings WHERE section_id = ? AND recorded_at > ?);`
+
+	sqlGetHourlyRollups = `SELECT hour, min_temperature, max_temperature, sum_temperature / readings_count, readings_count
+		FROM section_temperature_rollups
+		WHERE section_id = ? AND hour >= ? AND hour < ?
+		ORDER BY hour;`
+)
@@ -0,0 +1,177 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	auditlog "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/domain"
+)
+
+type mariadbRepository struct {
+	db *sql.DB
+}
+
+func NewMariaDBRepository(db *sql.DB) domain.TelemetryRepository {
+	return mariadbRepository{db: db}
+}
+
+// SaveReadings stores the readings in a single transaction. The section of
+// each reading is locked before its first reading is written, so callers
+// should sort the readings by section to keep the lock order stable. The
+// sections whose current temperature changed are audited once each.
+func (m mariadbRepository) SaveReadings(ctx context.Context, readings []domain.Reading) (*domain.IngestResult, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	result := &domain.IngestResult{}
+	locked := map[int64]audit.Row{}
+	sections := []int64{}
+	changed := map[int64]bool{}
+
+	for _, reading := range readings {
+		before, ok := locked[reading.SectionId]
+		if !ok {
+			if before, err = lockSection(ctx, tx, reading.SectionId); err != nil {
+				return nil, err
+			}
+			locked[reading.SectionId] = before
+			sections = append(sections, reading.SectionId)
+		}
+
+		inserted, err := tx.ExecContext(ctx, sqlInsertReading, reading.SectionId, reading.RecordedAt, reading.Temperature)
+		if err != nil {
+			return nil, err
+		}
+
+		affected, err := inserted.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+
+		if affected == 0 {
+			result.Duplicated++
+			continue
+		}
+
+		if _, err := tx.ExecContext(
+			ctx,
+			sqlUpsertRollup,
+			reading.SectionId,
+			reading.RecordedAt.Truncate(time.Hour),
+			reading.Temperature,
+			reading.Temperature,
+			reading.Temperature,
+		); err != nil {
+			return nil, err
+		}
+
+		updated, err := tx.ExecContext(
+			ctx,
+			sqlUpdateCurrentTemperature,
+			reading.Temperature,
+			reading.SectionId,
+			reading.SectionId,
+			reading.RecordedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		affected, err = updated.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+
+		if affected > 0 {
+			changed[reading.SectionId] = true
+		}
+
+		result.Stored++
+	}
+
+	for _, sectionId := range sections {
+		if !changed[sectionId] {
+			continue
+		}
+
+		if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "sections", sectionId, locked[sectionId]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m mariadbRepository) GetHourlyRollups(
+	ctx context.Context,
+	sectionId int64,
+	from,
+	to time.Time,
+) (*[]domain.HourlyRollup, error) {
+	rollups := []domain.HourlyRollup{}
+
+	rows, err := m.db.QueryContext(ctx, sqlGetHourlyRollups, sectionId, from, to)
+	if err != nil {
+		return &rollups, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var rollup domain.HourlyRollup
+
+		if err := rows.Scan(
+			&rollup.Hour,
+			&rollup.MinTemperature,
+			&rollup.MaxTemperature,
+			&rollup.AvgTemperature,
+			&rollup.ReadingsCount,
+		); err != nil {
+			return &rollups, err
+		}
+
+		rollups = append(rollups, rollup)
+	}
+
+	if err := rows.Err(); err != nil {
+		return &rollups, err
+	}
+
+	return &rollups, nil
+}
+
+func (m mariadbRepository) SectionExists(ctx context.Context, sectionId int64) (bool, error) {
+	var exists bool
+	if err := m.db.QueryRowContext(ctx, sqlSectionExists, sectionId).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// lockSection locks the section until tx ends and returns its snapshot, to
+// audit the changes of its current temperature.
+func lockSection(ctx context.Context, tx *sql.Tx, sectionId int64) (audit.Row, error) {
+	before, err := auditlog.Snapshot(ctx, tx, "sections", sectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if before == nil {
+		return nil, fmt.Errorf("%w: %d", domain.ErrSectionNotFound, sectionId)
+	}
+
+	return before, nil
+}
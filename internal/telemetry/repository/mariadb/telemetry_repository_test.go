@@ -0,0 +1,177 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	querySectionExists            = regexp.QuoteMeta(sqlSectionExists)
+	queryInsertReading            = regexp.QuoteMeta(sqlInsertReading)
+	queryUpsertRollup             = regexp.QuoteMeta(sqlUpsertRollup)
+	queryUpdateCurrentTemperature = regexp.QuoteMeta(sqlUpdateCurrentTemperature)
+	queryGetHourlyRollups         = regexp.QuoteMeta(sqlGetHourlyRollups)
+)
+
+var rowsHourlyRollupStruct = []string{
+	"hour",
+	"min_temperature",
+	"max_temperature",
+	"avg_temperature",
+	"readings_count",
+}
+
+func TestSaveReadings(t *testing.T) {
+	recordedAt := time.Date(2022, 6, 1, 10, 30, 0, 0, time.UTC)
+	hour := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	readings := []domain.Reading{
+		{SectionId: 1, RecordedAt: recordedAt, Temperature: 3},
+		{SectionId: 1, RecordedAt: recordedAt.Add(time.Minute), Temperature: 4},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "sections", 1)
+		mock.ExpectExec(queryInsertReading).WithArgs(1, recordedAt, 3.0).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(queryUpsertRollup).WithArgs(1, hour, 3.0, 3.0, 3.0).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryUpdateCurrentTemperature).WithArgs(3.0, 1, 1, recordedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryInsertReading).WithArgs(1, recordedAt.Add(time.Minute), 4.0).WillReturnResult(sqlmock.NewResult(0, 0))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "sections", 1)
+		mock.ExpectCommit()
+
+		repository := NewMariaDBRepository(db)
+
+		result, err := repository.SaveReadings(context.Background(), readings)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.IngestResult{Stored: 1, Duplicated: 1}, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("late reading leaves the section unaudited", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "sections", 1)
+		mock.ExpectExec(queryInsertReading).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(queryUpsertRollup).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryUpdateCurrentTemperature).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		repository := NewMariaDBRepository(db)
+
+		result, err := repository.SaveReadings(context.Background(), readings[:1])
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), result.Stored)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("section not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "sections", 1)
+		mock.ExpectRollback()
+
+		repository := NewMariaDBRepository(db)
+
+		_, err = repository.SaveReadings(context.Background(), readings)
+
+		assert.ErrorIs(t, err, domain.ErrSectionNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "sections", 1)
+		mock.ExpectExec(queryInsertReading).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(queryUpsertRollup).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		repository := NewMariaDBRepository(db)
+
+		_, err = repository.SaveReadings(context.Background(), readings)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetHourlyRollups(t *testing.T) {
+	from := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+
+	t.Run("ok", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(rowsHourlyRollupStruct).
+			AddRow(from, 2.5, 4.0, 3.25, 4).
+			AddRow(from.Add(time.Hour), 3.0, 3.0, 3.0, 1)
+
+		mock.ExpectQuery(queryGetHourlyRollups).WithArgs(1, from, to).WillReturnRows(rows)
+
+		repository := NewMariaDBRepository(db)
+
+		rollups, err := repository.GetHourlyRollups(context.Background(), 1, from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &[]domain.HourlyRollup{
+			{Hour: from, MinTemperature: 2.5, MaxTemperature: 4, AvgTemperature: 3.25, ReadingsCount: 4},
+			{Hour: from.Add(time.Hour), MinTemperature: 3, MaxTemperature: 3, AvgTemperature: 3, ReadingsCount: 1},
+		}, rollups)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetHourlyRollups).WillReturnError(sql.ErrConnDone)
+
+		repository := NewMariaDBRepository(db)
+
+		_, err = repository.GetHourlyRollups(context.Background(), 1, from, to)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSectionExists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(querySectionExists).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	repository := NewMariaDBRepository(db)
+
+	exists, err := repository.SectionExists(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
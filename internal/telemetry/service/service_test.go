@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIngest(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	local := time.FixedZone("BRT", -3*60*60)
+
+	t.Run("ok", func(t *testing.T) {
+		readings := []domain.Reading{
+			{SectionId: 2, RecordedAt: now.Add(-time.Minute), Temperature: 3},
+			{SectionId: 1, RecordedAt: now.Add(-time.Minute).In(local), Temperature: 4},
+			{SectionId: 1, RecordedAt: now.Add(-time.Hour), Temperature: 5},
+		}
+		sorted := []domain.Reading{
+			{SectionId: 1, RecordedAt: now.Add(-time.Hour), Temperature: 5},
+			{SectionId: 1, RecordedAt: now.Add(-time.Minute), Temperature: 4},
+			{SectionId: 2, RecordedAt: now.Add(-time.Minute), Temperature: 3},
+		}
+
+		repositoryMock := mocks.NewTelemetryRepository(t)
+		repositoryMock.On("SaveReadings", mock.Anything, sorted).
+			Return(&domain.IngestResult{Stored: 3}, nil).Once()
+
+		observerMock := mocks.NewSectionObserver(t)
+		observerMock.On("SectionsUpdated", mock.Anything, []int64{1, 2}).Once()
+
+		s := telemetryService{
+			repository: repositoryMock,
+			observers:  []domain.SectionObserver{observerMock},
+			now:        func() time.Time { return now },
+		}
+
+		result, err := s.Ingest(context.Background(), readings)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), result.Stored)
+	})
+
+	t.Run("only duplicated readings", func(t *testing.T) {
+		repositoryMock := mocks.NewTelemetryRepository(t)
+		repositoryMock.On("SaveReadings", mock.Anything, mock.Anything).
+			Return(&domain.IngestResult{Duplicated: 1}, nil).Once()
+
+		observerMock := mocks.NewSectionObserver(t)
+
+		s := telemetryService{
+			repository: repositoryMock,
+			observers:  []domain.SectionObserver{observerMock},
+			now:        func() time.Time { return now },
+		}
+
+		result, err := s.Ingest(context.Background(), []domain.Reading{{SectionId: 1, RecordedAt: now, Temperature: 4}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), result.Duplicated)
+	})
+
+	t.Run("reading in the future", func(t *testing.T) {
+		repositoryMock := mocks.NewTelemetryRepository(t)
+
+		s := telemetryService{repository: repositoryMock, now: func() time.Time { return now }}
+
+		_, err := s.Ingest(context.Background(), []domain.Reading{
+			{SectionId: 1, RecordedAt: now.Add(time.Hour), Temperature: 4},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrFutureReading)
+	})
+}
+
+func TestGetTemperatureReport(t *testing.T) {
+	from := time.Date(2022, 6, 1, 10, 30, 0, 0, time.UTC)
+	to := time.Date(2022, 6, 1, 13, 0, 0, 0, time.UTC)
+	hour := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("ok", func(t *testing.T) {
+		rollups := []domain.HourlyRollup{
+			{Hour: hour, MinTemperature: 2, MaxTemperature: 4, AvgTemperature: 3, ReadingsCount: 2},
+			{Hour: hour.Add(time.Hour), MinTemperature: 1, MaxTemperature: 7, AvgTemperature: 6, ReadingsCount: 4},
+		}
+
+		repositoryMock := mocks.NewTelemetryRepository(t)
+		repositoryMock.On("SectionExists", mock.Anything, int64(1)).Return(true, nil).Once()
+		repositoryMock.On("GetHourlyRollups", mock.Anything, int64(1), hour, to).Return(&rollups, nil).Once()
+
+		s := NewTelemetryService(repositoryMock)
+
+		report, err := s.GetTemperatureReport(context.Background(), 1, from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, hour, report.From)
+		assert.Equal(t, float64(1), report.MinTemperature)
+		assert.Equal(t, float64(7), report.MaxTemperature)
+		assert.Equal(t, float64(5), report.AvgTemperature)
+		assert.Equal(t, int64(6), report.ReadingsCount)
+		assert.Len(t, report.Hours, 2)
+	})
+
+	t.Run("no readings", func(t *testing.T) {
+		repositoryMock := mocks.NewTelemetryRepository(t)
+		repositoryMock.On("SectionExists", mock.Anything, int64(1)).Return(true, nil).Once()
+		repositoryMock.On("GetHourlyRollups", mock.Anything, int64(1), hour, to).
+			Return(&[]domain.HourlyRollup{}, nil).Once()
+
+		s := NewTelemetryService(repositoryMock)
+
+		report, err := s.GetTemperatureReport(context.Background(), 1, from, to)
+
+		assert.NoError(t, err)
+		assert.Zero(t, report.ReadingsCount)
+		assert.Empty(t, report.Hours)
+	})
+
+	t.Run("section not found", func(t *testing.T) {
+		repositoryMock := mocks.NewTelemetryRepository(t)
+		repositoryMock.On("SectionExists", mock.Anything, int64(1)).Return(false, nil).Once()
+
+		s := NewTelemetryService(repositoryMock)
+
+		_, err := s.GetTemperatureReport(context.Background(), 1, from, to)
+
+		assert.ErrorIs(t, err, domain.ErrSectionNotFound)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		repositoryMock := mocks.NewTelemetryRepository(t)
+
+		s := NewTelemetryService(repositoryMock)
+
+		_, err := s.GetTemperatureReport(context.Background(), 1, to, from)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidWindow)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repositoryMock := mocks.NewTelemetryRepository(t)
+		repositoryMock.On("SectionExists", mock.Anything, int64(1)).Return(false, errors.New("connection refused")).Once()
+
+		s := NewTelemetryService(repositoryMock)
+
+		_, err := s.GetTemperatureReport(context.Background(), 1, from, to)
+
+		assert.Error(t, err)
+	})
+}
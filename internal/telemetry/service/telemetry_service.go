@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/telemetry/domain"
+)
+
+// clockSkew is how far in the future a sensor clock may be before its
+// readings are rejected.
+const clockSkew = 5 * time.Minute
+
+type telemetryService struct {
+	repository domain.TelemetryRepository
+	observers  []domain.SectionObserver
+	now        func() time.Time
+}
+
+func NewTelemetryService(r domain.TelemetryRepository, observers ...domain.SectionObserver) domain.TelemetryService {
+	return &telemetryService{repository: r, observers: observers, now: time.Now}
+}
+
+// Ingest stores a batch of readings in UTC, ordered by section and time,
+// and then tells the observers which sections got new readings.
+func (s telemetryService) Ingest(ctx context.Context, readings []domain.Reading) (*domain.IngestResult, error) {
+	limit := s.now().Add(clockSkew)
+
+	sorted := make([]domain.Reading, len(readings))
+	for i, reading := range readings {
+		if reading.RecordedAt.After(limit) {
+			return nil, fmt.Errorf("%w: section %d at %s", domain.ErrFutureReading, reading.SectionId, reading.RecordedAt.Format(time.RFC3339))
+		}
+
+		reading.RecordedAt = reading.RecordedAt.UTC()
+		sorted[i] = reading
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].SectionId != sorted[j].SectionId {
+			return sorted[i].SectionId < sorted[j].SectionId
+		}
+		return sorted[i].RecordedAt.Before(sorted[j].RecordedAt)
+	})
+
+	result, err := s.repository.SaveReadings(ctx, sorted)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Stored > 0 {
+		var sectionIds []int64
+		for _, reading := range sorted {
+			if len(sectionIds) == 0 || sectionIds[len(sectionIds)-1] != reading.SectionId {
+				sectionIds = append(sectionIds, reading.SectionId)
+			}
+		}
+
+		for _, observer := range s.observers {
+			observer.SectionsUpdated(ctx, sectionIds)
+		}
+	}
+
+	return result, nil
+}
+
+// GetTemperatureReport summarises the hourly rollups of the section whose
+// hour starts between from, rounded down to the hour, and to.
+func (s telemetryService) GetTemperatureReport(
+	ctx context.Context,
+	sectionId int64,
+	from,
+	to time.Time,
+) (*domain.TemperatureReport, error) {
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidWindow
+	}
+
+	exists, err := s.repository.SectionExists(ctx, sectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, domain.ErrSectionNotFound
+	}
+
+	from = from.UTC().Truncate(time.Hour)
+	to = to.UTC()
+
+	rollups, err := s.repository.GetHourlyRollups(ctx, sectionId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.TemperatureReport{
+		SectionId: sectionId,
+		From:      from,
+		To:        to,
+		Hours:     *rollups,
+	}
+
+	if len(report.Hours) == 0 {
+		return report, nil
+	}
+
+	report.MinTemperature = math.Inf(1)
+	report.MaxTemperature = math.Inf(-1)
+
+	var sum float64
+	for _, rollup := range report.Hours {
+		report.MinTemperature = math.Min(report.MinTemperature, rollup.MinTemperature)
+		report.MaxTemperature = math.Max(report.MaxTemperature, rollup.MaxTemperature)
+		report.ReadingsCount += rollup.ReadingsCount
+		sum += rollup.AvgTemperature * float64(rollup.ReadingsCount)
+	}
+
+	report.AvgTemperature = sum / float64(report.ReadingsCount)
+
+	return report, nil
+}
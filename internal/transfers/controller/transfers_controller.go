@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/domain"
+)
+
+type TransferController struct {
+	service domain.TransferService
+}
+
+func NewTransferController(service domain.TransferService) (*TransferController, error) {
+	if service == nil {
+		return nil, errors.New("invalid service")
+	}
+
+	return &TransferController{
+		service: service,
+	}, nil
+}
+
+// @Summary List stock transfers
+// @Tags Transfers
+// @Description get all stock transfers between sections and warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Param status query string false "completed, in_transit or received"
+// @Param product_batch_id query int false "Product batch ID"
+// @Param from_warehouse_id query int false "Source warehouse ID"
+// @Param to_warehouse_id query int false "Target warehouse ID"
+// @Param from_section_id query int false "Source section ID"
+// @Param to_section_id query int false "Target section ID"
+// @Param created_at_from query string false "Created at or after"
+// @Param created_at_to query string false "Created at or before"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.Transfer}
+// @Failure 400 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /transfers [get]
+func (c TransferController) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.TransferListFields)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		transfers, total, err := c.service.GetAll(ctx.Request.Context(), params)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": transfers,
+			"meta": listing.NewMeta(params, total),
+		})
+	}
+}
+
+// @Summary Stock transfer by id
+// @Tags Transfers
+// @Description get a stock transfer by its id
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Transfer}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /transfers/{id} [get]
+func (c TransferController) GetById() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestTransferId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		transfer, err := c.service.GetById(ctx.Request.Context(), req.Id)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": transfer})
+	}
+}
+
+// @Summary Transfer stock
+// @Tags Transfers
+// @Description Move a product batch, or part of it, to another section. Moves inside a warehouse complete at once, moves to another warehouse stay in transit until received.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param transfer body domain.TransferRequest true "Transfer to create"
+// @Success 201 {object} schemas.JSONSuccessResult{data=domain.Transfer}
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /transfers [post]
+func (c TransferController) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.TransferRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		transfer, err := c.service.Create(ctx.Request.Context(), req)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, gin.H{"data": transfer})
+	}
+}
+
+// @Summary Receive stock transfer
+// @Tags Transfers
+// @Description Place the stock of a transfer in transit in its target section as a new batch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Transfer}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /transfers/{id}/receive [post]
+func (c TransferController) Receive() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestTransferId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		transfer, err := c.service.Receive(ctx.Request.Context(), req.Id)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": transfer})
+	}
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	products "github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func serve(serviceMock *mocks.TransferService, method, path string, body *bytes.Buffer) *httptest.ResponseRecorder {
+	if body == nil {
+		body = &bytes.Buffer{}
+	}
+
+	req := httptest.NewRequest(method, path, body)
+	rec := httptest.NewRecorder()
+
+	_, engine := gin.CreateTestContext(rec)
+
+	transferController := TransferController{service: serviceMock}
+
+	engine.GET("/api/v1/transfers", transferController.GetAll())
+	engine.GET("/api/v1/transfers/:id", transferController.GetById())
+	engine.POST("/api/v1/transfers", transferController.Create())
+	engine.POST("/api/v1/transfers/:id/receive", transferController.Receive())
+
+	engine.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		params := listing.Params{
+			Limit:   listing.DefaultLimit,
+			Filters: []listing.Filter{{Field: "status", Value: domain.StatusInTransit}},
+		}
+
+		serviceMock := mocks.NewTransferService(t)
+		serviceMock.On("GetAll", mock.Anything, params).Return(&[]domain.Transfer{{Id: 1}}, int64(1), nil).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/transfers?status=in_transit", nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"meta"`)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		serviceMock := mocks.NewTransferService(t)
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/transfers?sort=actor", nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewTransferService(t)
+		serviceMock.On("GetById", mock.Anything, int64(1)).Return(&domain.Transfer{Id: 1}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/transfers/1", nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		serviceMock := mocks.NewTransferService(t)
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/transfers/abc", nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		serviceMock := mocks.NewTransferService(t)
+		serviceMock.On("GetById", mock.Anything, int64(1)).Return(nil, domain.ErrTransferNotFound).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/transfers/1", nil)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	body := `{"product_batch_id": 1, "to_section_id": 5, "quantity": 4, "batch_number": 11}`
+	request := domain.TransferRequest{ProductBatchId: 1, ToSectionId: 5, Quantity: 4, BatchNumber: 11}
+
+	t.Run("created", func(t *testing.T) {
+		serviceMock := mocks.NewTransferService(t)
+		serviceMock.On("Create", mock.Anything, request).Return(&domain.Transfer{Id: 9}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodPost, "/api/v1/transfers", bytes.NewBufferString(body))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("unprocessable entity", func(t *testing.T) {
+		serviceMock := mocks.NewTransferService(t)
+
+		rec := serve(serviceMock, http.MethodPost, "/api/v1/transfers", bytes.NewBufferString(`{"to_section_id": 5}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"batch not found", domain.ErrProductBatchNotFound, http.StatusNotFound},
+		{"section not found", domain.ErrSectionNotFound, http.StatusNotFound},
+		{"same section", domain.ErrSameSection, http.StatusUnprocessableEntity},
+		{"batch number required", domain.ErrBatchNumberRequired, http.StatusUnprocessableEntity},
+		{"insufficient stock", domain.ErrInsufficientStock, http.StatusConflict},
+		{"batch number taken", domain.ErrBatchNumberTaken, http.StatusConflict},
+		{"section full", fmt.Errorf("%w: 2 free, 4 requested", products.ErrSectionCapacityExceeded), http.StatusConflict},
+		{"product type mismatch", products.ErrProductTypeMismatch, http.StatusConflict},
+		{"internal server error", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			serviceMock := mocks.NewTransferService(t)
+			serviceMock.On("Create", mock.Anything, request).Return(nil, c.err).Once()
+
+			rec := serve(serviceMock, http.MethodPost, "/api/v1/transfers", bytes.NewBufferString(body))
+
+			assert.Equal(t, c.status, rec.Code)
+		})
+	}
+}
+
+func TestReceive(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewTransferService(t)
+		serviceMock.On("Receive", mock.Anything, int64(9)).Return(&domain.Transfer{Id: 9, Status: domain.StatusReceived}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodPost, "/api/v1/transfers/9/receive", nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("not in transit", func(t *testing.T) {
+		serviceMock := mocks.NewTransferService(t)
+		serviceMock.On("Receive", mock.Anything, int64(9)).Return(nil, domain.ErrNotInTransit).Once()
+
+		rec := serve(serviceMock, http.MethodPost, "/api/v1/transfers/9/receive", nil)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
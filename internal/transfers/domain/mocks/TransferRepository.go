@@ -0,0 +1,143 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TransferRepository is an autogenerated mock type for the TransferRepository type
+type TransferRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, request, at
+func (_m *TransferRepository) Create(ctx context.Context, request domain.TransferRequest, at time.Time) (int64, error) {
+	ret := _m.Called(ctx, request, at)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransferRequest, time.Time) int64); ok {
+		r0 = rf(ctx, request, at)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TransferRequest, time.Time) error); ok {
+		r1 = rf(ctx, request, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, params
+func (_m *TransferRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Transfer, int64, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *[]domain.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.Transfer); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Transfer)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetBatchWarehouseId provides a mock function with given fields: ctx, batchId
+func (_m *TransferRepository) GetBatchWarehouseId(ctx context.Context, batchId int64) (int64, error) {
+	ret := _m.Called(ctx, batchId)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, batchId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, batchId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetById provides a mock function with given fields: ctx, id
+func (_m *TransferRepository) GetById(ctx context.Context, id int64) (*domain.Transfer, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Transfer); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Receive provides a mock function with given fields: ctx, id, at
+func (_m *TransferRepository) Receive(ctx context.Context, id int64, at time.Time) error {
+	ret := _m.Called(ctx, id, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTransferRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTransferRepository creates a new instance of TransferRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTransferRepository(t mockConstructorTestingTNewTransferRepository) *TransferRepository {
+	mock := &TransferRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
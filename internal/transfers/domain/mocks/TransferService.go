@@ -0,0 +1,131 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransferService is an autogenerated mock type for the TransferService type
+type TransferService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, request
+func (_m *TransferService) Create(ctx context.Context, request domain.TransferRequest) (*domain.Transfer, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *domain.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransferRequest) *domain.Transfer); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TransferRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, params
+func (_m *TransferService) GetAll(ctx context.Context, params listing.Params) (*[]domain.Transfer, int64, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *[]domain.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.Transfer); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Transfer)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetById provides a mock function with given fields: ctx, id
+func (_m *TransferService) GetById(ctx context.Context, id int64) (*domain.Transfer, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Transfer); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Receive provides a mock function with given fields: ctx, id
+func (_m *TransferService) Receive(ctx context.Context, id int64) (*domain.Transfer, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Transfer); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTransferService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTransferService creates a new instance of TransferService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTransferService(t mockConstructorTestingTNewTransferService) *TransferService {
+	mock := &TransferService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
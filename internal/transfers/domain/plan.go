@@ -0,0 +1,60 @@
+package domain
+
+import "fmt"
+
+// Kinds of transfer, chosen by Plan.
+const (
+	// KindMove rehomes the whole batch in another section of its warehouse.
+	KindMove = "move"
+	// KindSplit takes part of the batch into a new batch in another section
+	// of the same warehouse.
+	KindSplit = "split"
+	// KindDispatch sends stock to another warehouse, where it becomes a new
+	// batch once received.
+	KindDispatch = "dispatch"
+)
+
+// Source is the locked state of the batch being transferred.
+type Source struct {
+	SectionId   int64
+	WarehouseId int64
+	Quantity    int64
+	Reserved    int64
+}
+
+// Target is the section the stock goes to.
+type Target struct {
+	SectionId   int64
+	WarehouseId int64
+}
+
+// Plan decides how a transfer is carried out and how many units it moves.
+// Reserved stock never moves: purchase orders keep picking it where it is.
+func Plan(source Source, target Target, request TransferRequest) (string, int64, error) {
+	if source.SectionId == target.SectionId {
+		return "", 0, ErrSameSection
+	}
+
+	quantity := request.Quantity
+	if quantity == 0 {
+		quantity = source.Quantity
+	}
+
+	if available := source.Quantity - source.Reserved; quantity <= 0 || quantity > available {
+		return "", 0, fmt.Errorf("%w: %d available, %d requested", ErrInsufficientStock, available, quantity)
+	}
+
+	kind := KindDispatch
+	if source.WarehouseId == target.WarehouseId {
+		kind = KindSplit
+		if quantity == source.Quantity {
+			kind = KindMove
+		}
+	}
+
+	if kind != KindMove && request.BatchNumber == 0 {
+		return "", 0, ErrBatchNumberRequired
+	}
+
+	return kind, quantity, nil
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlan(t *testing.T) {
+	source := Source{SectionId: 1, WarehouseId: 1, Quantity: 10, Reserved: 0}
+	sameWarehouse := Target{SectionId: 2, WarehouseId: 1}
+	otherWarehouse := Target{SectionId: 3, WarehouseId: 2}
+
+	testCases := []struct {
+		name     string
+		source   Source
+		target   Target
+		request  TransferRequest
+		kind     string
+		quantity int64
+		err      error
+	}{
+		{
+			name:     "whole batch inside the warehouse",
+			source:   source,
+			target:   sameWarehouse,
+			request:  TransferRequest{},
+			kind:     KindMove,
+			quantity: 10,
+		},
+		{
+			name:     "whole batch given as quantity",
+			source:   source,
+			target:   sameWarehouse,
+			request:  TransferRequest{Quantity: 10},
+			kind:     KindMove,
+			quantity: 10,
+		},
+		{
+			name:     "part of the batch inside the warehouse",
+			source:   source,
+			target:   sameWarehouse,
+			request:  TransferRequest{Quantity: 4, BatchNumber: 99},
+			kind:     KindSplit,
+			quantity: 4,
+		},
+		{
+			name:     "to another warehouse",
+			source:   source,
+			target:   otherWarehouse,
+			request:  TransferRequest{BatchNumber: 99},
+			kind:     KindDispatch,
+			quantity: 10,
+		},
+		{
+			name:    "split without batch number",
+			source:  source,
+			target:  sameWarehouse,
+			request: TransferRequest{Quantity: 4},
+			err:     ErrBatchNumberRequired,
+		},
+		{
+			name:    "dispatch without batch number",
+			source:  source,
+			target:  otherWarehouse,
+			request: TransferRequest{Quantity: 4},
+			err:     ErrBatchNumberRequired,
+		},
+		{
+			name:    "same section",
+			source:  source,
+			target:  Target{SectionId: 1, WarehouseId: 1},
+			request: TransferRequest{},
+			err:     ErrSameSection,
+		},
+		{
+			name:    "more than the batch holds",
+			source:  source,
+			target:  sameWarehouse,
+			request: TransferRequest{Quantity: 11, BatchNumber: 99},
+			err:     ErrInsufficientStock,
+		},
+		{
+			name:    "whole batch with reserved stock",
+			source:  Source{SectionId: 1, WarehouseId: 1, Quantity: 10, Reserved: 2},
+			target:  sameWarehouse,
+			request: TransferRequest{},
+			err:     ErrInsufficientStock,
+		},
+		{
+			name:    "empty batch",
+			source:  Source{SectionId: 1, WarehouseId: 1},
+			target:  sameWarehouse,
+			request: TransferRequest{},
+			err:     ErrInsufficientStock,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, quantity, err := Plan(tc.source, tc.target, tc.request)
+
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.kind, kind)
+			assert.Equal(t, tc.quantity, quantity)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
+
+// Transfer statuses. Moves inside a warehouse complete at once, moves to
+// another warehouse stay in transit until the target warehouse receives them.
+const (
+	StatusCompleted = "completed"
+	StatusInTransit = "in_transit"
+	StatusReceived  = "received"
+)
+
+// Transfer moves Quantity units of a product batch to another section.
+// TargetBatchId is the batch holding the stock at the target: the same batch
+// when it moved whole, or the batch split from it, which is only created on
+// receipt for transfers between warehouses.
+type Transfer struct {
+	Id              int64      `json:"id"`
+	ProductBatchId  int64      `json:"product_batch_id"`
+	TargetBatchId   *int64     `json:"target_batch_id"`
+	BatchNumber     *int64     `json:"batch_number"`
+	FromSectionId   int64      `json:"from_section_id"`
+	ToSectionId     int64      `json:"to_section_id"`
+	FromWarehouseId int64      `json:"from_warehouse_id"`
+	ToWarehouseId   int64      `json:"to_warehouse_id"`
+	Quantity        int64      `json:"quantity"`
+	Status          string     `json:"status"`
+	Actor           string     `json:"actor"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ReceivedAt      *time.Time `json:"received_at"`
+}
+
+var TransferListFields = listing.Fields{
+	Sort:   []string{"id", "created_at", "received_at", "quantity"},
+	Filter: []string{"status", "product_batch_id", "from_warehouse_id", "to_warehouse_id", "from_section_id", "to_section_id"},
+	Range:  []string{"created_at"},
+}
+
+// TransferRequest asks to move a batch to ToSectionId. A zero Quantity moves
+// the whole batch. BatchNumber names the new batch and is required whenever
+// one is created, that is, when splitting or leaving the warehouse.
+type TransferRequest struct {
+	ProductBatchId int64 `json:"product_batch_id" binding:"required,min=1"`
+	ToSectionId    int64 `json:"to_section_id" binding:"required,min=1"`
+	Quantity       int64 `json:"quantity" binding:"omitempty,min=1"`
+	BatchNumber    int64 `json:"batch_number" binding:"omitempty,min=1"`
+}
+
+type RequestTransferId struct {
+	Id int64 `uri:"id" binding:"required,min=1"`
+}
+
+type TransferRepository interface {
+	GetAll(ctx context.Context, params listing.Params) (*[]Transfer, int64, error)
+	GetById(ctx context.Context, id int64) (*Transfer, error)
+	GetBatchWarehouseId(ctx context.Context, batchId int64) (int64, error)
+	Create(ctx context.Context, request TransferRequest, at time.Time) (int64, error)
+	Receive(ctx context.Context, id int64, at time.Time) error
+}
+
+type TransferService interface {
+	GetAll(ctx context.Context, params listing.Params) (*[]Transfer, int64, error)
+	GetById(ctx context.Context, id int64) (*Transfer, error)
+	Create(ctx context.Context, request TransferRequest) (*Transfer, error)
+	Receive(ctx context.Context, id int64) (*Transfer, error)
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+
+var (
+	ErrTransferNotFound     = apperrors.NotFound("transfer not found")
+	ErrProductBatchNotFound = apperrors.NotFound("product batch not found")
+	ErrSectionNotFound      = apperrors.NotFound("section not found")
+	ErrSameSection          = apperrors.Validation("product batch is already in the target section")
+	ErrInsufficientStock    = apperrors.Conflict("not enough unreserved stock in the product batch")
+	ErrBatchNumberRequired  = apperrors.Validation("batch_number is required to create a new batch")
+	ErrBatchNumberTaken     = apperrors.Conflict("batch_number is already in use")
+	ErrNotInTransit         = apperrors.Conflict("transfer is not in transit")
+)
@@ -0,0 +1,23 @@
+package mariadb
+
+const (
+	sqlGetAll = `SELECT id, product_batch_id, target_batch_id, batch_number, from_section_id, to_section_id, from_warehouse_id, to_warehouse_id, quantity, status, actor, created_at, received_at
+		FROM stock_transfers`
+	sqlGetById = sqlGetAll + " WHERE id = ?;"
+	sqlInsert  = `INSERT INTO stock_transfers (product_batch_id, batch_number, from_section_id, to_section_id, from_warehouse_id, to_warehouse_id, quantity, status, actor, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	sqlComplete     = "UPDATE stock_transfers SET status = ?, target_batch_id = ?, received_at = ? WHERE id = ?;"
+	sqlLockTransfer = "SELECT product_batch_id, to_section_id, quantity, COALESCE(batch_number, 0), status FROM stock_transfers WHERE id = ? FOR UPDATE;"
+
+	sqlLockBatch           = "SELECT section_id, COALESCE(current_quantity, 0), reserved_quantity, product_id FROM product_batches WHERE id = ? FOR UPDATE;"
+	sqlGetProductId        = "SELECT product_id FROM product_batches WHERE id = ?;"
+	sqlGetSectionWarehouse = "SELECT warehouse_id FROM sections WHERE id = ?;"
+	sqlGetBatchWarehouse   = "SELECT s.warehouse_id FROM product_batches b INNER JOIN sections s ON s.id = b.section_id WHERE b.id = ?;"
+	sqlBatchNumberTaken    = "SELECT EXISTS(SELECT 1 FROM product_batches WHERE batch_number = ?);"
+	sqlMoveBatch           = "UPDATE product_batches SET section_id = ? WHERE id = ?;"
+	sqlTakeFromBatch       = "UPDATE product_batches SET current_quantity = current_quantity - ? WHERE id = ?;"
+	sqlSplitBatch          = `INSERT INTO product_batches (batch_number, current_quantity, current_temperature, due_date, quarantined_at, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, section_id)
+		SELECT ?, ?, current_temperature, due_date, quarantined_at, ?, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, ?
+		FROM product_batches
+		WHERE id = ?;`
+)
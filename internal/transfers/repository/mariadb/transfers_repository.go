@@ -0,0 +1,377 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/actor"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	auditlog "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	movements "github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+	ledger "github.com/marcoglnd/mercado-fresco-packmain/internal/movements/repository/mariadb"
+	products "github.com/marcoglnd/mercado-fresco-packmain/internal/products/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/domain"
+)
+
+type mariadbRepository struct {
+	db *sql.DB
+}
+
+func NewMariaDBRepository(db *sql.DB) domain.TransferRepository {
+	return mariadbRepository{db: db}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (m mariadbRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Transfer, int64, error) {
+	transfers := []domain.Transfer{}
+
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAll, params, nil)
+	if err := m.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &transfers, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAll, params, nil)
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return &transfers, 0, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return &transfers, 0, err
+		}
+
+		transfers = append(transfers, *transfer)
+	}
+
+	return &transfers, total, rows.Err()
+}
+
+func (m mariadbRepository) GetById(ctx context.Context, id int64) (*domain.Transfer, error) {
+	transfer, err := scanTransfer(m.db.QueryRowContext(ctx, sqlGetById, id))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// GetBatchWarehouseId returns the warehouse holding a batch.
+func (m mariadbRepository) GetBatchWarehouseId(ctx context.Context, batchId int64) (int64, error) {
+	var warehouseId int64
+	err := m.db.QueryRowContext(ctx, sqlGetBatchWarehouse, batchId).Scan(&warehouseId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, domain.ErrProductBatchNotFound
+	}
+	return warehouseId, err
+}
+
+// Create carries out the transfer planned by domain.Plan in one transaction.
+// Moves and splits inside a warehouse take room in the target section and
+// complete at once. Dispatches take the stock out of the source batch and
+// its section and leave the transfer in transit, only checking that the
+// target section can hold it for now. The batch and the sections whose
+// capacity changes are audited next to the transfer.
+func (m mariadbRepository) Create(ctx context.Context, request domain.TransferRequest, at time.Time) (int64, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	var source domain.Source
+	var productId int64
+	err = tx.QueryRowContext(ctx, sqlLockBatch, request.ProductBatchId).
+		Scan(&source.SectionId, &source.Quantity, &source.Reserved, &productId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, domain.ErrProductBatchNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if source.WarehouseId, err = sectionWarehouse(ctx, tx, source.SectionId); err != nil {
+		return 0, err
+	}
+
+	target := domain.Target{SectionId: request.ToSectionId}
+	if target.WarehouseId, err = sectionWarehouse(ctx, tx, target.SectionId); err != nil {
+		return 0, err
+	}
+
+	kind, quantity, err := domain.Plan(source, target, request)
+	if err != nil {
+		return 0, err
+	}
+
+	var batchNumber *int64
+	if kind != domain.KindMove {
+		var taken bool
+		if err := tx.QueryRowContext(ctx, sqlBatchNumberTaken, request.BatchNumber).Scan(&taken); err != nil {
+			return 0, err
+		}
+		if taken {
+			return 0, domain.ErrBatchNumberTaken
+		}
+		batchNumber = &request.BatchNumber
+	}
+
+	changes := []change{
+		{table: "product_batches", id: request.ProductBatchId},
+		{table: "sections", id: source.SectionId},
+	}
+	if kind != domain.KindDispatch {
+		changes = append(changes, change{table: "sections", id: target.SectionId})
+	}
+	if err := snapshot(ctx, tx, changes); err != nil {
+		return 0, err
+	}
+
+	status := domain.StatusCompleted
+	if kind == domain.KindDispatch {
+		status = domain.StatusInTransit
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		sqlInsert,
+		request.ProductBatchId,
+		batchNumber,
+		source.SectionId,
+		target.SectionId,
+		source.WarehouseId,
+		target.WarehouseId,
+		quantity,
+		status,
+		actor.FromContext(ctx),
+		at,
+	)
+	if err != nil {
+		return 0, apperrors.FromMySQL(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if kind == domain.KindDispatch {
+		err = products.CheckSection(ctx, tx, target.SectionId, productId, quantity)
+	} else {
+		err = products.PlaceInSection(ctx, tx, target.SectionId, productId, quantity)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := products.ResizeInSection(ctx, tx, source.SectionId, -quantity); err != nil {
+		return 0, err
+	}
+
+	targetBatchId := request.ProductBatchId
+	if kind == domain.KindMove {
+		if _, err := tx.ExecContext(ctx, sqlMoveBatch, target.SectionId, request.ProductBatchId); err != nil {
+			return 0, apperrors.FromMySQL(err)
+		}
+		// The quantity stays the same, the entry only records the move.
+		err = recordTransfer(ctx, tx, id, request.ProductBatchId, 0, source.Quantity)
+	} else {
+		if _, err := tx.ExecContext(ctx, sqlTakeFromBatch, quantity, request.ProductBatchId); err != nil {
+			return 0, apperrors.FromMySQL(err)
+		}
+		err = recordTransfer(ctx, tx, id, request.ProductBatchId, -quantity, source.Quantity-quantity)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if kind == domain.KindSplit {
+		if targetBatchId, err = split(ctx, tx, id, request.ProductBatchId, request.BatchNumber, target.SectionId, quantity); err != nil {
+			return 0, err
+		}
+	}
+
+	if kind != domain.KindDispatch {
+		if _, err := tx.ExecContext(ctx, sqlComplete, status, targetBatchId, at, id); err != nil {
+			return 0, apperrors.FromMySQL(err)
+		}
+	}
+
+	if err := record(ctx, tx, changes); err != nil {
+		return 0, err
+	}
+
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "stock_transfers", id, nil); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Receive places the stock of a transfer in transit in its target section as
+// a new batch.
+func (m mariadbRepository) Receive(ctx context.Context, id int64, at time.Time) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var sourceBatchId, toSectionId, quantity, batchNumber int64
+	var status string
+	err = tx.QueryRowContext(ctx, sqlLockTransfer, id).Scan(&sourceBatchId, &toSectionId, &quantity, &batchNumber, &status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrTransferNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if status != domain.StatusInTransit {
+		return domain.ErrNotInTransit
+	}
+
+	changes := []change{
+		{table: "stock_transfers", id: id},
+		{table: "sections", id: toSectionId},
+	}
+	if err := snapshot(ctx, tx, changes); err != nil {
+		return err
+	}
+
+	var productId int64
+	if err := tx.QueryRowContext(ctx, sqlGetProductId, sourceBatchId).Scan(&productId); err != nil {
+		return err
+	}
+
+	if err := products.PlaceInSection(ctx, tx, toSectionId, productId, quantity); err != nil {
+		return err
+	}
+
+	targetBatchId, err := split(ctx, tx, id, sourceBatchId, batchNumber, toSectionId, quantity)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlComplete, domain.StatusReceived, targetBatchId, at, id); err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
+	if err := record(ctx, tx, changes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// split creates a batch in sectionId holding quantity units, copying the
+// product data of the source batch, and opens its ledger.
+func split(ctx context.Context, tx *sql.Tx, transferId, sourceBatchId, batchNumber, sectionId, quantity int64) (int64, error) {
+	result, err := tx.ExecContext(ctx, sqlSplitBatch, batchNumber, quantity, quantity, sectionId, sourceBatchId)
+	if err != nil {
+		return 0, apperrors.FromMySQL(err)
+	}
+
+	batchId, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := recordTransfer(ctx, tx, transferId, batchId, quantity, quantity); err != nil {
+		return 0, err
+	}
+
+	return batchId, auditlog.Record(ctx, tx, audit.ActionCreate, "product_batches", batchId, nil)
+}
+
+// change is a row a transfer updates, with what it held before.
+type change struct {
+	table  string
+	id     int64
+	before audit.Row
+}
+
+func snapshot(ctx context.Context, tx *sql.Tx, changes []change) error {
+	for i, c := range changes {
+		before, err := auditlog.Snapshot(ctx, tx, c.table, c.id)
+		if err != nil {
+			return err
+		}
+		changes[i].before = before
+	}
+	return nil
+}
+
+func record(ctx context.Context, tx *sql.Tx, changes []change) error {
+	for _, c := range changes {
+		if err := auditlog.Record(ctx, tx, audit.ActionUpdate, c.table, c.id, c.before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func recordTransfer(ctx context.Context, tx *sql.Tx, transferId, batchId, delta, balance int64) error {
+	return ledger.Record(ctx, tx, movements.Movement{
+		ProductBatchId: batchId,
+		Type:           movements.TypeTransfer,
+		Delta:          delta,
+		Balance:        balance,
+		TransferId:     &transferId,
+	})
+}
+
+func sectionWarehouse(ctx context.Context, tx *sql.Tx, sectionId int64) (int64, error) {
+	var warehouseId int64
+	err := tx.QueryRowContext(ctx, sqlGetSectionWarehouse, sectionId).Scan(&warehouseId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, domain.ErrSectionNotFound
+	}
+	return warehouseId, err
+}
+
+func scanTransfer(row scanner) (*domain.Transfer, error) {
+	var transfer domain.Transfer
+
+	if err := row.Scan(
+		&transfer.Id,
+		&transfer.ProductBatchId,
+		&transfer.TargetBatchId,
+		&transfer.BatchNumber,
+		&transfer.FromSectionId,
+		&transfer.ToSectionId,
+		&transfer.FromWarehouseId,
+		&transfer.ToWarehouseId,
+		&transfer.Quantity,
+		&transfer.Status,
+		&transfer.Actor,
+		&transfer.CreatedAt,
+		&transfer.ReceivedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
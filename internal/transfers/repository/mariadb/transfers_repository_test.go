@@ -0,0 +1,458 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/actor"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	movements "github.com/marcoglnd/mercado-fresco-packmain/internal/movements/domain"
+	products "github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	queryGetAll               = regexp.QuoteMeta(sqlGetAll)
+	queryGetById              = regexp.QuoteMeta(sqlGetById)
+	queryInsert               = regexp.QuoteMeta(sqlInsert)
+	queryComplete             = regexp.QuoteMeta(sqlComplete)
+	queryLockTransfer         = regexp.QuoteMeta(sqlLockTransfer)
+	queryLockBatch            = regexp.QuoteMeta(sqlLockBatch)
+	queryGetProductId         = regexp.QuoteMeta(sqlGetProductId)
+	queryGetSectionWarehouse  = regexp.QuoteMeta(sqlGetSectionWarehouse)
+	queryGetBatchWarehouse    = regexp.QuoteMeta(sqlGetBatchWarehouse)
+	queryBatchNumberTaken     = regexp.QuoteMeta(sqlBatchNumberTaken)
+	queryMoveBatch            = regexp.QuoteMeta(sqlMoveBatch)
+	queryTakeFromBatch        = regexp.QuoteMeta(sqlTakeFromBatch)
+	querySplitBatch           = regexp.QuoteMeta(sqlSplitBatch)
+	queryLockSection          = regexp.QuoteMeta("SELECT current_capacity, maximum_capacity, product_type_id FROM sections WHERE id = ? FOR UPDATE;")
+	queryGetProductTypeId     = regexp.QuoteMeta("SELECT product_type_id FROM products WHERE id = ?;")
+	queryAddToSectionCapacity = regexp.QuoteMeta("UPDATE sections SET current_capacity = current_capacity + ? WHERE id = ?;")
+	queryGetLastBalance       = regexp.QuoteMeta("SELECT balance FROM stock_movements WHERE product_batch_id = ?")
+	queryInsertMovement       = regexp.QuoteMeta("INSERT INTO stock_movements")
+)
+
+var rowsTransferStruct = []string{
+	"id", "product_batch_id", "target_batch_id", "batch_number", "from_section_id", "to_section_id",
+	"from_warehouse_id", "to_warehouse_id", "quantity", "status", "actor", "created_at", "received_at",
+}
+
+var at = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// expectSource locks batch 1, holding 10 units of product 4 with 2 reserved
+// in section 3, and looks up the warehouses of sections 3 and toSectionId.
+func expectSource(mock sqlmock.Sqlmock, toSectionId, toWarehouseId int64) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryLockBatch).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"section_id", "current_quantity", "reserved_quantity", "product_id"}).AddRow(3, 10, 2, 4))
+	mock.ExpectQuery(queryGetSectionWarehouse).WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(1))
+	mock.ExpectQuery(queryGetSectionWarehouse).WithArgs(toSectionId).
+		WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(toWarehouseId))
+}
+
+// expectSnapshots expects batch 1 and the sections to be read before the
+// transfer changes them.
+func expectSnapshots(mock sqlmock.Sqlmock, sectionIds ...int64) {
+	audittest.ExpectSnapshot(mock, "product_batches", 1)
+	for _, id := range sectionIds {
+		audittest.ExpectSnapshot(mock, "sections", id)
+	}
+}
+
+// expectRecords expects the changes of expectSnapshots and the transfer 9
+// to be recorded.
+func expectRecords(mock sqlmock.Sqlmock, sectionIds ...int64) {
+	audittest.ExpectRecord(mock, audit.ActionUpdate, "product_batches", 1)
+	for _, id := range sectionIds {
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "sections", id)
+	}
+	audittest.ExpectRecord(mock, audit.ActionCreate, "stock_transfers", 9)
+}
+
+func expectTargetSection(mock sqlmock.Sqlmock, sectionId, currentCapacity int64) {
+	mock.ExpectQuery(queryLockSection).WithArgs(sectionId).
+		WillReturnRows(sqlmock.NewRows([]string{"current_capacity", "maximum_capacity", "product_type_id"}).AddRow(currentCapacity, 20, 2))
+	mock.ExpectQuery(queryGetProductTypeId).WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"product_type_id"}).AddRow(2))
+}
+
+func expectMovement(mock sqlmock.Sqlmock, batchId, lastBalance, delta, balance int64) {
+	rows := sqlmock.NewRows([]string{"balance"})
+	if lastBalance >= 0 {
+		rows.AddRow(lastBalance)
+	}
+	mock.ExpectQuery(queryGetLastBalance).WithArgs(batchId).WillReturnRows(rows)
+	mock.ExpectExec(queryInsertMovement).
+		WithArgs(batchId, movements.TypeTransfer, delta, balance, actor.System, nil, nil, int64(9)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+		mock.ExpectQuery(queryGetAll).WillReturnRows(sqlmock.NewRows(rowsTransferStruct).
+			AddRow(1, 1, nil, 11, 3, 5, 1, 2, 4, domain.StatusInTransit, actor.System, at, nil))
+
+		transfers, total, err := NewMariaDBRepository(db).GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
+
+		batchNumber := int64(11)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []domain.Transfer{{
+			Id: 1, ProductBatchId: 1, BatchNumber: &batchNumber, FromSectionId: 3, ToSectionId: 5,
+			FromWarehouseId: 1, ToWarehouseId: 2, Quantity: 4, Status: domain.StatusInTransit, Actor: actor.System, CreatedAt: at,
+		}}, *transfers)
+	})
+
+	t.Run("fail to count", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*)")).WillReturnError(errors.New("connection refused"))
+
+		_, _, err = NewMariaDBRepository(db).GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetById).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows(rowsTransferStruct).
+			AddRow(1, 1, 1, nil, 3, 4, 1, 1, 10, domain.StatusCompleted, actor.System, at, at))
+
+		transfer, err := NewMariaDBRepository(db).GetById(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *transfer.TargetBatchId)
+		assert.Equal(t, at, *transfer.ReceivedAt)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetById).WithArgs(int64(1)).WillReturnError(sql.ErrNoRows)
+
+		transfer, err := NewMariaDBRepository(db).GetById(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, transfer)
+	})
+}
+
+func TestGetBatchWarehouseId(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetBatchWarehouse).WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(2))
+
+		warehouseId, err := NewMariaDBRepository(db).GetBatchWarehouseId(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), warehouseId)
+	})
+
+	t.Run("batch not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetBatchWarehouse).WithArgs(int64(1)).WillReturnError(sql.ErrNoRows)
+
+		_, err = NewMariaDBRepository(db).GetBatchWarehouseId(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domain.ErrProductBatchNotFound)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("move whole batch", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockBatch).WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"section_id", "current_quantity", "reserved_quantity", "product_id"}).AddRow(3, 10, 0, 4))
+		mock.ExpectQuery(queryGetSectionWarehouse).WithArgs(int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(1))
+		mock.ExpectQuery(queryGetSectionWarehouse).WithArgs(int64(5)).
+			WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(1))
+		expectSnapshots(mock, 3, 5)
+		mock.ExpectExec(queryInsert).
+			WithArgs(int64(1), nil, int64(3), int64(5), int64(1), int64(1), int64(10), domain.StatusCompleted, actor.System, at).
+			WillReturnResult(sqlmock.NewResult(9, 1))
+		expectTargetSection(mock, 5, 0)
+		mock.ExpectExec(queryAddToSectionCapacity).WithArgs(int64(10), int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryAddToSectionCapacity).WithArgs(int64(-10), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryMoveBatch).WithArgs(int64(5), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectMovement(mock, 1, 10, 0, 10)
+		mock.ExpectExec(queryComplete).WithArgs(domain.StatusCompleted, int64(1), at, int64(9)).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectRecords(mock, 3, 5)
+		mock.ExpectCommit()
+
+		id, err := NewMariaDBRepository(db).Create(context.Background(), domain.TransferRequest{ProductBatchId: 1, ToSectionId: 5}, at)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(9), id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("split inside the warehouse", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectSource(mock, 5, 1)
+		mock.ExpectQuery(queryBatchNumberTaken).WithArgs(int64(11)).WillReturnRows(sqlmock.NewRows([]string{"taken"}).AddRow(false))
+		expectSnapshots(mock, 3, 5)
+		mock.ExpectExec(queryInsert).
+			WithArgs(int64(1), int64(11), int64(3), int64(5), int64(1), int64(1), int64(4), domain.StatusCompleted, actor.System, at).
+			WillReturnResult(sqlmock.NewResult(9, 1))
+		expectTargetSection(mock, 5, 0)
+		mock.ExpectExec(queryAddToSectionCapacity).WithArgs(int64(4), int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryAddToSectionCapacity).WithArgs(int64(-4), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryTakeFromBatch).WithArgs(int64(4), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectMovement(mock, 1, 10, -4, 6)
+		mock.ExpectExec(querySplitBatch).WithArgs(int64(11), int64(4), int64(4), int64(5), int64(1)).WillReturnResult(sqlmock.NewResult(2, 1))
+		expectMovement(mock, 2, -1, 4, 4)
+		audittest.ExpectRecord(mock, audit.ActionCreate, "product_batches", 2)
+		mock.ExpectExec(queryComplete).WithArgs(domain.StatusCompleted, int64(2), at, int64(9)).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectRecords(mock, 3, 5)
+		mock.ExpectCommit()
+
+		id, err := NewMariaDBRepository(db).Create(
+			context.Background(),
+			domain.TransferRequest{ProductBatchId: 1, ToSectionId: 5, Quantity: 4, BatchNumber: 11},
+			at,
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(9), id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("dispatch to another warehouse", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectSource(mock, 6, 2)
+		mock.ExpectQuery(queryBatchNumberTaken).WithArgs(int64(11)).WillReturnRows(sqlmock.NewRows([]string{"taken"}).AddRow(false))
+		expectSnapshots(mock, 3)
+		mock.ExpectExec(queryInsert).
+			WithArgs(int64(1), int64(11), int64(3), int64(6), int64(1), int64(2), int64(4), domain.StatusInTransit, actor.System, at).
+			WillReturnResult(sqlmock.NewResult(9, 1))
+		expectTargetSection(mock, 6, 0)
+		mock.ExpectExec(queryAddToSectionCapacity).WithArgs(int64(-4), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(queryTakeFromBatch).WithArgs(int64(4), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectMovement(mock, 1, 10, -4, 6)
+		expectRecords(mock, 3)
+		mock.ExpectCommit()
+
+		id, err := NewMariaDBRepository(db).Create(
+			context.Background(),
+			domain.TransferRequest{ProductBatchId: 1, ToSectionId: 6, Quantity: 4, BatchNumber: 11},
+			at,
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(9), id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("batch not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockBatch).WithArgs(int64(1)).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err = NewMariaDBRepository(db).Create(context.Background(), domain.TransferRequest{ProductBatchId: 1, ToSectionId: 5}, at)
+
+		assert.ErrorIs(t, err, domain.ErrProductBatchNotFound)
+	})
+
+	t.Run("target section not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockBatch).WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"section_id", "current_quantity", "reserved_quantity", "product_id"}).AddRow(3, 10, 2, 4))
+		mock.ExpectQuery(queryGetSectionWarehouse).WithArgs(int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(1))
+		mock.ExpectQuery(queryGetSectionWarehouse).WithArgs(int64(5)).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err = NewMariaDBRepository(db).Create(context.Background(), domain.TransferRequest{ProductBatchId: 1, ToSectionId: 5}, at)
+
+		assert.ErrorIs(t, err, domain.ErrSectionNotFound)
+	})
+
+	t.Run("insufficient stock", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectSource(mock, 5, 1)
+		mock.ExpectRollback()
+
+		_, err = NewMariaDBRepository(db).Create(
+			context.Background(),
+			domain.TransferRequest{ProductBatchId: 1, ToSectionId: 5, Quantity: 9, BatchNumber: 11},
+			at,
+		)
+
+		assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+	})
+
+	t.Run("batch number taken", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectSource(mock, 5, 1)
+		mock.ExpectQuery(queryBatchNumberTaken).WithArgs(int64(11)).WillReturnRows(sqlmock.NewRows([]string{"taken"}).AddRow(true))
+		mock.ExpectRollback()
+
+		_, err = NewMariaDBRepository(db).Create(
+			context.Background(),
+			domain.TransferRequest{ProductBatchId: 1, ToSectionId: 5, Quantity: 4, BatchNumber: 11},
+			at,
+		)
+
+		assert.ErrorIs(t, err, domain.ErrBatchNumberTaken)
+	})
+
+	t.Run("target section full", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectSource(mock, 5, 1)
+		mock.ExpectQuery(queryBatchNumberTaken).WithArgs(int64(11)).WillReturnRows(sqlmock.NewRows([]string{"taken"}).AddRow(false))
+		expectSnapshots(mock, 3, 5)
+		mock.ExpectExec(queryInsert).WillReturnResult(sqlmock.NewResult(9, 1))
+		expectTargetSection(mock, 5, 18)
+		mock.ExpectRollback()
+
+		_, err = NewMariaDBRepository(db).Create(
+			context.Background(),
+			domain.TransferRequest{ProductBatchId: 1, ToSectionId: 5, Quantity: 4, BatchNumber: 11},
+			at,
+		)
+
+		assert.ErrorIs(t, err, products.ErrSectionCapacityExceeded)
+	})
+
+	t.Run("foreign key failure", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectSource(mock, 5, 1)
+		mock.ExpectQuery(queryBatchNumberTaken).WithArgs(int64(11)).WillReturnRows(sqlmock.NewRows([]string{"taken"}).AddRow(false))
+		expectSnapshots(mock, 3, 5)
+		mock.ExpectExec(queryInsert).
+			WillReturnError(&mysql.MySQLError{Number: 1452, Message: "foreign key constraint fails"})
+		mock.ExpectRollback()
+
+		_, err = NewMariaDBRepository(db).Create(
+			context.Background(),
+			domain.TransferRequest{ProductBatchId: 1, ToSectionId: 5, Quantity: 4, BatchNumber: 11},
+			at,
+		)
+
+		assert.ErrorIs(t, err, apperrors.ErrForeignKey)
+	})
+}
+
+func TestReceive(t *testing.T) {
+	rowsLockTransfer := []string{"product_batch_id", "to_section_id", "quantity", "batch_number", "status"}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockTransfer).WithArgs(int64(9)).
+			WillReturnRows(sqlmock.NewRows(rowsLockTransfer).AddRow(1, 6, 4, 11, domain.StatusInTransit))
+		audittest.ExpectSnapshot(mock, "stock_transfers", 9)
+		audittest.ExpectSnapshot(mock, "sections", 6)
+		mock.ExpectQuery(queryGetProductId).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"product_id"}).AddRow(4))
+		expectTargetSection(mock, 6, 0)
+		mock.ExpectExec(queryAddToSectionCapacity).WithArgs(int64(4), int64(6)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(querySplitBatch).WithArgs(int64(11), int64(4), int64(4), int64(6), int64(1)).WillReturnResult(sqlmock.NewResult(2, 1))
+		expectMovement(mock, 2, -1, 4, 4)
+		audittest.ExpectRecord(mock, audit.ActionCreate, "product_batches", 2)
+		mock.ExpectExec(queryComplete).WithArgs(domain.StatusReceived, int64(2), at, int64(9)).WillReturnResult(sqlmock.NewResult(0, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "stock_transfers", 9)
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "sections", 6)
+		mock.ExpectCommit()
+
+		err = NewMariaDBRepository(db).Receive(context.Background(), 9, at)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockTransfer).WithArgs(int64(9)).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		err = NewMariaDBRepository(db).Receive(context.Background(), 9, at)
+
+		assert.ErrorIs(t, err, domain.ErrTransferNotFound)
+	})
+
+	t.Run("already received", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryLockTransfer).WithArgs(int64(9)).
+			WillReturnRows(sqlmock.NewRows(rowsLockTransfer).AddRow(1, 6, 4, 11, domain.StatusReceived))
+		mock.ExpectRollback()
+
+		err = NewMariaDBRepository(db).Receive(context.Background(), 9, at)
+
+		assert.ErrorIs(t, err, domain.ErrNotInTransit)
+	})
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var now = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newService(repositoryMock *mocks.TransferRepository) transferService {
+	return transferService{repository: repositoryMock, now: func() time.Time { return now }}
+}
+
+func TestGetAll(t *testing.T) {
+	repositoryMock := mocks.NewTransferRepository(t)
+	params := listing.Params{Limit: listing.DefaultLimit}
+	repositoryMock.On("GetAll", mock.Anything, params).Return(&[]domain.Transfer{{Id: 1}}, int64(1), nil).Once()
+
+	transfers, total, err := NewTransferService(repositoryMock).GetAll(context.Background(), params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, *transfers, 1)
+}
+
+func TestGetById(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		repositoryMock := mocks.NewTransferRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(1)).Return(&domain.Transfer{Id: 1}, nil).Once()
+
+		transfer, err := newService(repositoryMock).GetById(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), transfer.Id)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repositoryMock := mocks.NewTransferRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(1)).Return(nil, nil).Once()
+
+		_, err := newService(repositoryMock).GetById(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domain.ErrTransferNotFound)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	request := domain.TransferRequest{ProductBatchId: 1, ToSectionId: 5}
+
+	t.Run("success", func(t *testing.T) {
+		repositoryMock := mocks.NewTransferRepository(t)
+		repositoryMock.On("Create", mock.Anything, request, now).Return(int64(9), nil).Once()
+		repositoryMock.On("GetById", mock.Anything, int64(9)).
+			Return(&domain.Transfer{Id: 9, Status: domain.StatusCompleted}, nil).Once()
+
+		transfer, err := newService(repositoryMock).Create(context.Background(), request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusCompleted, transfer.Status)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		repositoryMock := mocks.NewTransferRepository(t)
+		repositoryMock.On("Create", mock.Anything, request, now).Return(int64(0), domain.ErrInsufficientStock).Once()
+
+		_, err := newService(repositoryMock).Create(context.Background(), request)
+
+		assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+	})
+}
+
+func TestReceive(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repositoryMock := mocks.NewTransferRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(9)).
+			Return(&domain.Transfer{Id: 9, Status: domain.StatusInTransit}, nil).Once()
+		repositoryMock.On("Receive", mock.Anything, int64(9), now).Return(nil).Once()
+		repositoryMock.On("GetById", mock.Anything, int64(9)).
+			Return(&domain.Transfer{Id: 9, Status: domain.StatusReceived}, nil).Once()
+
+		transfer, err := newService(repositoryMock).Receive(context.Background(), 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusReceived, transfer.Status)
+	})
+
+	t.Run("not in transit", func(t *testing.T) {
+		repositoryMock := mocks.NewTransferRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(9)).
+			Return(&domain.Transfer{Id: 9, Status: domain.StatusCompleted}, nil).Once()
+
+		_, err := newService(repositoryMock).Receive(context.Background(), 9)
+
+		assert.ErrorIs(t, err, domain.ErrNotInTransit)
+	})
+
+	t.Run("fail to receive", func(t *testing.T) {
+		repositoryMock := mocks.NewTransferRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(9)).
+			Return(&domain.Transfer{Id: 9, Status: domain.StatusInTransit}, nil).Once()
+		repositoryMock.On("Receive", mock.Anything, int64(9), now).Return(errors.New("connection refused")).Once()
+
+		_, err := newService(repositoryMock).Receive(context.Background(), 9)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWarehouseScope(t *testing.T) {
+	warehouseId := int64(2)
+	ctx := auth.NewContext(context.Background(), auth.Claims{Roles: []string{policy.Employee}, WarehouseId: &warehouseId})
+
+	t.Run("transfer from another warehouse", func(t *testing.T) {
+		repositoryMock := mocks.NewTransferRepository(t)
+		repositoryMock.On("GetBatchWarehouseId", mock.Anything, int64(1)).Return(int64(5), nil).Once()
+
+		_, err := newService(repositoryMock).Create(ctx, domain.TransferRequest{ProductBatchId: 1, ToSectionId: 5})
+
+		assert.ErrorIs(t, err, policy.ErrOtherWarehouse)
+	})
+
+	t.Run("transfer from own warehouse", func(t *testing.T) {
+		request := domain.TransferRequest{ProductBatchId: 1, ToSectionId: 5}
+		repositoryMock := mocks.NewTransferRepository(t)
+		repositoryMock.On("GetBatchWarehouseId", mock.Anything, int64(1)).Return(int64(2), nil).Once()
+		repositoryMock.On("Create", mock.Anything, request, now).Return(int64(9), nil).Once()
+		repositoryMock.On("GetById", mock.Anything, int64(9)).Return(&domain.Transfer{Id: 9}, nil).Once()
+
+		_, err := newService(repositoryMock).Create(ctx, request)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("receive for another warehouse", func(t *testing.T) {
+		repositoryMock := mocks.NewTransferRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(9)).
+			Return(&domain.Transfer{Id: 9, FromWarehouseId: 2, ToWarehouseId: 5, Status: domain.StatusInTransit}, nil).Once()
+
+		_, err := newService(repositoryMock).Receive(ctx, 9)
+
+		assert.ErrorIs(t, err, policy.ErrOtherWarehouse)
+	})
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/transfers/domain"
+)
+
+type transferService struct {
+	repository domain.TransferRepository
+	now        func() time.Time
+}
+
+func NewTransferService(r domain.TransferRepository) domain.TransferService {
+	return &transferService{repository: r, now: time.Now}
+}
+
+func (s transferService) GetAll(ctx context.Context, params listing.Params) (*[]domain.Transfer, int64, error) {
+	return s.repository.GetAll(ctx, params)
+}
+
+func (s transferService) GetById(ctx context.Context, id int64) (*domain.Transfer, error) {
+	transfer, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer == nil {
+		return nil, domain.ErrTransferNotFound
+	}
+
+	return transfer, nil
+}
+
+// Create moves stock out of a batch, which employees may only do from their
+// own warehouse.
+func (s transferService) Create(ctx context.Context, request domain.TransferRequest) (*domain.Transfer, error) {
+	if !policy.Unrestricted(ctx) {
+		warehouseId, err := s.repository.GetBatchWarehouseId(ctx, request.ProductBatchId)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := policy.OwnWarehouse(ctx, warehouseId); err != nil {
+			return nil, err
+		}
+	}
+
+	id, err := s.repository.Create(ctx, request, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetById(ctx, id)
+}
+
+// Receive completes a transfer between warehouses once the stock arrives,
+// which employees may only do for their own warehouse.
+func (s transferService) Receive(ctx context.Context, id int64) (*domain.Transfer, error) {
+	transfer, err := s.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := policy.OwnWarehouse(ctx, transfer.ToWarehouseId); err != nil {
+		return nil, err
+	}
+
+	if transfer.Status != domain.StatusInTransit {
+		return nil, domain.ErrNotInTransit
+	}
+
+	if err := s.repository.Receive(ctx, id, s.now()); err != nil {
+		return nil, err
+	}
+
+	return s.GetById(ctx, id)
+}
@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/domain"
+)
+
+type RoleController struct {
+	service domain.RoleService
+}
+
+func NewRoleController(service domain.RoleService) (*RoleController, error) {
+	if service == nil {
+		return nil, errors.New("invalid service")
+	}
+
+	return &RoleController{
+		service: service,
+	}, nil
+}
+
+// @Summary List roles
+// @Tags Roles
+// @Description get all roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} schemas.JSONSuccessResult{data=[]domain.Role}
+// @Failure 401 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /roles [get]
+func (c RoleController) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		roles, err := c.service.GetAll(ctx.Request.Context())
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": roles})
+	}
+}
+
+// @Summary Role by id
+// @Tags Roles
+// @Description get a role by its id
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Role ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Role}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /roles/{id} [get]
+func (c RoleController) GetById() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		role, err := c.service.GetById(ctx.Request.Context(), req.Id)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": role})
+	}
+}
+
+// @Summary Create role
+// @Tags Roles
+// @Description Create a new role
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param role body domain.RequestRole true "Role to create"
+// @Success 201 {object} schemas.JSONSuccessResult{data=domain.Role}
+// @Failure 401 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /roles [post]
+func (c RoleController) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestRole
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		role, err := c.service.Create(ctx.Request.Context(), req)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, gin.H{"data": role})
+	}
+}
+
+// @Summary Update role
+// @Tags Roles
+// @Description Update the name and description of a role
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Role ID"
+// @Param role body domain.RequestRole true "Role to update"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Role}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /roles/{id} [patch]
+func (c RoleController) Update() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var reqId domain.RequestId
+		if err := ctx.ShouldBindUri(&reqId); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		var req domain.RequestRole
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		role, err := c.service.Update(ctx.Request.Context(), reqId.Id, req)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": role})
+	}
+}
+
+// @Summary Delete role
+// @Tags Roles
+// @Description Delete a role no user has
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Role ID"
+// @Success 204
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /roles/{id} [delete]
+func (c RoleController) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		if err := c.service.Delete(ctx.Request.Context(), req.Id); err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusNoContent, nil)
+	}
+}
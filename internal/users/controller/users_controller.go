@@ -0,0 +1,261 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/domain"
+)
+
+type UserController struct {
+	service domain.UserService
+}
+
+func NewUserController(service domain.UserService) (*UserController, error) {
+	if service == nil {
+		return nil, errors.New("invalid service")
+	}
+
+	return &UserController{
+		service: service,
+	}, nil
+}
+
+// @Summary Log in
+// @Tags Auth
+// @Description Check the username and password of a user and issue a token to send as "Authorization: Bearer <token>"
+// @Accept json
+// @Produce json
+// @Param credentials body domain.RequestLogin true "Username and password"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Token}
+// @Failure 401 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /auth/login [post]
+func (c UserController) Login() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestLogin
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		token, err := c.service.Login(ctx.Request.Context(), req.Username, req.Password)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": token})
+	}
+}
+
+// @Summary List users
+// @Tags Users
+// @Description get all users with their roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.User}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /users [get]
+func (c UserController) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.UserListFields)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		users, total, err := c.service.GetAll(ctx.Request.Context(), params)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": users,
+			"meta": listing.NewMeta(params, total),
+		})
+	}
+}
+
+// @Summary User by id
+// @Tags Users
+// @Description get a user and its roles by its id
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.User}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /users/{id} [get]
+func (c UserController) GetById() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		user, err := c.service.GetById(ctx.Request.Context(), req.Id)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": user})
+	}
+}
+
+// @Summary Create user
+// @Tags Users
+// @Description Create a user with a bcrypt hashed password and the given roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user body domain.RequestCreateUser true "User to create"
+// @Success 201 {object} schemas.JSONSuccessResult{data=domain.User}
+// @Failure 401 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /users [post]
+func (c UserController) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestCreateUser
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		user, err := c.service.Create(ctx.Request.Context(), req)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, gin.H{"data": user})
+	}
+}
+
+// @Summary Update user
+// @Tags Users
+// @Description Change the username or the password of a user
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param user body domain.RequestUpdateUser true "Fields to update"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.User}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /users/{id} [patch]
+func (c UserController) Update() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var reqId domain.RequestId
+		if err := ctx.ShouldBindUri(&reqId); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		var req domain.RequestUpdateUser
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		user, err := c.service.Update(ctx.Request.Context(), reqId.Id, req)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": user})
+	}
+}
+
+// @Summary Set user roles
+// @Tags Users
+// @Description Replace the roles of a user
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param roles body domain.RequestUserRoles true "Ids of the roles of the user"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.User}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /users/{id}/roles [put]
+func (c UserController) SetRoles() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var reqId domain.RequestId
+		if err := ctx.ShouldBindUri(&reqId); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		var req domain.RequestUserRoles
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		user, err := c.service.SetRoles(ctx.Request.Context(), reqId.Id, req.RoleIds)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": user})
+	}
+}
+
+// @Summary Delete user
+// @Tags Users
+// @Description Delete a user and its roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 204
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /users/{id} [delete]
+func (c UserController) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		if err := c.service.Delete(ctx.Request.Context(), req.Id); err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusNoContent, nil)
+	}
+}
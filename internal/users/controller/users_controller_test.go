@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func serve(userService *mocks.UserService, roleService *mocks.RoleService, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	_, engine := gin.CreateTestContext(rec)
+
+	userController := UserController{service: userService}
+	roleController := RoleController{service: roleService}
+
+	engine.POST("/api/v1/auth/login", userController.Login())
+	engine.GET("/api/v1/users", userController.GetAll())
+	engine.GET("/api/v1/users/:id", userController.GetById())
+	engine.POST("/api/v1/users", userController.Create())
+	engine.PATCH("/api/v1/users/:id", userController.Update())
+	engine.PUT("/api/v1/users/:id/roles", userController.SetRoles())
+	engine.DELETE("/api/v1/users/:id", userController.Delete())
+	engine.POST("/api/v1/roles", roleController.Create())
+	engine.DELETE("/api/v1/roles/:id", roleController.Delete())
+
+	engine.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestLogin(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewUserService(t)
+		serviceMock.On("Login", mock.Anything, "ana", "s3cret-pass").
+			Return(&domain.Token{AccessToken: "token", TokenType: "Bearer", ExpiresIn: 3600}, nil).Once()
+
+		rec := serve(serviceMock, nil, http.MethodPost, "/api/v1/auth/login", `{"username": "ana", "password": "s3cret-pass"}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"data": {"access_token": "token", "token_type": "Bearer", "expires_in": 3600}}`, rec.Body.String())
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		serviceMock := mocks.NewUserService(t)
+		serviceMock.On("Login", mock.Anything, "ana", "wrong").Return(nil, domain.ErrInvalidCredentials).Once()
+
+		rec := serve(serviceMock, nil, http.MethodPost, "/api/v1/auth/login", `{"username": "ana", "password": "wrong"}`)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.JSONEq(t, `{
+			"type": "/problems/unauthorized",
+			"title": "Unauthorized",
+			"status": 401,
+			"detail": "invalid username or password"
+		}`, rec.Body.String())
+	})
+
+	t.Run("missing password", func(t *testing.T) {
+		serviceMock := mocks.NewUserService(t)
+
+		rec := serve(serviceMock, nil, http.MethodPost, "/api/v1/auth/login", `{"username": "ana"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewUserService(t)
+		serviceMock.On("GetById", mock.Anything, int64(1)).
+			Return(&domain.User{Id: 1, Username: "ana", Password: "hash", Roles: []domain.Role{}}, nil).Once()
+
+		rec := serve(serviceMock, nil, http.MethodGet, "/api/v1/users/1", "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"data": {"id": 1, "username": "ana", "warehouse_id": null, "seller_id": null, "roles": []}}`, rec.Body.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		serviceMock := mocks.NewUserService(t)
+		serviceMock.On("GetById", mock.Anything, int64(1)).Return(nil, domain.ErrUserNotFound).Once()
+
+		rec := serve(serviceMock, nil, http.MethodGet, "/api/v1/users/1", "")
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		serviceMock := mocks.NewUserService(t)
+		serviceMock.On("Create", mock.Anything, domain.RequestCreateUser{Username: "ana", Password: "s3cret-pass", RoleIds: []int64{1}}).
+			Return(&domain.User{Id: 4, Username: "ana"}, nil).Once()
+
+		rec := serve(serviceMock, nil, http.MethodPost, "/api/v1/users", `{"username": "ana", "password": "s3cret-pass", "role_ids": [1]}`)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("short password", func(t *testing.T) {
+		serviceMock := mocks.NewUserService(t)
+
+		rec := serve(serviceMock, nil, http.MethodPost, "/api/v1/users", `{"username": "ana", "password": "short"}`)
+
+		var p problem.Problem
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, []problem.FieldError{{Field: "password", Rule: "min", Param: "8", Message: "password must be at least 8"}}, p.Errors)
+	})
+
+	t.Run("duplicated username", func(t *testing.T) {
+		serviceMock := mocks.NewUserService(t)
+		serviceMock.On("Create", mock.Anything, mock.Anything).Return(nil, apperrors.Conflict(`username "ana" is already in use`)).Once()
+
+		rec := serve(serviceMock, nil, http.MethodPost, "/api/v1/users", `{"username": "ana", "password": "s3cret-pass"}`)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	password := "n3w-password"
+	serviceMock := mocks.NewUserService(t)
+	serviceMock.On("Update", mock.Anything, int64(4), domain.RequestUpdateUser{Password: &password}).
+		Return(&domain.User{Id: 4, Username: "ana"}, nil).Once()
+
+	rec := serve(serviceMock, nil, http.MethodPatch, "/api/v1/users/4", `{"password": "n3w-password"}`)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestSetRoles(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewUserService(t)
+		serviceMock.On("SetRoles", mock.Anything, int64(4), []int64{1, 2}).Return(&domain.User{Id: 4}, nil).Once()
+
+		rec := serve(serviceMock, nil, http.MethodPut, "/api/v1/users/4/roles", `{"role_ids": [1, 2]}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		serviceMock := mocks.NewUserService(t)
+		serviceMock.On("SetRoles", mock.Anything, int64(4), []int64{7}).
+			Return(nil, &apperrors.Error{Kind: apperrors.ErrForeignKey, Entity: "rol", Field: "rol_id", Message: "rol_id: no rol with that id"}).Once()
+
+		rec := serve(serviceMock, nil, http.MethodPut, "/api/v1/users/4/roles", `{"role_ids": [7]}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("no content", func(t *testing.T) {
+		serviceMock := mocks.NewUserService(t)
+		serviceMock.On("Delete", mock.Anything, int64(4)).Return(nil).Once()
+
+		rec := serve(serviceMock, nil, http.MethodDelete, "/api/v1/users/4", "")
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		serviceMock := mocks.NewUserService(t)
+
+		rec := serve(serviceMock, nil, http.MethodDelete, "/api/v1/users/abc", "")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestRoles(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		serviceMock := mocks.NewRoleService(t)
+		serviceMock.On("Create", mock.Anything, domain.RequestRole{Name: "seller", Description: "Edits its own products"}).
+			Return(&domain.Role{Id: 2, Name: "seller", Description: "Edits its own products"}, nil).Once()
+
+		rec := serve(nil, serviceMock, http.MethodPost, "/api/v1/roles", `{"name": "seller", "description": "Edits its own products"}`)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"data": {"id": 2, "name": "seller", "description": "Edits its own products"}}`, rec.Body.String())
+	})
+
+	t.Run("delete assigned role", func(t *testing.T) {
+		serviceMock := mocks.NewRoleService(t)
+		serviceMock.On("Delete", mock.Anything, int64(1)).Return(apperrors.Conflict("still referenced by users_rol through rol_id")).Once()
+
+		rec := serve(nil, serviceMock, http.MethodDelete, "/api/v1/roles/1", "")
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
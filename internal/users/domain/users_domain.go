@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
+
+// User is someone who can log in. WarehouseId and SellerId tie the user to
+// the warehouse or the seller it works for, limiting what it can change.
+type User struct {
+	Id       int64  `json:"id"`
+	Username string `json:"username"`
+	// Password is the bcrypt hash of the password of the user.
+	Password    string `json:"-"`
+	WarehouseId *int64 `json:"warehouse_id"`
+	SellerId    *int64 `json:"seller_id"`
+	Roles       []Role `json:"roles"`
+}
+
+// RoleNames lists the names of the roles of the user.
+func (u User) RoleNames() []string {
+	names := make([]string, 0, len(u.Roles))
+	for _, role := range u.Roles {
+		names = append(names, role.Name)
+	}
+	return names
+}
+
+type Role struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+var UserListFields = listing.Fields{
+	Sort: []string{"id", "username"},
+}
+
+type RequestLogin struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// Token is answered to a successful login. ExpiresIn is in seconds.
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type" example:"Bearer"`
+	ExpiresIn   int64  `json:"expires_in" example:"3600"`
+}
+
+// Passwords are limited to 72 bytes, the most bcrypt uses.
+type RequestCreateUser struct {
+	Username    string  `json:"username" binding:"required,max=255"`
+	Password    string  `json:"password" binding:"required,min=8,max=72"`
+	WarehouseId *int64  `json:"warehouse_id" binding:"omitempty,min=1"`
+	SellerId    *int64  `json:"seller_id" binding:"omitempty,min=1"`
+	RoleIds     []int64 `json:"role_ids" binding:"dive,min=1"`
+}
+
+type RequestUpdateUser struct {
+	Username    *string `json:"username" binding:"omitempty,min=1,max=255"`
+	Password    *string `json:"password" binding:"omitempty,min=8,max=72"`
+	WarehouseId *int64  `json:"warehouse_id" binding:"omitempty,min=1"`
+	SellerId    *int64  `json:"seller_id" binding:"omitempty,min=1"`
+}
+
+type RequestUserRoles struct {
+	RoleIds []int64 `json:"role_ids" binding:"required,dive,min=1"`
+}
+
+type RequestRole struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description" binding:"required,max=255"`
+}
+
+type RequestId struct {
+	Id int64 `uri:"id" binding:"required,min=1"`
+}
+
+type UserRepository interface {
+	GetAll(ctx context.Context, params listing.Params) (*[]User, int64, error)
+	GetById(ctx context.Context, id int64) (*User, error)
+	GetByUsername(ctx context.Context, username string) (*User, error)
+	Count(ctx context.Context) (int64, error)
+	Create(ctx context.Context, user *User) (int64, error)
+	Update(ctx context.Context, user *User) error
+	SetRoles(ctx context.Context, id int64, roleIds []int64) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type RoleRepository interface {
+	GetAll(ctx context.Context) (*[]Role, error)
+	GetById(ctx context.Context, id int64) (*Role, error)
+	GetByName(ctx context.Context, name string) (*Role, error)
+	Create(ctx context.Context, role *Role) (int64, error)
+	Update(ctx context.Context, role *Role) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type UserService interface {
+	GetAll(ctx context.Context, params listing.Params) (*[]User, int64, error)
+	GetById(ctx context.Context, id int64) (*User, error)
+	Create(ctx context.Context, request RequestCreateUser) (*User, error)
+	Update(ctx context.Context, id int64, request RequestUpdateUser) (*User, error)
+	SetRoles(ctx context.Context, id int64, roleIds []int64) (*User, error)
+	Delete(ctx context.Context, id int64) error
+	Login(ctx context.Context, username, password string) (*Token, error)
+	Bootstrap(ctx context.Context, username, password string) error
+}
+
+type RoleService interface {
+	GetAll(ctx context.Context) (*[]Role, error)
+	GetById(ctx context.Context, id int64) (*Role, error)
+	Create(ctx context.Context, request RequestRole) (*Role, error)
+	Update(ctx context.Context, id int64, request RequestRole) (*Role, error)
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+
+var (
+	ErrUserNotFound = apperrors.NotFound("user not found")
+	ErrRoleNotFound = apperrors.NotFound("role not found")
+	// ErrInvalidCredentials does not tell whether the username exists.
+	ErrInvalidCredentials = apperrors.Unauthorized("invalid username or password")
+)
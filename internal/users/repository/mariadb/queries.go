@@ -0,0 +1,21 @@
+package mariadb
+
+const (
+	sqlGetAll          = "SELECT id, username, password, warehouse_id, seller_id FROM users"
+	sqlGetById         = "SELECT id, username, password, warehouse_id, seller_id FROM users WHERE id = ?;"
+	sqlGetByUsername   = "SELECT id, username, password, warehouse_id, seller_id FROM users WHERE username = ?;"
+	sqlCount           = "SELECT COUNT(*) FROM users;"
+	sqlInsert          = "INSERT INTO users (username, password, warehouse_id, seller_id) VALUES (?, ?, ?, ?);"
+	sqlUpdate          = "UPDATE users SET username = ?, password = ?, warehouse_id = ?, seller_id = ? WHERE id = ?;"
+	sqlDelete          = "DELETE FROM users WHERE id = ?;"
+	sqlGetRolesOfUser  = "SELECT r.id, r.rol_name, r.description FROM users_rol ur JOIN rol r ON r.id = ur.rol_id WHERE ur.usuario_id = ? ORDER BY r.id;"
+	sqlInsertUserRole  = "INSERT INTO users_rol (usuario_id, rol_id) VALUES (?, ?);"
+	sqlDeleteUserRoles = "DELETE FROM users_rol WHERE usuario_id = ?;"
+
+	sqlGetAllRoles   = "SELECT id, rol_name, description FROM rol ORDER BY id;"
+	sqlGetRoleById   = "SELECT id, rol_name, description FROM rol WHERE id = ?;"
+	sqlGetRoleByName = "SELECT id, rol_name, description FROM rol WHERE rol_name = ?;"
+	sqlInsertRole    = "INSERT INTO rol (rol_name, description) VALUES (?, ?);"
+	sqlUpdateRole    = "UPDATE rol SET rol_name = ?, description = ? WHERE id = ?;"
+	sqlDeleteRole    = "DELETE FROM rol WHERE id = ?;"
+)
@@ -0,0 +1,96 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/domain"
+)
+
+type rolesRepository struct {
+	db *sql.DB
+}
+
+func NewRolesRepository(db *sql.DB) domain.RoleRepository {
+	return rolesRepository{db: db}
+}
+
+func (m rolesRepository) GetAll(ctx context.Context) (*[]domain.Role, error) {
+	roles := []domain.Role{}
+
+	rows, err := m.db.QueryContext(ctx, sqlGetAllRoles)
+	if err != nil {
+		return &roles, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var role domain.Role
+
+		if err := rows.Scan(&role.Id, &role.Name, &role.Description); err != nil {
+			return &roles, err
+		}
+
+		roles = append(roles, role)
+	}
+
+	return &roles, rows.Err()
+}
+
+func (m rolesRepository) GetById(ctx context.Context, id int64) (*domain.Role, error) {
+	return m.getOne(ctx, sqlGetRoleById, id)
+}
+
+func (m rolesRepository) GetByName(ctx context.Context, name string) (*domain.Role, error) {
+	return m.getOne(ctx, sqlGetRoleByName, name)
+}
+
+func (m rolesRepository) getOne(ctx context.Context, query string, arg interface{}) (*domain.Role, error) {
+	var role domain.Role
+
+	err := m.db.QueryRowContext(ctx, query, arg).Scan(&role.Id, &role.Name, &role.Description)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (m rolesRepository) Create(ctx context.Context, role *domain.Role) (int64, error) {
+	result, err := m.db.ExecContext(ctx, sqlInsertRole, role.Name, role.Description)
+	if err != nil {
+		return 0, apperrors.FromMySQL(err)
+	}
+
+	return result.LastInsertId()
+}
+
+func (m rolesRepository) Update(ctx context.Context, role *domain.Role) error {
+	_, err := m.db.ExecContext(ctx, sqlUpdateRole, role.Name, role.Description, role.Id)
+	return apperrors.FromMySQL(err)
+}
+
+func (m rolesRepository) Delete(ctx context.Context, id int64) error {
+	result, err := m.db.ExecContext(ctx, sqlDeleteRole, id)
+	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrRoleNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/domain"
+)
+
+type mariadbRepository struct {
+	db *sql.DB
+}
+
+func NewMariaDBRepository(db *sql.DB) domain.UserRepository {
+	return mariadbRepository{db: db}
+}
+
+func (m mariadbRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.User, int64, error) {
+	users := []domain.User{}
+
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAll, params, nil)
+	if err := m.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &users, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAll, params, nil)
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return &users, 0, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var user domain.User
+
+		if err := rows.Scan(&user.Id, &user.Username, &user.Password, &user.WarehouseId, &user.SellerId); err != nil {
+			return &users, 0, err
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return &users, 0, err
+	}
+
+	for i := range users {
+		if users[i].Roles, err = m.rolesOf(ctx, users[i].Id); err != nil {
+			return &users, 0, err
+		}
+	}
+
+	return &users, total, nil
+}
+
+func (m mariadbRepository) GetById(ctx context.Context, id int64) (*domain.User, error) {
+	return m.getOne(ctx, sqlGetById, id)
+}
+
+func (m mariadbRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return m.getOne(ctx, sqlGetByUsername, username)
+}
+
+// getOne returns the user selected by query with its roles, or nil when
+// there is none.
+func (m mariadbRepository) getOne(ctx context.Context, query string, arg interface{}) (*domain.User, error) {
+	var user domain.User
+
+	err := m.db.QueryRowContext(ctx, query, arg).Scan(&user.Id, &user.Username, &user.Password, &user.WarehouseId, &user.SellerId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Roles, err = m.rolesOf(ctx, user.Id); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (m mariadbRepository) rolesOf(ctx context.Context, userId int64) ([]domain.Role, error) {
+	roles := []domain.Role{}
+
+	rows, err := m.db.QueryContext(ctx, sqlGetRolesOfUser, userId)
+	if err != nil {
+		return roles, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var role domain.Role
+
+		if err := rows.Scan(&role.Id, &role.Name, &role.Description); err != nil {
+			return roles, err
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+func (m mariadbRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := m.db.QueryRowContext(ctx, sqlCount).Scan(&count)
+	return count, err
+}
+
+// Create inserts the user together with its roles.
+func (m mariadbRepository) Create(ctx context.Context, user *domain.User) (int64, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, sqlInsert, user.Username, user.Password, user.WarehouseId, user.SellerId)
+	if err != nil {
+		return 0, apperrors.FromMySQL(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := insertRoles(ctx, tx, id, roleIds(user.Roles)); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (m mariadbRepository) Update(ctx context.Context, user *domain.User) error {
+	_, err := m.db.ExecContext(ctx, sqlUpdate, user.Username, user.Password, user.WarehouseId, user.SellerId, user.Id)
+	return apperrors.FromMySQL(err)
+}
+
+// SetRoles replaces the roles of the user with the ones of roleIds.
+func (m mariadbRepository) SetRoles(ctx context.Context, id int64, roleIds []int64) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, sqlDeleteUserRoles, id); err != nil {
+		return err
+	}
+
+	if err := insertRoles(ctx, tx, id, roleIds); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertRoles(ctx context.Context, tx *sql.Tx, userId int64, roleIds []int64) error {
+	for _, roleId := range roleIds {
+		if _, err := tx.ExecContext(ctx, sqlInsertUserRole, userId, roleId); err != nil {
+			return apperrors.FromMySQL(err)
+		}
+	}
+	return nil
+}
+
+func roleIds(roles []domain.Role) []int64 {
+	ids := make([]int64, 0, len(roles))
+	for _, role := range roles {
+		ids = append(ids, role.Id)
+	}
+	return ids
+}
+
+// Delete removes the user, whose roles go with it.
+func (m mariadbRepository) Delete(ctx context.Context, id int64) error {
+	result, err := m.db.ExecContext(ctx, sqlDelete, id)
+	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
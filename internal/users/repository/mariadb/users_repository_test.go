@@ -0,0 +1,235 @@
+package mariadb
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	queryGetAll          = regexp.QuoteMeta(sqlGetAll)
+	queryCountAll        = regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)
+	queryGetById         = regexp.QuoteMeta(sqlGetById)
+	queryGetByUsername   = regexp.QuoteMeta(sqlGetByUsername)
+	queryInsert          = regexp.QuoteMeta(sqlInsert)
+	queryDelete          = regexp.QuoteMeta(sqlDelete)
+	queryGetRolesOfUser  = regexp.QuoteMeta(sqlGetRolesOfUser)
+	queryInsertUserRole  = regexp.QuoteMeta(sqlInsertUserRole)
+	queryDeleteUserRoles = regexp.QuoteMeta(sqlDeleteUserRoles)
+	queryInsertRole      = regexp.QuoteMeta(sqlInsertRole)
+	queryDeleteRole      = regexp.QuoteMeta(sqlDeleteRole)
+)
+
+var (
+	rowsUserStruct = []string{"id", "username", "password", "warehouse_id", "seller_id"}
+	rowsRoleStruct = []string{"id", "rol_name", "description"}
+)
+
+var adminRole = domain.Role{Id: 1, Name: "admin", Description: "Manages users"}
+
+func TestGetAll(t *testing.T) {
+	warehouseId, sellerId := int64(3), int64(5)
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(queryCountAll).WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(2))
+	mock.ExpectQuery(queryGetAll).WillReturnRows(sqlmock.NewRows(rowsUserStruct).
+		AddRow(1, "ana", "hash-1", 3, nil).
+		AddRow(2, "bob", "hash-2", nil, 5))
+	mock.ExpectQuery(queryGetRolesOfUser).WithArgs(1).WillReturnRows(sqlmock.NewRows(rowsRoleStruct).AddRow(1, "admin", "Manages users"))
+	mock.ExpectQuery(queryGetRolesOfUser).WithArgs(2).WillReturnRows(sqlmock.NewRows(rowsRoleStruct))
+
+	users, total, err := NewMariaDBRepository(db).GetAll(context.Background(), listing.Params{Limit: listing.DefaultLimit})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, []domain.User{
+		{Id: 1, Username: "ana", Password: "hash-1", WarehouseId: &warehouseId, Roles: []domain.Role{adminRole}},
+		{Id: 2, Username: "bob", Password: "hash-2", SellerId: &sellerId, Roles: []domain.Role{}},
+	}, *users)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByUsername(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetByUsername).WithArgs("ana").WillReturnRows(sqlmock.NewRows(rowsUserStruct).AddRow(1, "ana", "hash-1", nil, nil))
+		mock.ExpectQuery(queryGetRolesOfUser).WithArgs(1).WillReturnRows(sqlmock.NewRows(rowsRoleStruct).AddRow(1, "admin", "Manages users"))
+
+		user, err := NewMariaDBRepository(db).GetByUsername(context.Background(), "ana")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.User{Id: 1, Username: "ana", Password: "hash-1", Roles: []domain.Role{adminRole}}, user)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetById).WithArgs(9).WillReturnRows(sqlmock.NewRows(rowsUserStruct))
+
+		user, err := NewMariaDBRepository(db).GetById(context.Background(), 9)
+
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsert).WithArgs("ana", "hash-1", nil, nil).WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectExec(queryInsertUserRole).WithArgs(4, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		id, err := NewMariaDBRepository(db).Create(context.Background(), &domain.User{
+			Username: "ana",
+			Password: "hash-1",
+			Roles:    []domain.Role{{Id: 1}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("duplicated username", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsert).WithArgs("ana", "hash-1", nil, nil).WillReturnError(&mysql.MySQLError{
+			Number:  1062,
+			Message: "Duplicate entry 'ana' for key 'username'",
+		})
+		mock.ExpectRollback()
+
+		_, err = NewMariaDBRepository(db).Create(context.Background(), &domain.User{Username: "ana", Password: "hash-1"})
+
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(queryInsert).WithArgs("ana", "hash-1", nil, nil).WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectExec(queryInsertUserRole).WithArgs(4, 7).WillReturnError(&mysql.MySQLError{
+			Number:  1452,
+			Message: "Cannot add or update a child row: a foreign key constraint fails (`mercado_fresco`.`users_rol`, CONSTRAINT `users_rol_ibfk_2` FOREIGN KEY (`rol_id`) REFERENCES `rol` (`id`))",
+		})
+		mock.ExpectRollback()
+
+		_, err = NewMariaDBRepository(db).Create(context.Background(), &domain.User{
+			Username: "ana",
+			Password: "hash-1",
+			Roles:    []domain.Role{{Id: 7}},
+		})
+
+		assert.ErrorIs(t, err, apperrors.ErrForeignKey)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSetRoles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(queryDeleteUserRoles).WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(queryInsertUserRole).WithArgs(4, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(queryInsertUserRole).WithArgs(4, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = NewMariaDBRepository(db).SetRoles(context.Background(), 4, []int64{1, 2})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(queryDelete).WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, NewMariaDBRepository(db).Delete(context.Background(), 4))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(queryDelete).WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, NewMariaDBRepository(db).Delete(context.Background(), 4), domain.ErrUserNotFound)
+	})
+}
+
+func TestRoles(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(queryInsertRole).WithArgs("seller", "Edits its own products").WillReturnResult(sqlmock.NewResult(2, 1))
+
+		id, err := NewRolesRepository(db).Create(context.Background(), &domain.Role{Name: "seller", Description: "Edits its own products"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), id)
+	})
+
+	t.Run("delete role still assigned", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(queryDeleteRole).WithArgs(1).WillReturnError(&mysql.MySQLError{
+			Number:  1451,
+			Message: "Cannot delete or update a parent row: a foreign key constraint fails (`mercado_fresco`.`users_rol`, CONSTRAINT `users_rol_ibfk_2` FOREIGN KEY (`rol_id`) REFERENCES `rol` (`id`))",
+		})
+
+		err = NewRolesRepository(db).Delete(context.Background(), 1)
+
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+	})
+
+	t.Run("fail to select roles", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAllRoles)).WillReturnError(errors.New("connection refused"))
+
+		_, err = NewRolesRepository(db).GetAll(context.Background())
+
+		assert.Error(t, err)
+	})
+}
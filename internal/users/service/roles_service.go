@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/domain"
+)
+
+type roleService struct {
+	repository domain.RoleRepository
+}
+
+func NewRoleService(r domain.RoleRepository) domain.RoleService {
+	return &roleService{repository: r}
+}
+
+func (s roleService) GetAll(ctx context.Context) (*[]domain.Role, error) {
+	return s.repository.GetAll(ctx)
+}
+
+func (s roleService) GetById(ctx context.Context, id int64) (*domain.Role, error) {
+	role, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if role == nil {
+		return nil, domain.ErrRoleNotFound
+	}
+
+	return role, nil
+}
+
+func (s roleService) Create(ctx context.Context, request domain.RequestRole) (*domain.Role, error) {
+	role := &domain.Role{Name: request.Name, Description: request.Description}
+
+	id, err := s.repository.Create(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	role.Id = id
+
+	return role, nil
+}
+
+func (s roleService) Update(ctx context.Context, id int64, request domain.RequestRole) (*domain.Role, error) {
+	role, err := s.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	role.Name = request.Name
+	role.Description = request.Description
+
+	if err := s.repository.Update(ctx, role); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// Delete removes a role no user has.
+func (s roleService) Delete(ctx context.Context, id int64) error {
+	return s.repository.Delete(ctx, id)
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var adminRole = domain.Role{Id: 1, Name: policy.Admin, Description: "admin"}
+
+func newTestUserService(users *mocks.UserRepository, roles *mocks.RoleRepository, signer *auth.Signer) userService {
+	return userService{users: users, roles: roles, signer: signer, cost: bcrypt.MinCost}
+}
+
+func hashOf(t *testing.T, password string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	assert.NoError(t, err)
+	return string(hash)
+}
+
+func TestGetById(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		usersMock := mocks.NewUserRepository(t)
+		usersMock.On("GetById", mock.Anything, int64(1)).Return(&domain.User{Id: 1, Username: "ana"}, nil).Once()
+
+		user, err := newTestUserService(usersMock, nil, nil).GetById(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ana", user.Username)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		usersMock := mocks.NewUserRepository(t)
+		usersMock.On("GetById", mock.Anything, int64(1)).Return(nil, nil).Once()
+
+		_, err := newTestUserService(usersMock, nil, nil).GetById(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	usersMock := mocks.NewUserRepository(t)
+	usersMock.On("Create", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+		return user.Username == "ana" &&
+			bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("s3cret-pass")) == nil &&
+			len(user.Roles) == 1 && user.Roles[0].Id == 1
+	})).Return(int64(4), nil).Once()
+	usersMock.On("GetById", mock.Anything, int64(4)).Return(&domain.User{Id: 4, Username: "ana", Roles: []domain.Role{adminRole}}, nil).Once()
+
+	user, err := newTestUserService(usersMock, nil, nil).Create(context.Background(), domain.RequestCreateUser{
+		Username: "ana",
+		Password: "s3cret-pass",
+		RoleIds:  []int64{1},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), user.Id)
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("password", func(t *testing.T) {
+		password := "n3w-password"
+		usersMock := mocks.NewUserRepository(t)
+		usersMock.On("GetById", mock.Anything, int64(4)).Return(&domain.User{Id: 4, Username: "ana", Password: "old"}, nil).Once()
+		usersMock.On("Update", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+			return user.Username == "ana" && bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) == nil
+		})).Return(nil).Once()
+
+		_, err := newTestUserService(usersMock, nil, nil).Update(context.Background(), 4, domain.RequestUpdateUser{Password: &password})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		usersMock := mocks.NewUserRepository(t)
+		usersMock.On("GetById", mock.Anything, int64(4)).Return(nil, nil).Once()
+
+		_, err := newTestUserService(usersMock, nil, nil).Update(context.Background(), 4, domain.RequestUpdateUser{})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestSetRoles(t *testing.T) {
+	usersMock := mocks.NewUserRepository(t)
+	usersMock.On("GetById", mock.Anything, int64(4)).Return(&domain.User{Id: 4}, nil).Once()
+	usersMock.On("SetRoles", mock.Anything, int64(4), []int64{1}).Return(nil).Once()
+	usersMock.On("GetById", mock.Anything, int64(4)).Return(&domain.User{Id: 4, Roles: []domain.Role{adminRole}}, nil).Once()
+
+	user, err := newTestUserService(usersMock, nil, nil).SetRoles(context.Background(), 4, []int64{1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{policy.Admin}, user.RoleNames())
+}
+
+func TestLogin(t *testing.T) {
+	signer := auth.NewSigner([]byte("secret"), time.Hour)
+	warehouseId := int64(3)
+	ana := &domain.User{Id: 4, Username: "ana", Password: hashOf(t, "s3cret-pass"), WarehouseId: &warehouseId, Roles: []domain.Role{adminRole}}
+
+	t.Run("success", func(t *testing.T) {
+		usersMock := mocks.NewUserRepository(t)
+		usersMock.On("GetByUsername", mock.Anything, "ana").Return(ana, nil).Once()
+
+		token, err := newTestUserService(usersMock, nil, signer).Login(context.Background(), "ana", "s3cret-pass")
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer", token.TokenType)
+		assert.Equal(t, int64(3600), token.ExpiresIn)
+
+		claims, err := signer.Parse(token.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, "ana", claims.Subject)
+		assert.Equal(t, int64(4), claims.UserId)
+		assert.Equal(t, []string{policy.Admin}, claims.Roles)
+		assert.Equal(t, &warehouseId, claims.WarehouseId)
+		assert.Nil(t, claims.SellerId)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		usersMock := mocks.NewUserRepository(t)
+		usersMock.On("GetByUsername", mock.Anything, "ana").Return(ana, nil).Once()
+
+		_, err := newTestUserService(usersMock, nil, signer).Login(context.Background(), "ana", "wrong-pass")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		usersMock := mocks.NewUserRepository(t)
+		usersMock.On("GetByUsername", mock.Anything, "bob").Return(nil, nil).Once()
+
+		_, err := newTestUserService(usersMock, nil, signer).Login(context.Background(), "bob", "s3cret-pass")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	})
+
+	t.Run("fail to get user", func(t *testing.T) {
+		usersMock := mocks.NewUserRepository(t)
+		usersMock.On("GetByUsername", mock.Anything, "ana").Return(nil, errors.New("connection refused")).Once()
+
+		_, err := newTestUserService(usersMock, nil, signer).Login(context.Background(), "ana", "s3cret-pass")
+
+		assert.EqualError(t, err, "connection refused")
+	})
+}
+
+func TestBootstrap(t *testing.T) {
+	t.Run("empty database", func(t *testing.T) {
+		usersMock := mocks.NewUserRepository(t)
+		rolesMock := mocks.NewRoleRepository(t)
+		usersMock.On("Count", mock.Anything).Return(int64(0), nil).Once()
+		rolesMock.On("GetByName", mock.Anything, policy.Admin).Return(&adminRole, nil).Once()
+		usersMock.On("Create", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+			return user.Username == "admin" && user.Roles[0] == adminRole
+		})).Return(int64(1), nil).Once()
+
+		err := newTestUserService(usersMock, rolesMock, nil).Bootstrap(context.Background(), "admin", "s3cret-pass")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("users exist", func(t *testing.T) {
+		usersMock := mocks.NewUserRepository(t)
+		usersMock.On("Count", mock.Anything).Return(int64(2), nil).Once()
+
+		err := newTestUserService(usersMock, nil, nil).Bootstrap(context.Background(), "admin", "s3cret-pass")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("no admin role", func(t *testing.T) {
+		usersMock := mocks.NewUserRepository(t)
+		rolesMock := mocks.NewRoleRepository(t)
+		usersMock.On("Count", mock.Anything).Return(int64(0), nil).Once()
+		rolesMock.On("GetByName", mock.Anything, policy.Admin).Return(nil, nil).Once()
+
+		err := newTestUserService(usersMock, rolesMock, nil).Bootstrap(context.Background(), "admin", "s3cret-pass")
+
+		assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+	})
+}
+
+func TestRoleUpdate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		rolesMock := mocks.NewRoleRepository(t)
+		rolesMock.On("GetById", mock.Anything, int64(2)).Return(&domain.Role{Id: 2, Name: "seller"}, nil).Once()
+		rolesMock.On("Update", mock.Anything, &domain.Role{Id: 2, Name: "sellers", Description: "sell"}).Return(nil).Once()
+
+		role, err := NewRoleService(rolesMock).Update(context.Background(), 2, domain.RequestRole{Name: "sellers", Description: "sell"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "sellers", role.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		rolesMock := mocks.NewRoleRepository(t)
+		rolesMock.On("GetById", mock.Anything, int64(2)).Return(nil, nil).Once()
+
+		_, err := NewRoleService(rolesMock).Update(context.Background(), 2, domain.RequestRole{Name: "sellers"})
+
+		assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+	})
+}
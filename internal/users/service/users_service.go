@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/auth"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/policy"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/users/domain"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// timingHash is compared against when the username of a login does not
+// exist, so that unknown and known usernames take as long to reject.
+const timingHash = "$2a$10$e5FvURmd0DhJS4nzm7Mynu9u4Y8HGd4nI3TATLqAG9rERsVB6E4Gq"
+
+type userService struct {
+	users  domain.UserRepository
+	roles  domain.RoleRepository
+	signer *auth.Signer
+	cost   int
+}
+
+func NewUserService(users domain.UserRepository, roles domain.RoleRepository, signer *auth.Signer) domain.UserService {
+	return &userService{users: users, roles: roles, signer: signer, cost: bcrypt.DefaultCost}
+}
+
+func (s userService) GetAll(ctx context.Context, params listing.Params) (*[]domain.User, int64, error) {
+	return s.users.GetAll(ctx, params)
+}
+
+func (s userService) GetById(ctx context.Context, id int64) (*domain.User, error) {
+	user, err := s.users.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (s userService) Create(ctx context.Context, request domain.RequestCreateUser) (*domain.User, error) {
+	hash, err := s.hash(request.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &domain.User{
+		Username:    request.Username,
+		Password:    hash,
+		WarehouseId: request.WarehouseId,
+		SellerId:    request.SellerId,
+	}
+	for _, roleId := range request.RoleIds {
+		user.Roles = append(user.Roles, domain.Role{Id: roleId})
+	}
+
+	id, err := s.users.Create(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetById(ctx, id)
+}
+
+// Update changes the username, the password, the warehouse or the seller of
+// the user, leaving alone the ones not given.
+func (s userService) Update(ctx context.Context, id int64, request domain.RequestUpdateUser) (*domain.User, error) {
+	user, err := s.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Username != nil {
+		user.Username = *request.Username
+	}
+
+	if request.Password != nil {
+		if user.Password, err = s.hash(*request.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	if request.WarehouseId != nil {
+		user.WarehouseId = request.WarehouseId
+	}
+
+	if request.SellerId != nil {
+		user.SellerId = request.SellerId
+	}
+
+	if err := s.users.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s userService) SetRoles(ctx context.Context, id int64, roleIds []int64) (*domain.User, error) {
+	if _, err := s.GetById(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.users.SetRoles(ctx, id, roleIds); err != nil {
+		return nil, err
+	}
+
+	return s.GetById(ctx, id)
+}
+
+func (s userService) Delete(ctx context.Context, id int64) error {
+	return s.users.Delete(ctx, id)
+}
+
+// Login checks the password of the user and issues a token carrying its
+// roles, warehouse and seller.
+func (s userService) Login(ctx context.Context, username, password string) (*domain.Token, error) {
+	user, err := s.users.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := timingHash
+	if user != nil {
+		hash = user.Password
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if user == nil || errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.signer.Sign(auth.Claims{
+		Subject:     user.Username,
+		UserId:      user.Id,
+		Roles:       user.RoleNames(),
+		WarehouseId: user.WarehouseId,
+		SellerId:    user.SellerId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Token{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.signer.TTL().Seconds()),
+	}, nil
+}
+
+// Bootstrap creates an admin user with username and password when there are
+// no users yet, so that someone can log in to create the others.
+func (s userService) Bootstrap(ctx context.Context, username, password string) error {
+	count, err := s.users.Count(ctx)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	admin, err := s.roles.GetByName(ctx, policy.Admin)
+	if err != nil {
+		return err
+	}
+
+	if admin == nil {
+		return domain.ErrRoleNotFound
+	}
+
+	hash, err := s.hash(password)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.users.Create(ctx, &domain.User{
+		Username: username,
+		Password: hash,
+		Roles:    []domain.Role{*admin},
+	}); err != nil {
+		return err
+	}
+
+	log.Printf("created admin user %q", username)
+	return nil
+}
+
+func (s userService) hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), s.cost)
+	return string(hash), err
+}
@@ -5,6 +5,8 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/domain"
 )
 
@@ -21,27 +23,22 @@ func NewWarehouseController(ws domain.WarehouseService) *WarehouseController {
 // @Description Add a new warehouse checking for duplicate warehouses code before
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param warehouse body domain.CreateWarehouseInput true "Warehouse to create"
 // @Success 201 {object} domain.Warehouse
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 409 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 422 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
 // @Router /warehouses [post]
 func (wc *WarehouseController) Create() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var warehouseInput domain.CreateWarehouseInput
 		if err := ctx.ShouldBindJSON(&warehouseInput); err != nil {
-			ctx.AbortWithStatusJSON(
-				http.StatusUnprocessableEntity,
-				gin.H{"error": err.Error()},
-			)
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 
-		if err := wc.service.IsWarehouseCodeAvailable(ctx, warehouseInput.WarehouseCode); err != nil {
-			ctx.AbortWithStatusJSON(
-				http.StatusConflict,
-				gin.H{"error": err.Error()},
-			)
+		if err := wc.service.IsWarehouseCodeAvailable(ctx.Request.Context(), warehouseInput.WarehouseCode); err != nil {
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -53,13 +50,10 @@ func (wc *WarehouseController) Create() gin.HandlerFunc {
 			MinimumTemperature: warehouseInput.MinimumTemperature,
 		}
 
-		createdWarehouse, err := wc.service.Create(ctx, &warehouse)
+		createdWarehouse, err := wc.service.Create(ctx.Request.Context(), &warehouse)
 
 		if err != nil {
-			ctx.AbortWithStatusJSON(
-				http.StatusUnprocessableEntity,
-				gin.H{"error": err.Error()},
-			)
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -74,24 +68,36 @@ func (wc *WarehouseController) Create() gin.HandlerFunc {
 // @Description get all warehouses
 // @Accept json
 // @Produce json
-// @Success 200 {object} schemas.JSONSuccessResult{data=[]domain.Warehouse}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Param locality_id query int false "Locality ID"
+// @Param warehouse_code query string false "Warehouse code"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.Warehouse}
+// @Failure 400 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /warehouses [get]
 func (wc *WarehouseController) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		ws, err := wc.service.GetAll(ctx)
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.WarehouseListFields)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		ws, total, err := wc.service.GetAll(ctx.Request.Context(), params)
 
 		if err != nil {
-			ctx.AbortWithStatusJSON(
-				http.StatusUnprocessableEntity,
-				gin.H{"error": err.Error()},
-			)
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 
 		ctx.JSON(
 			http.StatusOK, gin.H{
 				"data": ws,
+				"meta": listing.NewMeta(params, total),
 			},
 		)
 	}
@@ -102,27 +108,23 @@ func (wc *WarehouseController) GetAll() gin.HandlerFunc {
 // @Description get warehouse by it's id
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Warehouse ID"
 // @Success 200 {object} domain.Warehouse
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
 // @Router /warehouses/{id} [get]
 func (wc *WarehouseController) GetById() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		warehouseId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 		if err != nil {
-			ctx.AbortWithStatusJSON(
-				http.StatusBadRequest,
-				gin.H{"error": "invalid id type"},
-			)
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid id type")
 			return
 		}
 
-		w, err := wc.service.FindById(ctx, warehouseId)
+		w, err := wc.service.FindById(ctx.Request.Context(), warehouseId)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
-				"error": err.Error(),
-			})
+			problem.Abort(ctx, http.StatusNotFound, err)
 			return
 		}
 
@@ -137,38 +139,31 @@ func (wc *WarehouseController) GetById() gin.HandlerFunc {
 // @Description Update existing warehouse in list checking for duplicate warehouses code
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Warehouse ID"
 // @Param warehouse body domain.UpdateWarehouseInput true "Warehouse to update"
 // @Success 200 {object} domain.Warehouse
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 422 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
 // @Router /warehouses/{id} [patch]
 func (wc *WarehouseController) Update() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		warehouseId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 		if err != nil {
-			ctx.AbortWithStatusJSON(
-				http.StatusBadRequest,
-				gin.H{"error": "invalid id type"},
-			)
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid id type")
 			return
 		}
 
 		var warehouseInput domain.UpdateWarehouseInput
 		if err := ctx.ShouldBindJSON(&warehouseInput); err != nil {
-			ctx.AbortWithStatusJSON(
-				http.StatusUnprocessableEntity,
-				gin.H{"error": err.Error()},
-			)
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 
-		if _, err := wc.service.FindById(ctx, warehouseId); err != nil {
-			ctx.AbortWithStatusJSON(
-				http.StatusNotFound,
-				gin.H{"error": "could not find warehouse"},
-			)
+		if _, err := wc.service.FindById(ctx.Request.Context(), warehouseId); err != nil {
+			problem.AbortDetail(ctx, http.StatusNotFound, "could not find warehouse")
 			return
 		}
 
@@ -181,11 +176,9 @@ func (wc *WarehouseController) Update() gin.HandlerFunc {
 			MinimumTemperature: warehouseInput.MinimumTemperature,
 		}
 
-		updatedWarehouse, err := wc.service.Update(ctx, &warehouse)
+		updatedWarehouse, err := wc.service.Update(ctx.Request.Context(), &warehouse)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			problem.AbortError(ctx, err)
 			return
 		}
 
@@ -200,26 +193,23 @@ func (wc *WarehouseController) Update() gin.HandlerFunc {
 // @Description Delete existing warehouse in list
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "warehouse ID"
-// @Success 204 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 400 {object} schemas.JSONBadReqResult{error=string}
-// @Failure 404 {object} schemas.JSONBadReqResult{error=string}
+// @Success 204 {object} problem.Problem
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
 // @Router /warehouses/{id} [delete]
 func (wc *WarehouseController) Delete() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		warehouseId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 		if err != nil {
-			ctx.AbortWithStatusJSON(
-				http.StatusBadRequest,
-				gin.H{"error": "invalid id type"},
-			)
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid id type")
 			return
 		}
 
-		if err := wc.service.Delete(ctx, warehouseId); err != nil {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
-				"error": err.Error(),
-			})
+		if err := wc.service.Delete(ctx.Request.Context(), warehouseId); err != nil {
+			problem.AbortError(ctx, err)
 			return
 		}
 
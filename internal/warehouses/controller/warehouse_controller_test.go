@@ -11,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/controller"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/domain"
 	mock "github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/mocks"
@@ -75,7 +76,7 @@ func TestCreateConflict(t *testing.T) {
 	err := json.NewEncoder(&buf).Encode(warehouseInput)
 	assert.Nil(t, err)
 
-	serviceMock.EXPECT().IsWarehouseCodeAvailable(gomock.Any(), warehouseInput.WarehouseCode).Return(errors.New("duplicate"))
+	serviceMock.EXPECT().IsWarehouseCodeAvailable(gomock.Any(), warehouseInput.WarehouseCode).Return(domain.ErrWarehouseCodeDuplicate)
 
 	rr := httptest.NewRecorder()
 	_, engine := gin.CreateTestContext(rr)
@@ -100,7 +101,12 @@ func TestCreateFail(t *testing.T) {
 	assert.Nil(t, err)
 
 	serviceMock.EXPECT().IsWarehouseCodeAvailable(gomock.Any(), warehouseInput.WarehouseCode).Return(nil)
-	serviceMock.EXPECT().Create(gomock.Any(), &warehouseInput).Return(nil, errors.New("error saving"))
+	serviceMock.EXPECT().Create(gomock.Any(), &warehouseInput).Return(nil, &apperrors.Error{
+		Kind:    apperrors.ErrForeignKey,
+		Entity:  "localities",
+		Field:   "locality_id",
+		Message: "locality_id: no localities with that id",
+	})
 
 	rr := httptest.NewRecorder()
 	_, engine := gin.CreateTestContext(rr)
@@ -119,7 +125,7 @@ func TestGetAllFail(t *testing.T) {
 	serviceMock := mock.NewMockWarehouseService(ctrl)
 	controller := controller.NewWarehouseController(serviceMock)
 
-	serviceMock.EXPECT().GetAll(gomock.Any()).Return(nil, errors.New("error getting all"))
+	serviceMock.EXPECT().GetAll(gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("error getting all"))
 
 	rr := httptest.NewRecorder()
 	_, engine := gin.CreateTestContext(rr)
@@ -138,7 +144,7 @@ func TestGetAllOk(t *testing.T) {
 	serviceMock := mock.NewMockWarehouseService(ctrl)
 	controller := controller.NewWarehouseController(serviceMock)
 
-	serviceMock.EXPECT().GetAll(gomock.Any()).Return(&[]domain.Warehouse{}, nil)
+	serviceMock.EXPECT().GetAll(gomock.Any(), gomock.Any()).Return(&[]domain.Warehouse{}, int64(0), nil)
 
 	rr := httptest.NewRecorder()
 	_, engine := gin.CreateTestContext(rr)
@@ -278,7 +284,7 @@ func TestUpdateFail(t *testing.T) {
 	assert.Nil(t, err)
 
 	serviceMock.EXPECT().FindById(gomock.Any(), warehouseInput.ID).Return(&warehouseInput, nil)
-	serviceMock.EXPECT().Update(gomock.Any(), &warehouseInput).Return(nil, errors.New("fail update"))
+	serviceMock.EXPECT().Update(gomock.Any(), &warehouseInput).Return(nil, domain.ErrWarehouseCodeDuplicate)
 
 	rr := httptest.NewRecorder()
 	_, engine := gin.CreateTestContext(rr)
@@ -289,7 +295,7 @@ func TestUpdateFail(t *testing.T) {
 	engine.ServeHTTP(rr, req)
 
 	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, http.StatusConflict, rr.Code)
 }
 
 func TestUpdateOk(t *testing.T) {
@@ -340,7 +346,7 @@ func TestDeleteFail(t *testing.T) {
 	controller := controller.NewWarehouseController(serviceMock)
 
 	warehouseFakeInput := 1
-	serviceMock.EXPECT().Delete(gomock.Any(), int64(warehouseFakeInput)).Return(errors.New("fail finding id"))
+	serviceMock.EXPECT().Delete(gomock.Any(), int64(warehouseFakeInput)).Return(domain.ErrWarehouseNotFound)
 
 	rr := httptest.NewRecorder()
 	_, engine := gin.CreateTestContext(rr)
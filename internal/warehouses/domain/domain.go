@@ -1,6 +1,10 @@
 package domain
 
-import "context"
+import (
+	"context"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
 
 //go:generate mockgen -source=./domain.go -destination=../mocks/domain.go
 type WarehouseRepository interface {
@@ -8,7 +12,7 @@ type WarehouseRepository interface {
 	Update(ctx context.Context, warehouse *Warehouse) error
 	FindById(ctx context.Context, id int64) (*Warehouse, error)
 	FindByWarehouseCode(ctx context.Context, warehouseCode string) (*Warehouse, error)
-	GetAll(ctx context.Context) (*[]Warehouse, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]Warehouse, int64, error)
 	Delete(ctx context.Context, id int64) error
 }
 
@@ -18,6 +22,6 @@ type WarehouseService interface {
 	FindById(ctx context.Context, id int64) (*Warehouse, error)
 	FindByWarehouseCode(ctx context.Context, warehouseCode string) (*Warehouse, error)
 	IsWarehouseCodeAvailable(ctx context.Context, warehouseCode string) error
-	GetAll(ctx context.Context) (*[]Warehouse, error)
+	GetAll(ctx context.Context, params listing.Params) (*[]Warehouse, int64, error)
 	Delete(ctx context.Context, id int64) error
 }
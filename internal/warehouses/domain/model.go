@@ -1,5 +1,12 @@
 package domain
 
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+
+var WarehouseListFields = listing.Fields{
+	Sort:   []string{"id", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id"},
+	Filter: []string{"locality_id", "warehouse_code"},
+}
+
 type Warehouse struct {
 	ID                 int64   `json:"id"`
 	WarehouseCode      string  `json:"warehouse_code" binding:"required"`
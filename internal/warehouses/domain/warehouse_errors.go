@@ -0,0 +1,8 @@
+package domain
+
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+
+var (
+	ErrWarehouseNotFound      = apperrors.NotFound("could not find warehouse by id")
+	ErrWarehouseCodeDuplicate = apperrors.Conflict("warehouseCode already exists")
+)
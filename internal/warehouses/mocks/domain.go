@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/domain"
 )
 
@@ -95,18 +96,19 @@ func (mr *MockWarehouseRepositoryMockRecorder) FindByWarehouseCode(ctx, warehous
 }
 
 // GetAll mocks base method.
-func (m *MockWarehouseRepository) GetAll(ctx context.Context) (*[]domain.Warehouse, error) {
+func (m *MockWarehouseRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Warehouse, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret := m.ctrl.Call(m, "GetAll", ctx, params)
 	ret0, _ := ret[0].(*[]domain.Warehouse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockWarehouseRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+func (mr *MockWarehouseRepositoryMockRecorder) GetAll(ctx, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWarehouseRepository)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWarehouseRepository)(nil).GetAll), ctx, params)
 }
 
 // Update mocks base method.
@@ -206,18 +208,19 @@ func (mr *MockWarehouseServiceMockRecorder) FindByWarehouseCode(ctx, warehouseCo
 }
 
 // GetAll mocks base method.
-func (m *MockWarehouseService) GetAll(ctx context.Context) (*[]domain.Warehouse, error) {
+func (m *MockWarehouseService) GetAll(ctx context.Context, params listing.Params) (*[]domain.Warehouse, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret := m.ctrl.Call(m, "GetAll", ctx, params)
 	ret0, _ := ret[0].(*[]domain.Warehouse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockWarehouseServiceMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+func (mr *MockWarehouseServiceMockRecorder) GetAll(ctx, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWarehouseService)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWarehouseService)(nil).GetAll), ctx, params)
 }
 
 // IsWarehouseCodeAvailable mocks base method.
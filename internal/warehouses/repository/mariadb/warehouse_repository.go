@@ -5,6 +5,10 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	auditlog "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/repository/mariadb"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/domain"
 )
 
@@ -20,7 +24,14 @@ func (r *warehouseRepository) Create(
 	ctx context.Context,
 	warehouse *domain.Warehouse,
 ) (*domain.Warehouse, error) {
-	result, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
 		ctx,
 		sqlStore,
 		&warehouse.Address,
@@ -31,7 +42,7 @@ func (r *warehouseRepository) Create(
 		&warehouse.LocalityId,
 	)
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromMySQL(err)
 	}
 
 	lastID, err := result.LastInsertId()
@@ -41,6 +52,14 @@ func (r *warehouseRepository) Create(
 
 	warehouse.ID = lastID
 
+	if err := auditlog.Record(ctx, tx, audit.ActionCreate, "warehouses", lastID, nil); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return warehouse, nil
 }
 
@@ -48,7 +67,19 @@ func (r *warehouseRepository) Update(
 	ctx context.Context,
 	warehouse *domain.Warehouse,
 ) error {
-	_, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "warehouses", warehouse.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
 		ctx,
 		sqlUpdate,
 		&warehouse.WarehouseCode,
@@ -59,9 +90,14 @@ func (r *warehouseRepository) Update(
 		&warehouse.ID,
 	)
 	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
+	if err := auditlog.Record(ctx, tx, audit.ActionUpdate, "warehouses", warehouse.ID, before); err != nil {
 		return err
 	}
-	return nil
+
+	return tx.Commit()
 }
 
 func (r *warehouseRepository) FindById(
@@ -121,12 +157,20 @@ func (r *warehouseRepository) FindByWarehouseCode(
 }
 func (r *warehouseRepository) GetAll(
 	ctx context.Context,
-) (*[]domain.Warehouse, error) {
+	params listing.Params,
+) (*[]domain.Warehouse, int64, error) {
 	warehouses := []domain.Warehouse{}
 
-	rows, err := r.db.QueryContext(ctx, sqlGetAll)
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAll, params, nil)
+	if err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &warehouses, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAll, params, nil)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return &warehouses, err
+		return &warehouses, 0, err
 	}
 
 	defer rows.Close()
@@ -143,23 +187,35 @@ func (r *warehouseRepository) GetAll(
 			&warehouse.MinimumTemperature,
 			&warehouse.LocalityId,
 		); err != nil {
-			return &warehouses, err
+			return &warehouses, 0, err
 		}
 
 		warehouses = append(warehouses, warehouse)
 	}
 
-	return &warehouses, nil
+	return &warehouses, total, nil
 }
 func (r *warehouseRepository) Delete(
 	ctx context.Context,
 	id int64,
 ) error {
-	result, err := r.db.ExecContext(ctx, sqlDelete, id)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	before, err := auditlog.Snapshot(ctx, tx, "warehouses", id)
 	if err != nil {
 		return err
 	}
 
+	result, err := tx.ExecContext(ctx, sqlDelete, id)
+	if err != nil {
+		return apperrors.FromMySQL(err)
+	}
+
 	affectedRows, err := result.RowsAffected()
 
 	if err != nil {
@@ -170,5 +226,9 @@ func (r *warehouseRepository) Delete(
 		return sql.ErrNoRows
 	}
 
-	return nil
+	if err := auditlog.Record(ctx, tx, audit.ActionDelete, "warehouses", id, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/audit/audittest"
+	audit "github.com/marcoglnd/mercado-fresco-packmain/internal/audit/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,6 +23,7 @@ func TestCreate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(sqlStore)).
 			WithArgs(
 				warehouseFake.Address,
@@ -29,6 +33,8 @@ func TestCreate(t *testing.T) {
 				warehouseFake.MinimumTemperature,
 				warehouseFake.LocalityId,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionCreate, "warehouses", 1)
+		mock.ExpectCommit()
 
 		warehousesRepo := NewWarehouseRepository(db)
 
@@ -42,6 +48,7 @@ func TestCreate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(sqlStore)).
 			WithArgs(
 				0,
@@ -63,6 +70,7 @@ func TestCreate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(sqlStore)).
 			WithArgs(
 				warehouseFake.Address,
@@ -89,6 +97,8 @@ func TestUpdate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "warehouses", warehouseFake.ID)
 		mock.ExpectExec(regexp.QuoteMeta(sqlUpdate)).
 			WithArgs(
 				warehouseFake.WarehouseCode,
@@ -98,6 +108,8 @@ func TestUpdate(t *testing.T) {
 				warehouseFake.MinimumTemperature,
 				warehouseFake.ID,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectRecord(mock, audit.ActionUpdate, "warehouses", warehouseFake.ID)
+		mock.ExpectCommit()
 
 		warehousesRepo := NewWarehouseRepository(db)
 
@@ -110,6 +122,8 @@ func TestUpdate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "warehouses", warehouseFake.ID)
 		mock.ExpectExec(regexp.QuoteMeta(sqlUpdate)).
 			WithArgs(
 				0,
@@ -296,12 +310,14 @@ func TestGetAll(t *testing.T) {
 			warehouseFake.LocalityId,
 		)
 
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAll)).WillReturnRows(fakeRows)
 
 		warehousesRepo := NewWarehouseRepository(db)
 
-		whs, err := warehousesRepo.GetAll(context.TODO())
+		whs, total, err := warehousesRepo.GetAll(context.TODO(), listing.Params{Limit: listing.DefaultLimit})
 		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
 		assert.Equal(t, 2, len(*whs))
 	})
 
@@ -310,11 +326,12 @@ func TestGetAll(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAll)).WillReturnError(sql.ErrConnDone)
 
 		warehousesRepo := NewWarehouseRepository(db)
 
-		_, err = warehousesRepo.GetAll(context.TODO())
+		_, _, err = warehousesRepo.GetAll(context.TODO(), listing.Params{Limit: listing.DefaultLimit})
 		assert.Error(t, err)
 	})
 
@@ -351,11 +368,12 @@ func TestGetAll(t *testing.T) {
 			warehouseFake.LocalityId,
 		).RowError(2, errors.New("row error"))
 
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetAll)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAll)).WillReturnRows(fakeRows)
 
 		warehousesRepo := NewWarehouseRepository(db)
 
-		whs, err := warehousesRepo.GetAll(context.TODO())
+		whs, _, err := warehousesRepo.GetAll(context.TODO(), listing.Params{Limit: listing.DefaultLimit})
 		assert.Error(t, err)
 		assert.NotNil(t, whs)
 	})
@@ -369,8 +387,12 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "warehouses", warehouseFake.ID)
 		mock.ExpectExec(regexp.QuoteMeta(sqlDelete)).
 			WithArgs(warehouseFake.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+		audittest.ExpectEntry(mock, audit.ActionDelete, "warehouses", warehouseFake.ID)
+		mock.ExpectCommit()
 
 		warehousesRepo := NewWarehouseRepository(db)
 
@@ -383,6 +405,8 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "warehouses", warehouseFake.ID)
 		mock.ExpectExec(regexp.QuoteMeta(sqlDelete)).
 			WithArgs(warehouseFake.ID).WillReturnError(errors.New("fail"))
 
@@ -397,6 +421,8 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectMissingSnapshot(mock, "warehouses", warehouseFake.ID)
 		mock.ExpectExec(regexp.QuoteMeta(sqlDelete)).
 			WithArgs(warehouseFake.ID).WillReturnResult(sqlmock.NewResult(0, 0))
 
@@ -411,6 +437,8 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		audittest.ExpectSnapshot(mock, "warehouses", warehouseFake.ID)
 		mock.ExpectExec(regexp.QuoteMeta(sqlDelete)).
 			WithArgs(warehouseFake.ID).WillReturnResult(sqlmock.NewErrorResult(sql.ErrConnDone))
 
@@ -2,8 +2,8 @@ package service
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/domain"
 )
 
@@ -36,7 +36,7 @@ func (s *warehouseService) IsWarehouseCodeAvailable(ctx context.Context, warehou
 		return err
 	}
 	if warehouseDuplicated != nil {
-		return fmt.Errorf("warehouseCode already exists")
+		return domain.ErrWarehouseCodeDuplicate
 	}
 	return nil
 }
@@ -91,7 +91,7 @@ func (s *warehouseService) FindById(ctx context.Context, id int64) (*domain.Ware
 	}
 
 	if foundWarehouse == nil {
-		return nil, fmt.Errorf("could not find warehouse by id")
+		return nil, domain.ErrWarehouseNotFound
 	}
 
 	return foundWarehouse, nil
@@ -107,15 +107,15 @@ func (s *warehouseService) FindByWarehouseCode(ctx context.Context, warehouseCod
 	return foundWarehouse, nil
 }
 
-func (s *warehouseService) GetAll(ctx context.Context) (*[]domain.Warehouse, error) {
+func (s *warehouseService) GetAll(ctx context.Context, params listing.Params) (*[]domain.Warehouse, int64, error) {
 
-	warehouses, err := s.repository.GetAll(ctx)
+	warehouses, total, err := s.repository.GetAll(ctx, params)
 
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return warehouses, nil
+	return warehouses, total, nil
 }
 
 func (s *warehouseService) Delete(ctx context.Context, id int64) error {
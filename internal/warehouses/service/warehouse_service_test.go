@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/domain"
 	mock "github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/mocks"
 	"github.com/marcoglnd/mercado-fresco-packmain/internal/warehouses/service"
@@ -65,8 +66,8 @@ func TestFindAll(t *testing.T) {
 	service := service.NewWarehouseService(repositoryMock)
 	ctx := context.TODO()
 	warehousesFake := utils.CreateRandomListWarehouses()
-	repositoryMock.EXPECT().GetAll(ctx).Return(&warehousesFake, nil)
-	warehouses, err := service.GetAll(ctx)
+	repositoryMock.EXPECT().GetAll(ctx, listing.Params{}).Return(&warehousesFake, int64(len(warehousesFake)), nil)
+	warehouses, _, err := service.GetAll(ctx, listing.Params{})
 
 	assert.Nil(t, err)
 	assert.NotNil(t, warehouses)
@@ -0,0 +1,278 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/problem"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain"
+)
+
+type WebhookController struct {
+	service domain.WebhookService
+}
+
+func NewWebhookController(service domain.WebhookService) (*WebhookController, error) {
+	if service == nil {
+		return nil, errors.New("invalid service")
+	}
+
+	return &WebhookController{
+		service: service,
+	}, nil
+}
+
+// @Summary List webhook subscriptions
+// @Tags Webhooks
+// @Description get all webhook subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.Subscription}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /webhooks [get]
+func (c WebhookController) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.SubscriptionListFields)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		subscriptions, total, err := c.service.GetAll(ctx.Request.Context(), params)
+		if err != nil {
+			problem.Abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": subscriptions,
+			"meta": listing.NewMeta(params, total),
+		})
+	}
+}
+
+// @Summary Webhook subscription by id
+// @Tags Webhooks
+// @Description get a webhook subscription by its id
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Subscription}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /webhooks/{id} [get]
+func (c WebhookController) GetById() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		subscription, err := c.service.GetById(ctx.Request.Context(), req.Id)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": subscription})
+	}
+}
+
+// @Summary Create webhook subscription
+// @Tags Webhooks
+// @Description Subscribe a URL to events, signed with the secret answered here, which is generated when none is given
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param subscription body domain.RequestCreateSubscription true "Subscription to create"
+// @Success 201 {object} schemas.JSONSuccessResult{data=domain.Subscription}
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /webhooks [post]
+func (c WebhookController) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestCreateSubscription
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		subscription, err := c.service.Create(ctx.Request.Context(), req)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, gin.H{"data": subscription})
+	}
+}
+
+// @Summary Update webhook subscription
+// @Tags Webhooks
+// @Description Update the fields sent of a webhook subscription
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Param subscription body domain.RequestUpdateSubscription true "Fields to update"
+// @Success 200 {object} schemas.JSONSuccessResult{data=domain.Subscription}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /webhooks/{id} [patch]
+func (c WebhookController) Update() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var reqId domain.RequestId
+		if err := ctx.ShouldBindUri(&reqId); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		var req domain.RequestUpdateSubscription
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			problem.Abort(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		subscription, err := c.service.Update(ctx.Request.Context(), reqId.Id, req)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": subscription})
+	}
+}
+
+// @Summary Delete webhook subscription
+// @Tags Webhooks
+// @Description Delete a webhook subscription and its deliveries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Success 204
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /webhooks/{id} [delete]
+func (c WebhookController) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		if err := c.service.Delete(ctx.Request.Context(), req.Id); err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusNoContent, nil)
+	}
+}
+
+// @Summary Webhook deliveries
+// @Tags Webhooks
+// @Description get the deliveries of the events sent to a webhook subscription
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Param status query string false "pending, delivered or dead"
+// @Param event_type query string false "Event type"
+// @Param limit query int false "Page size (max 500)"
+// @Param offset query int false "Rows to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order"
+// @Success 200 {object} schemas.JSONPaginatedResult{data=[]domain.Delivery}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /webhooks/{id}/deliveries [get]
+func (c WebhookController) GetDeliveries() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		params, err := listing.Parse(ctx.Request.URL.Query(), domain.DeliveryListFields)
+		if err != nil {
+			problem.Abort(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		deliveries, total, err := c.service.GetDeliveries(ctx.Request.Context(), req.Id, params)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": deliveries,
+			"meta": listing.NewMeta(params, total),
+		})
+	}
+}
+
+// @Summary Replay webhook delivery
+// @Tags Webhooks
+// @Description Send a delivery again, dead or not, with a fresh count of attempts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Success 202 {object} schemas.JSONSuccessResult{data=domain.Delivery}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /webhooks/{id}/deliveries/{deliveryId}/replay [post]
+func (c WebhookController) Replay() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req domain.RequestDeliveryId
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			problem.AbortDetail(ctx, http.StatusBadRequest, "invalid ID")
+			return
+		}
+
+		delivery, err := c.service.Replay(ctx.Request.Context(), req.Id, req.DeliveryId)
+		if err != nil {
+			problem.AbortError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusAccepted, gin.H{"data": delivery})
+	}
+}
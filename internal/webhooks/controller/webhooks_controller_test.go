@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func serve(serviceMock *mocks.WebhookService, method, path string, body *bytes.Buffer) *httptest.ResponseRecorder {
+	if body == nil {
+		body = &bytes.Buffer{}
+	}
+
+	req := httptest.NewRequest(method, path, body)
+	rec := httptest.NewRecorder()
+
+	_, engine := gin.CreateTestContext(rec)
+
+	webhookController := WebhookController{service: serviceMock}
+
+	engine.GET("/api/v1/webhooks", webhookController.GetAll())
+	engine.GET("/api/v1/webhooks/:id", webhookController.GetById())
+	engine.POST("/api/v1/webhooks", webhookController.Create())
+	engine.PATCH("/api/v1/webhooks/:id", webhookController.Update())
+	engine.DELETE("/api/v1/webhooks/:id", webhookController.Delete())
+	engine.GET("/api/v1/webhooks/:id/deliveries", webhookController.GetDeliveries())
+	engine.POST("/api/v1/webhooks/:id/deliveries/:deliveryId/replay", webhookController.Replay())
+
+	engine.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestGetAll(t *testing.T) {
+	serviceMock := mocks.NewWebhookService(t)
+	serviceMock.On("GetAll", mock.Anything, mock.Anything).Return(&[]domain.Subscription{{Id: 1}}, int64(1), nil).Once()
+
+	rec := serve(serviceMock, http.MethodGet, "/api/v1/webhooks", nil)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"meta"`)
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		serviceMock := mocks.NewWebhookService(t)
+		serviceMock.On("Create", mock.Anything, domain.RequestCreateSubscription{
+			Url:        "http://example.com/hook",
+			EventTypes: []string{"inbound_order.created"},
+		}).Return(&domain.Subscription{Id: 1, Secret: "s3cret"}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodPost, "/api/v1/webhooks",
+			bytes.NewBufferString(`{"url":"http://example.com/hook","event_types":["inbound_order.created"]}`))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"secret":"s3cret"`)
+	})
+
+	t.Run("unknown event type", func(t *testing.T) {
+		rec := serve(mocks.NewWebhookService(t), http.MethodPost, "/api/v1/webhooks",
+			bytes.NewBufferString(`{"url":"http://example.com/hook","event_types":["order.lost"]}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		rec := serve(mocks.NewWebhookService(t), http.MethodPost, "/api/v1/webhooks", bytes.NewBufferString(`{"url":"example"}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewWebhookService(t)
+		serviceMock.On("Update", mock.Anything, int64(1), mock.Anything).Return(&domain.Subscription{Id: 1}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodPatch, "/api/v1/webhooks/1", bytes.NewBufferString(`{"active":false}`))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		serviceMock := mocks.NewWebhookService(t)
+		serviceMock.On("Update", mock.Anything, int64(1), mock.Anything).Return(nil, domain.ErrSubscriptionNotFound).Once()
+
+		rec := serve(serviceMock, http.MethodPatch, "/api/v1/webhooks/1", bytes.NewBufferString(`{}`))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	serviceMock := mocks.NewWebhookService(t)
+	serviceMock.On("Delete", mock.Anything, int64(1)).Return(nil).Once()
+
+	rec := serve(serviceMock, http.MethodDelete, "/api/v1/webhooks/1", nil)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestGetDeliveries(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		serviceMock := mocks.NewWebhookService(t)
+		serviceMock.On("GetDeliveries", mock.Anything, int64(1), mock.Anything).
+			Return(&[]domain.Delivery{{Id: 4, Status: domain.DeliveryDead}}, int64(1), nil).Once()
+
+		rec := serve(serviceMock, http.MethodGet, "/api/v1/webhooks/1/deliveries?status=dead", nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"status":"dead"`)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		rec := serve(mocks.NewWebhookService(t), http.MethodGet, "/api/v1/webhooks/1/deliveries?sort=url", nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestReplay(t *testing.T) {
+	t.Run("accepted", func(t *testing.T) {
+		serviceMock := mocks.NewWebhookService(t)
+		serviceMock.On("Replay", mock.Anything, int64(1), int64(4)).
+			Return(&domain.Delivery{Id: 4, Status: domain.DeliveryPending}, nil).Once()
+
+		rec := serve(serviceMock, http.MethodPost, "/api/v1/webhooks/1/deliveries/4/replay", nil)
+
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		serviceMock := mocks.NewWebhookService(t)
+		serviceMock.On("Replay", mock.Anything, int64(1), int64(4)).Return(nil, domain.ErrDeliveryNotFound).Once()
+
+		rec := serve(serviceMock, http.MethodPost, "/api/v1/webhooks/1/deliveries/4/replay", nil)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,136 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/events/dispatcher"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain"
+)
+
+const (
+	batchSize      = 100
+	requestTimeout = 10 * time.Second
+
+	// SignatureHeader holds the HMAC-SHA256 of the body, keyed with the
+	// secret of the subscription, as sha256=<hex>.
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// Deliverer sends the pending deliveries to their subscriptions. A delivery
+// that fails is retried with the backoff of the outbox, and becomes dead
+// after maxAttempts failures.
+type Deliverer struct {
+	repository  domain.WebhookRepository
+	client      *http.Client
+	maxAttempts int
+	now         func() time.Time
+}
+
+func NewDeliverer(repository domain.WebhookRepository, client *http.Client, maxAttempts int) *Deliverer {
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+
+	return &Deliverer{repository: repository, client: client, maxAttempts: maxAttempts, now: time.Now}
+}
+
+// Sign returns the value of SignatureHeader for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliver sends the deliveries that are due and returns how many of them
+// succeeded.
+func (d *Deliverer) Deliver(ctx context.Context) (int, error) {
+	deliveries, err := d.repository.Due(ctx, d.now(), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		statusCode, err := d.send(ctx, delivery)
+		if err != nil {
+			status, next := domain.DeliveryPending, d.now().Add(dispatcher.Backoff(delivery.Attempts))
+			if delivery.Attempts+1 >= d.maxAttempts {
+				status = domain.DeliveryDead
+			}
+
+			if err := d.repository.MarkFailed(ctx, delivery.Id, status, err.Error(), statusCode, next); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+
+		if err := d.repository.MarkDelivered(ctx, delivery.Id, statusCode, d.now()); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+
+	return delivered, nil
+}
+
+// send posts the event of delivery and returns the status code answered,
+// 0 when there was no answer.
+func (d *Deliverer) send(ctx context.Context, delivery domain.DueDelivery) (int, error) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.Id, 10))
+	req.Header.Set("X-Event-Id", strconv.FormatInt(delivery.Event.Id, 10))
+	req.Header.Set("X-Event-Type", delivery.Event.Type)
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("webhook %s answered %d", delivery.Url, res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// Run sends the due deliveries right away and then once per interval,
+// until ctx is cancelled.
+func (d *Deliverer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.Deliver(ctx); err != nil {
+			log.Printf("webhook deliverer: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSink(t *testing.T) {
+	warehouseId := int64(2)
+	event := events.Event{Id: 9, Type: events.TypeInboundOrderCreated, Payload: json.RawMessage(`{"warehouse_id":2}`)}
+
+	t.Run("queues the matching subscriptions", func(t *testing.T) {
+		repositoryMock := mocks.NewWebhookRepository(t)
+		repositoryMock.On("GetActive", mock.Anything).Return([]domain.Subscription{
+			{Id: 1, Active: true},
+			{Id: 2, Active: true, EventTypes: []string{events.TypeBatchReceived}},
+			{Id: 3, Active: true, EventTypes: []string{events.TypeInboundOrderCreated}, WarehouseId: &warehouseId},
+		}, nil).Once()
+		repositoryMock.On("Enqueue", mock.Anything, int64(9), []int64{1, 3}).Return(nil).Once()
+
+		assert.NoError(t, NewSink(repositoryMock).Send(context.Background(), event))
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		repositoryMock := mocks.NewWebhookRepository(t)
+		repositoryMock.On("GetActive", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+		assert.EqualError(t, NewSink(repositoryMock).Send(context.Background(), event), "connection refused")
+	})
+}
+
+func TestDeliver(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	due := func(url string, attempts int) domain.DueDelivery {
+		return domain.DueDelivery{
+			Delivery: domain.Delivery{Id: 4, EventId: 9, Attempts: attempts},
+			Url:      url,
+			Secret:   "0123456789abcdef",
+			Event:    events.Event{Id: 9, Type: events.TypeBatchReceived, Payload: json.RawMessage(`{"id":1}`)},
+		}
+	}
+
+	newDeliverer := func(repository domain.WebhookRepository) *Deliverer {
+		d := NewDeliverer(repository, nil, 3)
+		d.now = func() time.Time { return now }
+		return d
+	}
+
+	t.Run("signed and delivered", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, Sign("0123456789abcdef", body), r.Header.Get(SignatureHeader))
+			assert.Equal(t, "4", r.Header.Get("X-Webhook-Delivery"))
+			assert.Equal(t, events.TypeBatchReceived, r.Header.Get("X-Event-Type"))
+			assert.JSONEq(t, `{"id":1}`, string(mustPayload(t, body)))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		repositoryMock := mocks.NewWebhookRepository(t)
+		repositoryMock.On("Due", mock.Anything, now, batchSize).Return([]domain.DueDelivery{due(server.URL, 0)}, nil).Once()
+		repositoryMock.On("MarkDelivered", mock.Anything, int64(4), http.StatusOK, now).Return(nil).Once()
+
+		delivered, err := newDeliverer(repositoryMock).Deliver(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+	})
+
+	t.Run("retried with backoff", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		repositoryMock := mocks.NewWebhookRepository(t)
+		repositoryMock.On("Due", mock.Anything, now, batchSize).Return([]domain.DueDelivery{due(server.URL, 1)}, nil).Once()
+		repositoryMock.On(
+			"MarkFailed", mock.Anything, int64(4), domain.DeliveryPending, mock.Anything, http.StatusInternalServerError, now.Add(10*time.Second),
+		).Return(nil).Once()
+
+		delivered, err := newDeliverer(repositoryMock).Deliver(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+	})
+
+	t.Run("dead after the last attempt", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer server.Close()
+
+		repositoryMock := mocks.NewWebhookRepository(t)
+		repositoryMock.On("Due", mock.Anything, now, batchSize).Return([]domain.DueDelivery{due(server.URL, 2)}, nil).Once()
+		repositoryMock.On(
+			"MarkFailed", mock.Anything, int64(4), domain.DeliveryDead, mock.Anything, http.StatusGone, mock.Anything,
+		).Return(nil).Once()
+
+		_, err := newDeliverer(repositoryMock).Deliver(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("fail to read the deliveries", func(t *testing.T) {
+		repositoryMock := mocks.NewWebhookRepository(t)
+		repositoryMock.On("Due", mock.Anything, now, batchSize).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := newDeliverer(repositoryMock).Deliver(context.Background())
+
+		assert.EqualError(t, err, "connection refused")
+	})
+}
+
+func mustPayload(t *testing.T, body []byte) json.RawMessage {
+	var event events.Event
+	assert.NoError(t, json.Unmarshal(body, &event))
+	return event.Payload
+}
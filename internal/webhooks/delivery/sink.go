@@ -0,0 +1,38 @@
+package delivery
+
+import (
+	"context"
+
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain"
+)
+
+// Sink takes the events of the outbox and queues a delivery of each of them
+// to every active subscription it matches.
+type Sink struct {
+	repository domain.WebhookRepository
+}
+
+func NewSink(repository domain.WebhookRepository) *Sink {
+	return &Sink{repository: repository}
+}
+
+func (s *Sink) Name() string {
+	return "webhooks"
+}
+
+func (s *Sink) Send(ctx context.Context, event events.Event) error {
+	subscriptions, err := s.repository.GetActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	subscriptionIds := []int64{}
+	for _, subscription := range subscriptions {
+		if subscription.Matches(event) {
+			subscriptionIds = append(subscriptionIds, subscription.Id)
+		}
+	}
+
+	return s.repository.Enqueue(ctx, event.Id, subscriptionIds)
+}
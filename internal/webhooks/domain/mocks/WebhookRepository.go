@@ -0,0 +1,291 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// WebhookRepository is an autogenerated mock type for the WebhookRepository type
+type WebhookRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, subscription
+func (_m *WebhookRepository) Create(ctx context.Context, subscription *domain.Subscription) (int64, error) {
+	ret := _m.Called(ctx, subscription)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Subscription) int64); ok {
+		r0 = rf(ctx, subscription)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Subscription) error); ok {
+		r1 = rf(ctx, subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *WebhookRepository) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Due provides a mock function with given fields: ctx, now, limit
+func (_m *WebhookRepository) Due(ctx context.Context, now time.Time, limit int) ([]domain.DueDelivery, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	var r0 []domain.DueDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []domain.DueDelivery); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DueDelivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Enqueue provides a mock function with given fields: ctx, eventId, subscriptionIds
+func (_m *WebhookRepository) Enqueue(ctx context.Context, eventId int64, subscriptionIds []int64) error {
+	ret := _m.Called(ctx, eventId, subscriptionIds)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64) error); ok {
+		r0 = rf(ctx, eventId, subscriptionIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActive provides a mock function with given fields: ctx
+func (_m *WebhookRepository) GetActive(ctx context.Context) ([]domain.Subscription, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Subscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, params
+func (_m *WebhookRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Subscription, int64, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *[]domain.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.Subscription); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Subscription)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetById provides a mock function with given fields: ctx, id
+func (_m *WebhookRepository) GetById(ctx context.Context, id int64) (*domain.Subscription, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Subscription); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, subscriptionId, params
+func (_m *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionId int64, params listing.Params) (*[]domain.Delivery, int64, error) {
+	ret := _m.Called(ctx, subscriptionId, params)
+
+	var r0 *[]domain.Delivery
+	if rf, ok := ret.Get(0).(func(context.Context, int64, listing.Params) *[]domain.Delivery); ok {
+		r0 = rf(ctx, subscriptionId, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Delivery)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, int64, listing.Params) int64); ok {
+		r1 = rf(ctx, subscriptionId, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, listing.Params) error); ok {
+		r2 = rf(ctx, subscriptionId, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetDeliveryById provides a mock function with given fields: ctx, subscriptionId, id
+func (_m *WebhookRepository) GetDeliveryById(ctx context.Context, subscriptionId int64, id int64) (*domain.Delivery, error) {
+	ret := _m.Called(ctx, subscriptionId, id)
+
+	var r0 *domain.Delivery
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.Delivery); ok {
+		r0 = rf(ctx, subscriptionId, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Delivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, subscriptionId, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkDelivered provides a mock function with given fields: ctx, id, statusCode, at
+func (_m *WebhookRepository) MarkDelivered(ctx context.Context, id int64, statusCode int, at time.Time) error {
+	ret := _m.Called(ctx, id, statusCode, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, time.Time) error); ok {
+		r0 = rf(ctx, id, statusCode, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkFailed provides a mock function with given fields: ctx, id, status, lastError, statusCode, nextAttemptAt
+func (_m *WebhookRepository) MarkFailed(ctx context.Context, id int64, status string, lastError string, statusCode int, nextAttemptAt time.Time) error {
+	ret := _m.Called(ctx, id, status, lastError, statusCode, nextAttemptAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string, int, time.Time) error); ok {
+		r0 = rf(ctx, id, status, lastError, statusCode, nextAttemptAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Replay provides a mock function with given fields: ctx, id, at
+func (_m *WebhookRepository) Replay(ctx context.Context, id int64, at time.Time) error {
+	ret := _m.Called(ctx, id, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, subscription
+func (_m *WebhookRepository) Update(ctx context.Context, subscription *domain.Subscription) error {
+	ret := _m.Called(ctx, subscription)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Subscription) error); ok {
+		r0 = rf(ctx, subscription)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewWebhookRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewWebhookRepository(t mockConstructorTestingTNewWebhookRepository) *WebhookRepository {
+	mock := &WebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
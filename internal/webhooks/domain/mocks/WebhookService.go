@@ -0,0 +1,198 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	listing "github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	domain "github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookService is an autogenerated mock type for the WebhookService type
+type WebhookService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, request
+func (_m *WebhookService) Create(ctx context.Context, request domain.RequestCreateSubscription) (*domain.Subscription, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *domain.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, domain.RequestCreateSubscription) *domain.Subscription); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.RequestCreateSubscription) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *WebhookService) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, params
+func (_m *WebhookService) GetAll(ctx context.Context, params listing.Params) (*[]domain.Subscription, int64, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *[]domain.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Params) *[]domain.Subscription); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Subscription)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Params) int64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Params) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetById provides a mock function with given fields: ctx, id
+func (_m *WebhookService) GetById(ctx context.Context, id int64) (*domain.Subscription, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Subscription); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, subscriptionId, params
+func (_m *WebhookService) GetDeliveries(ctx context.Context, subscriptionId int64, params listing.Params) (*[]domain.Delivery, int64, error) {
+	ret := _m.Called(ctx, subscriptionId, params)
+
+	var r0 *[]domain.Delivery
+	if rf, ok := ret.Get(0).(func(context.Context, int64, listing.Params) *[]domain.Delivery); ok {
+		r0 = rf(ctx, subscriptionId, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Delivery)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, int64, listing.Params) int64); ok {
+		r1 = rf(ctx, subscriptionId, params)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, listing.Params) error); ok {
+		r2 = rf(ctx, subscriptionId, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Replay provides a mock function with given fields: ctx, subscriptionId, deliveryId
+func (_m *WebhookService) Replay(ctx context.Context, subscriptionId int64, deliveryId int64) (*domain.Delivery, error) {
+	ret := _m.Called(ctx, subscriptionId, deliveryId)
+
+	var r0 *domain.Delivery
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.Delivery); ok {
+		r0 = rf(ctx, subscriptionId, deliveryId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Delivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, subscriptionId, deliveryId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, request
+func (_m *WebhookService) Update(ctx context.Context, id int64, request domain.RequestUpdateSubscription) (*domain.Subscription, error) {
+	ret := _m.Called(ctx, id, request)
+
+	var r0 *domain.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.RequestUpdateSubscription) *domain.Subscription); ok {
+		r0 = rf(ctx, id, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.RequestUpdateSubscription) error); ok {
+		r1 = rf(ctx, id, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewWebhookService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewWebhookService creates a new instance of WebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewWebhookService(t mockConstructorTestingTNewWebhookService) *WebhookService {
+	mock := &WebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Subscription calls Url with the events of EventTypes, or of every type
+// when it is empty. When WarehouseId is set, only the events whose payload
+// has that warehouse_id are sent.
+type Subscription struct {
+	Id  int64  `json:"id"`
+	Url string `json:"url"`
+	// Secret signs the bodies sent to Url. It is only answered when the
+	// subscription is created.
+	Secret      string    `json:"secret,omitempty"`
+	EventTypes  []string  `json:"event_types"`
+	WarehouseId *int64    `json:"warehouse_id"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Matches tells whether event is to be sent to the subscription.
+func (s Subscription) Matches(event events.Event) bool {
+	if !s.Active {
+		return false
+	}
+
+	if len(s.EventTypes) > 0 && !contains(s.EventTypes, event.Type) {
+		return false
+	}
+
+	if s.WarehouseId == nil {
+		return true
+	}
+
+	var payload struct {
+		WarehouseId int64 `json:"warehouse_id"`
+	}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return false
+	}
+
+	return payload.WarehouseId == *s.WarehouseId
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+var SubscriptionListFields = listing.Fields{
+	Sort:   []string{"id", "created_at"},
+	Filter: []string{"warehouse_id"},
+}
+
+// Delivery is the sending of an event to a subscription. It is retried
+// until it succeeds or fails too many times, when it becomes dead until it
+// is replayed.
+type Delivery struct {
+	Id             int64      `json:"id"`
+	SubscriptionId int64      `json:"subscription_id"`
+	EventId        int64      `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastError      string     `json:"last_error,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+var DeliveryListFields = listing.Fields{
+	Sort:   []string{"id", "created_at", "next_attempt_at"},
+	Filter: []string{"status", "event_type"},
+}
+
+// DueDelivery is a delivery to be sent now, with the event to send and the
+// subscription to send it to.
+type DueDelivery struct {
+	Delivery
+	Url    string
+	Secret string
+	Event  events.Event
+}
+
+type RequestCreateSubscription struct {
+	Url         string   `json:"url" binding:"required,url,max=2048"`
+	Secret      string   `json:"secret" binding:"omitempty,min=16,max=255"`
+	EventTypes  []string `json:"event_types" binding:"dive,oneof=purchase_order.created purchase_order.status_changed inbound_order.created batch.received section.temperature_breached"`
+	WarehouseId *int64   `json:"warehouse_id" binding:"omitempty,min=1"`
+}
+
+// RequestUpdateSubscription only changes the fields that are sent.
+type RequestUpdateSubscription struct {
+	Url         *string   `json:"url" binding:"omitempty,url,max=2048"`
+	Secret      *string   `json:"secret" binding:"omitempty,min=16,max=255"`
+	EventTypes  *[]string `json:"event_types" binding:"omitempty,dive,oneof=purchase_order.created purchase_order.status_changed inbound_order.created batch.received section.temperature_breached"`
+	WarehouseId *int64    `json:"warehouse_id" binding:"omitempty,min=1"`
+	Active      *bool     `json:"active"`
+}
+
+type RequestId struct {
+	Id int64 `uri:"id" binding:"required,min=1"`
+}
+
+type RequestDeliveryId struct {
+	Id         int64 `uri:"id" binding:"required,min=1"`
+	DeliveryId int64 `uri:"deliveryId" binding:"required,min=1"`
+}
+
+type WebhookRepository interface {
+	GetAll(ctx context.Context, params listing.Params) (*[]Subscription, int64, error)
+	GetById(ctx context.Context, id int64) (*Subscription, error)
+	GetActive(ctx context.Context) ([]Subscription, error)
+	Create(ctx context.Context, subscription *Subscription) (int64, error)
+	Update(ctx context.Context, subscription *Subscription) error
+	Delete(ctx context.Context, id int64) error
+
+	GetDeliveries(ctx context.Context, subscriptionId int64, params listing.Params) (*[]Delivery, int64, error)
+	GetDeliveryById(ctx context.Context, subscriptionId, id int64) (*Delivery, error)
+	// Enqueue adds a pending delivery of the event to each subscription,
+	// skipping the subscriptions that already have one.
+	Enqueue(ctx context.Context, eventId int64, subscriptionIds []int64) error
+	// Due returns up to limit pending deliveries whose next attempt is due
+	// at now, oldest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]DueDelivery, error)
+	MarkDelivered(ctx context.Context, id int64, statusCode int, at time.Time) error
+	MarkFailed(ctx context.Context, id int64, status, lastError string, statusCode int, nextAttemptAt time.Time) error
+	// Replay makes the delivery pending again, due at at, with no attempts.
+	Replay(ctx context.Context, id int64, at time.Time) error
+}
+
+type WebhookService interface {
+	GetAll(ctx context.Context, params listing.Params) (*[]Subscription, int64, error)
+	GetById(ctx context.Context, id int64) (*Subscription, error)
+	Create(ctx context.Context, request RequestCreateSubscription) (*Subscription, error)
+	Update(ctx context.Context, id int64, request RequestUpdateSubscription) (*Subscription, error)
+	Delete(ctx context.Context, id int64) error
+	GetDeliveries(ctx context.Context, subscriptionId int64, params listing.Params) (*[]Delivery, int64, error)
+	Replay(ctx context.Context, subscriptionId, deliveryId int64) (*Delivery, error)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	events "github.com/marcoglnd/mercado-fresco-packmain/internal/events/domain"
+	incidents "github.com/marcoglnd/mercado-fresco-packmain/internal/incidents/domain"
+	products "github.com/marcoglnd/mercado-fresco-packmain/internal/products/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func event(t *testing.T, eventType string, payload interface{}) events.Event {
+	data, err := json.Marshal(payload)
+	assert.NoError(t, err)
+	return events.Event{Type: eventType, Payload: data}
+}
+
+func TestMatches(t *testing.T) {
+	warehouseId := int64(2)
+	subscription := Subscription{Active: true, WarehouseId: &warehouseId}
+
+	batchReceived := func(warehouseId int64) events.Event {
+		return event(t, events.TypeBatchReceived, products.BatchReceived{
+			ProductBatches: products.ProductBatches{Id: 1, SectionId: 3},
+			WarehouseId:    warehouseId,
+		})
+	}
+
+	temperatureBreached := func(warehouseId int64) events.Event {
+		return event(t, events.TypeSectionTemperatureBreached, incidents.SectionTemperatureBreached{
+			Incident:    incidents.Incident{ID: 8, Kind: incidents.KindSectionOutOfBand, SectionId: 3},
+			WarehouseId: warehouseId,
+		})
+	}
+
+	t.Run("batch received in the warehouse", func(t *testing.T) {
+		assert.True(t, subscription.Matches(batchReceived(2)))
+		assert.False(t, subscription.Matches(batchReceived(5)))
+	})
+
+	t.Run("section breached in the warehouse", func(t *testing.T) {
+		assert.True(t, subscription.Matches(temperatureBreached(2)))
+		assert.False(t, subscription.Matches(temperatureBreached(5)))
+	})
+
+	t.Run("event types", func(t *testing.T) {
+		filtered := Subscription{Active: true, EventTypes: []string{events.TypeBatchReceived}}
+
+		assert.True(t, filtered.Matches(batchReceived(5)))
+		assert.False(t, filtered.Matches(temperatureBreached(5)))
+	})
+
+	t.Run("inactive", func(t *testing.T) {
+		assert.False(t, Subscription{WarehouseId: &warehouseId}.Matches(batchReceived(2)))
+	})
+}
@@ -0,0 +1,8 @@
+package domain
+
+import "github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+
+var (
+	ErrSubscriptionNotFound = apperrors.NotFound("webhook subscription not found")
+	ErrDeliveryNotFound     = apperrors.NotFound("webhook delivery not found")
+)
@@ -0,0 +1,27 @@
+package mariadb
+
+const (
+	sqlGetAll    = "SELECT id, url, event_types, warehouse_id, active, created_at FROM webhook_subscriptions"
+	sqlGetById   = "SELECT id, url, event_types, warehouse_id, active, created_at FROM webhook_subscriptions WHERE id = ?;"
+	sqlGetActive = "SELECT id, url, event_types, warehouse_id, active, created_at FROM webhook_subscriptions WHERE active = TRUE;"
+	sqlInsert    = "INSERT INTO webhook_subscriptions (url, secret, event_types, warehouse_id, active) VALUES (?, ?, ?, ?, ?);"
+	// sqlUpdate keeps the secret when it is sent empty.
+	sqlUpdate = "UPDATE webhook_subscriptions SET url = ?, secret = COALESCE(NULLIF(?, ''), secret), event_types = ?, warehouse_id = ?, active = ? WHERE id = ?;"
+	sqlDelete = "DELETE FROM webhook_subscriptions WHERE id = ?;"
+
+	sqlGetDeliveries = `SELECT d.id, d.subscription_id, d.event_id, e.event_type, d.status, d.attempts, d.next_attempt_at,
+		d.last_error, d.last_status_code, d.delivered_at, d.created_at
+		FROM webhook_deliveries d JOIN outbox_events e ON e.id = d.event_id`
+	sqlGetDeliveryById = sqlGetDeliveries + " WHERE d.subscription_id = ? AND d.id = ?;"
+	sqlEnqueue         = "INSERT INTO webhook_deliveries (subscription_id, event_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE id = id;"
+	sqlDue             = `SELECT d.id, d.subscription_id, d.event_id, d.status, d.attempts, d.next_attempt_at, d.created_at,
+		s.url, s.secret, e.event_type, e.entity, e.entity_id, e.payload, e.occurred_at
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		JOIN outbox_events e ON e.id = d.event_id
+		WHERE d.status = ? AND s.active = TRUE AND d.next_attempt_at <= ?
+		ORDER BY d.id LIMIT ?;`
+	sqlMarkDelivered = "UPDATE webhook_deliveries SET status = ?, attempts = attempts + 1, last_error = '', last_status_code = ?, delivered_at = ? WHERE id = ?;"
+	sqlMarkFailed    = "UPDATE webhook_deliveries SET status = ?, attempts = attempts + 1, last_error = ?, last_status_code = ?, next_attempt_at = ? WHERE id = ?;"
+	sqlReplay        = "UPDATE webhook_deliveries SET status = ?, attempts = 0, next_attempt_at = ?, delivered_at = NULL WHERE id = ?;"
+)
@@ -0,0 +1,341 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/apperrors"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain"
+)
+
+// maxErrorLength is the size of the last_error column.
+const maxErrorLength = 1024
+
+var deliveryColumns = map[string]string{
+	"id":              "d.id",
+	"subscription_id": "d.subscription_id",
+	"status":          "d.status",
+	"event_type":      "e.event_type",
+	"next_attempt_at": "d.next_attempt_at",
+	"created_at":      "d.created_at",
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type mariadbRepository struct {
+	db *sql.DB
+}
+
+func NewMariaDBRepository(db *sql.DB) domain.WebhookRepository {
+	return mariadbRepository{db: db}
+}
+
+func (m mariadbRepository) GetAll(ctx context.Context, params listing.Params) (*[]domain.Subscription, int64, error) {
+	subscriptions := []domain.Subscription{}
+
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetAll, params, nil)
+	if err := m.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &subscriptions, 0, err
+	}
+
+	query, args := listing.Build(sqlGetAll, params, nil)
+	if err := m.query(ctx, &subscriptions, query, args...); err != nil {
+		return &subscriptions, 0, err
+	}
+
+	return &subscriptions, total, nil
+}
+
+func (m mariadbRepository) GetById(ctx context.Context, id int64) (*domain.Subscription, error) {
+	subscription, err := scanSubscription(m.db.QueryRowContext(ctx, sqlGetById, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return subscription, err
+}
+
+func (m mariadbRepository) GetActive(ctx context.Context) ([]domain.Subscription, error) {
+	subscriptions := []domain.Subscription{}
+	err := m.query(ctx, &subscriptions, sqlGetActive)
+	return subscriptions, err
+}
+
+func (m mariadbRepository) Create(ctx context.Context, subscription *domain.Subscription) (int64, error) {
+	eventTypes, err := json.Marshal(subscription.EventTypes)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := m.db.ExecContext(
+		ctx,
+		sqlInsert,
+		subscription.Url,
+		subscription.Secret,
+		string(eventTypes),
+		subscription.WarehouseId,
+		subscription.Active,
+	)
+	if err != nil {
+		return 0, apperrors.FromMySQL(err)
+	}
+
+	return result.LastInsertId()
+}
+
+func (m mariadbRepository) Update(ctx context.Context, subscription *domain.Subscription) error {
+	eventTypes, err := json.Marshal(subscription.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.db.ExecContext(
+		ctx,
+		sqlUpdate,
+		subscription.Url,
+		subscription.Secret,
+		string(eventTypes),
+		subscription.WarehouseId,
+		subscription.Active,
+		subscription.Id,
+	)
+	return apperrors.FromMySQL(err)
+}
+
+func (m mariadbRepository) Delete(ctx context.Context, id int64) error {
+	result, err := m.db.ExecContext(ctx, sqlDelete, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (m mariadbRepository) GetDeliveries(
+	ctx context.Context,
+	subscriptionId int64,
+	params listing.Params,
+) (*[]domain.Delivery, int64, error) {
+	deliveries := []domain.Delivery{}
+
+	params.Filters = append(
+		[]listing.Filter{{Field: "subscription_id", Value: strconv.FormatInt(subscriptionId, 10)}},
+		params.Filters...,
+	)
+
+	var total int64
+	countQuery, countArgs := listing.BuildCount(sqlGetDeliveries, params, deliveryColumns)
+	if err := m.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return &deliveries, 0, err
+	}
+
+	query, args := listing.Build(sqlGetDeliveries, params, deliveryColumns)
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return &deliveries, 0, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return &deliveries, 0, err
+		}
+
+		deliveries = append(deliveries, *delivery)
+	}
+
+	return &deliveries, total, rows.Err()
+}
+
+func (m mariadbRepository) GetDeliveryById(ctx context.Context, subscriptionId, id int64) (*domain.Delivery, error) {
+	delivery, err := scanDelivery(m.db.QueryRowContext(ctx, sqlGetDeliveryById, subscriptionId, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return delivery, err
+}
+
+func (m mariadbRepository) Enqueue(ctx context.Context, eventId int64, subscriptionIds []int64) error {
+	for _, subscriptionId := range subscriptionIds {
+		if _, err := m.db.ExecContext(ctx, sqlEnqueue, subscriptionId, eventId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m mariadbRepository) Due(ctx context.Context, now time.Time, limit int) ([]domain.DueDelivery, error) {
+	deliveries := []domain.DueDelivery{}
+
+	rows, err := m.db.QueryContext(ctx, sqlDue, domain.DeliveryPending, now, limit)
+	if err != nil {
+		return deliveries, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var delivery domain.DueDelivery
+		var payload []byte
+
+		if err := rows.Scan(
+			&delivery.Id,
+			&delivery.SubscriptionId,
+			&delivery.EventId,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.CreatedAt,
+			&delivery.Url,
+			&delivery.Secret,
+			&delivery.Event.Type,
+			&delivery.Event.Entity,
+			&delivery.Event.EntityId,
+			&payload,
+			&delivery.Event.OccurredAt,
+		); err != nil {
+			return deliveries, err
+		}
+
+		delivery.Event.Id = delivery.EventId
+		delivery.Event.Payload = json.RawMessage(payload)
+		delivery.EventType = delivery.Event.Type
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (m mariadbRepository) MarkDelivered(ctx context.Context, id int64, statusCode int, at time.Time) error {
+	_, err := m.db.ExecContext(ctx, sqlMarkDelivered, domain.DeliveryDelivered, statusCode, at, id)
+	return err
+}
+
+func (m mariadbRepository) MarkFailed(
+	ctx context.Context,
+	id int64,
+	status, lastError string,
+	statusCode int,
+	nextAttemptAt time.Time,
+) error {
+	if len(lastError) > maxErrorLength {
+		lastError = lastError[:maxErrorLength]
+	}
+
+	_, err := m.db.ExecContext(ctx, sqlMarkFailed, status, lastError, statusCode, nextAttemptAt, id)
+	return err
+}
+
+func (m mariadbRepository) Replay(ctx context.Context, id int64, at time.Time) error {
+	result, err := m.db.ExecContext(ctx, sqlReplay, domain.DeliveryPending, at, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrDeliveryNotFound
+	}
+
+	return nil
+}
+
+func (m mariadbRepository) query(ctx context.Context, subscriptions *[]domain.Subscription, query string, args ...interface{}) error {
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return err
+		}
+
+		*subscriptions = append(*subscriptions, *subscription)
+	}
+
+	return rows.Err()
+}
+
+func scanSubscription(row scanner) (*domain.Subscription, error) {
+	var subscription domain.Subscription
+	var eventTypes []byte
+	var warehouseId sql.NullInt64
+
+	if err := row.Scan(
+		&subscription.Id,
+		&subscription.Url,
+		&eventTypes,
+		&warehouseId,
+		&subscription.Active,
+		&subscription.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(eventTypes, &subscription.EventTypes); err != nil {
+		return nil, err
+	}
+
+	if warehouseId.Valid {
+		subscription.WarehouseId = &warehouseId.Int64
+	}
+
+	return &subscription, nil
+}
+
+func scanDelivery(row scanner) (*domain.Delivery, error) {
+	var delivery domain.Delivery
+	var deliveredAt sql.NullTime
+
+	if err := row.Scan(
+		&delivery.Id,
+		&delivery.SubscriptionId,
+		&delivery.EventId,
+		&delivery.EventType,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastError,
+		&delivery.LastStatusCode,
+		&deliveredAt,
+		&delivery.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+
+	return &delivery, nil
+}
@@ -0,0 +1,252 @@
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	queryGetById       = regexp.QuoteMeta(sqlGetById)
+	queryGetActive     = regexp.QuoteMeta(sqlGetActive)
+	queryInsert        = regexp.QuoteMeta(sqlInsert)
+	queryUpdate        = regexp.QuoteMeta(sqlUpdate)
+	queryDelete        = regexp.QuoteMeta(sqlDelete)
+	queryCountDelivery = regexp.QuoteMeta("SELECT COUNT(*) FROM (" + sqlGetDeliveries + " WHERE d.subscription_id = ? AND d.status = ?) AS listing")
+	queryDeliveries    = regexp.QuoteMeta(sqlGetDeliveries + " WHERE d.subscription_id = ? AND d.status = ? ORDER BY d.id ASC")
+	queryEnqueue       = regexp.QuoteMeta(sqlEnqueue)
+	queryDue           = regexp.QuoteMeta(sqlDue)
+	queryMarkDelivered = regexp.QuoteMeta(sqlMarkDelivered)
+	queryMarkFailed    = regexp.QuoteMeta(sqlMarkFailed)
+	queryReplay        = regexp.QuoteMeta(sqlReplay)
+)
+
+var (
+	subscriptionColumns = []string{"id", "url", "event_types", "warehouse_id", "active", "created_at"}
+	deliveryRowColumns  = []string{
+		"id", "subscription_id", "event_id", "event_type", "status", "attempts", "next_attempt_at",
+		"last_error", "last_status_code", "delivered_at", "created_at",
+	}
+	createdAt = time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func TestGetById(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetById).WithArgs(1).WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+			AddRow(1, "http://example.com", []byte(`["batch.received"]`), 2, true, createdAt))
+
+		subscription, err := NewMariaDBRepository(db).GetById(context.Background(), 1)
+
+		warehouseId := int64(2)
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Subscription{
+			Id:          1,
+			Url:         "http://example.com",
+			EventTypes:  []string{"batch.received"},
+			WarehouseId: &warehouseId,
+			Active:      true,
+			CreatedAt:   createdAt,
+		}, subscription)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(queryGetById).WithArgs(1).WillReturnError(sql.ErrNoRows)
+
+		subscription, err := NewMariaDBRepository(db).GetById(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, subscription)
+	})
+}
+
+func TestGetActive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(queryGetActive).WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+		AddRow(1, "http://example.com", []byte(`[]`), nil, true, createdAt))
+
+	subscriptions, err := NewMariaDBRepository(db).GetActive(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, subscriptions, 1)
+	assert.Nil(t, subscriptions[0].WarehouseId)
+	assert.Empty(t, subscriptions[0].EventTypes)
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(queryInsert).
+		WithArgs("http://example.com", "0123456789abcdef", `["batch.received"]`, nil, true).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	id, err := NewMariaDBRepository(db).Create(context.Background(), &domain.Subscription{
+		Url:        "http://example.com",
+		Secret:     "0123456789abcdef",
+		EventTypes: []string{"batch.received"},
+		Active:     true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(queryUpdate).
+		WithArgs("http://example.com", "", `[]`, nil, false, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewMariaDBRepository(db).Update(context.Background(), &domain.Subscription{
+		Id:         1,
+		Url:        "http://example.com",
+		EventTypes: []string{},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(queryDelete).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, NewMariaDBRepository(db).Delete(context.Background(), 1))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(queryDelete).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, NewMariaDBRepository(db).Delete(context.Background(), 1), domain.ErrSubscriptionNotFound)
+	})
+}
+
+func TestGetDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	params := listing.Params{Limit: 10, Filters: []listing.Filter{{Field: "status", Value: domain.DeliveryDead}}}
+
+	mock.ExpectQuery(queryCountDelivery).WithArgs("1", domain.DeliveryDead).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(queryDeliveries).WillReturnRows(sqlmock.NewRows(deliveryRowColumns).
+		AddRow(4, 1, 9, "batch.received", domain.DeliveryDead, 8, createdAt, "webhook answered 500", 500, nil, createdAt))
+
+	deliveries, total, err := NewMariaDBRepository(db).GetDeliveries(context.Background(), 1, params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, 500, (*deliveries)[0].LastStatusCode)
+	assert.Nil(t, (*deliveries)[0].DeliveredAt)
+}
+
+func TestEnqueue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(queryEnqueue).WithArgs(1, 9).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(queryEnqueue).WithArgs(3, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = NewMariaDBRepository(db).Enqueue(context.Background(), 9, []int64{1, 3})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(queryDue).WithArgs(domain.DeliveryPending, createdAt, 100).WillReturnRows(sqlmock.NewRows([]string{
+		"id", "subscription_id", "event_id", "status", "attempts", "next_attempt_at", "created_at",
+		"url", "secret", "event_type", "entity", "entity_id", "payload", "occurred_at",
+	}).AddRow(4, 1, 9, domain.DeliveryPending, 0, createdAt, createdAt,
+		"http://example.com", "0123456789abcdef", "batch.received", "product_batches", 5, []byte(`{"id":5}`), createdAt))
+
+	deliveries, err := NewMariaDBRepository(db).Due(context.Background(), createdAt, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, int64(9), deliveries[0].Event.Id)
+	assert.Equal(t, "batch.received", deliveries[0].EventType)
+	assert.JSONEq(t, `{"id":5}`, string(deliveries[0].Event.Payload))
+}
+
+func TestMarkDelivered(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(queryMarkDelivered).WithArgs(domain.DeliveryDelivered, 200, createdAt, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, NewMariaDBRepository(db).MarkDelivered(context.Background(), 4, 200, createdAt))
+}
+
+func TestMarkFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(queryMarkFailed).
+		WithArgs(domain.DeliveryDead, "webhook answered 410", 410, createdAt, 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewMariaDBRepository(db).MarkFailed(context.Background(), 4, domain.DeliveryDead, "webhook answered 410", 410, createdAt)
+
+	assert.NoError(t, err)
+}
+
+func TestReplay(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(queryReplay).WithArgs(domain.DeliveryPending, createdAt, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, NewMariaDBRepository(db).Replay(context.Background(), 4, createdAt))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(queryReplay).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, NewMariaDBRepository(db).Replay(context.Background(), 4, createdAt), domain.ErrDeliveryNotFound)
+	})
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreate(t *testing.T) {
+	t.Run("generates a secret", func(t *testing.T) {
+		repositoryMock := mocks.NewWebhookRepository(t)
+		repositoryMock.On("Create", mock.Anything, mock.MatchedBy(func(s *domain.Subscription) bool {
+			return len(s.Secret) == 2*secretLength && s.Active && s.EventTypes != nil
+		})).Return(int64(1), nil).Once()
+		repositoryMock.On("GetById", mock.Anything, int64(1)).Return(&domain.Subscription{Id: 1, Url: "http://example.com"}, nil).Once()
+
+		subscription, err := NewWebhookService(repositoryMock).Create(
+			context.Background(),
+			domain.RequestCreateSubscription{Url: "http://example.com"},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, subscription.Secret, 2*secretLength)
+	})
+
+	t.Run("keeps the given secret", func(t *testing.T) {
+		repositoryMock := mocks.NewWebhookRepository(t)
+		repositoryMock.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil).Once()
+		repositoryMock.On("GetById", mock.Anything, int64(1)).Return(&domain.Subscription{Id: 1}, nil).Once()
+
+		subscription, err := NewWebhookService(repositoryMock).Create(
+			context.Background(),
+			domain.RequestCreateSubscription{Url: "http://example.com", Secret: "0123456789abcdef"},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0123456789abcdef", subscription.Secret)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		active := false
+		repositoryMock := mocks.NewWebhookRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(1)).Return(&domain.Subscription{Id: 1, Active: true}, nil).Once()
+		repositoryMock.On("Update", mock.Anything, &domain.Subscription{Id: 1, Secret: "0123456789abcdef"}).Return(nil).Once()
+
+		secret := "0123456789abcdef"
+		subscription, err := NewWebhookService(repositoryMock).Update(
+			context.Background(), 1, domain.RequestUpdateSubscription{Active: &active, Secret: &secret},
+		)
+
+		assert.NoError(t, err)
+		assert.False(t, subscription.Active)
+		assert.Empty(t, subscription.Secret)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repositoryMock := mocks.NewWebhookRepository(t)
+		repositoryMock.On("GetById", mock.Anything, int64(1)).Return(nil, nil).Once()
+
+		_, err := NewWebhookService(repositoryMock).Update(context.Background(), 1, domain.RequestUpdateSubscription{})
+
+		assert.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+	})
+}
+
+func TestGetDeliveries(t *testing.T) {
+	repositoryMock := mocks.NewWebhookRepository(t)
+	repositoryMock.On("GetById", mock.Anything, int64(1)).Return(nil, nil).Once()
+
+	_, _, err := NewWebhookService(repositoryMock).GetDeliveries(context.Background(), 1, listing.Params{})
+
+	assert.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+}
+
+func TestReplay(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		repositoryMock := mocks.NewWebhookRepository(t)
+		repositoryMock.On("GetDeliveryById", mock.Anything, int64(1), int64(4)).
+			Return(&domain.Delivery{Id: 4, Status: domain.DeliveryDead}, nil).Once()
+		repositoryMock.On("Replay", mock.Anything, int64(4), mock.Anything).Return(nil).Once()
+		repositoryMock.On("GetDeliveryById", mock.Anything, int64(1), int64(4)).
+			Return(&domain.Delivery{Id: 4, Status: domain.DeliveryPending}, nil).Once()
+
+		delivery, err := NewWebhookService(repositoryMock).Replay(context.Background(), 1, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DeliveryPending, delivery.Status)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repositoryMock := mocks.NewWebhookRepository(t)
+		repositoryMock.On("GetDeliveryById", mock.Anything, int64(1), int64(4)).Return(nil, nil).Once()
+
+		_, err := NewWebhookService(repositoryMock).Replay(context.Background(), 1, 4)
+
+		assert.ErrorIs(t, err, domain.ErrDeliveryNotFound)
+	})
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/listing"
+	"github.com/marcoglnd/mercado-fresco-packmain/internal/webhooks/domain"
+)
+
+// secretLength is the number of random bytes of the secrets generated for
+// subscriptions created without one.
+const secretLength = 32
+
+type webhookService struct {
+	repository domain.WebhookRepository
+}
+
+func NewWebhookService(r domain.WebhookRepository) domain.WebhookService {
+	return &webhookService{repository: r}
+}
+
+func (s webhookService) GetAll(ctx context.Context, params listing.Params) (*[]domain.Subscription, int64, error) {
+	return s.repository.GetAll(ctx, params)
+}
+
+func (s webhookService) GetById(ctx context.Context, id int64) (*domain.Subscription, error) {
+	subscription, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription == nil {
+		return nil, domain.ErrSubscriptionNotFound
+	}
+
+	return subscription, nil
+}
+
+// Create answers the secret of the subscription, generating one when none
+// is given, since it is not answered again.
+func (s webhookService) Create(ctx context.Context, request domain.RequestCreateSubscription) (*domain.Subscription, error) {
+	subscription := domain.Subscription{
+		Url:         request.Url,
+		Secret:      request.Secret,
+		EventTypes:  request.EventTypes,
+		WarehouseId: request.WarehouseId,
+		Active:      true,
+	}
+
+	if subscription.EventTypes == nil {
+		subscription.EventTypes = []string{}
+	}
+
+	if subscription.Secret == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return nil, err
+		}
+		subscription.Secret = secret
+	}
+
+	id, err := s.repository.Create(ctx, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	created.Secret = subscription.Secret
+
+	return created, nil
+}
+
+func (s webhookService) Update(
+	ctx context.Context,
+	id int64,
+	request domain.RequestUpdateSubscription,
+) (*domain.Subscription, error) {
+	subscription, err := s.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Url != nil {
+		subscription.Url = *request.Url
+	}
+
+	if request.Secret != nil {
+		subscription.Secret = *request.Secret
+	}
+
+	if request.EventTypes != nil {
+		subscription.EventTypes = *request.EventTypes
+	}
+
+	if request.WarehouseId != nil {
+		subscription.WarehouseId = request.WarehouseId
+	}
+
+	if request.Active != nil {
+		subscription.Active = *request.Active
+	}
+
+	if err := s.repository.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	subscription.Secret = ""
+
+	return subscription, nil
+}
+
+func (s webhookService) Delete(ctx context.Context, id int64) error {
+	return s.repository.Delete(ctx, id)
+}
+
+func (s webhookService) GetDeliveries(
+	ctx context.Context,
+	subscriptionId int64,
+	params listing.Params,
+) (*[]domain.Delivery, int64, error) {
+	if _, err := s.GetById(ctx, subscriptionId); err != nil {
+		return nil, 0, err
+	}
+
+	return s.repository.GetDeliveries(ctx, subscriptionId, params)
+}
+
+// Replay sends the delivery again as soon as possible, whatever its status,
+// with a fresh count of attempts.
+func (s webhookService) Replay(ctx context.Context, subscriptionId, deliveryId int64) (*domain.Delivery, error) {
+	delivery, err := s.repository.GetDeliveryById(ctx, subscriptionId, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery == nil {
+		return nil, domain.ErrDeliveryNotFound
+	}
+
+	if err := s.repository.Replay(ctx, deliveryId, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetDeliveryById(ctx, subscriptionId, deliveryId)
+}
+
+func newSecret() (string, error) {
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -37,9 +37,9 @@ func RandomCode() int {
 }
 
 func RandomInt64() int64 {
-	return RandomInt(0, 1000)
+	return RandomInt(1, 1000)
 }
 
 func RandomFloat64() float64 {
-	return float64(RandomInt(0, 1000))
+	return float64(RandomInt(1, 1000))
 }
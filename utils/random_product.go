@@ -31,10 +31,11 @@ func CreateRandomListProduct() []domain.Product {
 }
 
 func CreateRandomProductRecords() domain.ProductRecords {
+	purchasePrice := RandomFloat64()
 	records := domain.ProductRecords{
 		LastUpdateDate: RandomString(10),
-		PurchasePrice:  RandomFloat64(),
-		SalePrice:      RandomFloat64(),
+		PurchasePrice:  purchasePrice,
+		SalePrice:      purchasePrice + RandomFloat64(),
 		ProductId:      RandomInt64(),
 	}
 	return records
@@ -51,13 +52,14 @@ func CreateRandomQtyOfRecords() domain.QtyOfRecords {
 
 func CreateRandomProductBatches() domain.ProductBatches {
 	batch := domain.ProductBatches{
+		Id:                 RandomInt64(),
 		BatchNumber:        RandomInt64(),
 		CurrentQuantity:    RandomInt64(),
 		CurrentTemperature: RandomFloat64(),
 		DueDate:            RandomString(10),
 		InitialQuantity:    RandomInt64(),
 		ManufacturingDate:  RandomString(10),
-		ManufacturingHour:  RandomInt(0, 24),
+		ManufacturingHour:  RandomInt(1, 23),
 		MinimumTemperature: RandomFloat64(),
 		ProductId:          RandomInt64(),
 		SectionId:          RandomInt64(),
@@ -8,10 +8,34 @@ func CreateRandomPurchaseOrder() domain.PurchaseOrder {
 		OrderNumber:   RandomString(6),
 		OrderDate:     RandomString(6),
 		TrackingCode:  RandomString(6),
-		BuyerId:       RandomInt(0, 10),
-		CarrierId:     RandomInt(0, 10),
-		OrderStatusId: 1,
-		WarehouseId:   RandomInt(0, 10),
+		BuyerId:       RandomInt(1, 10),
+		CarrierId:     RandomInt(1, 10),
+		OrderStatusId: domain.OrderStatusCreated,
+		WarehouseId:   RandomInt(1, 10),
 	}
 	return purchaseOrder
 }
+
+func CreateRandomOrderDetail() domain.OrderDetail {
+	orderDetail := domain.OrderDetail{
+		ID:                RandomInt(1, 100),
+		CleanLinessStatus: RandomString(6),
+		Quantity:          RandomInt(1, 10),
+		Temperature:       RandomFloat64(),
+		ProductRecordId:   RandomInt(1, 10),
+		PurchaseOrderId:   1,
+		SalePrice:         float64(RandomInt(1, 100)),
+	}
+	orderDetail.Total = float64(orderDetail.Quantity) * orderDetail.SalePrice
+	return orderDetail
+}
+
+func CreateRandomListOrderDetails() []domain.OrderDetail {
+	var listOfOrderDetails []domain.OrderDetail
+	for i := 1; i <= 5; i++ {
+		orderDetail := CreateRandomOrderDetail()
+		orderDetail.ID = int64(i)
+		listOfOrderDetails = append(listOfOrderDetails, orderDetail)
+	}
+	return listOfOrderDetails
+}